@@ -0,0 +1,320 @@
+package api
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+/* API tokens give scripts access to part of the API without the node's username and password.
+   Each token has a set of scopes which decide the endpoints it may call. Clients send the token
+   in an "Authorization: Bearer <token>" header. */
+
+const (
+	ScopeRead        = "read"
+	ScopeOrders      = "orders"
+	ScopeListings    = "listings"
+	ScopeChat        = "chat"
+	ScopeWalletSpend = "wallet-spend"
+
+	APITokenNameMaxCharacters = 40
+)
+
+var ErrInvalidAPIToken = errors.New("Invalid API token")
+
+// The routes each scope grants access to with any method they are routed for. A request is
+// authorized by the route it is sent to, so "/ob/order" covers "/ob/order/<orderId>" and
+// "/ob/cartcheckouts" only covers GET requests since POSTs to it go to "/ob/cartcheckout".
+var scopePaths = map[string][]string{
+	ScopeRead: {},
+	ScopeOrders: {
+		"/ob/order",
+		"/ob/orderconfirmation",
+		"/ob/ordercancel",
+		"/ob/orderfulfillment",
+		"/ob/ordercompletion",
+		"/ob/sales",
+		"/ob/purchase",
+		"/ob/purchases",
+		"/ob/estimatetotal",
+		"/ob/cart",
+		"/ob/cartcheckout",
+		"/ob/cartcheckouts",
+		"/ob/bid",
+		"/ob/bids",
+		"/ob/pledges",
+		"/ob/case",
+		"/ob/cases",
+		"/ob/opendispute",
+		"/ob/closedispute",
+		"/ob/disputeevidence",
+		"/ob/disputeproposal",
+		"/ob/acceptdisputeproposal",
+		"/ob/endorsedispute",
+		"/ob/digitaldelivery",
+	},
+	ScopeListings: {
+		"/ob/listing",
+		"/ob/listings",
+		"/ob/inventory",
+		"/ob/image",
+		"/ob/images",
+		"/ob/digitalgood",
+		"/ob/promotions",
+		"/ob/couponredemptions",
+		"/ob/post",
+		"/ob/posts",
+	},
+	ScopeChat: {
+		"/ob/chat",
+		"/ob/chatmessage",
+		"/ob/chatmessages",
+		"/ob/chatconversation",
+		"/ob/chatconversations",
+		"/ob/markchatasread",
+	},
+	// Refunds and releasing escrow send funds from our wallet
+	ScopeWalletSpend: {
+		"/wallet/spend",
+		"/wallet/bumpfee",
+		"/ob/refund",
+		"/ob/acceptrefund",
+		"/ob/releasefunds",
+	},
+}
+
+// The routes the read scope grants GET requests to
+var readPaths = []string{
+	"/ob/status",
+	"/ob/peers",
+	"/ob/closestpeers",
+	"/ob/exchangerate",
+	"/ob/profile",
+	"/ob/avatar",
+	"/ob/header",
+	"/ob/image",
+	"/ob/followers",
+	"/ob/following",
+	"/ob/followsme",
+	"/ob/isfollowing",
+	"/ob/listing",
+	"/ob/listings",
+	"/ob/inventory",
+	"/ob/moderators",
+	"/ob/order",
+	"/ob/sales",
+	"/ob/purchases",
+	"/ob/case",
+	"/ob/cases",
+	"/ob/bids",
+	"/ob/pledges",
+	"/ob/cart",
+	"/ob/cartcheckout",
+	"/ob/cartcheckouts",
+	"/ob/chatmessages",
+	"/ob/chatconversations",
+	"/ob/notifications",
+	"/ob/ratings",
+	"/ob/search",
+	"/ob/tags",
+	"/ob/post",
+	"/ob/posts",
+	"/ob/feed",
+	"/ob/channel",
+	"/ob/promotions",
+	"/ob/couponredemptions",
+	"/wallet/address",
+	"/wallet/balance",
+	"/wallet/transactions",
+	"/wallet/estimatefee",
+}
+
+// Endpoints which can only be called with the node's credentials. These match any path
+// starting with them.
+var credentialsOnlyPaths = []string{
+	"/ob/apitokens",
+	"/ob/auditlog",
+	"/ob/settings",
+	"/ob/shutdown",
+	"/wallet/mnemonic",
+}
+
+// POST routes which also pay from our wallet when the request sets "fund", which needs the
+// wallet-spend scope
+var fundingPaths = []string{
+	"/ob/cartcheckout",
+}
+
+func hashAPIToken(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+func validateAPIToken(name string, scopes []string) error {
+	if name == "" || len(name) > APITokenNameMaxCharacters {
+		return errors.New("API token name is missing or too long")
+	}
+	if len(scopes) == 0 {
+		return errors.New("API token must have at least one scope")
+	}
+	for _, scope := range scopes {
+		if _, ok := scopePaths[scope]; !ok {
+			return errors.New("Unknown API token scope: " + scope)
+		}
+	}
+	return nil
+}
+
+// Create a new API token and return its secret. The secret is not stored so it can't be
+// shown again. A zero expiry creates a token which doesn't expire.
+func CreateAPIToken(tokens repo.APITokens, name string, scopes []string, expiry time.Time) (string, error) {
+	if err := validateAPIToken(name, scopes); err != nil {
+		return "", err
+	}
+	if !expiry.IsZero() && expiry.Before(time.Now()) {
+		return "", errors.New("API token expiry is in the past")
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	secret := hex.EncodeToString(b)
+	err := tokens.Put(repo.APIToken{
+		Name:      name,
+		Hash:      hashAPIToken(secret),
+		Scopes:    scopes,
+		Expiry:    expiry,
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// Return the token sent in a request's Authorization header, if any
+func bearerToken(r *http.Request) (string, bool) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer ")), true
+}
+
+// Look up the token with the given secret, checking it hasn't expired or been revoked
+func authenticateAPIToken(tokens repo.APITokens, secret string) (repo.APIToken, error) {
+	if secret == "" {
+		return repo.APIToken{}, ErrInvalidAPIToken
+	}
+	token, err := tokens.GetByHash(hashAPIToken(secret))
+	if err != nil || token.Revoked {
+		return repo.APIToken{}, ErrInvalidAPIToken
+	}
+	if !token.Expiry.IsZero() && token.Expiry.Before(time.Now()) {
+		return repo.APIToken{}, ErrInvalidAPIToken
+	}
+	return token, nil
+}
+
+// Return the prefix of the route a request is sent to. ok is false if the request would
+// not reach any handler.
+func requestRoute(method, urlPath string) (prefix string, ok bool) {
+	u, err := url.Parse(urlPath)
+	if err != nil {
+		return "", false
+	}
+	rt, ok := matchRoute(method, u.String())
+	return rt.prefix, ok
+}
+
+func hasScope(token repo.APIToken, scope string) bool {
+	for _, s := range token.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Check whether a token's scopes allow a request
+func apiTokenAllows(token repo.APIToken, method, urlPath string) bool {
+	for _, p := range credentialsOnlyPaths {
+		if strings.HasPrefix(urlPath, p) {
+			return false
+		}
+	}
+	// No handler is called for OPTIONS requests
+	if method == "OPTIONS" {
+		return hasScope(token, ScopeRead)
+	}
+	prefix, ok := requestRoute(method, urlPath)
+	if !ok {
+		return false
+	}
+	for _, scope := range token.Scopes {
+		if scope == ScopeRead && method == "GET" && containsString(readPaths, prefix) {
+			return true
+		}
+		if containsString(scopePaths[scope], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Return whether a request asks to be paid from our wallet. The body is read and replaced
+// so the handler can still decode it. Bodies which can't be read are assumed to fund.
+func requestFunds(r *http.Request) bool {
+	if r.Body == nil {
+		return false
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return true
+	}
+	var req struct {
+		Fund bool `json:"fund"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return true
+	}
+	return req.Fund
+}
+
+// Authorize a request made with an API token, returning the name of the token
+func authorizeAPIToken(tokens repo.APITokens, r *http.Request, secret string) (string, bool) {
+	token, err := authenticateAPIToken(tokens, secret)
+	if err != nil {
+		return "", false
+	}
+	if !apiTokenAllows(token, r.Method, r.URL.Path) {
+		return token.Name, false
+	}
+	if r.Method == "POST" && !hasScope(token, ScopeWalletSpend) {
+		if prefix, _ := requestRoute(r.Method, r.URL.Path); containsString(fundingPaths, prefix) && requestFunds(r) {
+			return token.Name, false
+		}
+	}
+	return token.Name, true
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+func TestAPITokenAllows(t *testing.T) {
+	tests := []struct {
+		scopes  []string
+		method  string
+		path    string
+		allowed bool
+	}{
+		{[]string{ScopeRead}, "GET", "/ob/sales", true},
+		{[]string{ScopeRead}, "POST", "/ob/orderfulfillment", false},
+		{[]string{ScopeRead}, "GET", "/wallet/mnemonic", false},
+		{[]string{ScopeRead}, "GET", "/ob/apitokens", false},
+		{[]string{ScopeOrders}, "POST", "/ob/orderfulfillment", true},
+		{[]string{ScopeOrders}, "GET", "/ob/sales", true},
+		{[]string{ScopeOrders}, "GET", "/wallet/balance", false},
+		{[]string{ScopeOrders}, "POST", "/ob/refund", false},
+		{[]string{ScopeOrders}, "POST", "/wallet/spend", false},
+		{[]string{ScopeListings}, "PUT", "/ob/listing", true},
+		{[]string{ScopeChat}, "POST", "/ob/chat", true},
+		{[]string{ScopeChat}, "POST", "/ob/listing", false},
+		{[]string{ScopeWalletSpend}, "POST", "/wallet/spend", true},
+		{[]string{ScopeRead, ScopeOrders, ScopeListings, ScopeChat, ScopeWalletSpend}, "POST", "/ob/apitokens", false},
+		{[]string{ScopeRead, ScopeOrders, ScopeListings, ScopeChat, ScopeWalletSpend}, "PUT", "/ob/settings", false},
+		{[]string{ScopeRead}, "GET", "/ob/settings", false},
+		{[]string{ScopeRead}, "GET", "/ob/config", false},
+		{[]string{ScopeRead}, "GET", "/ob/profile/QmPeer", true},
+		{[]string{ScopeOrders}, "GET", "/ob/order/QmOrder", true},
+		{[]string{ScopeChat}, "POST", "/ob/chatfoo", true},
+		{[]string{ScopeOrders}, "POST", "/ob/refunds", false},
+		{[]string{ScopeWalletSpend}, "POST", "/ob/refunds", true},
+		{[]string{ScopeListings}, "PUT", "/ob/listingx", true},
+		{[]string{ScopeOrders}, "GET", "/ob/cartcheckouts", true},
+		{[]string{ScopeRead}, "POST", "/ob/cartcheckouts", false},
+		{[]string{ScopeRead}, "GET", "/ob/nosuchendpoint", false},
+	}
+	for _, test := range tests {
+		token := repo.APIToken{Scopes: test.scopes}
+		if apiTokenAllows(token, test.method, test.path) != test.allowed {
+			t.Errorf("Token with scopes %v allowed=%v for %s %s", test.scopes, !test.allowed, test.method, test.path)
+		}
+	}
+}
+
+func TestRequestFunds(t *testing.T) {
+	tests := []struct {
+		body  string
+		funds bool
+	}{
+		{`{"fund": true, "items": []}`, true},
+		{`{"fund": false}`, false},
+		{`{"items": []}`, false},
+		{`not json`, true},
+	}
+	for _, test := range tests {
+		r, err := http.NewRequest("POST", "/ob/cartcheckout", bytes.NewBufferString(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if requestFunds(r) != test.funds {
+			t.Errorf("Request %s funds should be %t", test.body, test.funds)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil || string(body) != test.body {
+			t.Error("Request body was not restored")
+		}
+	}
+}
+
+// Every route a scope lists must be one requests can be sent to
+func TestAPITokenScopesAreRoutes(t *testing.T) {
+	isRoute := func(prefix string) bool {
+		for _, method := range []string{"GET", "POST", "PUT", "PATCH", "DELETE"} {
+			for _, rt := range routesForMethod(method) {
+				if rt.prefix == prefix {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	lists := [][]string{readPaths, fundingPaths}
+	for _, paths := range scopePaths {
+		lists = append(lists, paths)
+	}
+	for _, paths := range lists {
+		for _, p := range paths {
+			if !isRoute(p) {
+				t.Errorf("%s is not a route", p)
+			}
+		}
+	}
+}
+
+type testAPITokens struct {
+	repo.APITokens
+	token repo.APIToken
+}
+
+func (t *testAPITokens) GetByHash(hash string) (repo.APIToken, error) {
+	if hash != t.token.Hash {
+		return repo.APIToken{}, errors.New("Not found")
+	}
+	return t.token, nil
+}
+
+func TestAuthorizeAPITokenFunding(t *testing.T) {
+	tokens := &testAPITokens{token: repo.APIToken{
+		Name:      "orders",
+		Hash:      hashAPIToken("secret"),
+		Scopes:    []string{ScopeOrders},
+		Timestamp: time.Now(),
+	}}
+	tests := []struct {
+		path    string
+		body    string
+		allowed bool
+	}{
+		{"/ob/cartcheckout", `{"fund": false}`, true},
+		{"/ob/cartcheckout", `{"fund": true}`, false},
+		{"/ob/cartcheckouts", `{"fund": false}`, true},
+		{"/ob/cartcheckouts", `{"fund": true}`, false},
+	}
+	for _, test := range tests {
+		r, err := http.NewRequest("POST", test.path, bytes.NewBufferString(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, allowed := authorizeAPIToken(tokens, r, "secret"); allowed != test.allowed {
+			t.Errorf("POST %s %s allowed=%t", test.path, test.body, allowed)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+/* Every call to the JSON API which can change the node's state is recorded in the audit log,
+   along with who made it and how it turned out. Requests refused by authentication are
+   recorded too. */
+
+const (
+	identityAnonymous   = "anonymous"
+	identityCookie      = "cookie"
+	identityUserPrefix  = "user:"
+	identityTokenPrefix = "token:"
+)
+
+// Records the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func isStateChanging(method string) bool {
+	switch method {
+	case "POST", "PUT", "PATCH", "DELETE":
+		return true
+	}
+	return false
+}
+
+func recordAudit(auditLog repo.AuditLog, r *http.Request, identity string, status int) {
+	remoteAddr, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remoteAddr = r.RemoteAddr
+	}
+	_, err = auditLog.Append(repo.AuditLogEntry{
+		Timestamp:  time.Now(),
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		Identity:   identity,
+		RemoteAddr: remoteAddr,
+		Status:     status,
+	})
+	if err != nil {
+		log.Errorf("Error writing audit log: %s", err)
+	}
+}
@@ -5,179 +5,161 @@ import (
 	"strings"
 )
 
-func put(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request) {
-	switch {
-	case strings.HasPrefix(path, "/ob/profile"):
-		i.PUTProfile(w, r)
-	case strings.HasPrefix(path, "/ob/settings"):
-		i.PUTSettings(w, r)
-	case strings.HasPrefix(path, "/ob/moderator"):
-		i.PUTModerator(w, r)
-	case strings.HasPrefix(path, "/ob/listing"):
-		i.PUTListing(w, r)
-	default:
-		ErrorResponse(w, http.StatusNotFound, "Not Found")
-	}
+// A route sends requests whose path starts with prefix to handler. Each method's routes
+// are tried in order, so a prefix must come after any longer prefix starting with it.
+type route struct {
+	prefix  string
+	handler func(*jsonAPIHandler, http.ResponseWriter, *http.Request)
 }
 
-func post(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request) {
-	switch {
-	case strings.HasPrefix(path, "/ob/listing"):
-		i.POSTListing(w, r)
-	case strings.HasPrefix(path, "/ob/purchase"):
-		i.POSTPurchase(w, r)
-	case strings.HasPrefix(path, "/ob/follow"):
-		i.POSTFollow(w, r)
-	case strings.HasPrefix(path, "/ob/unfollow"):
-		i.POSTUnfollow(w, r)
-	case strings.HasPrefix(path, "/ob/profile"):
-		i.POSTProfile(w, r)
-	case strings.HasPrefix(path, "/ob/images"):
-		i.POSTImage(w, r)
-	case strings.HasPrefix(path, "/wallet/spend"):
-		i.POSTSpendCoins(w, r)
-	case strings.HasPrefix(path, "/ob/settings"):
-		i.POSTSettings(w, r)
-	case strings.HasPrefix(path, "/ob/inventory"):
-		i.POSTInventory(w, r)
-	case strings.HasPrefix(path, "/ob/avatar"):
-		i.POSTAvatar(w, r)
-	case strings.HasPrefix(path, "/ob/header"):
-		i.POSTHeader(w, r)
-	case strings.HasPrefix(path, "/ob/orderconfirmation"):
-		i.POSTOrderConfirmation(w, r)
-	case strings.HasPrefix(path, "/ob/ordercancel"):
-		i.POSTOrderCancel(w, r)
-	case strings.HasPrefix(path, "/ob/orderfulfillment"):
-		i.POSTOrderFulfill(w, r)
-	case strings.HasPrefix(path, "/ob/ordercompletion"):
-		i.POSTOrderComplete(w, r)
-	case strings.HasPrefix(path, "/ob/refund"):
-		i.POSTRefund(w, r)
-	case strings.HasPrefix(path, "/wallet/resyncblockchain"):
-		i.POSTResyncBlockchain(w, r)
-	case strings.HasPrefix(path, "/wallet/bumpfee"):
-		i.POSTBumpFee(w, r)
-	case strings.HasPrefix(path, "/ob/opendispute"):
-		i.POSTOpenDispute(w, r)
-	case strings.HasPrefix(path, "/ob/closedispute"):
-		i.POSTCloseDispute(w, r)
-	case strings.HasPrefix(path, "/ob/releasefunds"):
-		i.POSTReleaseFunds(w, r)
-	case strings.HasPrefix(path, "/ob/chat"):
-		i.POSTChat(w, r)
-	case strings.HasPrefix(path, "/ob/markchatasread"):
-		i.POSTMarkChatAsRead(w, r)
-	case strings.HasPrefix(path, "/ob/marknotificationasread"):
-		i.POSTMarkNotificationAsRead(w, r)
-	case strings.HasPrefix(path, "/ob/fetchprofiles"):
-		i.POSTFetchProfiles(w, r)
-	case strings.HasPrefix(path, "/ob/blocknode"):
-		i.POSTBlockNode(w, r)
-	case strings.HasPrefix(path, "/ob/shutdown"):
-		i.POSTShutdown(w, r)
-	case strings.HasPrefix(path, "/ob/estimatetotal"):
-		i.POSTEstimateTotal(w, r)
-	default:
-		ErrorResponse(w, http.StatusNotFound, "Not Found")
-	}
+var putRoutes = []route{
+	{"/ob/profile", (*jsonAPIHandler).PUTProfile},
+	{"/ob/settings", (*jsonAPIHandler).PUTSettings},
+	{"/ob/moderator", (*jsonAPIHandler).PUTModerator},
+	{"/ob/listing", (*jsonAPIHandler).PUTListing},
+	{"/ob/post", (*jsonAPIHandler).PUTPost},
+	{"/ob/promotions", (*jsonAPIHandler).PUTPromotions},
 }
 
-func get(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request) {
-	switch {
-	case strings.HasPrefix(path, "/ob/status"):
-		i.GETStatus(w, r)
-	case strings.HasPrefix(path, "/ob/peers"):
-		i.GETPeers(w, r)
-	case strings.HasPrefix(path, "/ob/config"):
-		i.GETConfig(w, r)
-	case strings.HasPrefix(path, "/wallet/address"):
-		i.GETAddress(w, r)
-	case strings.HasPrefix(path, "/wallet/mnemonic"):
-		i.GETMnemonic(w, r)
-	case strings.HasPrefix(path, "/wallet/balance"):
-		i.GETBalance(w, r)
-	case strings.HasPrefix(path, "/wallet/transactions"):
-		i.GETTransactions(w, r)
-	case strings.HasPrefix(path, "/ob/settings"):
-		i.GETSettings(w, r)
-	case strings.HasPrefix(path, "/ob/closestpeers"):
-		i.GETClosestPeers(w, r)
-	case strings.HasPrefix(path, "/ob/exchangerate"):
-		i.GETExchangeRate(w, r)
-	case strings.HasPrefix(path, "/ob/followers"):
-		i.GETFollowers(w, r)
-	case strings.HasPrefix(path, "/ob/following"):
-		i.GETFollowing(w, r)
-	case strings.HasPrefix(path, "/ob/inventory"):
-		i.GETInventory(w, r)
-	case strings.HasPrefix(path, "/ob/profile"):
-		i.GETProfile(w, r)
-	case strings.HasPrefix(path, "/ob/listings"):
-		i.GETListings(w, r)
-	case strings.HasPrefix(path, "/ob/listing"):
-		i.GETListing(w, r)
-	case strings.HasPrefix(path, "/ob/followsme"):
-		i.GETFollowsMe(w, r)
-	case strings.HasPrefix(path, "/ob/isfollowing"):
-		i.GETIsFollowing(w, r)
-	case strings.HasPrefix(path, "/ob/order"):
-		i.GETOrder(w, r)
-	case strings.HasPrefix(path, "/ob/moderators"):
-		i.GETModerators(w, r)
-	case strings.HasPrefix(path, "/ob/case"):
-		i.GETCase(w, r)
-	case strings.HasPrefix(path, "/ob/chatmessages"):
-		i.GETChatMessages(w, r)
-	case strings.HasPrefix(path, "/ob/chatconversations"):
-		i.GETChatConversations(w, r)
-	case strings.HasPrefix(path, "/ob/notifications"):
-		i.GETNotifications(w, r)
-	case strings.HasPrefix(path, "/ob/image"):
-		i.GETImage(w, r)
-	case strings.HasPrefix(path, "/ob/avatar"):
-		i.GETAvatar(w, r)
-	case strings.HasPrefix(path, "/ob/header"):
-		i.GETHeader(w, r)
-	case strings.HasPrefix(path, "/ob/purchases"):
-		i.GETPurchases(w, r)
-	case strings.HasPrefix(path, "/ob/sales"):
-		i.GETSales(w, r)
-	case strings.HasPrefix(path, "/ob/cases"):
-		i.GETCases(w, r)
-	case strings.HasPrefix(path, "/wallet/estimatefee"):
-		i.GETEstimateFee(w, r)
-	default:
-		ErrorResponse(w, http.StatusNotFound, "Not Found")
-	}
+var postRoutes = []route{
+	{"/ob/listing", (*jsonAPIHandler).POSTListing},
+	{"/ob/purchase", (*jsonAPIHandler).POSTPurchase},
+	{"/ob/ratingresponse", (*jsonAPIHandler).POSTRatingResponse},
+	{"/ob/cartcheckout", (*jsonAPIHandler).POSTCartCheckout},
+	{"/ob/cart", (*jsonAPIHandler).POSTCart},
+	{"/ob/follow", (*jsonAPIHandler).POSTFollow},
+	{"/ob/unfollow", (*jsonAPIHandler).POSTUnfollow},
+	{"/ob/profile", (*jsonAPIHandler).POSTProfile},
+	{"/ob/images", (*jsonAPIHandler).POSTImage},
+	{"/wallet/spend", (*jsonAPIHandler).POSTSpendCoins},
+	{"/ob/settings", (*jsonAPIHandler).POSTSettings},
+	{"/ob/inventory", (*jsonAPIHandler).POSTInventory},
+	{"/ob/avatar", (*jsonAPIHandler).POSTAvatar},
+	{"/ob/header", (*jsonAPIHandler).POSTHeader},
+	{"/ob/orderconfirmation", (*jsonAPIHandler).POSTOrderConfirmation},
+	{"/ob/ordercancel", (*jsonAPIHandler).POSTOrderCancel},
+	{"/ob/orderfulfillment", (*jsonAPIHandler).POSTOrderFulfill},
+	{"/ob/ordercompletion", (*jsonAPIHandler).POSTOrderComplete},
+	{"/ob/refund", (*jsonAPIHandler).POSTRefund},
+	{"/ob/acceptrefund", (*jsonAPIHandler).POSTAcceptRefund},
+	{"/wallet/resyncblockchain", (*jsonAPIHandler).POSTResyncBlockchain},
+	{"/wallet/bumpfee", (*jsonAPIHandler).POSTBumpFee},
+	{"/ob/opendispute", (*jsonAPIHandler).POSTOpenDispute},
+	{"/ob/closedispute", (*jsonAPIHandler).POSTCloseDispute},
+	{"/ob/disputeevidence", (*jsonAPIHandler).POSTDisputeEvidence},
+	{"/ob/digitalgood", (*jsonAPIHandler).POSTDigitalGood},
+	{"/ob/disputeproposal", (*jsonAPIHandler).POSTDisputeProposal},
+	{"/ob/acceptdisputeproposal", (*jsonAPIHandler).POSTAcceptDisputeProposal},
+	{"/ob/endorsedispute", (*jsonAPIHandler).POSTEndorseDispute},
+	{"/ob/releasefunds", (*jsonAPIHandler).POSTReleaseFunds},
+	{"/ob/chat", (*jsonAPIHandler).POSTChat},
+	{"/ob/markchatasread", (*jsonAPIHandler).POSTMarkChatAsRead},
+	{"/ob/marknotificationasread", (*jsonAPIHandler).POSTMarkNotificationAsRead},
+	{"/ob/fetchprofiles", (*jsonAPIHandler).POSTFetchProfiles},
+	{"/ob/blocknode", (*jsonAPIHandler).POSTBlockNode},
+	{"/ob/shutdown", (*jsonAPIHandler).POSTShutdown},
+	{"/ob/estimatetotal", (*jsonAPIHandler).POSTEstimateTotal},
+	{"/ob/bid", (*jsonAPIHandler).POSTBid},
+	{"/ob/post", (*jsonAPIHandler).POSTPost},
+	{"/ob/apitokens", (*jsonAPIHandler).POSTAPIToken},
+}
+
+var getRoutes = []route{
+	{"/ob/status", (*jsonAPIHandler).GETStatus},
+	{"/ob/peers", (*jsonAPIHandler).GETPeers},
+	{"/ob/config", (*jsonAPIHandler).GETConfig},
+	{"/wallet/address", (*jsonAPIHandler).GETAddress},
+	{"/wallet/mnemonic", (*jsonAPIHandler).GETMnemonic},
+	{"/wallet/balance", (*jsonAPIHandler).GETBalance},
+	{"/wallet/transactions", (*jsonAPIHandler).GETTransactions},
+	{"/ob/settings", (*jsonAPIHandler).GETSettings},
+	{"/ob/closestpeers", (*jsonAPIHandler).GETClosestPeers},
+	{"/ob/exchangerate", (*jsonAPIHandler).GETExchangeRate},
+	{"/ob/followers", (*jsonAPIHandler).GETFollowers},
+	{"/ob/following", (*jsonAPIHandler).GETFollowing},
+	{"/ob/inventory", (*jsonAPIHandler).GETInventory},
+	{"/ob/profile", (*jsonAPIHandler).GETProfile},
+	{"/ob/listings", (*jsonAPIHandler).GETListings},
+	{"/ob/listing", (*jsonAPIHandler).GETListing},
+	{"/ob/followsme", (*jsonAPIHandler).GETFollowsMe},
+	{"/ob/isfollowing", (*jsonAPIHandler).GETIsFollowing},
+	{"/ob/order", (*jsonAPIHandler).GETOrder},
+	{"/ob/moderators/search", (*jsonAPIHandler).GETSearchModerators},
+	{"/ob/moderators", (*jsonAPIHandler).GETModerators},
+	{"/ob/disputeevidence", (*jsonAPIHandler).GETDisputeEvidence},
+	{"/ob/digitalgood", (*jsonAPIHandler).GETDigitalGood},
+	{"/ob/couponredemptions", (*jsonAPIHandler).GETCouponRedemptions},
+	{"/ob/digitaldelivery", (*jsonAPIHandler).GETDigitalDelivery},
+	{"/ob/case", (*jsonAPIHandler).GETCase},
+	{"/ob/chatmessages", (*jsonAPIHandler).GETChatMessages},
+	{"/ob/chatconversations", (*jsonAPIHandler).GETChatConversations},
+	{"/ob/notifierlog", (*jsonAPIHandler).GETNotifierLog},
+	{"/ob/notifications", (*jsonAPIHandler).GETNotifications},
+	{"/ob/image", (*jsonAPIHandler).GETImage},
+	{"/ob/avatar", (*jsonAPIHandler).GETAvatar},
+	{"/ob/header", (*jsonAPIHandler).GETHeader},
+	{"/ob/purchases", (*jsonAPIHandler).GETPurchases},
+	{"/ob/sales", (*jsonAPIHandler).GETSales},
+	{"/ob/cases", (*jsonAPIHandler).GETCases},
+	{"/wallet/estimatefee", (*jsonAPIHandler).GETEstimateFee},
+	{"/ob/bids", (*jsonAPIHandler).GETBids},
+	{"/ob/pledges", (*jsonAPIHandler).GETPledges},
+	{"/ob/ratings", (*jsonAPIHandler).GETRatings},
+	{"/ob/cartcheckouts", (*jsonAPIHandler).GETCartCheckouts},
+	{"/ob/cartcheckout", (*jsonAPIHandler).GETCartCheckout},
+	{"/ob/cart", (*jsonAPIHandler).GETCart},
+	{"/ob/search", (*jsonAPIHandler).GETSearch},
+	{"/ob/tags", (*jsonAPIHandler).GETTags},
+	{"/ob/posts", (*jsonAPIHandler).GETPosts},
+	{"/ob/post", (*jsonAPIHandler).GETPost},
+	{"/ob/promotions", (*jsonAPIHandler).GETPromotions},
+	{"/ob/apitokens", (*jsonAPIHandler).GETAPITokens},
+	{"/ob/auditlog", (*jsonAPIHandler).GETAuditLog},
+	{"/ob/feed", (*jsonAPIHandler).GETFeed},
+	{"/ob/channel", (*jsonAPIHandler).GETChannel},
+}
+
+var patchRoutes = []route{
+	{"/ob/settings", (*jsonAPIHandler).PATCHSettings},
+	{"/ob/profile", (*jsonAPIHandler).PATCHProfile},
+}
+
+var deleteRoutes = []route{
+	{"/ob/moderator", (*jsonAPIHandler).DELETEModerator},
+	{"/ob/listing", (*jsonAPIHandler).DELETEListing},
+	{"/ob/chatmessage", (*jsonAPIHandler).DELETEChatMessage},
+	{"/ob/chatconversation", (*jsonAPIHandler).DELETEChatConversation},
+	{"/ob/notifications", (*jsonAPIHandler).DELETENotification},
+	{"/ob/blocknode", (*jsonAPIHandler).DELETEBlockNode},
+	{"/ob/post", (*jsonAPIHandler).DELETEPost},
+	{"/ob/cart", (*jsonAPIHandler).DELETECart},
+	{"/ob/ratingresponse", (*jsonAPIHandler).DELETERatingResponse},
+	{"/ob/digitalgood", (*jsonAPIHandler).DELETEDigitalGood},
+	{"/ob/apitokens", (*jsonAPIHandler).DELETEAPIToken},
 }
 
-func patch(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request) {
-	switch {
-	case strings.HasPrefix(path, "/ob/settings"):
-		i.PATCHSettings(w, r)
-	case strings.HasPrefix(path, "/ob/profile"):
-		i.PATCHProfile(w, r)
-	default:
-		ErrorResponse(w, http.StatusNotFound, "Not Found")
+func routesForMethod(method string) []route {
+	switch method {
+	case "GET":
+		return getRoutes
+	case "POST":
+		return postRoutes
+	case "PUT":
+		return putRoutes
+	case "DELETE":
+		return deleteRoutes
+	case "PATCH":
+		return patchRoutes
 	}
+	return nil
 }
 
-func deleter(i *jsonAPIHandler, path string, w http.ResponseWriter, r *http.Request) {
-	switch {
-	case strings.HasPrefix(path, "/ob/moderator"):
-		i.DELETEModerator(w, r)
-	case strings.HasPrefix(path, "/ob/listing"):
-		i.DELETEListing(w, r)
-	case strings.HasPrefix(path, "/ob/chatmessage"):
-		i.DELETEChatMessage(w, r)
-	case strings.HasPrefix(path, "/ob/chatconversation"):
-		i.DELETEChatConversation(w, r)
-	case strings.HasPrefix(path, "/ob/notifications"):
-		i.DELETENotification(w, r)
-	case strings.HasPrefix(path, "/ob/blocknode"):
-		i.DELETEBlockNode(w, r)
-	default:
-		ErrorResponse(w, http.StatusNotFound, "Not Found")
+// Return the route a request with the given method and path is sent to
+func matchRoute(method, path string) (route, bool) {
+	for _, rt := range routesForMethod(method) {
+		if strings.HasPrefix(path, rt.prefix) {
+			return rt, true
+		}
 	}
+	return route{}, false
 }
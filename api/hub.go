@@ -7,6 +7,12 @@ type hub struct {
 	// Inbound messages from the connections
 	Broadcast chan []byte
 
+	// Messages for a single connection, such as replies to its requests
+	direct chan directMessage
+
+	// Changes to the notifications a connection is subscribed to
+	subscribe chan subscriptionChange
+
 	// Register requests from the connections
 	register chan *connection
 
@@ -14,15 +20,37 @@ type hub struct {
 	unregister chan *connection
 }
 
+type directMessage struct {
+	c       *connection
+	message []byte
+}
+
+type subscriptionChange struct {
+	c   *connection
+	sub *subscription
+}
+
 func newHub() *hub {
 	return &hub{
 		Broadcast:   make(chan []byte),
+		direct:      make(chan directMessage),
+		subscribe:   make(chan subscriptionChange),
 		register:    make(chan *connection),
 		unregister:  make(chan *connection),
 		connections: make(map[*connection]bool),
 	}
 }
 
+// Queue a message for a connection, dropping the connection if it isn't keeping up
+func (h *hub) send(c *connection, m []byte) {
+	select {
+	case c.send <- m:
+	default:
+		delete(h.connections, c)
+		close(c.send)
+	}
+}
+
 func (h *hub) run() {
 	for {
 		select {
@@ -35,13 +63,19 @@ func (h *hub) run() {
 				close(c.send)
 			}
 			log.Debug("Unregistered websocket connection")
+		case d := <-h.direct:
+			if _, ok := h.connections[d.c]; ok {
+				h.send(d.c, d.message)
+			}
+		case s := <-h.subscribe:
+			if _, ok := h.connections[s.c]; ok {
+				s.c.subscription = s.sub
+			}
 		case m := <-h.Broadcast:
+			kind, orderID := classifyNotification(m)
 			for c := range h.connections {
-				select {
-				case c.send <- m:
-				default:
-					delete(h.connections, c)
-					close(c.send)
+				if notificationAllowed(c.tokenScopes, kind) && c.subscription.matches(kind, orderID) {
+					h.send(c, m)
 				}
 			}
 		}
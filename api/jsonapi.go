@@ -2,9 +2,11 @@ package api
 
 import (
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	mh "gx/ipfs/QmbZ6Cee2uHjG7hf19qLHppgKDRtaG4CVtMzdmK9VCVqLu/go-multihash"
+	"mime"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
@@ -81,6 +83,12 @@ func newJsonAPIHandler(node *core.OpenBazaarNode, authCookie http.Cookie, config
 }
 
 func (i *jsonAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	identity := identityAnonymous
+	if isStateChanging(r.Method) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		w = rec
+		defer func() { recordAudit(i.node.Datastore.AuditLog(), r, identity, rec.status) }()
+	}
 	if !i.config.Enabled {
 		w.WriteHeader(http.StatusForbidden)
 		fmt.Fprint(w, "403 - Forbidden")
@@ -105,7 +113,17 @@ func (i *jsonAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.Header()[k] = v
 	}
 
-	if i.config.Authenticated {
+	if secret, ok := bearerToken(r); ok {
+		name, allowed := authorizeAPIToken(i.node.Datastore.APITokens(), r, secret)
+		if name != "" {
+			identity = identityTokenPrefix + name
+		}
+		if !allowed {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, "403 - Forbidden")
+			return
+		}
+	} else if i.config.Authenticated {
 		if i.config.Username == "" || i.config.Password == "" {
 			cookie, err := r.Cookie("OpenBazaar_Auth_Cookie")
 			if err != nil {
@@ -118,6 +136,7 @@ func (i *jsonAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				fmt.Fprint(w, "403 - Forbidden")
 				return
 			}
+			identity = identityCookie
 		} else {
 			username, password, ok := r.BasicAuth()
 			h := sha256.Sum256([]byte(password))
@@ -127,6 +146,7 @@ func (i *jsonAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				fmt.Fprint(w, "403 - Forbidden")
 				return
 			}
+			identity = identityUserPrefix + username
 		}
 	}
 
@@ -144,26 +164,30 @@ func (i *jsonAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			log.Error("A panic occurred in the rest api handler!")
 			log.Error(r)
 			debug.PrintStack()
+			// Also sets the status recorded in the audit log, whose defer runs after this one
+			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}()
 
+	i.route(w, r)
+}
+
+// Call the handler for a request which has been authenticated
+func (i *jsonAPIHandler) route(w http.ResponseWriter, r *http.Request) {
 	u, err := url.Parse(r.URL.Path)
 	if err != nil {
 		panic(err)
 	}
 	w.Header().Add("Content-Type", "application/json")
-	switch r.Method {
-	case "GET":
-		get(i, u.String(), w, r)
-	case "POST":
-		post(i, u.String(), w, r)
-	case "PUT":
-		put(i, u.String(), w, r)
-	case "DELETE":
-		deleter(i, u.String(), w, r)
-	case "PATCH":
-		patch(i, u.String(), w, r)
+	if routesForMethod(r.Method) == nil {
+		return
+	}
+	rt, ok := matchRoute(r.Method, u.String())
+	if !ok {
+		ErrorResponse(w, http.StatusNotFound, "Not Found")
+		return
 	}
+	rt.handler(i, w, r)
 }
 
 func ErrorResponse(w http.ResponseWriter, errorCode int, reason string) {
@@ -762,7 +786,12 @@ func (i *jsonAPIHandler) POSTUnfollow(w http.ResponseWriter, r *http.Request) {
 }
 
 func (i *jsonAPIHandler) GETAddress(w http.ResponseWriter, r *http.Request) {
-	addr := i.node.Wallet.CurrentAddress(spvwallet.EXTERNAL)
+	wal, err := i.node.WalletForCurrency(r.URL.Query().Get("coin"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	addr := wal.CurrentAddress(spvwallet.EXTERNAL)
 	SanitizedResponse(w, fmt.Sprintf(`{"address": "%s"}`, addr.EncodeAddress()))
 }
 
@@ -776,7 +805,12 @@ func (i *jsonAPIHandler) GETMnemonic(w http.ResponseWriter, r *http.Request) {
 }
 
 func (i *jsonAPIHandler) GETBalance(w http.ResponseWriter, r *http.Request) {
-	confirmed, unconfirmed := i.node.Wallet.Balance()
+	wal, err := i.node.WalletForCurrency(r.URL.Query().Get("coin"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	confirmed, unconfirmed := wal.Balance()
 	SanitizedResponse(w, fmt.Sprintf(`{"confirmed": %d, "unconfirmed": %d}`, int(confirmed), int(unconfirmed)))
 }
 
@@ -786,6 +820,7 @@ func (i *jsonAPIHandler) POSTSpendCoins(w http.ResponseWriter, r *http.Request)
 		Amount   int64  `json:"amount"`
 		FeeLevel string `json:"feeLevel"`
 		Memo     string `json:"memo"`
+		Coin     string `json:"coin"`
 	}
 	decoder := json.NewDecoder(r.Body)
 	var snd Send
@@ -805,12 +840,17 @@ func (i *jsonAPIHandler) POSTSpendCoins(w http.ResponseWriter, r *http.Request)
 	default:
 		feeLevel = spvwallet.NORMAL
 	}
-	addr, err := btc.DecodeAddress(snd.Address, i.node.Wallet.Params())
+	wal, err := i.node.WalletForCurrency(snd.Coin)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	addr, err := btc.DecodeAddress(snd.Address, wal.Params())
 	if err != nil {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	txid, err := i.node.Wallet.Spend(snd.Amount, addr, feeLevel)
+	txid, err := wal.Spend(snd.Amount, addr, feeLevel)
 	if err != nil {
 		ErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
@@ -853,8 +893,8 @@ func (i *jsonAPIHandler) POSTSpendCoins(w http.ResponseWriter, r *http.Request)
 		Timestamp          time.Time `json:"timestamp"`
 		Memo               string    `json:"memo"`
 	}
-	confirmed, unconfirmed := i.node.Wallet.Balance()
-	txn, err := i.node.Wallet.GetTransaction(*txid)
+	confirmed, unconfirmed := wal.Balance()
+	txn, err := wal.GetTransaction(*txid)
 	if err != nil {
 		ErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
@@ -881,7 +921,12 @@ func (i *jsonAPIHandler) GETConfig(w http.ResponseWriter, r *http.Request) {
 	if i.node.Wallet.Params().Name != chaincfg.MainNetParams.Name {
 		testnet = true
 	}
-	SanitizedResponse(w, fmt.Sprintf(`{"peerID": "%s", "cryptoCurrency": "%s", "testnet": %t}`, i.node.IpfsNode.Identity.Pretty(), strings.ToUpper(i.node.Wallet.CurrencyCode()), testnet))
+	currencies, err := json.Marshal(i.node.WalletCurrencies())
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, fmt.Sprintf(`{"peerID": "%s", "cryptoCurrency": "%s", "cryptoCurrencies": %s, "testnet": %t}`, i.node.IpfsNode.Identity.Pretty(), strings.ToUpper(i.node.Wallet.CurrencyCode()), string(currencies), testnet))
 }
 
 func (i *jsonAPIHandler) POSTSettings(w http.ResponseWriter, r *http.Request) {
@@ -896,6 +941,16 @@ func (i *jsonAPIHandler) POSTSettings(w http.ResponseWriter, r *http.Request) {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if err = validateNotifierSettings(settings); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if settings.OrderRules != nil {
+		if err = core.ValidateOrderRules(*settings.OrderRules); err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
 	_, err = i.node.Datastore.Settings().Get()
 	if err == nil {
 		ErrorResponse(w, http.StatusConflict, "Settings is already set. Use PUT.")
@@ -941,6 +996,16 @@ func (i *jsonAPIHandler) PUTSettings(w http.ResponseWriter, r *http.Request) {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if err = validateNotifierSettings(settings); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if settings.OrderRules != nil {
+		if err = core.ValidateOrderRules(*settings.OrderRules); err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
 	_, err = i.node.Datastore.Settings().Get()
 	if err != nil {
 		ErrorResponse(w, http.StatusNotFound, "Settings is not yet set. Use POST.")
@@ -999,6 +1064,16 @@ func (i *jsonAPIHandler) PATCHSettings(w http.ResponseWriter, r *http.Request) {
 		ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if err = validateNotifierSettings(settings); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if settings.OrderRules != nil {
+		if err = core.ValidateOrderRules(*settings.OrderRules); err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
 	if settings.StoreModerators != nil {
 		go i.node.NotifyModerators(*settings.StoreModerators)
 		if err := i.node.SetModeratorsOnListings(*settings.StoreModerators); err != nil {
@@ -1045,8 +1120,14 @@ func (i *jsonAPIHandler) GETClosestPeers(w http.ResponseWriter, r *http.Request)
 
 func (i *jsonAPIHandler) GETExchangeRate(w http.ResponseWriter, r *http.Request) {
 	_, currencyCode := path.Split(r.URL.Path)
+	coin := r.URL.Query().Get("coin")
+	if _, err := i.node.WalletForCurrency(coin); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	exchangeRates := i.node.ExchangeRatesForCurrency(coin)
 	if currencyCode == "" || strings.ToLower(currencyCode) == "exchangerate" {
-		currencyMap, err := i.node.ExchangeRates.GetAllRates()
+		currencyMap, err := exchangeRates.GetAllRates()
 		if err != nil {
 			ErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -1059,7 +1140,7 @@ func (i *jsonAPIHandler) GETExchangeRate(w http.ResponseWriter, r *http.Request)
 		SanitizedResponse(w, string(exchangeRateJson))
 
 	} else {
-		rate, err := i.node.ExchangeRates.GetExchangeRate(strings.ToUpper(currencyCode))
+		rate, err := exchangeRates.GetExchangeRate(strings.ToUpper(currencyCode))
 		if err != nil {
 			ErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -1558,6 +1639,11 @@ func (i *jsonAPIHandler) GETOrder(w http.ResponseWriter, r *http.Request) {
 	resp.Read = read
 	resp.State = state
 
+	wal, err := i.node.WalletForContract(contract)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 	txs := []*pb.TransactionRecord{}
 	for _, r := range records {
 		tx := new(pb.TransactionRecord)
@@ -1567,7 +1653,7 @@ func (i *jsonAPIHandler) GETOrder(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			continue
 		}
-		confirmations, err := i.node.Wallet.GetConfirmations(*ch)
+		confirmations, err := wal.GetConfirmations(*ch)
 		if err != nil {
 			continue
 		}
@@ -1576,6 +1662,13 @@ func (i *jsonAPIHandler) GETOrder(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp.Transactions = txs
+	if isSale {
+		resp.RuleDecisions, err = i.node.Datastore.Sales().GetRuleDecisions(orderId)
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
 
 	m := jsonpb.Marshaler{
 		EnumsAsInts:  false,
@@ -1604,7 +1697,9 @@ func (i *jsonAPIHandler) POSTShutdown(w http.ResponseWriter, r *http.Request) {
 			core.Node.Datastore.Close()
 			repoLockFile := filepath.Join(core.Node.RepoPath, lockfile.LockFile)
 			os.Remove(repoLockFile)
-			core.Node.Wallet.Close()
+			for _, w := range core.Node.Multiwallet.Wallets() {
+				w.Close()
+			}
 			core.Node.IpfsNode.Close()
 		}
 		os.Exit(1)
@@ -1616,7 +1711,9 @@ func (i *jsonAPIHandler) POSTShutdown(w http.ResponseWriter, r *http.Request) {
 
 func (i *jsonAPIHandler) POSTRefund(w http.ResponseWriter, r *http.Request) {
 	type orderCancel struct {
-		OrderId string `json:"orderId"`
+		OrderId string   `json:"orderId"`
+		Amount  uint64   `json:"amount"` // optional, refunds the whole order if left out
+		Items   []uint32 `json:"items"`  // indexes of the order items to refund, required with an amount
 	}
 	decoder := json.NewDecoder(r.Body)
 	var can orderCancel
@@ -1634,8 +1731,14 @@ func (i *jsonAPIHandler) POSTRefund(w http.ResponseWriter, r *http.Request) {
 		ErrorResponse(w, http.StatusBadRequest, "order must be funded and not complete or disputed before refunding")
 		return
 	}
-	err = i.node.RefundOrder(contract, records)
-	if err != nil {
+	err = i.node.RefundOrder(contract, records, can.Amount, can.Items)
+	if err == core.ErrRefundOfferPending {
+		ErrorResponse(w, http.StatusConflict, err.Error())
+		return
+	} else if err == core.ErrRefundExceedsPayment {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
 		ErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -1643,6 +1746,28 @@ func (i *jsonAPIHandler) POSTRefund(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
+func (i *jsonAPIHandler) POSTAcceptRefund(w http.ResponseWriter, r *http.Request) {
+	type acceptance struct {
+		OrderID string `json:"orderId"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var a acceptance
+	err := decoder.Decode(&a)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	err = i.node.AcceptRefund(a.OrderID)
+	if err == core.ErrRefundOfferNotFound {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	} else if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, `{}`)
+}
+
 func (i *jsonAPIHandler) GETModerators(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("async")
 	async, _ := strconv.ParseBool(query)
@@ -1936,6 +2061,7 @@ func (i *jsonAPIHandler) POSTCloseDispute(w http.ResponseWriter, r *http.Request
 		ErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	i.node.SeedNode()
 	SanitizedResponse(w, `{}`)
 	return
 }
@@ -1964,6 +2090,16 @@ func (i *jsonAPIHandler) GETCase(w http.ResponseWriter, r *http.Request) {
 	resp.Claim = claim
 	resp.Resolution = resolution
 	resp.Timestamp = ts
+	resp.Evidence, err = i.node.Datastore.Cases().GetEvidence(orderId)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	resp.Proposals, err = i.node.Datastore.Cases().GetProposals(orderId)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 
 	m := jsonpb.Marshaler{
 		EnumsAsInts:  false,
@@ -2740,7 +2876,12 @@ func (i *jsonAPIHandler) GETEstimateFee(w http.ResponseWriter, r *http.Request)
 	default:
 		feeLevel = spvwallet.NORMAL
 	}
-	fmt.Fprintf(w, "%d", int(i.node.Wallet.GetFeePerByte(feeLevel)))
+	wal, err := i.node.WalletForCurrency(r.URL.Query().Get("coin"))
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	fmt.Fprintf(w, "%d", int(wal.GetFeePerByte(feeLevel)))
 	return
 }
 
@@ -2760,3 +2901,1140 @@ func (i *jsonAPIHandler) POSTEstimateTotal(w http.ResponseWriter, r *http.Reques
 	fmt.Fprintf(w, "%d", int(amount))
 	return
 }
+
+func (i *jsonAPIHandler) POSTBid(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var data core.BidData
+	err := decoder.Decode(&data)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	bidId, err := i.node.PlaceBid(&data)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, fmt.Sprintf(`{"bidId": "%s"}`, bidId))
+	return
+}
+
+func (i *jsonAPIHandler) GETBids(w http.ResponseWriter, r *http.Request) {
+	// Bids on our own auctions are at /ob/bids/{slug}, our bids on another vendor's auction at /ob/bids/{peerId}/{slug}
+	vendorId := i.node.IpfsNode.Identity.Pretty()
+	urlPath, slug := path.Split(r.URL.Path)
+	_, peerId := path.Split(strings.TrimSuffix(urlPath, "/"))
+	if peerId != "bids" {
+		vendorId = peerId
+	}
+	bids, err := i.node.Datastore.Bids().Get(vendorId, slug)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(bids, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if string(ret) == "null" {
+		ret = []byte("[]")
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) GETPledges(w http.ResponseWriter, r *http.Request) {
+	_, slug := path.Split(r.URL.Path)
+	status, err := i.node.GetCrowdFundStatus(slug)
+	if err != nil {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(status, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) GETSearch(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query := repo.SearchQuery{
+		Query:         params.Get("q"),
+		Category:      params.Get("category"),
+		ShipsTo:       params.Get("shipsTo"),
+		PriceCurrency: params.Get("priceCurrency"),
+		Sort:          params.Get("sort"),
+	}
+	switch query.Sort {
+	case "", "relevance", "price-asc", "price-desc", "rating":
+	default:
+		ErrorResponse(w, http.StatusBadRequest, "Sort must be one of relevance, price-asc, price-desc or rating")
+		return
+	}
+	var err error
+	if minPrice := params.Get("minPrice"); minPrice != "" {
+		query.MinPrice, err = strconv.ParseUint(minPrice, 10, 64)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if maxPrice := params.Get("maxPrice"); maxPrice != "" {
+		query.MaxPrice, err = strconv.ParseUint(maxPrice, 10, 64)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if query.ComparesPrices() && query.PriceCurrency == "" {
+		ErrorResponse(w, http.StatusBadRequest, "priceCurrency is required to filter or sort by price")
+		return
+	}
+	results, err := i.node.Search(query)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(results, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) GETTags(w http.ResponseWriter, r *http.Request) {
+	_, tag := path.Split(r.URL.Path)
+	if tag == "" || strings.ToLower(tag) == "tags" {
+		ErrorResponse(w, http.StatusBadRequest, "A tag must be specified")
+		return
+	}
+	id, err := core.TagPointerID(tag)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	dht, ok := i.node.IpfsNode.Routing.(*routing.IpfsDHT)
+	if !ok {
+		ErrorResponse(w, http.StatusServiceUnavailable, "Tags can not be searched while offline")
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	peerInfoList, err := ipfs.FindPointers(dht, ctx, id, core.TagPointerPrefixLength)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	found := make(map[string]bool)
+	vendors := []string{}
+	for _, p := range peerInfoList {
+		pid, err := core.ExtractIDFromPointer(p)
+		if err != nil || found[pid] || pid == i.node.IpfsNode.Identity.Pretty() {
+			continue
+		}
+		found[pid] = true
+		vendors = append(vendors, pid)
+	}
+	ret, err := json.MarshalIndent(vendors, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) POSTPost(w http.ResponseWriter, r *http.Request) {
+	post := new(pb.Post)
+	err := jsonpb.Unmarshal(r.Body, post)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	// If the post already exists tell them to use PUT
+	if post.Slug != "" {
+		if _, err := i.node.GetPostFromSlug(post.Slug); err == nil {
+			ErrorResponse(w, http.StatusConflict, "Post already exists. Use PUT.")
+			return
+		}
+	}
+	slug, err := i.node.SavePost(post)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := i.node.SeedNode(); err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, fmt.Sprintf(`{"slug": "%s"}`, slug))
+	return
+}
+
+func (i *jsonAPIHandler) PUTPost(w http.ResponseWriter, r *http.Request) {
+	post := new(pb.Post)
+	err := jsonpb.Unmarshal(r.Body, post)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := i.node.GetPostFromSlug(post.Slug); err != nil {
+		ErrorResponse(w, http.StatusNotFound, "Post not found.")
+		return
+	}
+	if _, err := i.node.SavePost(post); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := i.node.SeedNode(); err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, `{}`)
+	return
+}
+
+func (i *jsonAPIHandler) DELETEPost(w http.ResponseWriter, r *http.Request) {
+	type deleteReq struct {
+		Slug string `json:"slug"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var req deleteReq
+	err := decoder.Decode(&req)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := i.node.GetPostFromSlug(req.Slug); err != nil {
+		ErrorResponse(w, http.StatusNotFound, "Post not found.")
+		return
+	}
+	if err := i.node.DeletePost(req.Slug); err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := i.node.SeedNode(); err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, `{}`)
+	return
+}
+
+func (i *jsonAPIHandler) GETPosts(w http.ResponseWriter, r *http.Request) {
+	_, peerId := path.Split(r.URL.Path)
+	var err error
+	if peerId == "" || strings.ToLower(peerId) == "posts" || peerId == i.node.IpfsNode.Identity.Pretty() {
+		index, err := i.node.GetFeedIndex()
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		ret, err := json.MarshalIndent(index, "", "    ")
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		SanitizedResponse(w, string(ret))
+		return
+	}
+	if strings.HasPrefix(peerId, "@") {
+		peerId, err = i.node.Resolver.Resolve(peerId)
+		if err != nil {
+			ErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+	}
+	postsBytes, err := ipfs.ResolveThenCat(i.node.Context, ipnspath.FromString(path.Join(peerId, "feed", "index.json")))
+	if err != nil {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(postsBytes))
+	w.Header().Set("Cache-Control", "public, max-age=600, immutable")
+}
+
+func (i *jsonAPIHandler) GETPost(w http.ResponseWriter, r *http.Request) {
+	urlPath, slug := path.Split(r.URL.Path)
+	_, peerId := path.Split(urlPath[:len(urlPath)-1])
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: false,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	var sp *pb.SignedPost
+	var err error
+	if peerId == "" || strings.ToLower(peerId) == "post" || peerId == i.node.IpfsNode.Identity.Pretty() {
+		sp, err = i.node.GetPostFromSlug(slug)
+		if err != nil {
+			ErrorResponse(w, http.StatusNotFound, "Post not found.")
+			return
+		}
+	} else {
+		if strings.HasPrefix(peerId, "@") {
+			peerId, err = i.node.Resolver.Resolve(peerId)
+			if err != nil {
+				ErrorResponse(w, http.StatusNotFound, err.Error())
+				return
+			}
+		}
+		sp, err = i.node.FetchPost(peerId, slug)
+		if err != nil {
+			ErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+	}
+	out, err := m.MarshalToString(sp)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponseM(w, out, new(pb.SignedPost))
+	return
+}
+
+func (i *jsonAPIHandler) GETFeed(w http.ResponseWriter, r *http.Request) {
+	posts, err := i.node.GetFeed()
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if l >= 0 && l < len(posts) {
+			posts = posts[:l]
+		}
+	}
+	ret, err := json.MarshalIndent(posts, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) GETChannel(w http.ResponseWriter, r *http.Request) {
+	_, channel := path.Split(r.URL.Path)
+	posts, err := i.node.GetChannel(channel)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(posts, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) GETNotifierLog(w http.ResponseWriter, r *http.Request) {
+	limit := r.URL.Query().Get("limit")
+	if limit == "" {
+		limit = "-1"
+	}
+	l, err := strconv.Atoi(limit)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	offset := r.URL.Query().Get("offsetId")
+	offsetId := 0
+	if offset != "" {
+		offsetId, err = strconv.Atoi(offset)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	deliveries, err := i.node.Datastore.NotifierDeliveries().GetAll(offsetId, l)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if deliveries == nil {
+		deliveries = []repo.NotifierDelivery{}
+	}
+	ret, err := json.MarshalIndent(deliveries, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) POSTCart(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var data core.PurchaseData
+	err := decoder.Decode(&data)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	itemIds, err := i.node.AddToCart(&data)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(map[string][]string{"itemIds": itemIds}, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) GETCart(w http.ResponseWriter, r *http.Request) {
+	items, err := i.node.Datastore.Cart().Get()
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if items == nil {
+		items = []repo.CartItem{}
+	}
+	ret, err := json.MarshalIndent(items, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+// DELETE /ob/cart/{itemId} removes a single item, /ob/cart empties the cart
+func (i *jsonAPIHandler) DELETECart(w http.ResponseWriter, r *http.Request) {
+	_, itemId := path.Split(strings.TrimSuffix(r.URL.Path, "/"))
+	var err error
+	if itemId == "cart" {
+		err = i.node.Datastore.Cart().DeleteAll()
+	} else {
+		err = i.node.Datastore.Cart().Delete(itemId)
+	}
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, `{}`)
+	return
+}
+
+func (i *jsonAPIHandler) POSTCartCheckout(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var data core.CartCheckoutData
+	err := decoder.Decode(&data)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := i.node.CheckoutCart(&data)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) GETCartCheckout(w http.ResponseWriter, r *http.Request) {
+	_, checkoutId := path.Split(r.URL.Path)
+	status, err := i.node.GetCartCheckoutStatus(checkoutId)
+	if err != nil {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(status, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) GETCartCheckouts(w http.ResponseWriter, r *http.Request) {
+	checkouts, err := i.node.Datastore.Cart().GetCheckouts()
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if checkouts == nil {
+		checkouts = []repo.CartCheckout{}
+	}
+	ret, err := json.MarshalIndent(checkouts, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+// GET /ob/ratings/{peerId} returns a store's ratings, /ob/ratings/{peerId}/{slug} those of one listing
+func (i *jsonAPIHandler) GETRatings(w http.ResponseWriter, r *http.Request) {
+	peerId := i.node.IpfsNode.Identity.Pretty()
+	var slug string
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/ob/ratings"), "/"), "/")
+	if len(segments) > 2 {
+		ErrorResponse(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if segments[0] != "" {
+		peerId = segments[0]
+	}
+	if len(segments) == 2 {
+		slug = segments[1]
+	}
+	var err error
+	if strings.HasPrefix(peerId, "@") {
+		peerId, err = i.node.Resolver.Resolve(peerId)
+		if err != nil {
+			ErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+	}
+	limit := -1
+	if l := r.URL.Query().Get("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	summary, err := i.node.GetRatings(peerId, slug, r.URL.Query().Get("offsetId"), limit)
+	if err != nil {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(summary, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) POSTRatingResponse(w http.ResponseWriter, r *http.Request) {
+	type ratingResponse struct {
+		RatingHash string `json:"ratingHash"`
+		Response   string `json:"response"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var req ratingResponse
+	err := decoder.Decode(&req)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	srr, err := i.node.RespondToRating(req.RatingHash, req.Response)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := i.node.SeedNode(); err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: false,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	out, err := m.MarshalToString(srr)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponseM(w, out, new(pb.SignedRatingResponse))
+	return
+}
+
+func (i *jsonAPIHandler) DELETERatingResponse(w http.ResponseWriter, r *http.Request) {
+	type deleteReq struct {
+		RatingHash string `json:"ratingHash"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var req deleteReq
+	err := decoder.Decode(&req)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := i.node.DeleteRatingResponse(req.RatingHash); err != nil {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err := i.node.SeedNode(); err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, `{}`)
+	return
+}
+
+func (i *jsonAPIHandler) GETSearchModerators(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := core.ModeratorQuery{
+		Language:            q.Get("language"),
+		Currency:            q.Get("currency"),
+		FeeType:             q.Get("feeType"),
+		MaxFixedFeeCurrency: q.Get("maxFixedFeeCurrency"),
+	}
+	if p := q.Get("maxPercentage"); p != "" {
+		maxPercentage, err := strconv.ParseFloat(p, 32)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		query.MaxPercentage = float32(maxPercentage)
+	}
+	if f := q.Get("maxFixedFee"); f != "" {
+		maxFixedFee, err := strconv.ParseUint(f, 10, 64)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if query.MaxFixedFeeCurrency == "" {
+			ErrorResponse(w, http.StatusBadRequest, "maxFixedFeeCurrency is required with maxFixedFee")
+			return
+		}
+		query.MaxFixedFee = maxFixedFee
+	}
+	mods, err := i.node.SearchModerators(query)
+	if err != nil && err == core.ErrUnknownFeeType {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: true,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	results := []json.RawMessage{}
+	for _, mod := range mods {
+		out, err := m.MarshalToString(&mod)
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		results = append(results, json.RawMessage(out))
+	}
+	ret, err := json.MarshalIndent(results, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+}
+
+func (i *jsonAPIHandler) POSTEndorseDispute(w http.ResponseWriter, r *http.Request) {
+	type endorsement struct {
+		OrderID string `json:"orderId"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var e endorsement
+	err := decoder.Decode(&e)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = i.node.EndorseDisputeResolution(e.OrderID)
+	if err != nil && err == core.ErrCaseNotFound {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	} else if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	i.node.SeedNode()
+	SanitizedResponse(w, `{}`)
+}
+
+func (i *jsonAPIHandler) POSTDisputeEvidence(w http.ResponseWriter, r *http.Request) {
+	type evidence struct {
+		OrderID   string `json:"orderId"`
+		Name      string `json:"name"`
+		MediaType string `json:"mediaType"`
+		Data      string `json:"data"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var e evidence
+	err := decoder.Decode(&e)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	data, err := base64.StdEncoding.DecodeString(e.Data)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	hash, err := i.node.SubmitDisputeEvidence(e.OrderID, e.Name, e.MediaType, data)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, fmt.Sprintf(`{"hash": "%s"}`, hash))
+}
+
+func (i *jsonAPIHandler) GETDisputeEvidence(w http.ResponseWriter, r *http.Request) {
+	urlPath, hash := path.Split(r.URL.Path)
+	_, caseID := path.Split(urlPath[:len(urlPath)-1])
+	evidence, data, err := i.node.FetchDisputeEvidence(caseID, hash)
+	if err != nil && err == core.ErrEvidenceNotFound {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	} else if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	// Evidence comes from the parties to a dispute so it is always served as a download
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": evidence.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if evidence.MediaType != "" {
+		w.Header().Set("Content-Type", evidence.MediaType)
+	}
+	w.Write(data)
+}
+
+func (i *jsonAPIHandler) POSTDisputeProposal(w http.ResponseWriter, r *http.Request) {
+	type proposal struct {
+		OrderID          string  `json:"orderId"`
+		BuyerPercentage  float32 `json:"buyerPercentage"`
+		VendorPercentage float32 `json:"vendorPercentage"`
+		Resolution       string  `json:"resolution"`
+		Counters         string  `json:"counters"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var p proposal
+	err := decoder.Decode(&p)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	proposalID, err := i.node.ProposeDisputeSplit(p.OrderID, p.BuyerPercentage, p.VendorPercentage, p.Resolution, p.Counters)
+	if err != nil && (err == core.ErrCaseNotFound || err == core.ErrProposalNotFound) {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	} else if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, fmt.Sprintf(`{"proposalId": "%s"}`, proposalID))
+}
+
+func (i *jsonAPIHandler) POSTAcceptDisputeProposal(w http.ResponseWriter, r *http.Request) {
+	type acceptance struct {
+		OrderID    string `json:"orderId"`
+		ProposalID string `json:"proposalId"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var a acceptance
+	err := decoder.Decode(&a)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	err = i.node.AcceptDisputeProposal(a.OrderID, a.ProposalID)
+	if err != nil && (err == core.ErrCaseNotFound || err == core.ErrProposalNotFound) {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	} else if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, `{}`)
+}
+
+func (i *jsonAPIHandler) POSTDigitalGood(w http.ResponseWriter, r *http.Request) {
+	type digitalGood struct {
+		Slug        string `json:"slug"`
+		Filename    string `json:"filename"`
+		MediaType   string `json:"mediaType"`
+		Data        string `json:"data"`
+		AutoFulfill bool   `json:"autoFulfill"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var dg digitalGood
+	err := decoder.Decode(&dg)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	data, err := base64.StdEncoding.DecodeString(dg.Data)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	good, err := i.node.SetDigitalGood(dg.Slug, dg.Filename, dg.MediaType, data, dg.AutoFulfill)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(good, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+}
+
+func (i *jsonAPIHandler) GETDigitalGood(w http.ResponseWriter, r *http.Request) {
+	_, slug := path.Split(r.URL.Path)
+	good, err := i.node.Datastore.DigitalGoods().Get(slug)
+	if err != nil {
+		ErrorResponse(w, http.StatusNotFound, core.ErrDigitalGoodNotFound.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(good, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+}
+
+func (i *jsonAPIHandler) DELETEDigitalGood(w http.ResponseWriter, r *http.Request) {
+	type deleteReq struct {
+		Slug string `json:"slug"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var req deleteReq
+	err := decoder.Decode(&req)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := i.node.Datastore.DigitalGoods().Get(req.Slug); err != nil {
+		ErrorResponse(w, http.StatusNotFound, core.ErrDigitalGoodNotFound.Error())
+		return
+	}
+	if err := i.node.Datastore.DigitalGoods().Delete(req.Slug); err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, `{}`)
+}
+
+func (i *jsonAPIHandler) GETDigitalDelivery(w http.ResponseWriter, r *http.Request) {
+	urlPath, hash := path.Split(r.URL.Path)
+	_, orderID := path.Split(urlPath[:len(urlPath)-1])
+	delivery, data, err := i.node.FetchDigitalDelivery(orderID, hash)
+	if err != nil && err == core.ErrDigitalDeliveryNotFound {
+		ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	} else if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": delivery.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if delivery.MediaType != "" {
+		w.Header().Set("Content-Type", delivery.MediaType)
+	}
+	w.Write(data)
+}
+
+func (i *jsonAPIHandler) GETCouponRedemptions(w http.ResponseWriter, r *http.Request) {
+	type couponRedemptions struct {
+		Code        string                  `json:"code"`
+		Hash        string                  `json:"hash"`
+		Redemptions []repo.CouponRedemption `json:"redemptions"`
+	}
+	_, slug := path.Split(r.URL.Path)
+	coupons, err := i.node.Datastore.Coupons().Get(slug)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ret := []couponRedemptions{}
+	for _, c := range coupons {
+		redemptions, err := i.node.Datastore.Coupons().GetRedemptions(slug, c.Hash)
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if redemptions == nil {
+			redemptions = []repo.CouponRedemption{}
+		}
+		ret = append(ret, couponRedemptions{c.Code, c.Hash, redemptions})
+	}
+	out, err := json.MarshalIndent(ret, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(out))
+}
+
+func (i *jsonAPIHandler) PUTPromotions(w http.ResponseWriter, r *http.Request) {
+	promotions := new(pb.Promotions)
+	err := jsonpb.Unmarshal(r.Body, promotions)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := i.node.SavePromotions(promotions.Promotions); err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := i.node.SeedNode(); err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, `{}`)
+	return
+}
+
+func (i *jsonAPIHandler) GETPromotions(w http.ResponseWriter, r *http.Request) {
+	_, peerId := path.Split(r.URL.Path)
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: false,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	var sp *pb.SignedPromotions
+	var err error
+	if peerId == "" || strings.ToLower(peerId) == "promotions" || peerId == i.node.IpfsNode.Identity.Pretty() {
+		sp, err = i.node.GetPromotions()
+		if err != nil {
+			ErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+	} else {
+		if strings.HasPrefix(peerId, "@") {
+			peerId, err = i.node.Resolver.Resolve(peerId)
+			if err != nil {
+				ErrorResponse(w, http.StatusNotFound, err.Error())
+				return
+			}
+		}
+		sp, err = i.node.FetchPromotions(peerId)
+		if err != nil {
+			ErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+	}
+	out, err := m.MarshalToString(sp)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponseM(w, out, new(pb.SignedPromotions))
+	return
+}
+
+func (i *jsonAPIHandler) POSTAPIToken(w http.ResponseWriter, r *http.Request) {
+	type tokenReq struct {
+		Name   string     `json:"name"`
+		Scopes []string   `json:"scopes"`
+		Expiry *time.Time `json:"expiry"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var req tokenReq
+	err := decoder.Decode(&req)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var expiry time.Time
+	if req.Expiry != nil {
+		expiry = *req.Expiry
+	}
+	secret, err := CreateAPIToken(i.node.Datastore.APITokens(), req.Name, req.Scopes, expiry)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ret, err := json.MarshalIndent(struct {
+		Name  string `json:"name"`
+		Token string `json:"token"`
+	}{req.Name, secret}, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) GETAPITokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := i.node.Datastore.APITokens().GetAll()
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if tokens == nil {
+		tokens = []repo.APIToken{}
+	}
+	ret, err := json.MarshalIndent(tokens, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
+
+func (i *jsonAPIHandler) DELETEAPIToken(w http.ResponseWriter, r *http.Request) {
+	type revokeReq struct {
+		Name string `json:"name"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	var req revokeReq
+	err := decoder.Decode(&req)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := i.node.Datastore.APITokens().Revoke(req.Name); err != nil {
+		ErrorResponse(w, http.StatusNotFound, "API token not found")
+		return
+	}
+	SanitizedResponse(w, `{}`)
+	return
+}
+
+// GET /ob/auditlog/head returns the log's head signed with the node's identity key over
+// "<id>\n<hash>". Monitors can keep signed heads elsewhere and later pass one to
+// /ob/auditlog/verify?id=<id>&hash=<hash> to check the log hasn't been rolled back past it.
+func (i *jsonAPIHandler) GETAuditLog(w http.ResponseWriter, r *http.Request) {
+	auditLog := i.node.Datastore.AuditLog()
+	switch _, last := path.Split(r.URL.Path); last {
+	case "head":
+		head, err := auditLog.Head()
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		sig, err := i.node.IpfsNode.PrivateKey.Sign([]byte(strconv.Itoa(head.ID) + "\n" + head.Hash))
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		type headResp struct {
+			repo.AuditLogHead
+			PeerId    string `json:"peerId"`
+			Signature string `json:"signature"`
+		}
+		ret, err := json.MarshalIndent(headResp{
+			AuditLogHead: head,
+			PeerId:       i.node.IpfsNode.Identity.Pretty(),
+			Signature:    base64.StdEncoding.EncodeToString(sig),
+		}, "", "    ")
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		SanitizedResponse(w, string(ret))
+		return
+	case "verify":
+		type verifyResp struct {
+			Valid bool   `json:"valid"`
+			Error string `json:"error,omitempty"`
+		}
+		resp := verifyResp{Valid: true}
+		if err := auditLog.Verify(); err != nil {
+			resp = verifyResp{Valid: false, Error: err.Error()}
+		} else if id := r.URL.Query().Get("id"); id != "" {
+			headID, err := strconv.Atoi(id)
+			if err != nil {
+				ErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			entries, err := auditLog.Get(repo.AuditLogQuery{OffsetId: headID + 1, Limit: 1})
+			if err != nil {
+				ErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			if len(entries) == 0 || entries[0].ID != headID || entries[0].Hash != r.URL.Query().Get("hash") {
+				resp = verifyResp{Valid: false, Error: fmt.Sprintf("Audit log does not contain head %d", headID)}
+			}
+		}
+		ret, err := json.MarshalIndent(resp, "", "    ")
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		SanitizedResponse(w, string(ret))
+		return
+	}
+	q := r.URL.Query()
+	query := repo.AuditLogQuery{
+		Method:   q.Get("method"),
+		Path:     q.Get("path"),
+		Identity: q.Get("identity"),
+	}
+	var err error
+	if since := q.Get("since"); since != "" {
+		query.Since, err = time.Parse(time.RFC3339, since)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if until := q.Get("until"); until != "" {
+		query.Until, err = time.Parse(time.RFC3339, until)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if offset := q.Get("offsetId"); offset != "" {
+		query.OffsetId, err = strconv.Atoi(offset)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if limit := q.Get("limit"); limit != "" {
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	entries, err := auditLog.Get(query)
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if entries == nil {
+		entries = []repo.AuditLogEntry{}
+	}
+	ret, err := json.MarshalIndent(entries, "", "    ")
+	if err != nil {
+		ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	SanitizedResponse(w, string(ret))
+	return
+}
@@ -56,8 +56,28 @@ const settingsJSON = `{
         "username": "urbanart",
         "password": "letmein",
         "senderEmail": "notifications@urbanart.com",
-        "recipientEmail": "Dave@gmail.com"
-    }
+        "recipientEmail": "Dave@gmail.com",
+        "events": ["order", "payment"]
+    },
+    "notifiers": [{
+        "type": "webhook",
+        "enabled": true,
+        "events": ["order"],
+        "url": "https://orders.urbanart.com/openbazaar",
+        "secret": "hunter2",
+        "homeServer": "",
+        "roomId": "",
+        "accessToken": ""
+    }],
+    "orderRules": [{
+        "name": "Small orders",
+        "enabled": true,
+        "action": "ACCEPT",
+        "contractTypes": null,
+        "maxTotal": 100000,
+        "shipsTo": null,
+        "notShipsTo": ["NORTH_KOREA"]
+    }]
 }`
 
 const settingsUpdateJSON = `{
@@ -90,8 +110,28 @@ const settingsUpdateJSON = `{
         "username": "urbanart",
         "password": "letmein",
         "senderEmail": "notifications@urbanart.com",
-        "recipientEmail": "Dave@gmail.com"
-    }
+        "recipientEmail": "Dave@gmail.com",
+        "events": ["order", "payment"]
+    },
+    "notifiers": [{
+        "type": "webhook",
+        "enabled": true,
+        "events": ["order"],
+        "url": "https://orders.urbanart.com/openbazaar",
+        "secret": "hunter2",
+        "homeServer": "",
+        "roomId": "",
+        "accessToken": ""
+    }],
+    "orderRules": [{
+        "name": "Small orders",
+        "enabled": true,
+        "action": "ACCEPT",
+        "contractTypes": null,
+        "maxTotal": 100000,
+        "shipsTo": null,
+        "notShipsTo": ["NORTH_KOREA"]
+    }]
 }`
 
 const settingsPatchJSON = `{
@@ -134,8 +174,28 @@ const settingsPatchedJSON = `{
         "username": "urbanart",
         "password": "letmein",
         "senderEmail": "notifications@urbanart.com",
-        "recipientEmail": "Dave@gmail.com"
-    }
+        "recipientEmail": "Dave@gmail.com",
+        "events": ["order", "payment"]
+    },
+    "notifiers": [{
+        "type": "webhook",
+        "enabled": true,
+        "events": ["order"],
+        "url": "https://orders.urbanart.com/openbazaar",
+        "secret": "hunter2",
+        "homeServer": "",
+        "roomId": "",
+        "accessToken": ""
+    }],
+    "orderRules": [{
+        "name": "Small orders",
+        "enabled": true,
+        "action": "ACCEPT",
+        "contractTypes": null,
+        "maxTotal": 100000,
+        "shipsTo": null,
+        "notShipsTo": ["NORTH_KOREA"]
+    }]
 }`
 
 const settingsMalformedJSON = `{
@@ -183,7 +243,8 @@ const profileJSON = `{
 	    "followingCount": 2,
 	    "listingCount": 3,
 	    "ratingCount": 21000000,
-	    "averageRating": 1
+	    "averageRating": 1,
+	    "disputesResolved": 0
     },
     "bitcoinPubkey": "0314e6def3bd71e2806d87ae06ec88ca175701b34ae308f81c16266f69ddc98053"
 }`
@@ -764,6 +825,109 @@ const insuffientFundsJSON = `{
     "reason": "insuffient funds"
 }`
 
+//
+// Cart
+//
+
+const emptyCartItemsJSON = `{
+    "success": false,
+    "reason": "No items to add to the cart"
+}`
+
+const emptyCartJSON = `{
+    "success": false,
+    "reason": "Cart is empty"
+}`
+
+const checkoutNotFoundJSON = `{
+    "success": false,
+    "reason": "Checkout not found"
+}`
+
+const caseNotFoundJSON = `{
+    "success": false,
+    "reason": "Case not found"
+}`
+
+const evidenceNotFoundJSON = `{
+    "success": false,
+    "reason": "Evidence not found"
+}`
+
+const invalidSplitJSON = `{
+    "success": false,
+    "reason": "Payout percentages must be positive and sum to 100"
+}`
+
+const digitalGoodNotFoundJSON = `{
+    "success": false,
+    "reason": "Digital good not found"
+}`
+
+const digitalDeliveryNotFoundJSON = `{
+    "success": false,
+    "reason": "Digital delivery not found"
+}`
+
+const invalidOrderRuleJSON = `{
+    "success": false,
+    "reason": "Fulfill rules must be limited to services or digital goods"
+}`
+
+const promotionsNotFoundJSON = `{
+    "success": false,
+    "reason": "Promotions not found"
+}`
+
+const invalidPromotionJSON = `{
+    "success": false,
+    "reason": "Percent discount must be between 0 and 100"
+}`
+
+const unknownAPITokenScopeJSON = `{
+    "success": false,
+    "reason": "Unknown API token scope: admin"
+}`
+
+const apiTokenNotFoundJSON = `{
+    "success": false,
+    "reason": "API token not found"
+}`
+
+const refundOfferNotFoundJSON = `{
+    "success": false,
+    "reason": "No refund offer for this order"
+}`
+
+const unknownFeeTypeJSON = `{
+    "success": false,
+    "reason": "Unknown fee type"
+}`
+
+const maxFixedFeeCurrencyRequiredJSON = `{
+    "success": false,
+    "reason": "maxFixedFeeCurrency is required with maxFixedFee"
+}`
+
+const priceCurrencyRequiredJSON = `{
+    "success": false,
+    "reason": "priceCurrency is required to filter or sort by price"
+}`
+
+//
+// Ratings
+//
+
+const ratingNotFoundJSON = `{
+    "success": false,
+    "reason": "Rating not found"
+}`
+
+const ratingResponseNotFoundJSON = `{
+    "success": false,
+    "reason": "Response not found"
+}`
+
 //
 // Peers
 //
@@ -51,6 +51,11 @@ func TestSettings(t *testing.T) {
 		{"POST", "/ob/settings", settingsMalformedJSON, 400, settingsMalformedJSONResponse},
 	})
 
+	// Invalid order rules
+	runAPITests(t, apiTests{
+		{"POST", "/ob/settings", `{"orderRules": [{"name": "Ship everything", "enabled": true, "action": "FULFILL"}]}`, 400, invalidOrderRuleJSON},
+	})
+
 	// Invalid JSON
 	runAPITests(t, apiTests{
 		{"POST", "/ob/settings", settingsJSON, 200, "{}"},
@@ -210,6 +215,115 @@ func TestPeers(t *testing.T) {
 	})
 }
 
+func TestCart(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/cart", "", 200, `[]`},
+		{"POST", "/ob/cart", `{"items":[]}`, 400, emptyCartItemsJSON},
+		{"POST", "/ob/cartcheckout", `{}`, 500, emptyCartJSON},
+		{"GET", "/ob/cartcheckouts", "", 200, `[]`},
+		{"GET", "/ob/cartcheckout/QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG", "", 404, checkoutNotFoundJSON},
+		{"DELETE", "/ob/cart", "", 200, `{}`},
+	})
+}
+
+func TestRatings(t *testing.T) {
+	runAPITests(t, apiTests{
+		// TODO: Need better JSON matching, the response contains our peer ID
+		{"GET", "/ob/ratings", "", 200, anyResponseJSON},
+		{"GET", "/ob/ratings/QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG/slug/extra", "", 404, notFoundJSON},
+		{"POST", "/ob/ratingresponse", `{"ratingHash":"QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG","response":"Thanks"}`, 400, ratingNotFoundJSON},
+		{"DELETE", "/ob/ratingresponse", `{"ratingHash":"QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"}`, 404, ratingResponseNotFoundJSON},
+	})
+}
+
+func TestSearchModerators(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/moderators/search?feeType=HOURLY", "", 400, unknownFeeTypeJSON},
+		{"GET", "/ob/moderators/search?maxFixedFee=100", "", 400, maxFixedFeeCurrencyRequiredJSON},
+		{"GET", "/ob/moderators/search?maxPercentage=abc", "", 400, anyResponseJSON},
+	})
+}
+
+func TestSearchPriceCurrency(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/search?minPrice=100", "", 400, priceCurrencyRequiredJSON},
+		{"GET", "/ob/search?sort=price-asc", "", 400, priceCurrencyRequiredJSON},
+	})
+}
+
+func TestEndorseDispute(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"POST", "/ob/endorsedispute", `{"orderId":"QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"}`, 404, caseNotFoundJSON},
+	})
+}
+
+func TestDisputeEvidence(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/disputeevidence/QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG/e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", "", 404, evidenceNotFoundJSON},
+		{"POST", "/ob/disputeevidence", `{"orderId":"QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG","name":"photo.jpg","data":"not base64!"}`, 400, anyResponseJSON},
+	})
+}
+
+func TestDisputeProposals(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"POST", "/ob/disputeproposal", `{"orderId":"QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG","buyerPercentage":70,"vendorPercentage":20}`, 500, invalidSplitJSON},
+		{"POST", "/ob/disputeproposal", `{"orderId":"QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG","buyerPercentage":70,"vendorPercentage":30}`, 404, caseNotFoundJSON},
+		{"POST", "/ob/acceptdisputeproposal", `{"orderId":"QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG","proposalId":"abc"}`, 404, caseNotFoundJSON},
+	})
+}
+
+func TestDigitalGoods(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/digitalgood/ebook", "", 404, digitalGoodNotFoundJSON},
+		{"POST", "/ob/digitalgood", `{"slug":"ebook","filename":"book.epub","data":"Ym9vaw=="}`, 400, anyResponseJSON},
+		{"DELETE", "/ob/digitalgood", `{"slug":"ebook"}`, 404, digitalGoodNotFoundJSON},
+		{"GET", "/ob/digitaldelivery/QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG/QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o", "", 404, digitalDeliveryNotFoundJSON},
+	})
+}
+
+func TestCouponRedemptions(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/couponredemptions/ron-swanson-tshirt", "", 200, "[]"},
+	})
+}
+
+func TestPromotions(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/promotions", "", 404, promotionsNotFoundJSON},
+		{"PUT", "/ob/promotions", `{"promotions":[{"id":"sale","percentDiscount":150}]}`, 400, invalidPromotionJSON},
+		{"PUT", "/ob/promotions", `{"promotions":[{"id":"sale","title":"Weekend sale","categories":["shirts"],"percentDiscount":10}]}`, 200, `{}`},
+		{"GET", "/ob/promotions", "", 200, anyResponseJSON},
+	})
+}
+
+func TestAPITokens(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"GET", "/ob/apitokens", "", 200, "[]"},
+		{"POST", "/ob/apitokens", `{"name":"fulfillment","scopes":["admin"]}`, 400, unknownAPITokenScopeJSON},
+		{"POST", "/ob/apitokens", `{"name":"fulfillment","scopes":["read","orders"]}`, 200, anyResponseJSON},
+		{"GET", "/ob/apitokens", "", 200, anyResponseJSON},
+		{"DELETE", "/ob/apitokens", `{"name":"fulfillment"}`, 200, "{}"},
+		{"DELETE", "/ob/apitokens", `{"name":"nobody"}`, 404, apiTokenNotFoundJSON},
+	})
+}
+
+func TestAuditLog(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"DELETE", "/ob/apitokens", `{"name":"audited"}`, 404, apiTokenNotFoundJSON},
+		{"GET", "/ob/auditlog?method=DELETE&path=/ob/apitokens&limit=1", "", 200, anyResponseJSON},
+		{"GET", "/ob/auditlog?since=yesterday", "", 400, anyResponseJSON},
+		{"GET", "/ob/auditlog/verify", "", 200, `{"valid": true}`},
+		{"GET", "/ob/auditlog/head", "", 200, anyResponseJSON},
+		{"GET", "/ob/auditlog/verify?id=100000&hash=abc", "", 200, `{"valid": false, "error": "Audit log does not contain head 100000"}`},
+	})
+}
+
+func TestAcceptRefund(t *testing.T) {
+	runAPITests(t, apiTests{
+		{"POST", "/ob/acceptrefund", `{"orderId":"QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"}`, 404, refundOfferNotFoundJSON},
+	})
+}
+
 func Test404(t *testing.T) {
 	// Test undefined endpoints
 	runAPITests(t, apiTests{
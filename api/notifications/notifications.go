@@ -62,6 +62,10 @@ type refundWrapper struct {
 	RefundNotification `json:"refund"`
 }
 
+type refundOfferWrapper struct {
+	RefundOfferNotification `json:"refundOffer"`
+}
+
 type fulfillmentWrapper struct {
 	FulfillmentNotification `json:"orderFulfillment"`
 }
@@ -82,6 +86,42 @@ type disputeCloseWrapper struct {
 	DisputeCloseNotification `json:"disputeClose"`
 }
 
+type disputeEndorsementWrapper struct {
+	DisputeEndorsementNotification `json:"disputeEndorsement"`
+}
+
+type disputeProposalWrapper struct {
+	DisputeProposalNotification `json:"disputeProposal"`
+}
+
+type disputeProposalAcceptedWrapper struct {
+	DisputeProposalAcceptedNotification `json:"disputeProposalAccepted"`
+}
+
+type bidWrapper struct {
+	BidNotification `json:"bid"`
+}
+
+type auctionWonWrapper struct {
+	AuctionWonNotification `json:"auctionWon"`
+}
+
+type auctionLostWrapper struct {
+	AuctionLostNotification `json:"auctionLost"`
+}
+
+type crowdFundWrapper struct {
+	CrowdFundNotification `json:"crowdFund"`
+}
+
+type orderExpiringWrapper struct {
+	OrderExpiringNotification `json:"orderExpiring"`
+}
+
+type escrowTimeoutWrapper struct {
+	EscrowTimeoutNotification `json:"escrowTimeout"`
+}
+
 type OrderNotification struct {
 	Title             string `json:"title"`
 	BuyerId           string `json:"buyerId"`
@@ -108,6 +148,11 @@ type RefundNotification struct {
 	OrderId string `json:"orderId"`
 }
 
+type RefundOfferNotification struct {
+	OrderId string `json:"orderId"`
+	Amount  uint64 `json:"amount"`
+}
+
 type FulfillmentNotification struct {
 	OrderId string `json:"orderId"`
 }
@@ -128,6 +173,67 @@ type DisputeCloseNotification struct {
 	OrderId string `json:"orderId"`
 }
 
+// A moderator on an order's panel has endorsed the dispute resolution. Funds can be
+// released once Signatures, which counts the coordinating moderator, reaches Required.
+type DisputeEndorsementNotification struct {
+	OrderId    string `json:"orderId"`
+	Moderator  string `json:"moderator"`
+	Signatures int    `json:"signatures"`
+	Required   int    `json:"required"`
+}
+
+type DisputeProposalNotification struct {
+	OrderId          string  `json:"orderId"`
+	ProposalId       string  `json:"proposalId"`
+	ProposedBy       string  `json:"proposedBy"`
+	BuyerPercentage  float32 `json:"buyerPercentage"`
+	VendorPercentage float32 `json:"vendorPercentage"`
+}
+
+type DisputeProposalAcceptedNotification struct {
+	OrderId    string `json:"orderId"`
+	ProposalId string `json:"proposalId"`
+	AcceptedBy string `json:"acceptedBy"`
+}
+
+type BidNotification struct {
+	BidId  string `json:"bidId"`
+	Slug   string `json:"slug"`
+	PeerId string `json:"peerId"`
+	Amount uint64 `json:"amount"`
+}
+
+type AuctionWonNotification struct {
+	BidId   string `json:"bidId"`
+	Slug    string `json:"slug"`
+	OrderId string `json:"orderId"`
+}
+
+type AuctionLostNotification struct {
+	BidId  string `json:"bidId"`
+	Slug   string `json:"slug"`
+	Reason string `json:"reason"`
+}
+
+type CrowdFundNotification struct {
+	Slug    string `json:"slug"`
+	Goal    uint64 `json:"goal"`
+	Raised  uint64 `json:"raised"`
+	GoalMet bool   `json:"goalMet"`
+}
+
+type OrderExpiringNotification struct {
+	OrderId string    `json:"orderId"`
+	Title   string    `json:"title"`
+	Expires time.Time `json:"expires"`
+}
+
+type EscrowTimeoutNotification struct {
+	OrderId string    `json:"orderId"`
+	Title   string    `json:"title"`
+	Timeout time.Time `json:"timeout"`
+}
+
 type FollowNotification struct {
 	Follow string `json:"follow"`
 }
@@ -214,6 +320,12 @@ func Serialize(i interface{}) []byte {
 				RefundNotification: i.(RefundNotification),
 			},
 		}
+	case RefundOfferNotification:
+		n = notificationWrapper{
+			refundOfferWrapper{
+				RefundOfferNotification: i.(RefundOfferNotification),
+			},
+		}
 	case FulfillmentNotification:
 		n = notificationWrapper{
 			fulfillmentWrapper{
@@ -244,6 +356,60 @@ func Serialize(i interface{}) []byte {
 				DisputeCloseNotification: i.(DisputeCloseNotification),
 			},
 		}
+	case DisputeEndorsementNotification:
+		n = notificationWrapper{
+			disputeEndorsementWrapper{
+				DisputeEndorsementNotification: i.(DisputeEndorsementNotification),
+			},
+		}
+	case DisputeProposalNotification:
+		n = notificationWrapper{
+			disputeProposalWrapper{
+				DisputeProposalNotification: i.(DisputeProposalNotification),
+			},
+		}
+	case DisputeProposalAcceptedNotification:
+		n = notificationWrapper{
+			disputeProposalAcceptedWrapper{
+				DisputeProposalAcceptedNotification: i.(DisputeProposalAcceptedNotification),
+			},
+		}
+	case BidNotification:
+		n = notificationWrapper{
+			bidWrapper{
+				BidNotification: i.(BidNotification),
+			},
+		}
+	case AuctionWonNotification:
+		n = notificationWrapper{
+			auctionWonWrapper{
+				AuctionWonNotification: i.(AuctionWonNotification),
+			},
+		}
+	case AuctionLostNotification:
+		n = notificationWrapper{
+			auctionLostWrapper{
+				AuctionLostNotification: i.(AuctionLostNotification),
+			},
+		}
+	case CrowdFundNotification:
+		n = notificationWrapper{
+			crowdFundWrapper{
+				CrowdFundNotification: i.(CrowdFundNotification),
+			},
+		}
+	case OrderExpiringNotification:
+		n = notificationWrapper{
+			orderExpiringWrapper{
+				OrderExpiringNotification: i.(OrderExpiringNotification),
+			},
+		}
+	case EscrowTimeoutNotification:
+		n = notificationWrapper{
+			escrowTimeoutWrapper{
+				EscrowTimeoutNotification: i.(EscrowTimeoutNotification),
+			},
+		}
 	case FollowNotification:
 		n = notificationWrapper{
 			i.(FollowNotification),
@@ -296,6 +462,76 @@ func Serialize(i interface{}) []byte {
 	return b
 }
 
+// Event types which can be forwarded to external notifiers
+var EventTypes = []string{
+	"order", "payment", "orderConfirmation", "orderCancel", "refund", "refundOffer",
+	"fulfillment", "completion", "disputeOpen", "disputeUpdate", "disputeClose", "disputeEndorsement",
+	"disputeProposal", "disputeProposalAccepted", "bid", "auctionWon", "auctionLost",
+	"crowdFund", "orderExpiring", "escrowTimeout", "follow", "unfollow", "moderatorAdd",
+	"moderatorRemove", "chatMessage", "incomingTransaction",
+}
+
+// EventType returns the name used to filter a notification in notifier settings.
+// Transient updates such as typing indicators return an empty string and are only
+// sent to the websocket.
+func EventType(i interface{}) string {
+	switch i.(type) {
+	case OrderNotification:
+		return "order"
+	case PaymentNotification:
+		return "payment"
+	case OrderConfirmationNotification:
+		return "orderConfirmation"
+	case OrderCancelNotification:
+		return "orderCancel"
+	case RefundNotification:
+		return "refund"
+	case RefundOfferNotification:
+		return "refundOffer"
+	case FulfillmentNotification:
+		return "fulfillment"
+	case CompletionNotification:
+		return "completion"
+	case DisputeOpenNotification:
+		return "disputeOpen"
+	case DisputeUpdateNotification:
+		return "disputeUpdate"
+	case DisputeCloseNotification:
+		return "disputeClose"
+	case DisputeEndorsementNotification:
+		return "disputeEndorsement"
+	case DisputeProposalNotification:
+		return "disputeProposal"
+	case DisputeProposalAcceptedNotification:
+		return "disputeProposalAccepted"
+	case BidNotification:
+		return "bid"
+	case AuctionWonNotification:
+		return "auctionWon"
+	case AuctionLostNotification:
+		return "auctionLost"
+	case CrowdFundNotification:
+		return "crowdFund"
+	case OrderExpiringNotification:
+		return "orderExpiring"
+	case EscrowTimeoutNotification:
+		return "escrowTimeout"
+	case FollowNotification:
+		return "follow"
+	case UnfollowNotification:
+		return "unfollow"
+	case ModeratorAddNotification:
+		return "moderatorAdd"
+	case ModeratorRemoveNotification:
+		return "moderatorRemove"
+	case ChatMessage:
+		return "chatMessage"
+	case IncomingTransaction:
+		return "incomingTransaction"
+	}
+	return ""
+}
+
 func Describe(i interface{}) (string, string) {
 	var head, body string
 	switch i.(type) {
@@ -340,6 +576,13 @@ func Describe(i interface{}) (string, string) {
 		form := "Payment refund for order \"%s\" received."
 		body = fmt.Sprintf(form, n.OrderId)
 
+	case RefundOfferNotification:
+		head = "Partial refund offered"
+
+		n := i.(RefundOfferNotification)
+		form := "The vendor offered to refund %d of the payment for order \"%s\" and release the rest of the escrow to themselves. The refund is only made once you accept it."
+		body = fmt.Sprintf(form, n.Amount, n.OrderId)
+
 	case FulfillmentNotification:
 		head = "Order fulfilled"
 
@@ -374,6 +617,74 @@ func Describe(i interface{}) (string, string) {
 		n := i.(DisputeCloseNotification)
 		form := "Dispute around order \"%s\" was closed."
 		body = fmt.Sprintf(form, n.OrderId)
+
+	case DisputeEndorsementNotification:
+		head = "Dispute resolution endorsed"
+
+		n := i.(DisputeEndorsementNotification)
+		form := "Moderator %s endorsed the resolution of the dispute around order \"%s\". %d of the %d moderator signatures needed to release the funds have been received."
+		body = fmt.Sprintf(form, n.Moderator, n.OrderId, n.Signatures, n.Required)
+
+	case DisputeProposalNotification:
+		head = "Dispute settlement proposed"
+
+		n := i.(DisputeProposalNotification)
+		form := "%s proposed settling the dispute around order \"%s\" with %g%% to the buyer and %g%% to the vendor.\n\nProposal ID: %s"
+		body = fmt.Sprintf(form, n.ProposedBy, n.OrderId, n.BuyerPercentage, n.VendorPercentage, n.ProposalId)
+
+	case DisputeProposalAcceptedNotification:
+		head = "Dispute settlement accepted"
+
+		n := i.(DisputeProposalAcceptedNotification)
+		form := "%s accepted the proposal to settle the dispute around order \"%s\".\n\nProposal ID: %s"
+		body = fmt.Sprintf(form, n.AcceptedBy, n.OrderId, n.ProposalId)
+
+	case BidNotification:
+		head = "Bid received"
+
+		n := i.(BidNotification)
+		form := "You received a bid of %d on \"%s\".\n\nBid ID: %s\nBidder: %s"
+		body = fmt.Sprintf(form, n.Amount, n.Slug, n.BidId, n.PeerId)
+
+	case AuctionWonNotification:
+		head = "Auction won"
+
+		n := i.(AuctionWonNotification)
+		form := "You won the auction for \"%s\".\n\nBid ID: %s\nOrder ID: %s"
+		body = fmt.Sprintf(form, n.Slug, n.BidId, n.OrderId)
+
+	case AuctionLostNotification:
+		head = "Auction lost"
+
+		n := i.(AuctionLostNotification)
+		form := "Your bid on \"%s\" did not win the auction (%s)."
+		body = fmt.Sprintf(form, n.Slug, n.Reason)
+
+	case CrowdFundNotification:
+		head = "Crowdfund closed"
+
+		n := i.(CrowdFundNotification)
+		if n.GoalMet {
+			form := "Your crowdfund \"%s\" met its goal, raising %d of %d. Pledges are being released."
+			body = fmt.Sprintf(form, n.Slug, n.Raised, n.Goal)
+		} else {
+			form := "Your crowdfund \"%s\" raised %d of %d and did not meet its goal. Pledges are being refunded."
+			body = fmt.Sprintf(form, n.Slug, n.Raised, n.Goal)
+		}
+
+	case OrderExpiringNotification:
+		head = "Unpaid order expiring"
+
+		n := i.(OrderExpiringNotification)
+		form := "The order for \"%s\" has not been paid and will be canceled on %s.\n\nOrder ID: %s"
+		body = fmt.Sprintf(form, n.Title, n.Expires.Format(time.RFC1123), n.OrderId)
+
+	case EscrowTimeoutNotification:
+		head = "Escrow timeout approaching"
+
+		n := i.(EscrowTimeoutNotification)
+		form := "The vendor may claim the escrowed funds for \"%s\" after %s unless the order is completed or disputed.\n\nOrder ID: %s"
+		body = fmt.Sprintf(form, n.Title, n.Timeout.Format(time.RFC1123), n.OrderId)
 	}
 	return head, body
 }
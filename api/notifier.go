@@ -1,16 +1,43 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"net/smtp"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
-	"errors"
 	"github.com/OpenBazaar/openbazaar-go/api/notifications"
 	"github.com/OpenBazaar/openbazaar-go/core"
 	"github.com/OpenBazaar/openbazaar-go/repo"
 )
 
+const (
+	// Number of times a notifier is tried before the delivery is logged as failed
+	NotifierMaxAttempts = 5
+
+	// How long delivery records are kept
+	NotifierDeliveryLogAge = time.Hour * 24 * 30
+
+	notifierTimeout = time.Second * 30
+)
+
+// Delay before the first retry. It doubles after each failed attempt.
+var notifierRetryDelay = time.Second * 2
+
 // Notification manager intercepts data form 'inChan' which is embedded
 // in different parts of the system and retransmits to the 'outChan',
 // which is listened by websocket API, while adding specific handling for
@@ -21,6 +48,9 @@ type notificationManager struct {
 
 func manageNotifications(node *core.OpenBazaarNode, out chan []byte) chan interface{} {
 	manager := &notificationManager{node: node}
+	if err := node.Datastore.NotifierDeliveries().Prune(time.Now().Add(-NotifierDeliveryLogAge)); err != nil {
+		log.Error(err)
+	}
 	nodeBroadcast := make(chan interface{})
 	go func() {
 		for {
@@ -40,32 +70,127 @@ func manageNotifications(node *core.OpenBazaarNode, out chan []byte) chan interf
 	return nodeBroadcast
 }
 
-type notifier interface {
-	notify(n interface{}) error
+// Notifier delivers notifications to a service outside of the node
+type Notifier interface {
+	// Name identifies the notifier in the delivery log
+	Name() string
+
+	// Deliver a notification. A returned error causes the delivery to be retried.
+	Notify(n interface{}) error
+}
+
+// NotifierFactory builds a notifier from its settings. It should return an error
+// if the settings are incomplete so they can be rejected when saved.
+type NotifierFactory func(settings repo.NotifierSettings) (Notifier, error)
+
+var notifierFactories = make(map[string]NotifierFactory)
+
+// RegisterNotifier makes a notifier available under the given type in the notifier
+// settings. It should be called from an init function.
+func RegisterNotifier(notifierType string, factory NotifierFactory) {
+	notifierFactories[notifierType] = factory
+}
+
+func init() {
+	RegisterNotifier("webhook", newWebhookNotifier)
+	RegisterNotifier("matrix", newMatrixNotifier)
+}
+
+// A notifier along with the events it has been configured to receive
+type filteredNotifier struct {
+	Notifier
+	events []string
+}
+
+func (f filteredNotifier) wants(event string) bool {
+	if len(f.events) == 0 {
+		return true
+	}
+	for _, e := range f.events {
+		if e == event {
+			return true
+		}
+	}
+	return false
 }
 
 // Send notification via all supported notifier mechanisms
 func (m *notificationManager) sendNotification(n interface{}) {
+	event := notifications.EventType(n)
+	if event == "" {
+		return
+	}
 	for _, notifier := range m.getNotifiers() {
-		if err := notifier.notify(n); err != nil {
-			log.Errorf("Notification failed")
+		if notifier.wants(event) {
+			go m.deliver(notifier, event, n)
 		}
 	}
 }
 
-// Create list of notifiers based on settings data (currently only SMTP is available)
-// TODO: should be extended to include new notifiers in the list
-func (m *notificationManager) getNotifiers() []notifier {
+// Try the notifier with exponential backoff and record the outcome in the delivery log
+func (m *notificationManager) deliver(notifier Notifier, event string, n interface{}) {
+	delay := notifierRetryDelay
+	var err error
+	attempts := 0
+	for attempts < NotifierMaxAttempts {
+		attempts++
+		if err = notifier.Notify(n); err == nil {
+			break
+		}
+		log.Warningf("%s notification failed (attempt %d): %s", notifier.Name(), attempts, err)
+		if attempts < NotifierMaxAttempts {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+	delivery := repo.NotifierDelivery{
+		Notifier:  notifier.Name(),
+		Event:     event,
+		Attempts:  attempts,
+		Delivered: err == nil,
+		Timestamp: time.Now(),
+	}
+	if err != nil {
+		log.Errorf("%s notification failed: %s", notifier.Name(), err)
+		delivery.Error = err.Error()
+	}
+	if err := m.node.Datastore.NotifierDeliveries().Put(delivery); err != nil {
+		log.Error(err)
+	}
+}
+
+// Create list of notifiers based on settings data
+func (m *notificationManager) getNotifiers() []filteredNotifier {
 	settings, err := m.node.Datastore.Settings().Get()
-	notifiers := make([]notifier, 0)
+	notifiers := make([]filteredNotifier, 0)
 	if err != nil {
 		return notifiers
 	}
 
 	// SMTP notifier
 	conf := settings.SMTPSettings
-	if conf.Notifications {
-		notifiers = append(notifiers, &smtpNotifier{settings: conf})
+	if conf != nil && conf.Notifications {
+		notifiers = append(notifiers, filteredNotifier{&smtpNotifier{settings: conf}, conf.Events})
+	}
+
+	// Registered notifiers
+	if settings.Notifiers != nil {
+		for _, s := range *settings.Notifiers {
+			if !s.Enabled {
+				continue
+			}
+			factory, ok := notifierFactories[s.Type]
+			if !ok {
+				log.Errorf("Unknown notifier type %s", s.Type)
+				continue
+			}
+			notifier, err := factory(s)
+			if err != nil {
+				log.Errorf("Invalid %s notifier: %s", s.Type, err)
+				continue
+			}
+			notifiers = append(notifiers, filteredNotifier{notifier, s.Events})
+		}
 	}
 	return notifiers
 }
@@ -75,7 +200,11 @@ type smtpNotifier struct {
 	settings *repo.SMTPSettings
 }
 
-func (notifier *smtpNotifier) notify(n interface{}) error {
+func (notifier *smtpNotifier) Name() string {
+	return "smtp"
+}
+
+func (notifier *smtpNotifier) Notify(n interface{}) error {
 	template := strings.Join([]string{
 		"From: %s",
 		"To: %s",
@@ -102,10 +231,210 @@ func sendEmail(conf *repo.SMTPSettings, body []byte) error {
 	return smtp.SendMail(conf.ServerAddress, auth, conf.SenderEmail, recipients, body)
 }
 
+// The webhook notifier posts the serialized notification to a URL. The receiver can
+// authenticate the request by computing the HMAC-SHA256 of the timestamp header, a
+// period and the body with the shared secret and comparing it to the signature header.
+type webhookNotifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func newWebhookNotifier(settings repo.NotifierSettings) (Notifier, error) {
+	u, err := url.Parse(settings.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("Webhook URL must be an http or https URL")
+	}
+	if settings.Secret == "" {
+		return nil, errors.New("Webhook secret must be set")
+	}
+	return &webhookNotifier{
+		url:    settings.URL,
+		secret: settings.Secret,
+		client: &http.Client{Timeout: notifierTimeout},
+	}, nil
+}
+
+func (notifier *webhookNotifier) Name() string {
+	return "webhook"
+}
+
+func webhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (notifier *webhookNotifier) Notify(n interface{}) error {
+	body := notifications.Serialize(n)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequest("POST", notifier.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-OpenBazaar-Event", notifications.EventType(n))
+	req.Header.Set("X-OpenBazaar-Timestamp", timestamp)
+	req.Header.Set("X-OpenBazaar-Signature", "sha256="+webhookSignature(notifier.secret, timestamp, body))
+	resp, err := notifier.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Webhook returned status %s", resp.Status)
+	}
+	return nil
+}
+
+// The matrix notifier posts a text message describing the notification to a room
+type matrixNotifier struct {
+	homeServer  string
+	roomID      string
+	accessToken string
+	client      *http.Client
+}
+
+func newMatrixNotifier(settings repo.NotifierSettings) (Notifier, error) {
+	u, err := url.Parse(settings.HomeServer)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("Matrix home server must be an http or https URL")
+	}
+	if settings.RoomID == "" || settings.AccessToken == "" {
+		return nil, errors.New("Matrix room ID and access token must be set")
+	}
+	return &matrixNotifier{
+		homeServer:  strings.TrimRight(settings.HomeServer, "/"),
+		roomID:      settings.RoomID,
+		accessToken: settings.AccessToken,
+		client:      &http.Client{Timeout: notifierTimeout},
+	}, nil
+}
+
+func (notifier *matrixNotifier) Name() string {
+	return "matrix"
+}
+
+func (notifier *matrixNotifier) Notify(n interface{}) error {
+	head, body := notifications.Describe(n)
+	if head == "" {
+		head = notifications.EventType(n)
+	}
+	message, err := json.Marshal(map[string]string{
+		"msgtype": "m.text",
+		"body":    "[OpenBazaar] " + head + "\n\n" + body,
+	})
+	if err != nil {
+		return err
+	}
+	txnID := make([]byte, 16)
+	if _, err := rand.Read(txnID); err != nil {
+		return err
+	}
+	endpoint := notifier.homeServer + "/_matrix/client/r0/rooms/" + url.PathEscape(notifier.roomID) + "/send/m.room.message/" + hex.EncodeToString(txnID)
+	req, err := http.NewRequest("PUT", endpoint, bytes.NewReader(message))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+notifier.accessToken)
+	resp, err := notifier.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Matrix returned status %s", resp.Status)
+	}
+	return nil
+}
+
+// The command notifier runs a local command with the serialized notification on
+// stdin and the event type in the OB_EVENT environment variable. A non-zero exit
+// status counts as a failed delivery.
+type commandNotifier struct {
+	command string
+	args    []string
+}
+
+// Return a factory for the command notifier, which runs a command with each notification on
+// its standard input. The command is taken from the config file so the API can't be used to
+// run programs on the host. The notifier settings only enable it and choose its events.
+func CommandNotifierFactory(command string, args []string) NotifierFactory {
+	return func(settings repo.NotifierSettings) (Notifier, error) {
+		return &commandNotifier{command: command, args: args}, nil
+	}
+}
+
+func (notifier *commandNotifier) Name() string {
+	return "command"
+}
+
+func (notifier *commandNotifier) Notify(n interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), notifierTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, notifier.command, notifier.args...)
+	cmd.Stdin = bytes.NewReader(notifications.Serialize(n))
+	cmd.Env = append(os.Environ(), "OB_EVENT="+notifications.EventType(n))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdout = ioutil.Discard
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
 func validateSMTPSettings(s repo.SettingsData) error {
 	if s.SMTPSettings != nil && s.SMTPSettings.Notifications &&
 		(s.SMTPSettings.Password == "" || s.SMTPSettings.Username == "" || s.SMTPSettings.RecipientEmail == "" || s.SMTPSettings.SenderEmail == "" || s.SMTPSettings.ServerAddress == "") {
 		return errors.New("SMTP fields must be set if notifications are turned on")
 	}
+	if s.SMTPSettings != nil {
+		if err := validateNotifierEvents(s.SMTPSettings.Events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateNotifierSettings(s repo.SettingsData) error {
+	if s.Notifiers == nil {
+		return nil
+	}
+	for _, n := range *s.Notifiers {
+		factory, ok := notifierFactories[n.Type]
+		if !ok {
+			return fmt.Errorf("Unknown notifier type %s", n.Type)
+		}
+		if n.Enabled {
+			if _, err := factory(n); err != nil {
+				return err
+			}
+		}
+		if err := validateNotifierEvents(n.Events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateNotifierEvents(events []string) error {
+	for _, event := range events {
+		known := false
+		for _, e := range notifications.EventTypes {
+			if e == event {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("Unknown notification event %s", event)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	n := notifications.OrderNotification{Title: "Widget", OrderId: "QmOrder"}
+	var body []byte
+	var header http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer ts.Close()
+
+	notifier, err := newWebhookNotifier(repo.NotifierSettings{URL: ts.URL, Secret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := notifier.Notify(n); err != nil {
+		t.Error(err)
+	}
+	if string(body) != string(notifications.Serialize(n)) {
+		t.Error("Webhook body was not the serialized notification")
+	}
+	if header.Get("X-OpenBazaar-Event") != "order" {
+		t.Error("Webhook sent incorrect event type")
+	}
+	sig := webhookSignature("secret", header.Get("X-OpenBazaar-Timestamp"), body)
+	if header.Get("X-OpenBazaar-Signature") != "sha256="+sig {
+		t.Error("Webhook signature did not verify")
+	}
+}
+
+func TestWebhookNotifierStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+	notifier, _ := newWebhookNotifier(repo.NotifierSettings{URL: ts.URL, Secret: "secret"})
+	if err := notifier.Notify(notifications.PaymentNotification{}); err == nil {
+		t.Error("Webhook failure was not reported")
+	}
+}
+
+func TestValidateNotifierSettings(t *testing.T) {
+	valid := []repo.NotifierSettings{
+		{Type: "webhook", Enabled: true, URL: "https://example.com/hook", Secret: "secret", Events: []string{"order"}},
+		{Type: "matrix", Enabled: true, HomeServer: "https://matrix.org", RoomID: "!room:matrix.org", AccessToken: "token"},
+		{Type: "webhook", Enabled: false},
+	}
+	if err := validateNotifierSettings(repo.SettingsData{Notifiers: &valid}); err != nil {
+		t.Error(err)
+	}
+	// The command notifier only exists when the config file sets its command
+	command := []repo.NotifierSettings{{Type: "command", Enabled: true}}
+	if err := validateNotifierSettings(repo.SettingsData{Notifiers: &command}); err == nil {
+		t.Error("Accepted the command notifier without a command in the config file")
+	}
+	RegisterNotifier("command", CommandNotifierFactory("/usr/local/bin/process-order", nil))
+	defer delete(notifierFactories, "command")
+	if err := validateNotifierSettings(repo.SettingsData{Notifiers: &command}); err != nil {
+		t.Error(err)
+	}
+	invalid := [][]repo.NotifierSettings{
+		{{Type: "pager", Enabled: true}},
+		{{Type: "webhook", Enabled: true, URL: "ftp://example.com", Secret: "secret"}},
+		{{Type: "webhook", Enabled: true, URL: "https://example.com/hook"}},
+		{{Type: "command", Enabled: true, Events: []string{"typing"}}},
+	}
+	for _, notifiers := range invalid {
+		n := notifiers
+		if err := validateNotifierSettings(repo.SettingsData{Notifiers: &n}); err == nil {
+			t.Errorf("Accepted invalid notifier settings %+v", n[0])
+		}
+	}
+}
@@ -9,9 +9,14 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/ipfs/go-ipfs/commands"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
+// The number of calls each connection may have in progress at once. Further requests wait
+// until one finishes.
+const maxConcurrentCalls = 8
+
 type connection struct {
 	// The websocket connection
 	ws *websocket.Conn
@@ -21,6 +26,28 @@ type connection struct {
 
 	// The hub
 	h *hub
+
+	// Handles API calls made over the connection
+	api *jsonAPIHandler
+
+	// Who opened the connection, as recorded in the audit log
+	identity string
+
+	// The API token the connection was opened with, if any. Calls are checked against
+	// its scopes so revoking the token takes effect immediately.
+	tokenSecret string
+
+	// The scopes of the API token, which limit the notifications sent to the connection.
+	// Nil for connections opened with the node's credentials.
+	tokenScopes []string
+
+	// Limits the calls in progress
+	calls chan struct{}
+
+	remoteAddr string
+
+	// The notifications sent to the connection. Only read and written by the hub.
+	subscription *subscription
 }
 
 func (c *connection) reader() {
@@ -30,9 +57,11 @@ func (c *connection) reader() {
 			break
 		}
 		log.Debugf("Incoming websocket message: %s", string(message))
-
-		// Just echo for now until we set up the API
-		c.h.Broadcast <- message
+		c.calls <- struct{}{}
+		go func() {
+			defer func() { <-c.calls }()
+			c.handleRequest(message)
+		}()
 	}
 	c.ws.Close()
 }
@@ -47,40 +76,48 @@ func (c *connection) writer() {
 	c.ws.Close()
 }
 
-var upgrader = &websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin:     func(r *http.Request) bool { return true },
-}
-
 var handler wsHandler
 
 type wsHandler struct {
 	h             *hub
+	node          *core.OpenBazaarNode
+	api           *jsonAPIHandler
 	path          string
 	context       commands.Context
 	enabled       bool
 	authenticated bool
 	allowedIPs    map[string]bool
+	allowedOrigin string
 	cookie        http.Cookie
 	username      string
 	password      string
 }
 
 func newWSAPIHandler(node *core.OpenBazaarNode, ctx commands.Context, authCookie http.Cookie, config repo.APIConfig) (*wsHandler, error) {
+	api, err := newJsonAPIHandler(node, authCookie, config)
+	if err != nil {
+		return nil, err
+	}
 	hub := newHub()
 	go hub.run()
 	allowedIps := make(map[string]bool)
 	for _, ip := range config.AllowedIPs {
 		allowedIps[ip] = true
 	}
+	var allowedOrigin string
+	if config.CORS != nil {
+		allowedOrigin = *config.CORS
+	}
 	handler = wsHandler{
 		h:             hub,
+		node:          node,
+		api:           api,
 		path:          ctx.ConfigRoot,
 		context:       ctx,
 		enabled:       config.Enabled,
 		authenticated: config.Authenticated,
 		allowedIPs:    allowedIps,
+		allowedOrigin: allowedOrigin,
 		cookie:        authCookie,
 		username:      config.Username,
 		password:      config.Password,
@@ -88,12 +125,25 @@ func newWSAPIHandler(node *core.OpenBazaarNode, ctx commands.Context, authCookie
 	return &handler, nil
 }
 
-func (wsh wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ws, err := upgrader.Upgrade(w, r, nil)
+// Browsers don't apply CORS to websockets but do send the origin of the page opening them.
+// Only our own pages and the origin allowed by the CORS setting may connect, otherwise any
+// website could use the auth cookie. Clients other than browsers don't send an origin.
+func (wsh wsHandler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
 	if err != nil {
-		log.Error("Error upgrading to websockets:", err)
-		return
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
 	}
+	return wsh.allowedOrigin != "" && wsh.allowedOrigin != "*" && origin == wsh.allowedOrigin
+}
+
+func (wsh wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if !wsh.enabled {
 		w.WriteHeader(http.StatusForbidden)
 		fmt.Fprint(w, "403 - Forbidden")
@@ -107,7 +157,20 @@ func (wsh wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	if wsh.authenticated {
+	identity := identityAnonymous
+	var token repo.APIToken
+	secret, hasToken := bearerToken(r)
+	if hasToken {
+		// The token's scopes are checked on each call rather than for the connection itself
+		var err error
+		token, err = authenticateAPIToken(wsh.node.Datastore.APITokens(), secret)
+		if err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, "403 - Forbidden")
+			return
+		}
+		identity = identityTokenPrefix + token.Name
+	} else if wsh.authenticated {
 		if wsh.username == "" || wsh.password == "" {
 			cookie, err := r.Cookie("OpenBazaar_Auth_Cookie")
 			if err != nil {
@@ -120,6 +183,7 @@ func (wsh wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				fmt.Fprint(w, "403 - Forbidden")
 				return
 			}
+			identity = identityCookie
 		} else {
 			username, password, ok := r.BasicAuth()
 			h := sha256.Sum256([]byte(password))
@@ -129,9 +193,32 @@ func (wsh wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				fmt.Fprint(w, "403 - Forbidden")
 				return
 			}
+			identity = identityUserPrefix + username
 		}
 	}
-	c := &connection{send: make(chan []byte, 256), ws: ws, h: wsh.h}
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     wsh.checkOrigin,
+	}
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Error("Error upgrading to websockets:", err)
+		return
+	}
+	c := &connection{
+		send:       make(chan []byte, 256),
+		ws:         ws,
+		h:          wsh.h,
+		api:        wsh.api,
+		identity:   identity,
+		remoteAddr: r.RemoteAddr,
+		calls:      make(chan struct{}, maxConcurrentCalls),
+	}
+	if hasToken {
+		c.tokenSecret = secret
+		c.tokenScopes = token.Scopes
+	}
 	c.h.register <- c
 	defer func() { c.h.unregister <- c }()
 	go c.writer()
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime/debug"
+	"strings"
+)
+
+/* Clients may call the JSON API over the websocket as well as receive notifications on it.
+   Requests and responses follow JSON-RPC 2.0. The method is an API call written as the HTTP
+   method and path, such as "GET /ob/sales" or "POST /ob/orderfulfillment", and the params are
+   the request body. Calls are authorized and audited as if they were made over HTTP by
+   whoever opened the connection: connections opened with the node's credentials or auth
+   cookie may make any call, while those opened with an API token are limited to its scopes.
+   Websockets from other origins are refused when they are opened, so a page on another
+   site can't use the auth cookie the browser sends with them.
+
+   A connection receives every notification its API token's scopes allow until it calls
+   "subscribe", whose params {"types": [...], "orderIds": [...]} limit it to notifications of
+   those types and about those orders. Either list may be left out. "unsubscribe" stops all
+   notifications. */
+
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcInternalError  = -32603
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+func rpcResult(id json.RawMessage, result interface{}) rpcResponse {
+	return rpcResponse{JSONRPC: "2.0", ID: id, Result: result}
+}
+
+func rpcErrorResponse(id json.RawMessage, code int, message string) rpcResponse {
+	return rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
+
+// The notifications a connection has subscribed to. A nil subscription receives everything.
+type subscription struct {
+	none     bool
+	types    map[string]bool
+	orderIDs map[string]bool
+}
+
+func (s *subscription) matches(kind, orderID string) bool {
+	if s == nil {
+		return true
+	}
+	if s.none {
+		return false
+	}
+	if len(s.types) > 0 && !s.types[kind] {
+		return false
+	}
+	if len(s.orderIDs) > 0 && !s.orderIDs[orderID] {
+		return false
+	}
+	return true
+}
+
+// The scope, besides read, an API token needs to receive each type of notification.
+// Types not listed here need the read scope.
+var notificationScopes = map[string]string{
+	"message":                 ScopeChat,
+	"messageRead":             ScopeChat,
+	"messageTyping":           ScopeChat,
+	"wallet":                  ScopeWalletSpend,
+	"order":                   ScopeOrders,
+	"payment":                 ScopeOrders,
+	"orderConfirmation":       ScopeOrders,
+	"refund":                  ScopeOrders,
+	"refundOffer":             ScopeOrders,
+	"orderFulfillment":        ScopeOrders,
+	"orderCompletion":         ScopeOrders,
+	"disputeOpen":             ScopeOrders,
+	"disputeUpdate":           ScopeOrders,
+	"disputeClose":            ScopeOrders,
+	"disputeEndorsement":      ScopeOrders,
+	"disputeProposal":         ScopeOrders,
+	"disputeProposalAccepted": ScopeOrders,
+	"bid":                     ScopeOrders,
+	"auctionWon":              ScopeOrders,
+	"auctionLost":             ScopeOrders,
+	"crowdFund":               ScopeOrders,
+	"orderExpiring":           ScopeOrders,
+	"escrowTimeout":           ScopeOrders,
+	"warning":                 ScopeOrders,
+}
+
+// Check whether a connection opened with a token with the given scopes may receive a type of
+// notification. Connections opened with the node's credentials have no scopes and receive all.
+func notificationAllowed(scopes []string, kind string) bool {
+	if scopes == nil {
+		return true
+	}
+	for _, scope := range scopes {
+		if scope == ScopeRead || scope == notificationScopes[kind] {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the type of a serialized notification, such as "order" or "wallet", and the order
+// it is about if any
+func classifyNotification(m []byte) (kind string, orderID string) {
+	unwrap := func(b []byte) (string, []byte) {
+		var wrapper map[string]json.RawMessage
+		if err := json.Unmarshal(b, &wrapper); err != nil || len(wrapper) != 1 {
+			return "", nil
+		}
+		for k, v := range wrapper {
+			return k, v
+		}
+		return "", nil
+	}
+	kind, inner := unwrap(m)
+	if kind == "notification" {
+		kind, inner = unwrap(inner)
+	}
+	var fields struct {
+		OrderId string `json:"orderId"`
+	}
+	json.Unmarshal(inner, &fields)
+	return kind, fields.OrderId
+}
+
+func (c *connection) handleRequest(message []byte) {
+	ret, err := json.Marshal(c.call(message))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	c.h.direct <- directMessage{c, ret}
+}
+
+func (c *connection) call(message []byte) rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(message, &req); err != nil {
+		return rpcErrorResponse(nil, rpcParseError, "Invalid JSON")
+	}
+	if req.Method == "" {
+		return rpcErrorResponse(req.ID, rpcInvalidRequest, "Request is missing a method")
+	}
+	switch req.Method {
+	case "subscribe":
+		var params struct {
+			Types    []string `json:"types"`
+			OrderIds []string `json:"orderIds"`
+		}
+		if len(req.Params) > 0 {
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				return rpcErrorResponse(req.ID, rpcInvalidParams, err.Error())
+			}
+		}
+		sub := &subscription{types: make(map[string]bool), orderIDs: make(map[string]bool)}
+		for _, t := range params.Types {
+			sub.types[t] = true
+		}
+		for _, id := range params.OrderIds {
+			sub.orderIDs[id] = true
+		}
+		c.h.subscribe <- subscriptionChange{c, sub}
+		return rpcResult(req.ID, struct{}{})
+	case "unsubscribe":
+		c.h.subscribe <- subscriptionChange{c, &subscription{none: true}}
+		return rpcResult(req.ID, struct{}{})
+	}
+	return c.callAPI(req)
+}
+
+// Make a call to the JSON API on behalf of the connection
+func (c *connection) callAPI(req rpcRequest) (resp rpcResponse) {
+	fields := strings.SplitN(req.Method, " ", 2)
+	if len(fields) != 2 || !(strings.HasPrefix(fields[1], "/ob/") || strings.HasPrefix(fields[1], "/wallet/")) {
+		return rpcErrorResponse(req.ID, rpcMethodNotFound, "Unknown method: "+req.Method)
+	}
+	method := strings.ToUpper(fields[0])
+	switch method {
+	case "GET", "POST", "PUT", "PATCH", "DELETE":
+	default:
+		return rpcErrorResponse(req.ID, rpcMethodNotFound, "Unknown method: "+req.Method)
+	}
+	r, err := http.NewRequest(method, fields[1], bytes.NewReader(req.Params))
+	if err != nil {
+		return rpcErrorResponse(req.ID, rpcInvalidRequest, err.Error())
+	}
+	r.RemoteAddr = c.remoteAddr
+
+	rec := httptest.NewRecorder()
+	if isStateChanging(method) {
+		defer func() { recordAudit(c.api.node.Datastore.AuditLog(), r, c.identity, rec.Code) }()
+	}
+	if c.tokenSecret != "" {
+		if _, ok := authorizeAPIToken(c.api.node.Datastore.APITokens(), r, c.tokenSecret); !ok {
+			rec.WriteHeader(http.StatusForbidden)
+			return rpcErrorResponse(req.ID, http.StatusForbidden, "Forbidden")
+		}
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			log.Error("A panic occurred in the websocket api handler!")
+			log.Error(p)
+			debug.PrintStack()
+			rec.Code = http.StatusInternalServerError
+			resp = rpcErrorResponse(req.ID, rpcInternalError, "Internal error")
+		}
+	}()
+	c.api.route(rec, r)
+
+	body := rec.Body.Bytes()
+	if rec.Code >= 400 {
+		var apiErr struct {
+			Reason string `json:"reason"`
+		}
+		msg := strings.TrimSpace(string(body))
+		if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Reason != "" {
+			msg = apiErr.Reason
+		}
+		return rpcErrorResponse(req.ID, rec.Code, msg)
+	}
+	if len(body) == 0 {
+		return rpcResult(req.ID, struct{}{})
+	}
+	// Responses which aren't JSON, such as images, are returned base64 encoded
+	var result json.RawMessage
+	if err := json.Unmarshal(body, &result); err != nil {
+		return rpcResult(req.ID, body)
+	}
+	return rpcResult(req.ID, result)
+}
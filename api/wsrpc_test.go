@@ -0,0 +1,210 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/test"
+	"github.com/gorilla/websocket"
+)
+
+func TestClassifyNotification(t *testing.T) {
+	tests := []struct {
+		message string
+		kind    string
+		orderID string
+	}{
+		{`{"notification": {"order": {"orderId": "QmOrder", "title": "Shirt"}}}`, "order", "QmOrder"},
+		{`{"notification": {"follow": {"peerId": "QmPeer"}}}`, "follow", ""},
+		{`{"wallet": {"txid": "abcd"}}`, "wallet", ""},
+		{`{"status": "publishing"}`, "status", ""},
+		{`not json`, "", ""},
+	}
+	for _, test := range tests {
+		kind, orderID := classifyNotification([]byte(test.message))
+		if kind != test.kind || orderID != test.orderID {
+			t.Errorf("Classified %s as %q %q", test.message, kind, orderID)
+		}
+	}
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	var all *subscription
+	if !all.matches("order", "QmOrder") {
+		t.Error("Connections without a subscription should receive everything")
+	}
+	if (&subscription{none: true}).matches("order", "QmOrder") {
+		t.Error("Unsubscribed connections should receive nothing")
+	}
+	orders := &subscription{types: map[string]bool{"order": true, "payment": true}}
+	if !orders.matches("payment", "QmOrder") || orders.matches("wallet", "") {
+		t.Error("Subscription to types matched incorrectly")
+	}
+	order := &subscription{orderIDs: map[string]bool{"QmOrder": true}}
+	if !order.matches("fulfillment", "QmOrder") || order.matches("fulfillment", "QmOther") {
+		t.Error("Subscription to orders matched incorrectly")
+	}
+}
+
+func TestNotificationAllowed(t *testing.T) {
+	if !notificationAllowed(nil, "wallet") {
+		t.Error("Connections opened with the node's credentials should receive everything")
+	}
+	chat := []string{ScopeChat}
+	if !notificationAllowed(chat, "message") {
+		t.Error("Chat tokens should receive chat messages")
+	}
+	if notificationAllowed(chat, "wallet") || notificationAllowed(chat, "order") || notificationAllowed(chat, "follow") {
+		t.Error("Chat tokens should only receive chat notifications")
+	}
+	if !notificationAllowed([]string{ScopeRead}, "wallet") {
+		t.Error("Read tokens should receive everything")
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	wsh := wsHandler{allowedOrigin: "http://localhost:8080"}
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"", true},
+		{"http://127.0.0.1:4002", true},
+		{"http://localhost:8080", true},
+		{"https://evil.example", false},
+	}
+	for _, test := range tests {
+		r, err := http.NewRequest("GET", "http://127.0.0.1:4002/ws", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.origin != "" {
+			r.Header.Set("Origin", test.origin)
+		}
+		if wsh.checkOrigin(r) != test.allowed {
+			t.Errorf("Origin %q allowed should be %t", test.origin, test.allowed)
+		}
+	}
+	wsh.allowedOrigin = "*"
+	r, _ := http.NewRequest("GET", "http://127.0.0.1:4002/ws", nil)
+	r.Header.Set("Origin", "https://evil.example")
+	if wsh.checkOrigin(r) {
+		t.Error("A wildcard CORS setting should not allow websockets from any origin")
+	}
+}
+
+func TestWebsocketRPC(t *testing.T) {
+	header := http.Header{}
+	req, err := buildRequest("GET", "/ws", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.Set("Authorization", req.Header.Get("Authorization"))
+	header.Set("Cookie", test.GetAuthCookie().String())
+	conn, _, err := websocket.DefaultDialer.Dial("ws://127.0.0.1:9191/ws", header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	call := func(message string) rpcResponse {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+			t.Fatal(err)
+		}
+		var sent rpcRequest
+		json.Unmarshal([]byte(message), &sent)
+		conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+		for {
+			_, b, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var resp rpcResponse
+			// Skip any notifications
+			if err := json.Unmarshal(b, &resp); err != nil || resp.JSONRPC != "2.0" || string(resp.ID) != string(sent.ID) {
+				continue
+			}
+			return resp
+		}
+	}
+
+	resp := call(`{"jsonrpc": "2.0", "id": 1, "method": "subscribe", "params": {"types": ["order"]}}`)
+	if resp.Error != nil {
+		t.Errorf("Subscribe failed: %s", resp.Error.Message)
+	}
+	resp = call(`{"jsonrpc": "2.0", "id": 2, "method": "GET /ob/apitokens"}`)
+	if resp.Error != nil || resp.Result == nil {
+		t.Error("API call over the websocket failed")
+	}
+	resp = call(`{"jsonrpc": "2.0", "id": 3, "method": "DELETE /ob/apitokens", "params": {"name": "nobody"}}`)
+	if resp.Error != nil && resp.Error.Code == http.StatusForbidden {
+		t.Error("State changing call on a connection opened with the node's credentials was refused")
+	}
+	resp = call(`{"jsonrpc": "2.0", "id": 5, "method": "GET /ob/auditlog?method=DELETE&path=/ob/apitokens"}`)
+	if entries, ok := resp.Result.([]interface{}); resp.Error != nil || !ok || len(entries) == 0 {
+		t.Error("State changing call over the websocket was not audited")
+	}
+	resp = call(`{"jsonrpc": "2.0", "id": 4, "method": "launchRockets"}`)
+	if resp.Error == nil || resp.Error.Code != rpcMethodNotFound {
+		t.Error("Unknown method did not return an error")
+	}
+}
+
+func TestWebsocketRPCWithAPIToken(t *testing.T) {
+	req, err := buildRequest("POST", "/ob/apitokens", `{"name":"websocket","scopes":["orders"]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpResp, err := request(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var created struct {
+		Token string `json:"token"`
+	}
+	err = json.NewDecoder(httpResp.Body).Decode(&created)
+	httpResp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+created.Token)
+	header.Set("Origin", "https://evil.example")
+	if _, _, err := websocket.DefaultDialer.Dial("ws://127.0.0.1:9191/ws", header); err == nil {
+		t.Error("Websocket from another origin was not refused")
+	}
+	header.Del("Origin")
+	conn, _, err := websocket.DefaultDialer.Dial("ws://127.0.0.1:9191/ws", header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	call := func(message string) rpcResponse {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+			t.Fatal(err)
+		}
+		conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+		_, b, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resp rpcResponse
+		if err := json.Unmarshal(b, &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp := call(`{"jsonrpc": "2.0", "id": 1, "method": "POST /ob/closedispute", "params": {}}`)
+	if resp.Error != nil && resp.Error.Code == http.StatusForbidden {
+		t.Error("State changing call within the token's scopes was refused")
+	}
+	resp = call(`{"jsonrpc": "2.0", "id": 2, "method": "DELETE /ob/apitokens", "params": {"name": "websocket"}}`)
+	if resp.Error == nil || resp.Error.Code != http.StatusForbidden {
+		t.Error("Call outside the token's scopes was not refused")
+	}
+}
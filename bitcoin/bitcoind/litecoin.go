@@ -0,0 +1,68 @@
+package bitcoind
+
+import (
+	"errors"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// Litecoin Core has the same RPC interface as bitcoind and signs transactions the same way,
+// so the bitcoind wallet runs litecoind with Litecoin's network parameters.
+
+var LitecoinMainNetParams = chaincfg.Params{
+	Name:             "litecoin-mainnet",
+	Net:              wire.BitcoinNet(0xdbb6c0fb),
+	DefaultPort:      "9333",
+	PubKeyHashAddrID: 0x30,
+	ScriptHashAddrID: 0x32,
+	PrivateKeyID:     0xb0,
+	HDPrivateKeyID:   [4]byte{0x01, 0x9d, 0x9c, 0xfe}, // Ltpv
+	HDPublicKeyID:    [4]byte{0x01, 0x9d, 0xa4, 0x62}, // Ltub
+	HDCoinType:       2,
+}
+
+var LitecoinTestNet4Params = chaincfg.Params{
+	Name:             "litecoin-testnet4",
+	Net:              wire.BitcoinNet(0xf1c8d2fd),
+	DefaultPort:      "19335",
+	PubKeyHashAddrID: 0x6f,
+	ScriptHashAddrID: 0x3a,
+	PrivateKeyID:     0xef,
+	HDPrivateKeyID:   [4]byte{0x04, 0x35, 0x83, 0x94}, // tprv
+	HDPublicKeyID:    [4]byte{0x04, 0x35, 0x87, 0xcf}, // tpub
+	HDCoinType:       1,
+}
+
+var litecoinDaemon = daemon{
+	name:           "litecoind",
+	currencyCode:   "ltc",
+	mainnet:        LitecoinMainNetParams.Name,
+	rpcPort:        9332,
+	testnetRPCPort: 19332,
+	notifyPort:     8331,
+}
+
+func init() {
+	// Registering the networks lets Litecoin addresses be decoded
+	for _, params := range []*chaincfg.Params{&LitecoinMainNetParams, &LitecoinTestNet4Params} {
+		if err := chaincfg.Register(params); err != nil {
+			panic(err)
+		}
+	}
+	bitcoin.RegisterWalletType("litecoind", func(opts bitcoin.WalletOptions) (bitcoin.BitcoinWallet, error) {
+		if opts.Binary == "" {
+			return nil, errors.New("The path to the litecoind binary must be specified in the config file when using litecoind")
+		}
+		return NewLitecoindWallet(opts), nil
+	})
+}
+
+func NewLitecoindWallet(opts bitcoin.WalletOptions) *BitcoindWallet {
+	params := &LitecoinMainNetParams
+	if opts.Testnet {
+		params = &LitecoinTestNet4Params
+	}
+	return newDaemonWallet(litecoinDaemon, opts.Mnemonic, params, opts.RepoPath, opts.TrustedPeer, opts.Binary, opts.RPCUser, opts.RPCPassword, opts.UseTor, opts.TorControlPort)
+}
@@ -0,0 +1,47 @@
+package bitcoind
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	btc "github.com/btcsuite/btcutil"
+)
+
+func TestLitecoindWalletType(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	if _, err := bitcoin.NewWallet("litecoind", bitcoin.WalletOptions{Mnemonic: mnemonic}); err == nil {
+		t.Error("Expected an error without a litecoind binary")
+	}
+	w, err := bitcoin.NewWallet("litecoind", bitcoin.WalletOptions{Mnemonic: mnemonic, Binary: "/usr/bin/litecoind"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.CurrencyCode() != "ltc" {
+		t.Errorf("Expected ltc, got %s", w.CurrencyCode())
+	}
+	w, err = bitcoin.NewWallet("litecoind", bitcoin.WalletOptions{Mnemonic: mnemonic, Binary: "/usr/bin/litecoind", Testnet: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.CurrencyCode() != "tltc" {
+		t.Errorf("Expected tltc, got %s", w.CurrencyCode())
+	}
+}
+
+func TestLitecoinAddresses(t *testing.T) {
+	addr, err := btc.NewAddressScriptHash([]byte{0x51}, &LitecoinMainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(addr.EncodeAddress(), "M") {
+		t.Errorf("Unexpected Litecoin P2SH address %s", addr.EncodeAddress())
+	}
+	decoded, err := btc.DecodeAddress(addr.EncodeAddress(), &LitecoinMainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.IsForNet(&LitecoinMainNetParams) || decoded.EncodeAddress() != addr.EncodeAddress() {
+		t.Error("Litecoin address did not decode to the same address")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/btcsuite/btcrpcclient"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -66,11 +67,13 @@ func (l *NotificationListener) notify(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func startNotificationListener(client *btcrpcclient.Client, listeners []func(spvwallet.TransactionCallback)) {
+func startNotificationListener(client *btcrpcclient.Client, listeners []func(spvwallet.TransactionCallback), port int) {
 	l := NotificationListener{
 		client:    client,
 		listeners: listeners,
 	}
-	http.HandleFunc("/", l.notify)
-	http.ListenAndServe(":8330", nil)
+	// Each daemon notifies its own port so more than one can run at a time
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", l.notify)
+	http.ListenAndServe(":"+strconv.Itoa(port), mux)
 }
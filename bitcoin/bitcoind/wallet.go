@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
 	"github.com/OpenBazaar/spvwallet"
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -22,6 +23,7 @@ import (
 	"bytes"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -42,27 +44,54 @@ type BitcoindWallet struct {
 	binary           string
 	controlPort      int
 	useTor           bool
+	daemon           daemon
+	connCfg          *btcrpcclient.ConnConfig
+
+	// Held while a spend has set the wallet's fee rate
+	spendLock sync.Mutex
+}
+
+// The settings which differ between the daemons this wallet can run
+type daemon struct {
+	name           string
+	currencyCode   string
+	mainnet        string
+	rpcPort        int
+	testnetRPCPort int
+	notifyPort     int
 }
 
-var connCfg *btcrpcclient.ConnConfig = &btcrpcclient.ConnConfig{
-	Host:                 "localhost:8332",
-	HTTPPostMode:         true, // Bitcoin core only supports HTTP POST mode
-	DisableTLS:           true, // Bitcoin core does not provide TLS by default
-	DisableAutoReconnect: false,
-	DisableConnectOnNew:  false,
+var bitcoinDaemon = daemon{
+	name:           "bitcoind",
+	currencyCode:   "btc",
+	mainnet:        chaincfg.MainNetParams.Name,
+	rpcPort:        8332,
+	testnetRPCPort: 18332,
+	notifyPort:     8330,
 }
 
 func NewBitcoindWallet(mnemonic string, params *chaincfg.Params, repoPath string, trustedPeer string, binary string, username string, password string, useTor bool, torControlPort int) *BitcoindWallet {
+	return newDaemonWallet(bitcoinDaemon, mnemonic, params, repoPath, trustedPeer, binary, username, password, useTor, torControlPort)
+}
+
+func newDaemonWallet(d daemon, mnemonic string, params *chaincfg.Params, repoPath string, trustedPeer string, binary string, username string, password string, useTor bool, torControlPort int) *BitcoindWallet {
 	seed := b39.NewSeed(mnemonic, "")
 	mPrivKey, _ := hd.NewMaster(seed, params)
 	mPubKey, _ := mPrivKey.Neuter()
 
-	if params.Name == chaincfg.TestNet3Params.Name || params.Name == chaincfg.RegressionNetParams.Name {
-		connCfg.Host = "localhost:18332"
+	rpcPort := d.rpcPort
+	if params.Name != d.mainnet {
+		rpcPort = d.testnetRPCPort
+	}
+	connCfg := &btcrpcclient.ConnConfig{
+		Host:                 "localhost:" + strconv.Itoa(rpcPort),
+		User:                 username,
+		Pass:                 password,
+		HTTPPostMode:         true, // Bitcoin core only supports HTTP POST mode
+		DisableTLS:           true, // Bitcoin core does not provide TLS by default
+		DisableAutoReconnect: false,
+		DisableConnectOnNew:  false,
 	}
-
-	connCfg.User = username
-	connCfg.Pass = password
 
 	if trustedPeer != "" {
 		trustedPeer = strings.Split(trustedPeer, ":")[0]
@@ -77,19 +106,21 @@ func NewBitcoindWallet(mnemonic string, params *chaincfg.Params, repoPath string
 		binary:           binary,
 		controlPort:      torControlPort,
 		useTor:           useTor,
+		daemon:           d,
+		connCfg:          connCfg,
 	}
 	return &w
 }
 
 func (w *BitcoindWallet) BuildArguments(rescan bool) []string {
-	notify := `curl -d %s http://localhost:8330/`
+	notify := `curl -d %s http://localhost:` + strconv.Itoa(w.daemon.notifyPort) + `/`
 	args := []string{"-walletnotify=" + notify, "-server"}
 	if rescan {
 		args = append(args, "-rescan")
 	}
 	args = append(args, "-torcontrol=127.0.0.1:"+strconv.Itoa(w.controlPort))
 
-	if w.params.Name == chaincfg.TestNet3Params.Name {
+	if w.params.Name == chaincfg.TestNet3Params.Name || w.params.Name == LitecoinTestNet4Params.Name {
 		args = append(args, "-testnet")
 	} else if w.params.Name == chaincfg.RegressionNetParams.Name {
 		args = append(args, "-regtest")
@@ -107,16 +138,16 @@ func (w *BitcoindWallet) BuildArguments(rescan bool) []string {
 func (w *BitcoindWallet) Start() {
 	w.shutdownIfActive()
 	args := w.BuildArguments(false)
-	client, _ := btcrpcclient.New(connCfg, nil)
+	client, _ := btcrpcclient.New(w.connCfg, nil)
 	w.rpcClient = client
-	go startNotificationListener(client, w.listeners)
+	go startNotificationListener(client, w.listeners, w.daemon.notifyPort)
 
 	cmd := exec.Command(w.binary, args...)
 	cmd.Start()
 	ticker := time.NewTicker(time.Second * 30)
 	go func() {
 		for range ticker.C {
-			log.Fatal("Failed to connect to bitcoind")
+			log.Fatalf("Failed to connect to %s", w.daemon.name)
 		}
 	}()
 	for {
@@ -127,12 +158,12 @@ func (w *BitcoindWallet) Start() {
 		time.Sleep(time.Second)
 	}
 	ticker.Stop()
-	log.Info("Connected to bitcoind")
+	log.Infof("Connected to %s", w.daemon.name)
 }
 
 // If bitcoind is already running let's shut it down so we restart it with our options
 func (w *BitcoindWallet) shutdownIfActive() {
-	client, err := btcrpcclient.New(connCfg, nil)
+	client, err := btcrpcclient.New(w.connCfg, nil)
 	if err != nil {
 		return
 	}
@@ -142,10 +173,10 @@ func (w *BitcoindWallet) shutdownIfActive() {
 }
 
 func (w *BitcoindWallet) CurrencyCode() string {
-	if w.params.Name == chaincfg.MainNetParams.Name {
-		return "btc"
+	if w.params.Name == w.daemon.mainnet {
+		return w.daemon.currencyCode
 	} else {
-		return "tbtc"
+		return "t" + w.daemon.currencyCode
 	}
 }
 
@@ -252,6 +283,57 @@ func (w *BitcoindWallet) Spend(amount int64, addr btc.Address, feeLevel spvwalle
 	return w.rpcClient.SendFrom(Account, addr, amt)
 }
 
+func (w *BitcoindWallet) SpendMany(outputs []bitcoin.SpendOutput, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	amounts := make(map[btc.Address]btc.Amount)
+	for _, out := range outputs {
+		amt, err := btc.NewAmount(float64(out.Value) / 100000000)
+		if err != nil {
+			return nil, err
+		}
+		amounts[out.Address] += amt
+	}
+	w.spendLock.Lock()
+	defer w.spendLock.Unlock()
+	if err := w.setTxFee(feeLevel); err != nil {
+		return nil, err
+	}
+	// Go back to bitcoind's own fee estimation once the transaction has been sent
+	defer w.rpcClient.RawRequest("settxfee", []json.RawMessage{json.RawMessage(`0`)})
+	return w.rpcClient.SendMany(Account, amounts)
+}
+
+// Set the fee rate bitcoind pays on the transactions it sends to the estimate for the fee
+// level. Falls back on GetFeePerByte if bitcoind can't make a smart fee estimate.
+func (w *BitcoindWallet) setTxFee(feeLevel spvwallet.FeeLevel) error {
+	var feePerKb float64
+	var nBlocks json.RawMessage
+	switch feeLevel {
+	case spvwallet.PRIOIRTY, spvwallet.FEE_BUMP:
+		nBlocks = json.RawMessage(`1`)
+	case spvwallet.NORMAL:
+		nBlocks = json.RawMessage(`3`)
+	case spvwallet.ECONOMIC:
+		nBlocks = json.RawMessage(`6`)
+	}
+	if nBlocks != nil {
+		resp, err := w.rpcClient.RawRequest("estimatesmartfee", []json.RawMessage{nBlocks})
+		if err == nil {
+			var estimate struct {
+				FeeRate float64 `json:"feerate"`
+			}
+			if json.Unmarshal(resp, &estimate) == nil {
+				feePerKb = estimate.FeeRate
+			}
+		}
+	}
+	if feePerKb <= 0 {
+		feePerKb = float64(w.GetFeePerByte(feeLevel)*1000) / 100000000
+	}
+	rate := json.RawMessage(strconv.FormatFloat(feePerKb, 'f', 8, 64))
+	_, err := w.rpcClient.RawRequest("settxfee", []json.RawMessage{rate})
+	return err
+}
+
 func (w *BitcoindWallet) BumpFee(txid chainhash.Hash) (*chainhash.Hash, error) {
 	includeWatchOnly := false
 	tx, err := w.rpcClient.GetTransaction(&txid, &includeWatchOnly)
@@ -577,6 +659,11 @@ func (w *BitcoindWallet) GenerateMultisigScript(keys []hd.ExtendedKey, threshold
 	return addr, redeemScript, nil
 }
 
+func (w *BitcoindWallet) Broadcast(tx *wire.MsgTx) error {
+	_, err := w.rpcClient.SendRawTransaction(tx, false)
+	return err
+}
+
 func (w *BitcoindWallet) AddWatchedScript(script []byte) error {
 	_, addrs, _, err := txscript.ExtractPkScriptAddrs(script, w.params)
 	if err != nil {
@@ -593,9 +680,9 @@ func (w *BitcoindWallet) ReSyncBlockchain(fromHeight int32) {
 	cmd := exec.Command(w.binary, args...)
 	cmd.Start()
 
-	client, err := btcrpcclient.New(connCfg, nil)
+	client, err := btcrpcclient.New(w.connCfg, nil)
 	if err != nil {
-		log.Error("Could not connect to bitcoind during rescan")
+		log.Errorf("Could not connect to %s during rescan", w.daemon.name)
 	}
 	w.rpcClient = client
 }
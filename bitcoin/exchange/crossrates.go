@@ -0,0 +1,65 @@
+package exchange
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+)
+
+// CrossRateFetcher prices an alternative coin by dividing the bitcoin rate of
+// each currency by the bitcoin rate of the coin. The providers used by the
+// BitcoinPriceFetcher quote LTC and BCH alongside fiat so no extra requests
+// are needed.
+type CrossRateFetcher struct {
+	coin      string
+	btcRates  bitcoin.ExchangeRates
+	unitsCoin int
+}
+
+func NewCrossRateFetcher(coin string, btcRates bitcoin.ExchangeRates, unitsPerCoin int) *CrossRateFetcher {
+	return &CrossRateFetcher{strings.ToUpper(coin), btcRates, unitsPerCoin}
+}
+
+func (c *CrossRateFetcher) GetExchangeRate(currencyCode string) (float64, error) {
+	return c.cross(currencyCode, c.btcRates.GetExchangeRate)
+}
+
+func (c *CrossRateFetcher) GetLatestRate(currencyCode string) (float64, error) {
+	return c.cross(currencyCode, c.btcRates.GetLatestRate)
+}
+
+func (c *CrossRateFetcher) GetAllRates() (map[string]float64, error) {
+	all, err := c.btcRates.GetAllRates()
+	if err != nil {
+		return nil, err
+	}
+	coinRate, ok := all[c.coin]
+	if !ok || coinRate <= 0 {
+		return nil, errors.New("Currency not tracked")
+	}
+	rates := make(map[string]float64)
+	for code, rate := range all {
+		rates[code] = rate / coinRate
+	}
+	return rates, nil
+}
+
+func (c *CrossRateFetcher) UnitsPerCoin() int {
+	return c.unitsCoin
+}
+
+func (c *CrossRateFetcher) cross(currencyCode string, get func(string) (float64, error)) (float64, error) {
+	coinRate, err := get(c.coin)
+	if err != nil {
+		return 0, err
+	}
+	if coinRate <= 0 {
+		return 0, errors.New("Currency not tracked")
+	}
+	rate, err := c.btcRates.GetExchangeRate(currencyCode)
+	if err != nil {
+		return 0, err
+	}
+	return rate / coinRate, nil
+}
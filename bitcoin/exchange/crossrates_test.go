@@ -0,0 +1,66 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+)
+
+type mockRates map[string]float64
+
+func (m mockRates) GetExchangeRate(currencyCode string) (float64, error) {
+	rate, ok := m[currencyCode]
+	if !ok {
+		return 0, errors.New("Currency not tracked")
+	}
+	return rate, nil
+}
+
+func (m mockRates) GetLatestRate(currencyCode string) (float64, error) {
+	return m.GetExchangeRate(currencyCode)
+}
+
+func (m mockRates) GetAllRates() (map[string]float64, error) {
+	return m, nil
+}
+
+func (m mockRates) UnitsPerCoin() int {
+	return SatoshiPerBTC
+}
+
+func TestCrossRateFetcher(t *testing.T) {
+	btcRates := mockRates{"BTC": 1, "USD": 4000, "LTC": 80}
+	c := NewCrossRateFetcher("ltc", btcRates, SatoshiPerBTC)
+
+	rate, err := c.GetExchangeRate("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate != 50 {
+		t.Errorf("Expected 50 USD per LTC, got %f", rate)
+	}
+	rate, err = c.GetLatestRate("BTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate != 1.0/80 {
+		t.Errorf("Expected %f BTC per LTC, got %f", 1.0/80, rate)
+	}
+	all, err := c.GetAllRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all["LTC"] != 1 || all["USD"] != 50 {
+		t.Error("Incorrect cross rates returned by GetAllRates")
+	}
+	if _, err := c.GetExchangeRate("EUR"); err == nil {
+		t.Error("Expected an error for an untracked currency")
+	}
+
+	untracked := NewCrossRateFetcher("BCH", btcRates, SatoshiPerBTC)
+	if _, err := untracked.GetExchangeRate("USD"); err == nil {
+		t.Error("Expected an error when the coin is not tracked")
+	}
+	if _, err := untracked.GetAllRates(); err == nil {
+		t.Error("Expected an error when the coin is not tracked")
+	}
+}
@@ -24,11 +24,14 @@ type TransactionListener struct {
 	db        repo.Datastore
 	broadcast chan interface{}
 	params    *chaincfg.Params
+
+	// Called with the order ID when a sale becomes fully funded
+	onSaleFunded func(orderID string)
 	*sync.Mutex
 }
 
-func NewTransactionListener(db repo.Datastore, broadcast chan interface{}, params *chaincfg.Params) *TransactionListener {
-	l := &TransactionListener{db, broadcast, params, new(sync.Mutex)}
+func NewTransactionListener(db repo.Datastore, broadcast chan interface{}, params *chaincfg.Params, onSaleFunded func(orderID string)) *TransactionListener {
+	l := &TransactionListener{db, broadcast, params, onSaleFunded, new(sync.Mutex)}
 	return l
 }
 
@@ -100,6 +103,9 @@ func (l *TransactionListener) OnTransactionReceived(cb spvwallet.TransactionCall
 			// This is a dispute payout. We should set the order state.
 			if state == pb.OrderState_DECIDED && len(records) > 0 && fundsReleased {
 				l.db.Sales().Put(orderId, *contract, pb.OrderState_RESOLVED, false)
+			} else if (state == pb.OrderState_FUNDED || state == pb.OrderState_FULFILLED) && fundsReleased && core.IsRefundOffer(contract) {
+				// The buyer accepted our partial refund
+				l.db.Sales().Put(orderId, *contract, pb.OrderState_PARTIALLY_REFUNDED, false)
 			}
 		} else {
 			l.db.Purchases().UpdateFunding(orderId, funded, records)
@@ -130,11 +136,13 @@ func (l *TransactionListener) processSalePayment(txid []byte, output spvwallet.T
 	if err != nil {
 		return
 	}
+	var fundedNow bool
 	if !funded {
 		requestedAmount := int64(contract.BuyerOrder.Payment.Amount)
 		if funding >= requestedAmount {
 			log.Debugf("Recieved payment for order %s", orderId)
 			funded = true
+			fundedNow = true
 			if state == pb.OrderState_CONFIRMED {
 				l.db.Sales().Put(orderId, *contract, pb.OrderState_FUNDED, false)
 			}
@@ -163,6 +171,11 @@ func (l *TransactionListener) processSalePayment(txid []byte, output spvwallet.T
 	records = append(records, record)
 	l.db.Sales().UpdateFunding(orderId, funded, records)
 
+	// Order rules may spend from the wallet, which calls back into this listener
+	if fundedNow && l.onSaleFunded != nil {
+		go l.onSaleFunded(orderId)
+	}
+
 	// Save tx metadata
 	var thumbnail string
 	var title string
@@ -0,0 +1,136 @@
+package bitcoin
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/proxy"
+)
+
+var ErrUnsupportedCurrency = errors.New("No wallet is available for this currency")
+
+// MultiWallet holds one wallet implementation per cryptocurrency keyed by
+// its upper case currency code. The primary wallet is the one the node was
+// started with and is used for anything that isn't tied to a specific coin,
+// such as the keys published in the profile.
+type MultiWallet struct {
+	primary string
+	wallets map[string]BitcoinWallet
+}
+
+func NewMultiWallet(primary BitcoinWallet, others ...BitcoinWallet) *MultiWallet {
+	m := &MultiWallet{
+		primary: strings.ToUpper(primary.CurrencyCode()),
+		wallets: make(map[string]BitcoinWallet),
+	}
+	m.wallets[m.primary] = primary
+	for _, w := range others {
+		code := strings.ToUpper(w.CurrencyCode())
+		if _, ok := m.wallets[code]; ok {
+			continue
+		}
+		m.wallets[code] = w
+	}
+	return m
+}
+
+// Return the wallet the node was started with
+func (m *MultiWallet) Primary() BitcoinWallet {
+	return m.wallets[m.primary]
+}
+
+// Return the wallet for the given currency code. An empty code refers to
+// the primary wallet so that contracts created before a coin was recorded
+// in the order keep working.
+func (m *MultiWallet) WalletForCurrencyCode(code string) (BitcoinWallet, error) {
+	if code == "" {
+		return m.Primary(), nil
+	}
+	w, ok := m.wallets[strings.ToUpper(code)]
+	if !ok {
+		return nil, ErrUnsupportedCurrency
+	}
+	return w, nil
+}
+
+// Return the currency codes of all wallets with the primary wallet first
+func (m *MultiWallet) CurrencyCodes() []string {
+	codes := []string{m.primary}
+	var others []string
+	for code := range m.wallets {
+		if code != m.primary {
+			others = append(others, code)
+		}
+	}
+	sort.Strings(others)
+	return append(codes, others...)
+}
+
+// Return all wallets in the same order as CurrencyCodes
+func (m *MultiWallet) Wallets() []BitcoinWallet {
+	var wallets []BitcoinWallet
+	for _, code := range m.CurrencyCodes() {
+		wallets = append(wallets, m.wallets[code])
+	}
+	return wallets
+}
+
+// WalletOptions holds what a wallet implementation needs to run alongside the primary wallet
+type WalletOptions struct {
+	// The node's mnemonic. Every wallet must derive its keys from it so that the keys
+	// in the node's profile and listings can be used in escrow for any coin.
+	Mnemonic string
+
+	Testnet     bool
+	RepoPath    string
+	Binary      string
+	TrustedPeer string
+	RPCUser     string
+	RPCPassword string
+	FeeAPI      string
+	MaxFee      uint64
+	LowFee      uint64
+	MediumFee   uint64
+	HighFee     uint64
+	Proxy       proxy.Dialer
+
+	// Wallets which run a daemon route it over Tor through this control port
+	UseTor         bool
+	TorControlPort int
+}
+
+// WalletFactory builds a wallet for an additional currency
+type WalletFactory func(opts WalletOptions) (BitcoinWallet, error)
+
+var walletFactories = make(map[string]WalletFactory)
+
+// RegisterWalletType makes a wallet implementation available under the given type in the
+// Wallets section of the config. It should be called from an init function.
+func RegisterWalletType(walletType string, factory WalletFactory) {
+	walletFactories[strings.ToLower(walletType)] = factory
+}
+
+// Return the wallet types which have been registered, in alphabetical order
+func RegisteredWalletTypes() []string {
+	var types []string
+	for t := range walletFactories {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Build a wallet of a registered type. The bitcoind package registers litecoind.
+func NewWallet(walletType string, opts WalletOptions) (BitcoinWallet, error) {
+	factory, ok := walletFactories[strings.ToLower(walletType)]
+	if !ok {
+		registered := "none"
+		if types := RegisteredWalletTypes(); len(types) > 0 {
+			registered = strings.Join(types, ", ")
+		}
+		return nil, fmt.Errorf("Unknown wallet type %s. Registered wallet types: %s", walletType, registered)
+	}
+	return factory(opts)
+}
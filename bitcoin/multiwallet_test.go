@@ -0,0 +1,68 @@
+package bitcoin
+
+import (
+	"testing"
+)
+
+type mockWallet struct {
+	BitcoinWallet
+	code string
+}
+
+func (w *mockWallet) CurrencyCode() string {
+	return w.code
+}
+
+func TestMultiWallet(t *testing.T) {
+	btc := &mockWallet{code: "btc"}
+	ltc := &mockWallet{code: "ltc"}
+	bch := &mockWallet{code: "BCH"}
+	m := NewMultiWallet(btc, ltc, bch, &mockWallet{code: "LTC"})
+
+	if m.Primary() != btc {
+		t.Error("Wrong primary wallet")
+	}
+	codes := m.CurrencyCodes()
+	expected := []string{"BTC", "BCH", "LTC"}
+	if len(codes) != len(expected) {
+		t.Fatalf("Expected %d currencies, got %d", len(expected), len(codes))
+	}
+	for i := range expected {
+		if codes[i] != expected[i] {
+			t.Errorf("Expected %s at index %d, got %s", expected[i], i, codes[i])
+		}
+	}
+	w, err := m.WalletForCurrencyCode("ltc")
+	if err != nil || w != ltc {
+		t.Error("Failed to look up the wallet by currency code")
+	}
+	w, err = m.WalletForCurrencyCode("")
+	if err != nil || w != btc {
+		t.Error("Empty currency code should return the primary wallet")
+	}
+	if _, err := m.WalletForCurrencyCode("DOGE"); err != ErrUnsupportedCurrency {
+		t.Error("Expected an error for a currency without a wallet")
+	}
+	if len(m.Wallets()) != 3 || m.Wallets()[0] != btc {
+		t.Error("Wallets should list the primary wallet first")
+	}
+}
+
+func TestNewWallet(t *testing.T) {
+	RegisterWalletType("Mock", func(opts WalletOptions) (BitcoinWallet, error) {
+		return &mockWallet{code: "LTC"}, nil
+	})
+	w, err := NewWallet("mock", WalletOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.CurrencyCode() != "LTC" {
+		t.Error("Factory returned the wrong wallet")
+	}
+	if _, err := NewWallet("unknown", WalletOptions{}); err == nil {
+		t.Error("Expected an error for an unregistered wallet type")
+	}
+	if types := RegisteredWalletTypes(); len(types) != 1 || types[0] != "mock" {
+		t.Error("Returned incorrect registered wallet types")
+	}
+}
@@ -0,0 +1,272 @@
+package bitcoin
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/btcsuite/btcutil/txsort"
+)
+
+// The largest number of moderators which may sit on an escrow panel
+const MaxPanelSize = 5
+
+// Return the number of panel moderators which must sign to resolve a dispute
+func PanelMajority(panelSize int) int {
+	return panelSize/2 + 1
+}
+
+// Generate an escrow script for an order moderated by a panel. The buyer and vendor can
+// release the funds together as usual while a dispute needs either of them to sign along
+// with a majority of the panel.
+//
+//	OP_DEPTH OP_3 OP_EQUAL
+//	OP_IF
+//	    2 <buyerKey> <vendorKey> 2 OP_CHECKMULTISIG
+//	OP_ELSE
+//	    1 <buyerKey> <vendorKey> 2 OP_CHECKMULTISIGVERIFY
+//	    <majority> <moderatorKeys...> <n> OP_CHECKMULTISIG
+//	OP_ENDIF
+//
+// If a timeout is given the script is wrapped in the same timeout branch as
+// TimelockedMultisigScript, letting the vendor claim the funds once it has passed.
+func PanelEscrowScript(buyerKey, vendorKey hd.ExtendedKey, moderatorKeys []hd.ExtendedKey, timeout uint32, params *chaincfg.Params) (addr btc.Address, redeemScript []byte, err error) {
+	if len(moderatorKeys) < 2 || len(moderatorKeys) > MaxPanelSize {
+		return nil, nil, errors.New("Invalid moderator panel size")
+	}
+	if timeout > MaxTimeoutBlocks {
+		return nil, nil, errors.New("Invalid escrow timeout")
+	}
+	parties, err := serializedPubKeys([]hd.ExtendedKey{buyerKey, vendorKey})
+	if err != nil {
+		return nil, nil, err
+	}
+	moderators, err := serializedPubKeys(moderatorKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	builder := txscript.NewScriptBuilder()
+	if timeout > 0 {
+		builder.AddOp(txscript.OP_DEPTH)
+		builder.AddOp(txscript.OP_1)
+		builder.AddOp(txscript.OP_EQUAL)
+		builder.AddOp(txscript.OP_IF)
+		builder.AddInt64(int64(timeout))
+		builder.AddOp(txscript.OP_CHECKSEQUENCEVERIFY)
+		builder.AddOp(txscript.OP_DROP)
+		builder.AddData(parties[1])
+		builder.AddOp(txscript.OP_CHECKSIG)
+		builder.AddOp(txscript.OP_ELSE)
+	}
+	builder.AddOp(txscript.OP_DEPTH)
+	builder.AddOp(txscript.OP_3)
+	builder.AddOp(txscript.OP_EQUAL)
+	builder.AddOp(txscript.OP_IF)
+	addMultisig(builder, 2, parties, txscript.OP_CHECKMULTISIG)
+	builder.AddOp(txscript.OP_ELSE)
+	addMultisig(builder, 1, parties, txscript.OP_CHECKMULTISIGVERIFY)
+	addMultisig(builder, PanelMajority(len(moderators)), moderators, txscript.OP_CHECKMULTISIG)
+	builder.AddOp(txscript.OP_ENDIF)
+	if timeout > 0 {
+		builder.AddOp(txscript.OP_ENDIF)
+	}
+	redeemScript, err = builder.Script()
+	if err != nil {
+		return nil, nil, err
+	}
+	addr, err = btc.NewAddressScriptHash(redeemScript, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	return addr, redeemScript, nil
+}
+
+func serializedPubKeys(keys []hd.ExtendedKey) ([][]byte, error) {
+	var ret [][]byte
+	for _, key := range keys {
+		ecKey, err := key.ECPubKey()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, ecKey.SerializeCompressed())
+	}
+	return ret, nil
+}
+
+func addMultisig(builder *txscript.ScriptBuilder, threshold int, pubKeys [][]byte, op byte) {
+	builder.AddInt64(int64(threshold))
+	for _, key := range pubKeys {
+		builder.AddData(key)
+	}
+	builder.AddInt64(int64(len(pubKeys)))
+	builder.AddOp(op)
+}
+
+// Return the moderator keys in a script generated by PanelEscrowScript, in panel order
+func PanelModeratorKeys(redeemScript []byte, panelSize int) ([][]byte, error) {
+	pushes, err := txscript.PushedData(redeemScript)
+	if err != nil {
+		return nil, err
+	}
+	var keys [][]byte
+	for _, data := range pushes {
+		if len(data) == 33 {
+			keys = append(keys, data)
+		}
+	}
+	// The buyer and vendor keys appear twice, and the vendor's once more after the timeout
+	expected := panelSize + 4
+	if len(pushes) > 0 && len(pushes[0]) != 33 {
+		expected++
+	}
+	if panelSize < 2 || len(keys) != expected {
+		return nil, errors.New("Redeem script is not a panel escrow script")
+	}
+	return keys[len(keys)-panelSize:], nil
+}
+
+// Build the unsigned payout transaction which signatures on an escrow are made over
+func payoutTx(ins []spvwallet.TransactionInput, outs []spvwallet.TransactionOutput) (*wire.MsgTx, error) {
+	tx := new(wire.MsgTx)
+	for _, in := range ins {
+		ch, err := chainhash.NewHashFromStr(hex.EncodeToString(in.OutpointHash))
+		if err != nil {
+			return nil, err
+		}
+		tx.TxIn = append(tx.TxIn, wire.NewTxIn(wire.NewOutPoint(ch, in.OutpointIndex), []byte{}))
+	}
+	for _, out := range outs {
+		tx.TxOut = append(tx.TxOut, wire.NewTxOut(out.Value, out.ScriptPubKey))
+	}
+
+	// BIP 69 sorting
+	txsort.InPlaceSort(tx)
+	return tx, nil
+}
+
+// Check a SIGHASH_ALL signature on an input spending a P2SH script with the given key
+func checkInputSignature(tx *wire.MsgTx, index int, redeemScript, sig, pubKey []byte) bool {
+	if len(sig) == 0 || txscript.SigHashType(sig[len(sig)-1]) != txscript.SigHashAll {
+		return false
+	}
+	signature, err := btcec.ParseDERSignature(sig[:len(sig)-1], btcec.S256())
+	if err != nil {
+		return false
+	}
+	key, err := btcec.ParsePubKey(pubKey, btcec.S256())
+	if err != nil {
+		return false
+	}
+	txCopy := tx.Copy()
+	for i := range txCopy.TxIn {
+		txCopy.TxIn[i].SignatureScript = nil
+	}
+	txCopy.TxIn[index].SignatureScript = redeemScript
+	var buf bytes.Buffer
+	if err := txCopy.Serialize(&buf); err != nil {
+		return false
+	}
+	binary.Write(&buf, binary.LittleEndian, uint32(txscript.SigHashAll))
+	return signature.Verify(chainhash.DoubleHashB(buf.Bytes()), key)
+}
+
+// Check a moderator's signatures on a payout cover every input and were made with the
+// given escrow key
+func VerifyPayoutSignatures(ins []spvwallet.TransactionInput, outs []spvwallet.TransactionOutput, sigs []spvwallet.Signature, pubKey, redeemScript []byte) error {
+	tx, err := payoutTx(ins, outs)
+	if err != nil {
+		return err
+	}
+	for i := range tx.TxIn {
+		if !checkInputSignature(tx, i, redeemScript, signatureForInput(sigs, i), pubKey) {
+			return errors.New("Signatures on the payout failed to verify")
+		}
+	}
+	return nil
+}
+
+// Build a transaction which spends the given inputs through the dispute branch of a
+// script generated by PanelEscrowScript. partySigs are the buyer's or vendor's signatures
+// and moderatorSigs holds the signatures of each panel moderator in panel order, nil for
+// those who have not signed. Each moderator signature is checked against that moderator's
+// key in the script and a majority of the valid ones is used, so a bad signature from one
+// moderator doesn't block the payout while enough others have signed. As with the wallet's
+// Multisign the outputs must already have the fee subtracted.
+func MultisignPanel(ins []spvwallet.TransactionInput, outs []spvwallet.TransactionOutput, partySigs []spvwallet.Signature, moderatorSigs [][]spvwallet.Signature, redeemScript []byte) (*wire.MsgTx, error) {
+	tx, err := payoutTx(ins, outs)
+	if err != nil {
+		return nil, err
+	}
+	moderatorKeys, err := PanelModeratorKeys(redeemScript, len(moderatorSigs))
+	if err != nil {
+		return nil, err
+	}
+
+	majority := PanelMajority(len(moderatorSigs))
+	for i, input := range tx.TxIn {
+		partySig := signatureForInput(partySigs, i)
+		if partySig == nil {
+			return nil, errors.New("Missing buyer or vendor signature")
+		}
+		builder := txscript.NewScriptBuilder()
+		builder.AddOp(txscript.OP_0)
+		signed := 0
+		// OP_CHECKMULTISIG needs the signatures in the same order as the keys
+		for m, sigs := range moderatorSigs {
+			if signed == majority {
+				break
+			}
+			if sig := signatureForInput(sigs, i); checkInputSignature(tx, i, redeemScript, sig, moderatorKeys[m]) {
+				builder.AddData(sig)
+				signed++
+			}
+		}
+		if signed < majority {
+			return nil, errors.New("Not enough valid moderator signatures")
+		}
+		builder.AddOp(txscript.OP_0)
+		builder.AddData(partySig)
+		builder.AddData(redeemScript)
+		scriptSig, err := builder.Script()
+		if err != nil {
+			return nil, err
+		}
+		input.SignatureScript = scriptSig
+	}
+
+	// Check the whole script executes before handing the transaction back, which also
+	// covers the buyer's or vendor's signature
+	scriptPubKey, err := txscript.NewScriptBuilder().AddOp(txscript.OP_HASH160).AddData(btc.Hash160(redeemScript)).AddOp(txscript.OP_EQUAL).Script()
+	if err != nil {
+		return nil, err
+	}
+	for i := range tx.TxIn {
+		vm, err := txscript.NewEngine(scriptPubKey, tx, i, txscript.StandardVerifyFlags, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := vm.Execute(); err != nil {
+			return nil, errors.New("Signatures on the payout failed to verify")
+		}
+	}
+	return tx, nil
+}
+
+func signatureForInput(sigs []spvwallet.Signature, index int) []byte {
+	for _, sig := range sigs {
+		if int(sig.InputIndex) == index {
+			return sig.Signature
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package bitcoin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+)
+
+type panelTest struct {
+	buyer, vendor *hd.ExtendedKey
+	moderators    []*hd.ExtendedKey
+	redeemScript  []byte
+	scriptPubKey  []byte
+	in            spvwallet.TransactionInput
+	out           spvwallet.TransactionOutput
+}
+
+func newPanelTest(t *testing.T, timeout uint32) *panelTest {
+	p := &panelTest{buyer: newTestKey(t, 1), vendor: newTestKey(t, 2)}
+	var modKeys []hd.ExtendedKey
+	for i := byte(3); i < 6; i++ {
+		key := newTestKey(t, i)
+		p.moderators = append(p.moderators, key)
+		modKeys = append(modKeys, *key)
+	}
+	addr, redeemScript, err := PanelEscrowScript(*p.buyer, *p.vendor, modKeys, timeout, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.redeemScript = redeemScript
+	p.scriptPubKey, err = txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.in = spvwallet.TransactionInput{OutpointHash: bytes.Repeat([]byte{0xaa}, 32), OutpointIndex: 0, Value: 100000}
+	p.out = spvwallet.TransactionOutput{ScriptPubKey: p.scriptPubKey, Value: 90000}
+	return p
+}
+
+// Sign the payout the same way the wallet's CreateMultisigSignature does
+func (p *panelTest) sign(t *testing.T, key *hd.ExtendedKey) []spvwallet.Signature {
+	tx := new(wire.MsgTx)
+	ch, _ := chainhash.NewHashFromStr(hex.EncodeToString(p.in.OutpointHash))
+	tx.TxIn = append(tx.TxIn, wire.NewTxIn(wire.NewOutPoint(ch, p.in.OutpointIndex), []byte{}))
+	tx.TxOut = append(tx.TxOut, wire.NewTxOut(p.out.Value, p.out.ScriptPubKey))
+	signingKey, _ := key.ECPrivKey()
+	sig, err := txscript.RawTxInSignature(tx, 0, p.redeemScript, txscript.SigHashAll, signingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []spvwallet.Signature{{InputIndex: 0, Signature: sig}}
+}
+
+func (p *panelTest) multisign(t *testing.T, party *hd.ExtendedKey, moderatorSigs [][]spvwallet.Signature) (*wire.MsgTx, error) {
+	return MultisignPanel([]spvwallet.TransactionInput{p.in}, []spvwallet.TransactionOutput{p.out}, p.sign(t, party), moderatorSigs, p.redeemScript)
+}
+
+func TestPanelMajority(t *testing.T) {
+	for size, majority := range map[int]int{2: 2, 3: 2, 4: 3, 5: 3} {
+		if PanelMajority(size) != majority {
+			t.Errorf("Expected a majority of %d for a panel of %d", majority, size)
+		}
+	}
+}
+
+func TestPanelDisputeBranch(t *testing.T) {
+	for _, timeout := range []uint32{0, 144} {
+		p := newPanelTest(t, timeout)
+		modSigs := [][]spvwallet.Signature{nil, p.sign(t, p.moderators[1]), p.sign(t, p.moderators[2])}
+		tx, err := p.multisign(t, p.buyer, modSigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := executeScript(p.scriptPubKey, tx); err != nil {
+			t.Errorf("Timeout %d: %s", timeout, err)
+		}
+		tx, err = p.multisign(t, p.vendor, modSigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := executeScript(p.scriptPubKey, tx); err != nil {
+			t.Errorf("Timeout %d: %s", timeout, err)
+		}
+	}
+}
+
+func TestPanelDisputeBranchNeedsMajority(t *testing.T) {
+	p := newPanelTest(t, 0)
+	_, err := p.multisign(t, p.buyer, [][]spvwallet.Signature{p.sign(t, p.moderators[0]), nil, nil})
+	if err == nil {
+		t.Error("Built a dispute payout with a minority of the panel")
+	}
+
+	// Moderator signatures alone can't spend the escrow
+	_, err = p.multisign(t, p.moderators[2], [][]spvwallet.Signature{p.sign(t, p.moderators[0]), p.sign(t, p.moderators[1]), nil})
+	if err == nil {
+		t.Error("Moderators spent the escrow without the buyer or vendor")
+	}
+}
+
+func TestPanelDisputeBranchSkipsInvalidSignatures(t *testing.T) {
+	p := newPanelTest(t, 0)
+	// The first moderator's signature was made with the wrong key. The other two make a
+	// valid majority.
+	modSigs := [][]spvwallet.Signature{p.sign(t, p.vendor), p.sign(t, p.moderators[1]), p.sign(t, p.moderators[2])}
+	tx, err := p.multisign(t, p.buyer, modSigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := executeScript(p.scriptPubKey, tx); err != nil {
+		t.Error(err)
+	}
+
+	modSigs = [][]spvwallet.Signature{p.sign(t, p.vendor), p.sign(t, p.moderators[1]), nil}
+	if _, err := p.multisign(t, p.buyer, modSigs); err == nil {
+		t.Error("Built a dispute payout counting an invalid moderator signature")
+	}
+}
+
+func TestPanelModeratorKeys(t *testing.T) {
+	for _, timeout := range []uint32{0, 144} {
+		p := newPanelTest(t, timeout)
+		keys, err := PanelModeratorKeys(p.redeemScript, len(p.moderators))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, mod := range p.moderators {
+			pubKey, _ := mod.ECPubKey()
+			if !bytes.Equal(keys[i], pubKey.SerializeCompressed()) {
+				t.Errorf("Timeout %d: incorrect key for moderator %d", timeout, i)
+			}
+		}
+		if _, err := PanelModeratorKeys(p.redeemScript, 2); err == nil {
+			t.Errorf("Timeout %d: returned keys for the wrong panel size", timeout)
+		}
+	}
+}
+
+func TestVerifyPayoutSignatures(t *testing.T) {
+	p := newPanelTest(t, 0)
+	ins := []spvwallet.TransactionInput{p.in}
+	outs := []spvwallet.TransactionOutput{p.out}
+	pubKey, _ := p.moderators[1].ECPubKey()
+	if err := VerifyPayoutSignatures(ins, outs, p.sign(t, p.moderators[1]), pubKey.SerializeCompressed(), p.redeemScript); err != nil {
+		t.Error(err)
+	}
+	if err := VerifyPayoutSignatures(ins, outs, p.sign(t, p.moderators[2]), pubKey.SerializeCompressed(), p.redeemScript); err == nil {
+		t.Error("Verified a signature made with another moderator's key")
+	}
+	if err := VerifyPayoutSignatures(ins, outs, nil, pubKey.SerializeCompressed(), p.redeemScript); err == nil {
+		t.Error("Verified a payout with no signatures")
+	}
+	outs[0].Value--
+	if err := VerifyPayoutSignatures(ins, outs, p.sign(t, p.moderators[1]), pubKey.SerializeCompressed(), p.redeemScript); err == nil {
+		t.Error("Verified a signature on a different payout")
+	}
+}
+
+func TestPanelCooperativeBranch(t *testing.T) {
+	p := newPanelTest(t, 144)
+	tx := new(wire.MsgTx)
+	ch, _ := chainhash.NewHashFromStr(hex.EncodeToString(p.in.OutpointHash))
+	tx.TxIn = append(tx.TxIn, wire.NewTxIn(wire.NewOutPoint(ch, 0), []byte{}))
+	tx.TxOut = append(tx.TxOut, wire.NewTxOut(p.out.Value, p.out.ScriptPubKey))
+	buyerSig, vendorSig := p.sign(t, p.buyer), p.sign(t, p.vendor)
+	tx.TxIn[0].SignatureScript, _ = txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(buyerSig[0].Signature).AddData(vendorSig[0].Signature).AddData(p.redeemScript).Script()
+	if err := executeScript(p.scriptPubKey, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPanelTimeoutBranch(t *testing.T) {
+	p := newPanelTest(t, 144)
+	tx, err := BuildTimeoutTransaction([]spvwallet.TransactionInput{p.in}, p.out, p.vendor, p.redeemScript, 144, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := executeScript(p.scriptPubKey, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInvalidPanelSize(t *testing.T) {
+	key := newTestKey(t, 1)
+	if _, _, err := PanelEscrowScript(*key, *key, []hd.ExtendedKey{*key}, 0, params); err == nil {
+		t.Error("Generated a panel script with a single moderator")
+	}
+	var keys []hd.ExtendedKey
+	for i := 0; i <= MaxPanelSize; i++ {
+		keys = append(keys, *key)
+	}
+	if _, _, err := PanelEscrowScript(*key, *key, keys, 0, params); err == nil {
+		t.Error("Generated a panel script with too many moderators")
+	}
+}
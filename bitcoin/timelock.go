@@ -0,0 +1,125 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/btcsuite/btcutil/txsort"
+)
+
+// The largest relative timelock, in blocks, which can be encoded in an input sequence number
+const MaxTimeoutBlocks = 0xffff
+
+// Generate a multisig script with an escape branch which allows the timeout key to spend
+// the funds on its own once the funding transaction is the given number of blocks deep.
+//
+//	OP_DEPTH OP_1 OP_EQUAL
+//	OP_IF
+//	    <timeout> OP_CHECKSEQUENCEVERIFY OP_DROP <timeoutKey> OP_CHECKSIG
+//	OP_ELSE
+//	    <threshold> <keys...> <n> OP_CHECKMULTISIG
+//	OP_ENDIF
+//
+// The branch is selected by the number of items in the signature script so the multisig
+// branch can still be spent with the usual OP_0 <sig1> <sig2> <redeemScript>.
+func TimelockedMultisigScript(keys []hd.ExtendedKey, threshold int, timeoutKey hd.ExtendedKey, timeout uint32, params *chaincfg.Params) (addr btc.Address, redeemScript []byte, err error) {
+	if timeout == 0 || timeout > MaxTimeoutBlocks {
+		return nil, nil, errors.New("Invalid escrow timeout")
+	}
+	var addrPubKeys []*btc.AddressPubKey
+	for _, key := range keys {
+		ecKey, err := key.ECPubKey()
+		if err != nil {
+			return nil, nil, err
+		}
+		k, err := btc.NewAddressPubKey(ecKey.SerializeCompressed(), params)
+		if err != nil {
+			return nil, nil, err
+		}
+		addrPubKeys = append(addrPubKeys, k)
+	}
+	multisig, err := txscript.MultiSigScript(addrPubKeys, threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+	timeoutPubKey, err := timeoutKey.ECPubKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	builder := txscript.NewScriptBuilder()
+	builder.AddOp(txscript.OP_DEPTH)
+	builder.AddOp(txscript.OP_1)
+	builder.AddOp(txscript.OP_EQUAL)
+	builder.AddOp(txscript.OP_IF)
+	builder.AddInt64(int64(timeout))
+	builder.AddOp(txscript.OP_CHECKSEQUENCEVERIFY)
+	builder.AddOp(txscript.OP_DROP)
+	builder.AddData(timeoutPubKey.SerializeCompressed())
+	builder.AddOp(txscript.OP_CHECKSIG)
+	builder.AddOp(txscript.OP_ELSE)
+	builder.AddOps(multisig)
+	builder.AddOp(txscript.OP_ENDIF)
+	redeemScript, err = builder.Script()
+	if err != nil {
+		return nil, nil, err
+	}
+	addr, err = btc.NewAddressScriptHash(redeemScript, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	return addr, redeemScript, nil
+}
+
+// Build and sign a transaction which spends the given inputs through the timeout branch
+// of a script generated by TimelockedMultisigScript. The fee is subtracted from the output.
+func BuildTimeoutTransaction(ins []spvwallet.TransactionInput, out spvwallet.TransactionOutput, key *hd.ExtendedKey, redeemScript []byte, timeout uint32, feePerByte uint64) (*wire.MsgTx, error) {
+	tx := wire.NewMsgTx(2) // Relative timelocks require version 2 transactions
+	for _, in := range ins {
+		ch, err := chainhash.NewHashFromStr(hex.EncodeToString(in.OutpointHash))
+		if err != nil {
+			return nil, err
+		}
+		input := wire.NewTxIn(wire.NewOutPoint(ch, in.OutpointIndex), []byte{})
+		input.Sequence = timeout
+		tx.TxIn = append(tx.TxIn, input)
+	}
+	output := wire.NewTxOut(out.Value, out.ScriptPubKey)
+	tx.TxOut = append(tx.TxOut, output)
+
+	estimatedSize := spvwallet.EstimateSerializeSize(len(ins), tx.TxOut, false)
+	output.Value -= int64(estimatedSize) * int64(feePerByte)
+	if output.Value <= 0 {
+		return nil, errors.New("Escrowed funds are too small to cover the transaction fee")
+	}
+
+	// BIP 69 sorting
+	txsort.InPlaceSort(tx)
+
+	signingKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+	for i, input := range tx.TxIn {
+		sig, err := txscript.RawTxInSignature(tx, i, redeemScript, txscript.SigHashAll, signingKey)
+		if err != nil {
+			return nil, err
+		}
+		builder := txscript.NewScriptBuilder()
+		builder.AddData(sig)
+		builder.AddData(redeemScript)
+		scriptSig, err := builder.Script()
+		if err != nil {
+			return nil, err
+		}
+		input.SignatureScript = scriptSig
+	}
+	return tx, nil
+}
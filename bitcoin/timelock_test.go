@@ -0,0 +1,104 @@
+package bitcoin
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+)
+
+var params = &chaincfg.TestNet3Params
+
+func newTestKey(t *testing.T, b byte) *hd.ExtendedKey {
+	key, err := hd.NewMaster(bytes.Repeat([]byte{b}, 32), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func newTestTimeoutTx(t *testing.T, timeout uint32) ([]*hd.ExtendedKey, []byte, []byte, *wire.MsgTx) {
+	buyer, vendor, moderator := newTestKey(t, 1), newTestKey(t, 2), newTestKey(t, 3)
+	keys := []hd.ExtendedKey{*buyer, *vendor, *moderator}
+	addr, redeemScript, err := TimelockedMultisigScript(keys, 2, *vendor, timeout, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scriptPubKey, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := spvwallet.TransactionInput{OutpointHash: bytes.Repeat([]byte{0xaa}, 32), OutpointIndex: 0, Value: 100000}
+	out := spvwallet.TransactionOutput{ScriptPubKey: scriptPubKey, Value: 100000}
+	tx, err := BuildTimeoutTransaction([]spvwallet.TransactionInput{in}, out, vendor, redeemScript, timeout, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []*hd.ExtendedKey{buyer, vendor, moderator}, redeemScript, scriptPubKey, tx
+}
+
+func executeScript(scriptPubKey []byte, tx *wire.MsgTx) error {
+	vm, err := txscript.NewEngine(scriptPubKey, tx, 0, txscript.StandardVerifyFlags, nil)
+	if err != nil {
+		return err
+	}
+	return vm.Execute()
+}
+
+func TestTimeoutBranch(t *testing.T) {
+	_, _, scriptPubKey, tx := newTestTimeoutTx(t, 144)
+	if err := executeScript(scriptPubKey, tx); err != nil {
+		t.Error(err)
+	}
+	if tx.TxOut[0].Value >= 100000 {
+		t.Error("Fee was not subtracted from the output")
+	}
+}
+
+func TestTimeoutBranchBeforeTimeout(t *testing.T) {
+	keys, redeemScript, scriptPubKey, tx := newTestTimeoutTx(t, 144)
+	tx.TxIn[0].Sequence = 143
+	signingKey, _ := keys[1].ECPrivKey()
+	sig, err := txscript.RawTxInSignature(tx, 0, redeemScript, txscript.SigHashAll, signingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.TxIn[0].SignatureScript, _ = txscript.NewScriptBuilder().AddData(sig).AddData(redeemScript).Script()
+	if err := executeScript(scriptPubKey, tx); err == nil {
+		t.Error("Timeout branch was spendable before the timeout")
+	}
+}
+
+func TestMultisigBranch(t *testing.T) {
+	keys, redeemScript, scriptPubKey, tx := newTestTimeoutTx(t, 144)
+	tx.TxIn[0].Sequence = wire.MaxTxInSequenceNum
+	var sigs [][]byte
+	for _, key := range keys[:2] {
+		signingKey, _ := key.ECPrivKey()
+		sig, err := txscript.RawTxInSignature(tx, 0, redeemScript, txscript.SigHashAll, signingKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigs = append(sigs, sig)
+	}
+	tx.TxIn[0].SignatureScript, _ = txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(sigs[0]).AddData(sigs[1]).AddData(redeemScript).Script()
+	if err := executeScript(scriptPubKey, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInvalidTimeout(t *testing.T) {
+	key := newTestKey(t, 1)
+	_, _, err := TimelockedMultisigScript([]hd.ExtendedKey{*key, *key}, 2, *key, 0, params)
+	if err == nil {
+		t.Error("Generated a script with no timeout")
+	}
+	_, _, err = TimelockedMultisigScript([]hd.ExtendedKey{*key, *key}, 2, *key, MaxTimeoutBlocks+1, params)
+	if err == nil {
+		t.Error("Generated a script with an unencodable timeout")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/OpenBazaar/spvwallet"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
 	btc "github.com/btcsuite/btcutil"
 	hd "github.com/btcsuite/btcutil/hdkeychain"
 )
@@ -70,6 +71,9 @@ type BitcoinWallet interface {
 	// Generate a multisig script from public keys
 	GenerateMultisigScript(keys []hd.ExtendedKey, threshold int) (addr btc.Address, redeemScript []byte, err error)
 
+	// Broadcast a signed transaction to the network
+	Broadcast(tx *wire.MsgTx) error
+
 	// Add a script to the wallet and get notifications back when coins are received or spent from it
 	AddWatchedScript(script []byte) error
 
@@ -85,3 +89,14 @@ type BitcoinWallet interface {
 	// Cleanly disconnect from the wallet
 	Close()
 }
+
+// A payment to a single address within a batched spend
+type SpendOutput struct {
+	Address btc.Address
+	Value   int64
+}
+
+// Wallets which can pay several addresses in one transaction implement BatchSpender
+type BatchSpender interface {
+	SpendMany(outputs []SpendOutput, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error)
+}
@@ -0,0 +1,399 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// How often the node checks for auctions which have passed their close time
+const AuctionCloseInterval = time.Minute * 5
+
+// BidData contains the bid amount along with the purchase data that will be used to
+// automatically place the order if the bid wins the auction.
+type BidData struct {
+	Amount uint64 `json:"amount"`
+	PurchaseData
+}
+
+// Locks held per auction while checking and saving bids, closing the auction and recording
+// the order for the winning bid so concurrent messages can't interleave
+var (
+	auctionLocks     = make(map[string]*sync.Mutex)
+	auctionLocksLock sync.Mutex
+)
+
+func auctionLock(slug string) *sync.Mutex {
+	auctionLocksLock.Lock()
+	defer auctionLocksLock.Unlock()
+	l, ok := auctionLocks[slug]
+	if !ok {
+		l = new(sync.Mutex)
+		auctionLocks[slug] = l
+	}
+	return l
+}
+
+func auctionClosed(listing *pb.Listing) bool {
+	if listing.Auction == nil || listing.Auction.Closes == nil {
+		return true
+	}
+	return !time.Unix(listing.Auction.Closes.Seconds, 0).After(time.Now())
+}
+
+// Place a bid on a remote auction listing. Returns the ID assigned to the bid by the vendor.
+func (n *OpenBazaarNode) PlaceBid(data *BidData) (string, error) {
+	if len(data.Items) != 1 {
+		return "", errors.New("Bids must contain exactly one item")
+	}
+	if data.Items[0].Quantity != 1 {
+		return "", errors.New("Bids must be for a quantity of one")
+	}
+	if data.Amount == 0 {
+		return "", errors.New("Bid amount must be greater than zero")
+	}
+	b, err := ipfs.Cat(n.Context, data.Items[0].ListingHash)
+	if err != nil {
+		return "", err
+	}
+	sl := new(pb.SignedListing)
+	err = jsonpb.UnmarshalString(string(b), sl)
+	if err != nil {
+		return "", err
+	}
+	if err := validateVendorID(sl.Listing); err != nil {
+		return "", err
+	}
+	if err := validateListing(sl.Listing); err != nil {
+		return "", fmt.Errorf("Listing failed to validate, reason: %q", err.Error())
+	}
+	if err := verifySignaturesOnListing(sl); err != nil {
+		return "", err
+	}
+	if sl.Listing.Metadata.Format != pb.Listing_Metadata_AUCTION {
+		return "", errors.New("Listing is not an auction")
+	}
+	if auctionClosed(sl.Listing) {
+		return "", errors.New("Auction has closed")
+	}
+
+	ts, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return "", err
+	}
+	bid := &pb.Bid{
+		ListingHash: data.Items[0].ListingHash,
+		Slug:        sl.Listing.Slug,
+		Amount:      data.Amount,
+		Timestamp:   ts,
+	}
+	ack, err := n.SendBid(sl.Listing.VendorID.PeerID, bid)
+	if err != nil {
+		return "", err
+	}
+	if ack.Status != pb.BidAck_ACCEPTED {
+		return "", fmt.Errorf("Vendor rejected bid, reason: %s", ack.Reason)
+	}
+
+	purchaseData, err := json.Marshal(data.PurchaseData)
+	if err != nil {
+		return "", err
+	}
+	err = n.Datastore.Bids().Put(repo.Bid{
+		BidId:        ack.BidID,
+		VendorId:     sl.Listing.VendorID.PeerID,
+		Slug:         sl.Listing.Slug,
+		ListingHash:  data.Items[0].ListingHash,
+		PeerId:       n.IpfsNode.Identity.Pretty(),
+		Amount:       data.Amount,
+		Status:       pb.BidAck_ACCEPTED.String(),
+		Timestamp:    time.Now(),
+		PurchaseData: purchaseData,
+	})
+	if err != nil {
+		return "", err
+	}
+	return ack.BidID, nil
+}
+
+// Validate and save a bid on one of our auctions. The returned ack is sent back to the bidder.
+func (n *OpenBazaarNode) ProcessBid(peerId string, bid *pb.Bid) (*pb.BidAck, error) {
+	reject := func(reason string) *pb.BidAck {
+		return &pb.BidAck{
+			Slug:   bid.Slug,
+			Status: pb.BidAck_REJECTED,
+			Reason: reason,
+		}
+	}
+	sl, err := n.GetListingFromSlug(bid.Slug)
+	if err != nil {
+		return reject("Listing not found"), nil
+	}
+	if sl.Listing.Metadata.Format != pb.Listing_Metadata_AUCTION {
+		return reject("Listing is not an auction"), nil
+	}
+	if auctionClosed(sl.Listing) {
+		return reject("Auction has closed"), nil
+	}
+	if peerId == n.IpfsNode.Identity.Pretty() {
+		return reject("Vendor cannot bid on their own auction"), nil
+	}
+
+	lock := auctionLock(bid.Slug)
+	lock.Lock()
+	defer lock.Unlock()
+	bids, err := n.Datastore.Bids().Get(n.IpfsNode.Identity.Pretty(), bid.Slug)
+	if err != nil {
+		return nil, err
+	}
+	minimum := sl.Listing.Item.Price
+	if len(bids) > 0 {
+		increment := sl.Listing.Auction.MinimumIncrement
+		if increment == 0 {
+			increment = 1
+		}
+		minimum = bids[0].Amount + increment
+	}
+	if bid.Amount < minimum {
+		ack := reject(fmt.Sprintf("Bid must be at least %d", minimum))
+		if len(bids) > 0 {
+			ack.HighBid = bids[0].Amount
+		}
+		return ack, nil
+	}
+
+	ser, err := proto.Marshal(bid)
+	if err != nil {
+		return nil, err
+	}
+	bidID, err := EncodeMultihash(append(ser, []byte(peerId)...))
+	if err != nil {
+		return nil, err
+	}
+	err = n.Datastore.Bids().Put(repo.Bid{
+		BidId:       bidID.B58String(),
+		VendorId:    n.IpfsNode.Identity.Pretty(),
+		Slug:        bid.Slug,
+		ListingHash: bid.ListingHash,
+		PeerId:      peerId,
+		Amount:      bid.Amount,
+		Status:      pb.BidAck_ACCEPTED.String(),
+		Timestamp:   time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	notif := notifications.BidNotification{
+		BidId:  bidID.B58String(),
+		Slug:   bid.Slug,
+		PeerId: peerId,
+		Amount: bid.Amount,
+	}
+	n.Broadcast <- notif
+	n.Datastore.Notifications().Put(notif, time.Now())
+
+	return &pb.BidAck{
+		BidID:   bidID.B58String(),
+		Slug:    bid.Slug,
+		Status:  pb.BidAck_ACCEPTED,
+		HighBid: bid.Amount,
+	}, nil
+}
+
+// Close one of our auctions. If the highest bid meets the reserve price it is marked as
+// the winner and the bidder is notified so their node can place the order. All other
+// bidders are told they lost.
+func (n *OpenBazaarNode) CloseAuction(slug string) error {
+	sl, err := n.GetListingFromSlug(slug)
+	if err != nil {
+		return err
+	}
+	if sl.Listing.Metadata.Format != pb.Listing_Metadata_AUCTION {
+		return errors.New("Listing is not an auction")
+	}
+	if !auctionClosed(sl.Listing) {
+		return errors.New("Auction has not reached its close time")
+	}
+	lock := auctionLock(slug)
+	lock.Lock()
+	defer lock.Unlock()
+	bids, err := n.Datastore.Bids().Get(n.IpfsNode.Identity.Pretty(), slug)
+	if err != nil {
+		return err
+	}
+	for _, bid := range bids {
+		if bid.Status != pb.BidAck_ACCEPTED.String() {
+			return nil // Already closed
+		}
+	}
+	for i, bid := range bids {
+		ack := &pb.BidAck{
+			BidID:   bid.BidId,
+			Slug:    slug,
+			Status:  pb.BidAck_LOST,
+			HighBid: bids[0].Amount,
+		}
+		if i == 0 && bid.Amount >= sl.Listing.Auction.ReservePrice {
+			ack.Status = pb.BidAck_WON
+		} else if bids[0].Amount < sl.Listing.Auction.ReservePrice {
+			ack.Reason = "Reserve price not met"
+		} else {
+			ack.Reason = "Outbid"
+		}
+		if err := n.Datastore.Bids().UpdateStatus(bid.BidId, ack.Status); err != nil {
+			return err
+		}
+		if err := n.SendBidAck(bid.PeerId, ack); err != nil {
+			log.Errorf("Error sending auction result to %s: %s", bid.PeerId, err)
+		}
+	}
+	return nil
+}
+
+// Close all of our auctions which have passed their close time
+func (n *OpenBazaarNode) CloseExpiredAuctions() {
+	index, err := n.getListingIndex()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, l := range index {
+		sl, err := n.GetListingFromSlug(l.Slug)
+		if err != nil {
+			continue
+		}
+		if sl.Listing.Metadata.Format != pb.Listing_Metadata_AUCTION || !auctionClosed(sl.Listing) {
+			continue
+		}
+		if err := n.CloseAuction(l.Slug); err != nil {
+			log.Errorf("Error closing auction %s: %s", l.Slug, err)
+		}
+	}
+}
+
+// Periodically close expired auctions. This should be run in a separate goroutine.
+func (n *OpenBazaarNode) RunAuctionCloser() {
+	n.CloseExpiredAuctions()
+	t := time.NewTicker(AuctionCloseInterval)
+	for range t.C {
+		n.CloseExpiredAuctions()
+	}
+}
+
+// Process the result of an auction we bid on. If our bid won we place the order for the
+// item at the bid amount using the purchase data saved when the bid was made.
+func (n *OpenBazaarNode) ProcessAuctionResult(peerId string, ack *pb.BidAck) error {
+	bid, err := n.Datastore.Bids().GetById(ack.BidID)
+	if err != nil {
+		return errors.New("Received auction result for an unknown bid")
+	}
+	if bid.VendorId != peerId {
+		return errors.New("Auction result was not sent by the vendor")
+	}
+	if bid.Status != pb.BidAck_ACCEPTED.String() {
+		return errors.New("Auction result already received")
+	}
+	if ack.Status != pb.BidAck_WON && ack.Status != pb.BidAck_LOST {
+		return errors.New("Invalid auction result")
+	}
+	if err := n.Datastore.Bids().UpdateStatus(bid.BidId, ack.Status); err != nil {
+		return err
+	}
+	if ack.Status == pb.BidAck_LOST {
+		notif := notifications.AuctionLostNotification{
+			BidId:  bid.BidId,
+			Slug:   bid.Slug,
+			Reason: ack.Reason,
+		}
+		n.Broadcast <- notif
+		n.Datastore.Notifications().Put(notif, time.Now())
+		return nil
+	}
+
+	var data PurchaseData
+	if err := json.Unmarshal(bid.PurchaseData, &data); err != nil {
+		return err
+	}
+	for i := range data.Items {
+		data.Items[i].Bid = bid.Amount
+	}
+	orderId, _, _, _, err := n.Purchase(&data)
+	if err != nil {
+		return err
+	}
+	notif := notifications.AuctionWonNotification{
+		BidId:   bid.BidId,
+		Slug:    bid.Slug,
+		OrderId: orderId,
+	}
+	n.Broadcast <- notif
+	n.Datastore.Notifications().Put(notif, time.Now())
+	return nil
+}
+
+// Validate an order for an auction item matches the winning bid placed by the buyer and
+// that no other order has been placed for it. Returns the winning bid.
+func (n *OpenBazaarNode) validateWinningBid(listing *pb.Listing, item *pb.Order_Item, buyerId string, orderId string) (*repo.Bid, error) {
+	if item.Quantity != 1 {
+		return nil, errors.New("Auction orders must be for a quantity of one")
+	}
+	bids, err := n.Datastore.Bids().Get(n.IpfsNode.Identity.Pretty(), listing.Slug)
+	if err != nil {
+		return nil, err
+	}
+	for _, bid := range bids {
+		if bid.Status != pb.BidAck_WON.String() {
+			continue
+		}
+		if bid.PeerId != buyerId || bid.Amount != item.Bid {
+			break
+		}
+		if bid.OrderId != "" && bid.OrderId != orderId {
+			return nil, errors.New("An order has already been placed for the winning bid")
+		}
+		return &bid, nil
+	}
+	return nil, errors.New("Order does not match the winning bid for this auction")
+}
+
+// Check the auction items in a sale we are confirming still match an unused winning bid
+// and record the order against it
+func (n *OpenBazaarNode) redeemWinningBids(contract *pb.RicardianContract, orderId string) error {
+	for _, item := range contract.BuyerOrder.Items {
+		listing, err := ParseContractForListing(item.ListingHash, contract)
+		if err != nil {
+			return err
+		}
+		if listing.Metadata.Format != pb.Listing_Metadata_AUCTION {
+			continue
+		}
+		if err := n.redeemWinningBid(listing, item, contract.BuyerOrder.BuyerID.PeerID, orderId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *OpenBazaarNode) redeemWinningBid(listing *pb.Listing, item *pb.Order_Item, buyerId string, orderId string) error {
+	lock := auctionLock(listing.Slug)
+	lock.Lock()
+	defer lock.Unlock()
+	bid, err := n.validateWinningBid(listing, item, buyerId, orderId)
+	if err != nil {
+		return err
+	}
+	if bid.OrderId == orderId {
+		return nil
+	}
+	return n.Datastore.Bids().SetOrderId(bid.BidId, orderId)
+}
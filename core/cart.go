@@ -0,0 +1,350 @@
+package core
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	btc "github.com/btcsuite/btcutil"
+)
+
+var ErrCartBatchSpendUnsupported = errors.New("The wallet cannot pay several orders in one transaction. Fund each order separately.")
+
+// CartCheckoutData holds the shipping and payment details used for every order in the cart.
+// The moderator in the embedded PurchaseData is used for any vendor without an entry in
+// Moderators, which maps vendor peer IDs to the moderator to use for that vendor's order.
+type CartCheckoutData struct {
+	PurchaseData
+	Moderators map[string]string `json:"moderators"`
+	Fund       bool              `json:"fund"`
+	FeeLevel   string            `json:"feeLevel"`
+}
+
+// The outcome of placing the order for one vendor in the cart
+type CartOrder struct {
+	VendorId       string `json:"vendorId"`
+	OrderId        string `json:"orderId,omitempty"`
+	PaymentAddress string `json:"paymentAddress,omitempty"`
+	Amount         uint64 `json:"amount,omitempty"`
+	VendorOnline   bool   `json:"vendorOnline"`
+	Error          string `json:"error,omitempty"`
+}
+
+type CartCheckoutResult struct {
+	CheckoutId   string      `json:"checkoutId"`
+	Orders       []CartOrder `json:"orders"`
+	Txids        []string    `json:"txids"`
+	FundingError string      `json:"fundingError,omitempty"`
+}
+
+type CartOrderStatus struct {
+	OrderId  string `json:"orderId"`
+	VendorId string `json:"vendorId"`
+	Title    string `json:"title"`
+	Total    uint64 `json:"total"`
+	State    string `json:"state"`
+	Funded   bool   `json:"funded"`
+}
+
+// CartCheckoutStatus tracks the orders placed by a checkout as a group. State is the
+// state shared by all of the orders or MIXED if they have diverged.
+type CartCheckoutStatus struct {
+	CheckoutId string            `json:"checkoutId"`
+	Txids      []string          `json:"txids"`
+	Timestamp  time.Time         `json:"timestamp"`
+	State      string            `json:"state"`
+	Funded     bool              `json:"funded"`
+	Orders     []CartOrderStatus `json:"orders"`
+}
+
+// Add the items to the shopping cart. Returns the IDs assigned to the cart items.
+func (n *OpenBazaarNode) AddToCart(data *PurchaseData) ([]string, error) {
+	if len(data.Items) == 0 {
+		return nil, errors.New("No items to add to the cart")
+	}
+	var cartItems []repo.CartItem
+	for _, item := range data.Items {
+		if item.Quantity < 1 {
+			return nil, errors.New("Item quantity must be at least one")
+		}
+		b, err := ipfs.Cat(n.Context, item.ListingHash)
+		if err != nil {
+			return nil, err
+		}
+		sl := new(pb.SignedListing)
+		if err := jsonpb.UnmarshalString(string(b), sl); err != nil {
+			return nil, err
+		}
+		if err := validateVendorID(sl.Listing); err != nil {
+			return nil, err
+		}
+		if sl.Listing.Metadata.Format == pb.Listing_Metadata_AUCTION {
+			return nil, errors.New("Auction listings must be purchased by bidding")
+		}
+		if sl.Listing.VendorID.PeerID == n.IpfsNode.Identity.Pretty() {
+			return nil, errors.New("Cannot add your own listing to the cart")
+		}
+		ser, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		nonce := make([]byte, 32)
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, err
+		}
+		itemID, err := EncodeMultihash(append(ser, nonce...))
+		if err != nil {
+			return nil, err
+		}
+		cartItems = append(cartItems, repo.CartItem{
+			ItemId:      itemID.B58String(),
+			VendorId:    sl.Listing.VendorID.PeerID,
+			ListingHash: item.ListingHash,
+			Item:        ser,
+			Timestamp:   time.Now(),
+		})
+	}
+	var ids []string
+	for _, ci := range cartItems {
+		if err := n.Datastore.Cart().Put(ci); err != nil {
+			return ids, err
+		}
+		ids = append(ids, ci.ItemId)
+	}
+	return ids, nil
+}
+
+// Split the cart into one order per vendor and place each of them. Items are removed
+// from the cart once their vendor's order has been placed so a failed order can be
+// retried with another checkout. If requested, all of the orders are then funded from
+// the wallet in a single transaction.
+func (n *OpenBazaarNode) CheckoutCart(data *CartCheckoutData) (*CartCheckoutResult, error) {
+	cartItems, err := n.Datastore.Cart().Get()
+	if err != nil {
+		return nil, err
+	}
+	if len(cartItems) == 0 {
+		return nil, errors.New("Cart is empty")
+	}
+	wal, err := n.WalletForCurrency(data.PaymentCoin)
+	if err != nil {
+		return nil, err
+	}
+
+	var vendors []string
+	vendorItems := make(map[string][]repo.CartItem)
+	for _, ci := range cartItems {
+		if _, ok := vendorItems[ci.VendorId]; !ok {
+			vendors = append(vendors, ci.VendorId)
+		}
+		vendorItems[ci.VendorId] = append(vendorItems[ci.VendorId], ci)
+	}
+
+	result := &CartCheckoutResult{}
+	var orderIds []string
+	for _, vendor := range vendors {
+		purchase := data.PurchaseData
+		purchase.Items = nil
+		for _, ci := range vendorItems[vendor] {
+			var i item
+			if err := json.Unmarshal(ci.Item, &i); err != nil {
+				return nil, err
+			}
+			purchase.Items = append(purchase.Items, i)
+		}
+		if moderator, ok := data.Moderators[vendor]; ok {
+			purchase.Moderator = moderator
+		}
+		order := CartOrder{VendorId: vendor}
+		orderId, paymentAddr, amount, online, err := n.Purchase(&purchase)
+		if err != nil {
+			log.Errorf("Cart checkout failed to purchase from %s: %s", vendor, err.Error())
+			order.Error = err.Error()
+			result.Orders = append(result.Orders, order)
+			continue
+		}
+		order.OrderId = orderId
+		order.PaymentAddress = paymentAddr
+		order.Amount = amount
+		order.VendorOnline = online
+		result.Orders = append(result.Orders, order)
+		orderIds = append(orderIds, orderId)
+		for _, ci := range vendorItems[vendor] {
+			if err := n.Datastore.Cart().Delete(ci.ItemId); err != nil {
+				log.Errorf("Failed to remove item %s from the cart: %s", ci.ItemId, err.Error())
+			}
+		}
+	}
+	if len(orderIds) == 0 {
+		return nil, fmt.Errorf("No orders could be placed, reason: %s", result.Orders[0].Error)
+	}
+
+	checkoutID, err := EncodeMultihash([]byte(strings.Join(orderIds, "")))
+	if err != nil {
+		return nil, err
+	}
+	result.CheckoutId = checkoutID.B58String()
+
+	if data.Fund {
+		txids, err := n.fundCartOrders(wal, result.Orders, feeLevelFromString(data.FeeLevel))
+		if err != nil {
+			result.FundingError = err.Error()
+		}
+		result.Txids = txids
+	}
+
+	err = n.Datastore.Cart().PutCheckout(repo.CartCheckout{
+		CheckoutId: result.CheckoutId,
+		OrderIds:   orderIds,
+		Txids:      result.Txids,
+		Timestamp:  time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Pay each order's payment address in one transaction. Wallets which don't implement
+// bitcoin.BatchSpender, such as the SPV wallet, can only fund a checkout with a single
+// order. Sending a transaction per order could leave the checkout partly funded, so the
+// orders are left for the user to fund one at a time instead.
+func (n *OpenBazaarNode) fundCartOrders(wal bitcoin.BitcoinWallet, orders []CartOrder, feeLevel spvwallet.FeeLevel) ([]string, error) {
+	var outputs []bitcoin.SpendOutput
+	var funded []CartOrder
+	for _, order := range orders {
+		if order.OrderId == "" {
+			continue
+		}
+		addr, err := btc.DecodeAddress(order.PaymentAddress, wal.Params())
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, bitcoin.SpendOutput{Address: addr, Value: int64(order.Amount)})
+		funded = append(funded, order)
+	}
+
+	if spender, ok := wal.(bitcoin.BatchSpender); ok {
+		txid, err := spender.SpendMany(outputs, feeLevel)
+		if err != nil {
+			return nil, err
+		}
+		n.putCartTxMetadata(txid, funded)
+		return []string{txid.String()}, nil
+	}
+	if len(outputs) > 1 {
+		return nil, ErrCartBatchSpendUnsupported
+	}
+
+	var txids []string
+	for i, out := range outputs {
+		txid, err := wal.Spend(out.Value, out.Address, feeLevel)
+		if err != nil {
+			return txids, err
+		}
+		n.putCartTxMetadata(txid, funded[i:i+1])
+		txids = append(txids, txid.String())
+	}
+	return txids, nil
+}
+
+func (n *OpenBazaarNode) putCartTxMetadata(txid *chainhash.Hash, orders []CartOrder) {
+	var titles, addrs []string
+	var thumbnail string
+	for _, order := range orders {
+		addrs = append(addrs, order.PaymentAddress)
+		contract, _, _, _, _, err := n.Datastore.Purchases().GetByOrderId(order.OrderId)
+		if err != nil || len(contract.VendorListings) == 0 || contract.VendorListings[0].Item == nil {
+			continue
+		}
+		titles = append(titles, contract.VendorListings[0].Item.Title)
+		if thumbnail == "" && len(contract.VendorListings[0].Item.Images) > 0 {
+			thumbnail = contract.VendorListings[0].Item.Images[0].Tiny
+		}
+	}
+	md := repo.Metadata{
+		Txid:      txid.String(),
+		Address:   strings.Join(addrs, ","),
+		Memo:      strings.Join(titles, ", "),
+		Thumbnail: thumbnail,
+	}
+	if len(orders) == 1 {
+		md.OrderId = orders[0].OrderId
+	}
+	if err := n.Datastore.TxMetadata().Put(md); err != nil {
+		log.Errorf("Failed to save metadata for cart transaction %s: %s", txid.String(), err.Error())
+	}
+}
+
+// Return the current state of each order placed by a checkout
+func (n *OpenBazaarNode) GetCartCheckoutStatus(checkoutID string) (*CartCheckoutStatus, error) {
+	checkout, err := n.Datastore.Cart().GetCheckout(checkoutID)
+	if err != nil {
+		return nil, errors.New("Checkout not found")
+	}
+	status := &CartCheckoutStatus{
+		CheckoutId: checkout.CheckoutId,
+		Txids:      checkout.Txids,
+		Timestamp:  checkout.Timestamp,
+		Funded:     true,
+	}
+	var orders []CartOrderStatus
+	for _, orderID := range checkout.OrderIds {
+		contract, state, funded, _, _, err := n.Datastore.Purchases().GetByOrderId(orderID)
+		if err != nil {
+			return nil, err
+		}
+		order := CartOrderStatus{
+			OrderId: orderID,
+			State:   state.String(),
+			Funded:  funded,
+		}
+		if len(contract.VendorListings) > 0 {
+			order.VendorId = contract.VendorListings[0].VendorID.PeerID
+			if contract.VendorListings[0].Item != nil {
+				order.Title = contract.VendorListings[0].Item.Title
+			}
+		}
+		if contract.BuyerOrder != nil && contract.BuyerOrder.Payment != nil {
+			order.Total = contract.BuyerOrder.Payment.Amount
+		}
+		orders = append(orders, order)
+	}
+	status.Orders = orders
+	status.State, status.Funded = cartCheckoutState(orders)
+	return status, nil
+}
+
+func cartCheckoutState(orders []CartOrderStatus) (state string, funded bool) {
+	funded = len(orders) > 0
+	for i, order := range orders {
+		if i == 0 {
+			state = order.State
+		} else if order.State != state {
+			state = "MIXED"
+		}
+		funded = funded && order.Funded
+	}
+	return state, funded
+}
+
+func feeLevelFromString(level string) spvwallet.FeeLevel {
+	switch strings.ToUpper(level) {
+	case "PRIORITY":
+		return spvwallet.PRIOIRTY
+	case "ECONOMIC":
+		return spvwallet.ECONOMIC
+	default:
+		return spvwallet.NORMAL
+	}
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	btc "github.com/btcsuite/btcutil"
+)
+
+type cartTestWallet struct {
+	refundTestWallet
+	spends int
+}
+
+func (w *cartTestWallet) Spend(amount int64, addr btc.Address, feeLevel spvwallet.FeeLevel) (*chainhash.Hash, error) {
+	w.spends++
+	return &chainhash.Hash{}, nil
+}
+
+func TestCartCheckoutState(t *testing.T) {
+	state, funded := cartCheckoutState([]CartOrderStatus{
+		{State: "PENDING", Funded: true},
+		{State: "PENDING", Funded: true},
+	})
+	if state != "PENDING" || !funded {
+		t.Error("Orders sharing a state should report that state")
+	}
+	state, funded = cartCheckoutState([]CartOrderStatus{
+		{State: "PENDING", Funded: true},
+		{State: "CONFIRMED", Funded: false},
+	})
+	if state != "MIXED" || funded {
+		t.Error("Orders in different states should report MIXED")
+	}
+	if _, funded := cartCheckoutState(nil); funded {
+		t.Error("A checkout without orders is not funded")
+	}
+}
+
+func TestFundCartOrdersWithoutBatchSpend(t *testing.T) {
+	n := &OpenBazaarNode{}
+	wal := &cartTestWallet{}
+	orders := []CartOrder{
+		{OrderId: "a", PaymentAddress: refundTestAddress(t, 1), Amount: 1000},
+		{OrderId: "b", PaymentAddress: refundTestAddress(t, 2), Amount: 2000},
+		{VendorId: "offline"},
+	}
+	if _, err := n.fundCartOrders(wal, orders, spvwallet.NORMAL); err != ErrCartBatchSpendUnsupported {
+		t.Errorf("Expected %s, got %v", ErrCartBatchSpendUnsupported, err)
+	}
+	if wal.spends != 0 {
+		t.Error("No order should be funded when they can't all be paid in one transaction")
+	}
+}
@@ -16,7 +16,6 @@ import (
 	"github.com/OpenBazaar/openbazaar-go/ipfs"
 	"github.com/OpenBazaar/openbazaar-go/pb"
 	"github.com/OpenBazaar/spvwallet"
-	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcutil"
 	hd "github.com/btcsuite/btcutil/hdkeychain"
@@ -47,6 +46,10 @@ type RatingData struct {
 }
 
 func (n *OpenBazaarNode) CompleteOrder(orderRatings *OrderRatings, contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
+	}
 
 	orderId, err := n.CalcOrderId(contract.BuyerOrder)
 	if err != nil {
@@ -156,7 +159,7 @@ func (n *OpenBazaarNode) CompleteOrder(orderRatings *OrderRatings, contract *pb.
 			}
 		}
 
-		payoutAddress, err := btcutil.DecodeAddress(contract.VendorOrderFulfillment[0].Payout.PayoutAddress, n.Wallet.Params())
+		payoutAddress, err := btcutil.DecodeAddress(contract.VendorOrderFulfillment[0].Payout.PayoutAddress, wal.Params())
 		if err != nil {
 			return err
 		}
@@ -173,7 +176,7 @@ func (n *OpenBazaarNode) CompleteOrder(orderRatings *OrderRatings, contract *pb.
 			return err
 		}
 		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := n.Wallet.MasterPrivateKey()
+		mPrivKey := wal.MasterPrivateKey()
 		if err != nil {
 			return err
 		}
@@ -182,7 +185,7 @@ func (n *OpenBazaarNode) CompleteOrder(orderRatings *OrderRatings, contract *pb.
 			return err
 		}
 		hdKey := hd.NewExtendedKey(
-			n.Wallet.Params().HDPrivateKeyID[:],
+			wal.Params().HDPrivateKeyID[:],
 			mECKey.Serialize(),
 			chaincode,
 			parentFP,
@@ -199,7 +202,7 @@ func (n *OpenBazaarNode) CompleteOrder(orderRatings *OrderRatings, contract *pb.
 			return err
 		}
 
-		buyerSignatures, err := n.Wallet.CreateMultisigSignature(ins, []spvwallet.TransactionOutput{output}, buyerKey, redeemScript, contract.VendorOrderFulfillment[0].Payout.PayoutFeePerByte)
+		buyerSignatures, err := wal.CreateMultisigSignature(ins, []spvwallet.TransactionOutput{output}, buyerKey, redeemScript, contract.VendorOrderFulfillment[0].Payout.PayoutFeePerByte)
 		if err != nil {
 			return err
 		}
@@ -216,7 +219,7 @@ func (n *OpenBazaarNode) CompleteOrder(orderRatings *OrderRatings, contract *pb.
 			sig := spvwallet.Signature{InputIndex: s.InputIndex, Signature: s.Signature}
 			vendorSignatures = append(vendorSignatures, sig)
 		}
-		_, err = n.Wallet.Multisign(ins, []spvwallet.TransactionOutput{output}, buyerSignatures, vendorSignatures, redeemScript, contract.VendorOrderFulfillment[0].Payout.PayoutFeePerByte, true)
+		_, err = wal.Multisign(ins, []spvwallet.TransactionOutput{output}, buyerSignatures, vendorSignatures, redeemScript, contract.VendorOrderFulfillment[0].Payout.PayoutFeePerByte, true)
 		if err != nil {
 			return err
 		}
@@ -282,27 +285,10 @@ func (n *OpenBazaarNode) ValidateOrderCompletion(contract *pb.RicardianContract)
 
 func (n *OpenBazaarNode) ValidateAndSaveRating(contract *pb.RicardianContract) error {
 	for _, rating := range contract.BuyerOrderCompletion.Ratings {
-		pubkey, err := btcec.ParsePubKey(rating.RatingData.RatingKey, btcec.S256())
-		if err != nil {
-			return err
-		}
-
-		signature, err := btcec.ParseSignature(rating.Signature, btcec.S256())
-		if err != nil {
+		if err := verifyRatingKeySignature(rating); err != nil {
 			return err
 		}
 
-		ser, err := proto.Marshal(rating.RatingData)
-		if err != nil {
-			return err
-		}
-		hashed := sha256.Sum256(ser)
-		verified := signature.Verify(hashed[:], pubkey)
-
-		if !verified {
-			return errors.New("Invalid rating signature on rating")
-		}
-
 		ratingSigData, err := proto.Marshal(rating.RatingData.VendorSig.Metadata)
 		if err != nil {
 			return err
@@ -312,20 +298,8 @@ func (n *OpenBazaarNode) ValidateAndSaveRating(contract *pb.RicardianContract) e
 			return errors.New("Invalid vendor signature on rating")
 		}
 
-		if rating.RatingData.Overall < RatingMin || rating.RatingData.Overall > RatingMax {
-			return errors.New("Rating not within valid range")
-		}
-		if rating.RatingData.Quality < RatingMin || rating.RatingData.Quality > RatingMax {
-			return errors.New("Rating not within valid range")
-		}
-		if rating.RatingData.Description < RatingMin || rating.RatingData.Description > RatingMax {
-			return errors.New("Rating not within valid range")
-		}
-		if rating.RatingData.DeliverySpeed < RatingMin || rating.RatingData.DeliverySpeed > RatingMax {
-			return errors.New("Rating not within valid range")
-		}
-		if rating.RatingData.CustomerService < RatingMin || rating.RatingData.CustomerService > RatingMax {
-			return errors.New("Rating not within valid range")
+		if err := validateRatingRanges(rating.RatingData); err != nil {
+			return err
 		}
 
 		m := jsonpb.Marshaler{
@@ -388,19 +362,14 @@ func (n *OpenBazaarNode) ValidateAndSaveRating(contract *pb.RicardianContract) e
 func (n *OpenBazaarNode) updateRatingIndex(rating *pb.Rating, ratingPath string) error {
 	indexPath := path.Join(n.RepoPath, "root", "ratings", "index.json")
 
-	type ratingShort struct {
-		Hash string `json:"hash"`
-		Slug string `json:"slug"`
-	}
-
-	var index []ratingShort
+	var index []ratingIndexEntry
 
 	ratingHash, err := ipfs.GetHashOfFile(n.Context, ratingPath)
 	if err != nil {
 		return err
 	}
 
-	rs := ratingShort{
+	rs := ratingIndexEntry{
 		Hash: ratingHash,
 		Slug: rating.RatingData.VendorSig.Metadata.ListingSlug,
 	}
@@ -425,7 +394,7 @@ func (n *OpenBazaarNode) updateRatingIndex(rating *pb.Rating, ratingPath string)
 		}
 
 		if len(index) == 1 {
-			index = []ratingShort{}
+			index = []ratingIndexEntry{}
 			break
 		}
 		index = append(index[:i], index[i+1:]...)
@@ -28,7 +28,11 @@ func (n *OpenBazaarNode) NewOrderConfirmation(contract *pb.RicardianContract, ad
 	}
 	oc.OrderID = orderID
 	if addressRequest {
-		addr := n.Wallet.NewAddress(spvwallet.EXTERNAL)
+		wal, err := n.WalletForContract(contract)
+		if err != nil {
+			return nil, err
+		}
+		addr := wal.NewAddress(spvwallet.EXTERNAL)
 		oc.PaymentAddress = addr.EncodeAddress()
 	}
 
@@ -73,6 +77,12 @@ func (n *OpenBazaarNode) NewOrderConfirmation(contract *pb.RicardianContract, ad
 	if err != nil {
 		return nil, err
 	}
+	if err := n.redeemCoupons(contract, orderID); err != nil {
+		return nil, err
+	}
+	if err := n.redeemWinningBids(contract, orderID); err != nil {
+		return nil, err
+	}
 	contract.VendorOrderConfirmation = oc
 	contract, err = n.SignOrderConfirmation(contract)
 	if err != nil {
@@ -82,7 +92,12 @@ func (n *OpenBazaarNode) NewOrderConfirmation(contract *pb.RicardianContract, ad
 }
 
 func (n *OpenBazaarNode) ConfirmOfflineOrder(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
-	contract, err := n.NewOrderConfirmation(contract, false)
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
+	}
+	// Fails if other orders have used up the coupons since this one arrived
+	contract, err = n.NewOrderConfirmation(contract, false)
 	if err != nil {
 		return err
 	}
@@ -113,7 +128,7 @@ func (n *OpenBazaarNode) ConfirmOfflineOrder(contract *pb.RicardianContract, rec
 			return err
 		}
 		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := n.Wallet.MasterPrivateKey()
+		mPrivKey := wal.MasterPrivateKey()
 		if err != nil {
 			return err
 		}
@@ -122,7 +137,7 @@ func (n *OpenBazaarNode) ConfirmOfflineOrder(contract *pb.RicardianContract, rec
 			return err
 		}
 		hdKey := hd.NewExtendedKey(
-			n.Wallet.Params().HDPrivateKeyID[:],
+			wal.Params().HDPrivateKeyID[:],
 			mECKey.Serialize(),
 			chaincode,
 			parentFP,
@@ -138,7 +153,7 @@ func (n *OpenBazaarNode) ConfirmOfflineOrder(contract *pb.RicardianContract, rec
 		if err != nil {
 			return err
 		}
-		_, err = n.Wallet.SweepAddress(utxos, nil, vendorKey, &redeemScript, spvwallet.NORMAL)
+		_, err = wal.SweepAddress(utxos, nil, vendorKey, &redeemScript, spvwallet.NORMAL)
 		if err != nil {
 			return err
 		}
@@ -152,6 +167,10 @@ func (n *OpenBazaarNode) ConfirmOfflineOrder(contract *pb.RicardianContract, rec
 }
 
 func (n *OpenBazaarNode) RejectOfflineOrder(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
+	}
 	orderId, err := n.CalcOrderId(contract.BuyerOrder)
 	if err != nil {
 		return err
@@ -178,7 +197,7 @@ func (n *OpenBazaarNode) RejectOfflineOrder(contract *pb.RicardianContract, reco
 			}
 		}
 
-		refundAddress, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, n.Wallet.Params())
+		refundAddress, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, wal.Params())
 		if err != nil {
 			return err
 		}
@@ -196,7 +215,7 @@ func (n *OpenBazaarNode) RejectOfflineOrder(contract *pb.RicardianContract, reco
 			return err
 		}
 		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := n.Wallet.MasterPrivateKey()
+		mPrivKey := wal.MasterPrivateKey()
 		if err != nil {
 			return err
 		}
@@ -205,7 +224,7 @@ func (n *OpenBazaarNode) RejectOfflineOrder(contract *pb.RicardianContract, reco
 			return err
 		}
 		hdKey := hd.NewExtendedKey(
-			n.Wallet.Params().HDPrivateKeyID[:],
+			wal.Params().HDPrivateKeyID[:],
 			mECKey.Serialize(),
 			chaincode,
 			parentFP,
@@ -221,7 +240,7 @@ func (n *OpenBazaarNode) RejectOfflineOrder(contract *pb.RicardianContract, reco
 		if err != nil {
 			return err
 		}
-		signatures, err := n.Wallet.CreateMultisigSignature(ins, []spvwallet.TransactionOutput{output}, vendorKey, redeemScript, contract.BuyerOrder.RefundFee)
+		signatures, err := wal.CreateMultisigSignature(ins, []spvwallet.TransactionOutput{output}, vendorKey, redeemScript, contract.BuyerOrder.RefundFee)
 		if err != nil {
 			return err
 		}
@@ -296,7 +315,11 @@ func (n *OpenBazaarNode) ValidateOrderConfirmation(contract *pb.RicardianContrac
 		}
 	}
 	if validateAddress {
-		_, err = btcutil.DecodeAddress(contract.VendorOrderConfirmation.PaymentAddress, n.Wallet.Params())
+		wal, err := n.WalletForContract(contract)
+		if err != nil {
+			return err
+		}
+		_, err = btcutil.DecodeAddress(contract.VendorOrderConfirmation.PaymentAddress, wal.Params())
 		if err != nil {
 			return err
 		}
@@ -349,6 +372,33 @@ func verifySignaturesOnOrderConfirmation(contract *pb.RicardianContract) error {
 	return nil
 }
 
+// Return the hex encoded moderator key in an escrow redeem script, or an empty string for
+// 2 of 2 crowdfund scripts which have none. Every escrow script we generate pushes the buyer's
+// and vendor's keys before any moderator's, possibly more than once when it has a timeout or
+// panel branch, so the moderator's key (the first moderator's for a panel) is the third
+// distinct key in the script.
 func ExtraModeratorKeyFromReddemScript(redeemScript string) string {
-	return redeemScript[134:200]
+	script, err := hex.DecodeString(redeemScript)
+	if err != nil {
+		return ""
+	}
+	pushes, err := txscript.PushedData(script)
+	if err != nil {
+		return ""
+	}
+	seen := make(map[string]bool)
+	for _, data := range pushes {
+		if len(data) != 33 {
+			continue
+		}
+		key := hex.EncodeToString(data)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if len(seen) == 3 {
+			return key
+		}
+	}
+	return ""
 }
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+)
+
+func escrowTestKey(t *testing.T, b byte) hd.ExtendedKey {
+	key, err := hd.NewMaster(bytes.Repeat([]byte{b}, 32), &chaincfg.TestNet3Params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := key.Neuter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *pub
+}
+
+func serializedTestKey(t *testing.T, key hd.ExtendedKey) []byte {
+	ecKey, err := key.ECPubKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ecKey.SerializeCompressed()
+}
+
+func TestExtraModeratorKeyFromRedeemScript(t *testing.T) {
+	params := &chaincfg.TestNet3Params
+	buyer, vendor, moderator, second := escrowTestKey(t, 1), escrowTestKey(t, 2), escrowTestKey(t, 3), escrowTestKey(t, 4)
+	want := hex.EncodeToString(serializedTestKey(t, moderator))
+
+	var addrKeys []*btcutil.AddressPubKey
+	for _, key := range []hd.ExtendedKey{buyer, vendor, moderator} {
+		k, err := btcutil.NewAddressPubKey(serializedTestKey(t, key), params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrKeys = append(addrKeys, k)
+	}
+	plain, err := txscript.MultiSigScript(addrKeys, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, timelocked, err := bitcoin.TimelockedMultisigScript([]hd.ExtendedKey{buyer, vendor, moderator}, 2, vendor, 432, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, panel, err := bitcoin.PanelEscrowScript(buyer, vendor, []hd.ExtendedKey{moderator, second}, 0, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, timelockedPanel, err := bitcoin.PanelEscrowScript(buyer, vendor, []hd.ExtendedKey{moderator, second}, 432, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, script := range [][]byte{plain, timelocked, panel, timelockedPanel} {
+		if key := ExtraModeratorKeyFromReddemScript(hex.EncodeToString(script)); key != want {
+			t.Errorf("Extracted moderator key %s from %x", key, script)
+		}
+	}
+
+	crowdfund, err := txscript.MultiSigScript(addrKeys[:2], 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key := ExtraModeratorKeyFromReddemScript(hex.EncodeToString(crowdfund)); key != "" {
+		t.Error("Extracted a moderator key from a 2 of 2 script")
+	}
+}
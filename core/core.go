@@ -66,6 +66,9 @@ type OpenBazaarNode struct {
 	// Bitcoin wallet implementation
 	Wallet bitcoin.BitcoinWallet
 
+	// All wallets keyed by currency code including the primary Wallet above
+	Multiwallet *bitcoin.MultiWallet
+
 	// Storage for our outgoing messages
 	MessageStorage sto.OfflineMessagingStorage
 
@@ -81,6 +84,9 @@ type OpenBazaarNode struct {
 	// A service that periodically fetches and caches the bitcoin exchange rates
 	ExchangeRates bitcoin.ExchangeRates
 
+	// Exchange rates for the coins of any additional wallets keyed by currency code
+	CoinExchangeRates map[string]bitcoin.ExchangeRates
+
 	// An optional gateway URL where we can crosspost data to ensure persistence
 	CrosspostGateways []*url.URL
 
@@ -92,6 +98,9 @@ type OpenBazaarNode struct {
 
 	// Manage blocked peers
 	BanManager *net.BanManager
+
+	// Time limits for each stage of an order
+	OrderTimeouts repo.OrderTimeoutConfig
 }
 
 // Unpin the current node repo, re-add it, then publish to IPNS
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+var (
+	ErrCouponExpired         = errors.New("Coupon code has expired")
+	ErrCouponMinimumPurchase = errors.New("Order does not meet the coupon's minimum purchase")
+	ErrCouponExhausted       = errors.New("Coupon code has been fully redeemed")
+	ErrCouponAlreadyRedeemed = errors.New("Coupon code has already been redeemed by this buyer")
+)
+
+// Return the coupon on the listing matching a code entered by the buyer, or nil if none does
+func findCoupon(listing *pb.Listing, code string) (*pb.Listing_Coupon, error) {
+	multihash, err := EncodeMultihash([]byte(code))
+	if err != nil {
+		return nil, err
+	}
+	for _, coupon := range listing.Coupons {
+		if multihash.B58String() == coupon.GetHash() {
+			return coupon, nil
+		}
+	}
+	return nil, nil
+}
+
+// Check whether a coupon had expired when an order was placed. Both the buyer and vendor
+// check against the order's timestamp so they agree on the order total. The vendor only
+// accepts a timestamp close to when it received the order, see validateOrderTime.
+func couponExpired(coupon *pb.Listing_Coupon, orderTime *timestamp.Timestamp) bool {
+	if coupon.Expiry == nil {
+		return false
+	}
+	placed := time.Now().Unix()
+	if orderTime != nil {
+		placed = orderTime.Seconds
+	}
+	return placed > coupon.Expiry.Seconds
+}
+
+// Check the coupons in an order against the vendor's record of their redemptions
+func (n *OpenBazaarNode) ValidateCouponRedemptions(contract *pb.RicardianContract) error {
+	orderID, err := n.CalcOrderId(contract.BuyerOrder)
+	if err != nil {
+		return err
+	}
+	for _, item := range contract.BuyerOrder.Items {
+		listing, err := ParseContractForListing(item.ListingHash, contract)
+		if err != nil {
+			return err
+		}
+		for _, code := range item.CouponCodes {
+			coupon, err := findCoupon(listing, code)
+			if err != nil {
+				return err
+			}
+			if coupon == nil || (coupon.MaxRedemptions == 0 && !coupon.OncePerBuyer) {
+				continue
+			}
+			redemptions, err := n.Datastore.Coupons().GetRedemptions(listing.Slug, coupon.GetHash())
+			if err != nil {
+				return err
+			}
+			var count int
+			for _, r := range redemptions {
+				if r.OrderId == orderID {
+					continue
+				}
+				if coupon.OncePerBuyer && r.BuyerId == contract.BuyerOrder.BuyerID.PeerID {
+					return ErrCouponAlreadyRedeemed
+				}
+				count++
+			}
+			if coupon.MaxRedemptions > 0 && count >= int(coupon.MaxRedemptions) {
+				return ErrCouponExhausted
+			}
+		}
+	}
+	return nil
+}
+
+// Held while checking and recording coupon redemptions so that concurrent sales can't both
+// take the last redemption of a coupon
+var couponRedemptionLock sync.Mutex
+
+// Check the coupons in a sale we are confirming can still be redeemed and record their
+// redemption
+func (n *OpenBazaarNode) redeemCoupons(contract *pb.RicardianContract, orderID string) error {
+	couponRedemptionLock.Lock()
+	defer couponRedemptionLock.Unlock()
+	if err := n.ValidateCouponRedemptions(contract); err != nil {
+		return err
+	}
+	return n.recordCouponRedemptions(contract, orderID)
+}
+
+// Record the coupons redeemed by a sale we are confirming
+func (n *OpenBazaarNode) recordCouponRedemptions(contract *pb.RicardianContract, orderID string) error {
+	for _, item := range contract.BuyerOrder.Items {
+		listing, err := ParseContractForListing(item.ListingHash, contract)
+		if err != nil {
+			return err
+		}
+		for _, code := range item.CouponCodes {
+			coupon, err := findCoupon(listing, code)
+			if err != nil {
+				return err
+			}
+			if coupon == nil {
+				continue
+			}
+			err = n.Datastore.Coupons().PutRedemption(repo.CouponRedemption{
+				Slug:      listing.Slug,
+				Hash:      coupon.GetHash(),
+				OrderId:   orderID,
+				BuyerId:   contract.BuyerOrder.BuyerID.PeerID,
+				Timestamp: time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
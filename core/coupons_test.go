@@ -0,0 +1,76 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/golang/protobuf/ptypes"
+)
+
+func TestFindCoupon(t *testing.T) {
+	multihash, err := EncodeMultihash([]byte("SUMMER"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	listing := &pb.Listing{
+		Coupons: []*pb.Listing_Coupon{
+			{Code: &pb.Listing_Coupon_Hash{Hash: multihash.B58String()}},
+		},
+	}
+	coupon, err := findCoupon(listing, "SUMMER")
+	if err != nil || coupon != listing.Coupons[0] {
+		t.Error("Failed to find coupon by its code")
+	}
+	coupon, err = findCoupon(listing, "WINTER")
+	if err != nil || coupon != nil {
+		t.Error("Found a coupon for an unknown code")
+	}
+}
+
+func TestCouponExpired(t *testing.T) {
+	expiry, _ := ptypes.TimestampProto(time.Now().Add(-time.Hour))
+	placedBefore, _ := ptypes.TimestampProto(time.Now().Add(-time.Hour * 2))
+	placedAfter, _ := ptypes.TimestampProto(time.Now())
+	if couponExpired(&pb.Listing_Coupon{}, placedAfter) {
+		t.Error("A coupon without an expiry never expires")
+	}
+	coupon := &pb.Listing_Coupon{Expiry: expiry}
+	if couponExpired(coupon, placedBefore) {
+		t.Error("Order placed before the expiry should get the discount")
+	}
+	if !couponExpired(coupon, placedAfter) {
+		t.Error("Order placed after the expiry should not get the discount")
+	}
+}
+
+func TestValidateOrderTimeWithCoupon(t *testing.T) {
+	multihash, err := EncodeMultihash([]byte("SUMMER"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiry, _ := ptypes.TimestampProto(time.Now().Add(-time.Hour))
+	listing := &pb.Listing{
+		Coupons: []*pb.Listing_Coupon{
+			{Code: &pb.Listing_Coupon_Hash{Hash: multihash.B58String()}, Expiry: expiry},
+		},
+	}
+	listings := map[string]*pb.Listing{"QmListing": listing}
+	placed, _ := ptypes.TimestampProto(time.Now().Add(-time.Hour * 2))
+	contract := &pb.RicardianContract{
+		BuyerOrder: &pb.Order{
+			Timestamp: placed,
+			Items:     []*pb.Order_Item{{ListingHash: "QmListing", CouponCodes: []string{"SUMMER"}}},
+		},
+	}
+	if err := validateOrderTime(contract, listings, time.Now()); err == nil {
+		t.Error("Expected an error for an order backdated to before the coupon expired")
+	}
+	if err := validateOrderTime(contract, listings, time.Now().Add(-time.Hour*2)); err != nil {
+		t.Error(err)
+	}
+	listing.Coupons[0].Expiry = nil
+	if err := validateOrderTime(contract, listings, time.Now()); err != nil {
+		t.Error("Coupons without an expiry should not depend on the order's timestamp")
+	}
+}
@@ -0,0 +1,371 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+)
+
+// How often the node checks for crowdfunds which have passed their deadline
+const CrowdFundSettleInterval = time.Minute * 5
+
+// Pledges only count towards a crowdfund's goal once their funding has this many confirmations
+const CrowdFundConfirmations = 1
+
+// How long after its deadline a crowdfund which met its goal may be settled. Buyers don't
+// release their pledges to fulfillments which arrive later.
+const CrowdFundSettlementWindow = time.Hour * 24 * 7
+
+// How long after the settlement window a pledge without a moderator stays in escrow before
+// the buyer may reclaim it. This leaves time for a late release to confirm.
+const PledgeReclaimMargin = time.Hour * 72
+
+// The latest deadline a crowdfund may set. Every pledge must be reclaimable by its buyer
+// within the longest timeout an escrow script allows.
+func maxCrowdFundDeadline(now time.Time) time.Time {
+	return now.Add(time.Hour*time.Duration(MaxEscrowTimeoutHours()) - CrowdFundSettlementWindow - PledgeReclaimMargin)
+}
+
+// CrowdFundStatus is the progress of one of our crowdfund listings towards its goal.
+// Goal and Raised are denominated in satoshi. Raised only counts confirmed pledges.
+type CrowdFundStatus struct {
+	Slug     string        `json:"slug"`
+	Goal     uint64        `json:"goal"`
+	Raised   uint64        `json:"raised"`
+	Deadline time.Time     `json:"deadline"`
+	Closed   bool          `json:"closed"`
+	Pledges  []repo.Pledge `json:"pledges"`
+}
+
+// Returns true if the contract is a pledge to a crowdfund. Crowdfund pledges cannot be
+// combined with other items so it's enough to check the first listing.
+func IsCrowdFund(contract *pb.RicardianContract) bool {
+	if len(contract.VendorListings) == 0 || contract.VendorListings[0].Metadata == nil {
+		return false
+	}
+	return contract.VendorListings[0].Metadata.ContractType == pb.Listing_Metadata_CROWD_FUND
+}
+
+// Returns true if the contract is a crowdfund pledge paid to a 2 of 2 address between the
+// buyer and vendor. Such pledges time out back to the buyer.
+func isUnmoderatedPledge(contract *pb.RicardianContract) bool {
+	payment := contract.BuyerOrder.Payment
+	return IsCrowdFund(contract) && payment.Moderator == "" && len(payment.ModeratorPanel) == 0
+}
+
+// Return the escrow timeout, in blocks of the given coin, of a pledge without a moderator
+// placed at the given time. It lasts until the settlement window has closed plus a margin.
+func PledgeTimeoutBlocks(listing *pb.Listing, placed time.Time, coin string) (uint32, error) {
+	if listing.CrowdFund == nil || listing.CrowdFund.Deadline == nil {
+		return 0, errors.New("Listing is not a crowdfund")
+	}
+	reclaim := time.Unix(listing.CrowdFund.Deadline.Seconds, 0).Add(CrowdFundSettlementWindow + PledgeReclaimMargin)
+	var hours uint64
+	if reclaim.After(placed) {
+		hours = uint64(math.Ceil(reclaim.Sub(placed).Hours()))
+	}
+	blocks := hours * uint64(BlocksPerHour(coin))
+	if blocks > bitcoin.MaxTimeoutBlocks {
+		return 0, errors.New("Crowdfund deadline is too far away")
+	}
+	return uint32(blocks), nil
+}
+
+// Generate the escrow address for a pledge without a moderator. The buyer may reclaim the
+// funds through the timeout branch if the vendor doesn't settle in time.
+func generatePledgeScript(wal bitcoin.BitcoinWallet, buyerKey, vendorKey hd.ExtendedKey, timeout uint32) (btc.Address, []byte, error) {
+	keys := []hd.ExtendedKey{buyerKey, vendorKey}
+	if timeout == 0 {
+		return wal.GenerateMultisigScript(keys, 2)
+	}
+	return bitcoin.TimelockedMultisigScript(keys, 2, buyerKey, timeout, wal.Params())
+}
+
+func crowdFundClosed(listing *pb.Listing) bool {
+	if listing.CrowdFund == nil || listing.CrowdFund.Deadline == nil {
+		return true
+	}
+	return !time.Unix(listing.CrowdFund.Deadline.Seconds, 0).After(time.Now())
+}
+
+// Record a pledge made to one of our crowdfunds. The pledge only counts towards the goal
+// once the buyer has funded the escrow address.
+func (n *OpenBazaarNode) RecordPledge(contract *pb.RicardianContract) error {
+	orderId, err := n.CalcOrderId(contract.BuyerOrder)
+	if err != nil {
+		return err
+	}
+	return n.Datastore.Pledges().Put(repo.Pledge{
+		OrderId:   orderId,
+		Slug:      contract.VendorListings[0].Slug,
+		BuyerId:   contract.BuyerOrder.BuyerID.PeerID,
+		Amount:    contract.BuyerOrder.Payment.Amount,
+		Timestamp: time.Now(),
+	})
+}
+
+// Return the progress of one of our crowdfunds
+func (n *OpenBazaarNode) GetCrowdFundStatus(slug string) (*CrowdFundStatus, error) {
+	sl, err := n.GetListingFromSlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	if sl.Listing.Metadata.ContractType != pb.Listing_Metadata_CROWD_FUND {
+		return nil, errors.New("Listing is not a crowdfund")
+	}
+	goal, err := n.getPriceInSatoshi(sl.Listing.Metadata.PricingCurrency, sl.Listing.CrowdFund.Goal)
+	if err != nil {
+		return nil, err
+	}
+	pledges, err := n.Datastore.Pledges().Get(slug)
+	if err != nil {
+		return nil, err
+	}
+	if pledges == nil {
+		pledges = []repo.Pledge{}
+	}
+	status := &CrowdFundStatus{
+		Slug:     slug,
+		Goal:     goal,
+		Deadline: time.Unix(sl.Listing.CrowdFund.Deadline.Seconds, 0),
+		Closed:   crowdFundClosed(sl.Listing),
+		Pledges:  pledges,
+	}
+	for _, pledge := range pledges {
+		contract, _, funded, records, _, err := n.Datastore.Sales().GetByOrderId(pledge.OrderId)
+		if err != nil || !funded {
+			continue
+		}
+		contributions, err := n.confirmedContributions(contract, records)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range contributions {
+			status.Raised += c.Value
+		}
+	}
+	return status, nil
+}
+
+// Return the funding outputs of a pledge which have enough confirmations to count towards
+// the goal
+func (n *OpenBazaarNode) confirmedContributions(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) ([]*pb.OrderFulfillment_CrowdFundResult_Contribution, error) {
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return nil, err
+	}
+	var contributions []*pb.OrderFulfillment_CrowdFundResult_Contribution
+	for _, r := range records {
+		if r.Value <= 0 {
+			continue
+		}
+		txid, err := chainhash.NewHashFromStr(r.Txid)
+		if err != nil {
+			return nil, err
+		}
+		confirmations, err := wal.GetConfirmations(*txid)
+		if err != nil || confirmations < CrowdFundConfirmations {
+			continue
+		}
+		contributions = append(contributions, &pb.OrderFulfillment_CrowdFundResult_Contribution{
+			Txid:  r.Txid,
+			Index: r.Index,
+			Value: uint64(r.Value),
+		})
+	}
+	return contributions, nil
+}
+
+// Return the result of a crowdfund to send with the fulfillment of each pledge
+func (n *OpenBazaarNode) crowdFundResult(status *CrowdFundStatus) (*pb.OrderFulfillment_CrowdFundResult, error) {
+	result := &pb.OrderFulfillment_CrowdFundResult{}
+	for _, pledge := range status.Pledges {
+		contract, _, funded, records, _, err := n.Datastore.Sales().GetByOrderId(pledge.OrderId)
+		if err != nil || !funded {
+			continue
+		}
+		contributions, err := n.confirmedContributions(contract, records)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range contributions {
+			result.Raised += c.Value
+			result.Contributions = append(result.Contributions, c)
+		}
+	}
+	return result, nil
+}
+
+// Check whether a crowdfund can still be settled, which is only once its deadline has passed
+// and for a limited time afterwards
+func crowdFundSettlementOpen(listing *pb.Listing, now time.Time) error {
+	if listing.CrowdFund == nil || listing.CrowdFund.Deadline == nil {
+		return errors.New("Listing is not a crowdfund")
+	}
+	deadline := time.Unix(listing.CrowdFund.Deadline.Seconds, 0)
+	if now.Before(deadline) {
+		return errors.New("Crowdfund has not reached its deadline")
+	}
+	if now.After(deadline.Add(CrowdFundSettlementWindow)) {
+		return errors.New("Crowdfund was not settled in time")
+	}
+	return nil
+}
+
+// Return the contributions in a crowdfund result by outpoint, checking none is listed twice
+// and that they add up to the amount raised
+func crowdFundContributions(result *pb.OrderFulfillment_CrowdFundResult) (map[string]uint64, error) {
+	contributions := make(map[string]uint64)
+	var raised uint64
+	for _, c := range result.Contributions {
+		outpoint := fmt.Sprintf("%s:%d", c.Txid, c.Index)
+		if _, ok := contributions[outpoint]; ok {
+			return nil, errors.New("Crowdfund result lists a contribution more than once")
+		}
+		contributions[outpoint] = c.Value
+		raised += c.Value
+	}
+	if raised != result.Raised {
+		return nil, errors.New("Crowdfund contributions don't add up to the amount raised")
+	}
+	return contributions, nil
+}
+
+// Check the fulfillment of one of our pledges before releasing the escrow to the vendor.
+// The fulfillment must arrive within the settlement window and report a goal which was met
+// by confirmed contributions including our own. Otherwise the pledge stays in escrow until
+// the vendor refunds it or, without a moderator, until we reclaim it after the timeout.
+//
+// Only our own contribution can be checked since our wallet doesn't see the other buyers'
+// escrow addresses. The rest of the result is the vendor's word. It is signed as part of the
+// fulfillment and kept in the contract so a false report can be shown later, and what we
+// risk by trusting it is limited to our own pledge.
+func (n *OpenBazaarNode) ValidateCrowdFundRelease(contract *pb.RicardianContract, fulfillment *pb.OrderFulfillment, records []*spvwallet.TransactionRecord) error {
+	listing := contract.VendorListings[0]
+	if err := crowdFundSettlementOpen(listing, time.Now()); err != nil {
+		return err
+	}
+	if fulfillment.CrowdFund == nil {
+		return errors.New("Fulfillment does not report the crowdfund's result")
+	}
+	contributions, err := crowdFundContributions(fulfillment.CrowdFund)
+	if err != nil {
+		return err
+	}
+	goal, err := n.getPriceInSatoshi(listing.Metadata.PricingCurrency, listing.CrowdFund.Goal)
+	if err != nil {
+		return err
+	}
+	if !n.ValidatePaymentAmount(goal, fulfillment.CrowdFund.Raised) {
+		return errors.New("Crowdfund did not reach its goal")
+	}
+	ours, err := n.confirmedContributions(contract, records)
+	if err != nil {
+		return err
+	}
+	if len(ours) == 0 {
+		return errors.New("Our pledge is not confirmed")
+	}
+	for _, c := range ours {
+		if value, ok := contributions[fmt.Sprintf("%s:%d", c.Txid, c.Index)]; !ok || value != c.Value {
+			return errors.New("Crowdfund result does not include our pledge")
+		}
+	}
+	return nil
+}
+
+// Settle one of our crowdfunds once its deadline has passed. If the confirmed pledges meet
+// the goal each one is fulfilled so the buyer releases the escrow to us, otherwise every
+// funded pledge is refunded. Pledges which were never funded are left to expire.
+func (n *OpenBazaarNode) SettleCrowdFund(slug string) error {
+	status, err := n.GetCrowdFundStatus(slug)
+	if err != nil {
+		return err
+	}
+	if !status.Closed {
+		return errors.New("Crowdfund has not reached its deadline")
+	}
+	// Buyers only release pledges within the settlement window so refund them after it
+	goalMet := status.Raised >= status.Goal && time.Now().Before(status.Deadline.Add(CrowdFundSettlementWindow))
+	var result *pb.OrderFulfillment_CrowdFundResult
+	if goalMet {
+		result, err = n.crowdFundResult(status)
+		if err != nil {
+			return err
+		}
+	}
+	settled := 0
+	for _, pledge := range status.Pledges {
+		if pledge.Settled {
+			continue
+		}
+		contract, _, funded, records, _, err := n.Datastore.Sales().GetByOrderId(pledge.OrderId)
+		if err != nil || !funded {
+			continue
+		}
+		if goalMet {
+			err = n.FulfillOrder(&pb.OrderFulfillment{OrderId: pledge.OrderId, Slug: slug, CrowdFund: result}, contract, records)
+		} else {
+			err = n.RefundOrder(contract, records, 0, nil)
+		}
+		if err != nil {
+			log.Errorf("Error settling pledge %s: %s", pledge.OrderId, err)
+			continue
+		}
+		if err := n.Datastore.Pledges().MarkAsSettled(pledge.OrderId); err != nil {
+			return err
+		}
+		settled++
+	}
+	if settled > 0 {
+		notif := notifications.CrowdFundNotification{
+			Slug:    slug,
+			Goal:    status.Goal,
+			Raised:  status.Raised,
+			GoalMet: goalMet,
+		}
+		n.Broadcast <- notif
+		n.Datastore.Notifications().Put(notif, time.Now())
+	}
+	return nil
+}
+
+// Settle all of our crowdfunds which have passed their deadline
+func (n *OpenBazaarNode) SettleExpiredCrowdFunds() {
+	index, err := n.getListingIndex()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, l := range index {
+		sl, err := n.GetListingFromSlug(l.Slug)
+		if err != nil {
+			continue
+		}
+		if sl.Listing.Metadata.ContractType != pb.Listing_Metadata_CROWD_FUND || !crowdFundClosed(sl.Listing) {
+			continue
+		}
+		if err := n.SettleCrowdFund(l.Slug); err != nil {
+			log.Errorf("Error settling crowdfund %s: %s", l.Slug, err)
+		}
+	}
+}
+
+// Periodically settle expired crowdfunds. This should be run in a separate goroutine.
+func (n *OpenBazaarNode) RunCrowdFundSettler() {
+	n.SettleExpiredCrowdFunds()
+	t := time.NewTicker(CrowdFundSettleInterval)
+	for range t.C {
+		n.SettleExpiredCrowdFunds()
+	}
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/golang/protobuf/ptypes"
+)
+
+func TestCrowdFundSettlementOpen(t *testing.T) {
+	deadline := time.Now().Add(-time.Hour)
+	ts, _ := ptypes.TimestampProto(deadline)
+	listing := &pb.Listing{CrowdFund: &pb.Listing_CrowdFund{Goal: 1000, Deadline: ts}}
+	if err := crowdFundSettlementOpen(listing, time.Now()); err != nil {
+		t.Error(err)
+	}
+	if err := crowdFundSettlementOpen(listing, deadline.Add(-time.Minute)); err == nil {
+		t.Error("Expected an error for a settlement before the deadline")
+	}
+	if err := crowdFundSettlementOpen(listing, deadline.Add(CrowdFundSettlementWindow+time.Minute)); err == nil {
+		t.Error("Expected an error for a settlement after the window")
+	}
+}
+
+func TestCrowdFundContributions(t *testing.T) {
+	result := &pb.OrderFulfillment_CrowdFundResult{
+		Raised: 3000,
+		Contributions: []*pb.OrderFulfillment_CrowdFundResult_Contribution{
+			{Txid: "aa", Index: 0, Value: 1000},
+			{Txid: "aa", Index: 1, Value: 2000},
+		},
+	}
+	contributions, err := crowdFundContributions(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contributions["aa:1"] != 2000 {
+		t.Error("Contribution was not returned by outpoint")
+	}
+	result.Raised = 5000
+	if _, err := crowdFundContributions(result); err == nil {
+		t.Error("Expected an error when the contributions don't add up")
+	}
+	result.Raised = 4000
+	result.Contributions = append(result.Contributions, &pb.OrderFulfillment_CrowdFundResult_Contribution{Txid: "aa", Index: 0, Value: 1000})
+	if _, err := crowdFundContributions(result); err == nil {
+		t.Error("Expected an error for a contribution listed twice")
+	}
+}
+
+func TestPledgeTimeoutBlocks(t *testing.T) {
+	placed := time.Now()
+	deadline := placed.Add(time.Hour * 24 * 30)
+	ts, _ := ptypes.TimestampProto(deadline)
+	listing := &pb.Listing{CrowdFund: &pb.Listing_CrowdFund{Goal: 1000, Deadline: ts}}
+	timeout, err := PledgeTimeoutBlocks(listing, placed, "BTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hours := uint32((deadline.Sub(placed) + CrowdFundSettlementWindow + PledgeReclaimMargin) / time.Hour)
+	if timeout != hours*BlocksPerHour("BTC") {
+		t.Errorf("Expected a timeout of %d blocks, got %d", hours*BlocksPerHour("BTC"), timeout)
+	}
+	timeout, err = PledgeTimeoutBlocks(listing, placed, "LTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeout != hours*BlocksPerHour("LTC") {
+		t.Errorf("Expected a timeout of %d blocks, got %d", hours*BlocksPerHour("LTC"), timeout)
+	}
+	ts, _ = ptypes.TimestampProto(placed.Add(time.Hour * 24 * 365))
+	listing.CrowdFund.Deadline = ts
+	if _, err := PledgeTimeoutBlocks(listing, placed, "LTC"); err == nil {
+		t.Error("Expected an error for a timeout which does not fit in an escrow script")
+	}
+	ts, _ = ptypes.TimestampProto(maxCrowdFundDeadline(placed))
+	listing.CrowdFund.Deadline = ts
+	if _, err := PledgeTimeoutBlocks(listing, placed, "LTC"); err != nil {
+		t.Errorf("The latest crowdfund deadline should fit in an escrow script: %s", err)
+	}
+}
@@ -0,0 +1,298 @@
+package core
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+/* Vendors of digital goods may upload the file they sell instead of delivering a link by
+   hand. Each buyer receives their own copy of the file, encrypted with a fresh key and pinned
+   to IPFS, and the key is put in the order fulfillment. Listings set to auto-fulfill are
+   fulfilled once the order's payment is confirmed so the vendor doesn't need to be at the
+   node. */
+
+const (
+	// The largest file which may be sold as a digital good
+	MaxDigitalGoodSize = 100 << 20
+
+	MaxDigitalGoodNameLength = 256
+
+	// How often the node looks for paid orders of auto-fulfilled digital goods
+	DigitalDeliveryInterval = time.Minute
+
+	// The confirmations an order's payment needs before its digital goods are delivered
+	// automatically. Delivery can't be taken back if the payment is double spent.
+	DigitalDeliveryConfirmations = 1
+)
+
+var (
+	ErrDigitalGoodNotFound     = errors.New("Digital good not found")
+	ErrDigitalDeliveryNotFound = errors.New("Digital delivery not found")
+)
+
+// Save the file delivered to buyers of one of our digital good listings
+func (n *OpenBazaarNode) SetDigitalGood(slug, filename, mediaType string, data []byte, autoFulfill bool) (repo.DigitalGood, error) {
+	if len(data) == 0 {
+		return repo.DigitalGood{}, errors.New("File is empty")
+	}
+	if len(data) > MaxDigitalGoodSize {
+		return repo.DigitalGood{}, errors.New("File is too large")
+	}
+	if filename == "" || len(filename) > MaxDigitalGoodNameLength {
+		return repo.DigitalGood{}, errors.New("Filename is missing or too long")
+	}
+	sl, err := n.GetListingFromSlug(slug)
+	if err != nil {
+		return repo.DigitalGood{}, errors.New("Listing not found")
+	}
+	if sl.Listing.Metadata.ContractType != pb.Listing_Metadata_DIGITAL_GOOD {
+		return repo.DigitalGood{}, errors.New("Files can only be attached to digital good listings")
+	}
+	h := sha256.Sum256(data)
+	good := repo.DigitalGood{
+		Slug:        slug,
+		Filename:    filename,
+		MediaType:   mediaType,
+		Hash:        hex.EncodeToString(h[:]),
+		Size:        len(data),
+		AutoFulfill: autoFulfill,
+		Data:        data,
+		Timestamp:   time.Now(),
+	}
+	if err := n.Datastore.DigitalGoods().Put(good); err != nil {
+		return repo.DigitalGood{}, err
+	}
+	return good, nil
+}
+
+// Encrypt a file with a new AES-256-GCM key. The nonce is prepended to the ciphertext.
+func encryptDigitalGood(data []byte) (key, ciphertext []byte, err error) {
+	key = make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	return key, gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+func decryptDigitalGood(key, ciphertext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("Ciphertext is too short")
+	}
+	return gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], nil)
+}
+
+// Encrypt a copy of the file for a listing and pin it to IPFS
+func (n *OpenBazaarNode) deliverDigitalGood(good repo.DigitalGood) (*pb.OrderFulfillment_DigitalDelivery, error) {
+	key, ciphertext, err := encryptDigitalGood(good.Data)
+	if err != nil {
+		return nil, err
+	}
+	f, err := ioutil.TempFile("", "digitalgood")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(ciphertext)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := ipfs.AddFile(n.Context, f.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.OrderFulfillment_DigitalDelivery{
+		Hash:      hash,
+		Key:       key,
+		Filename:  good.Filename,
+		MediaType: good.MediaType,
+		Checksum:  good.Hash,
+	}, nil
+}
+
+// Deliver the uploaded file for a digital good unless the vendor entered a delivery by hand.
+// Listings without an uploaded file are fulfilled as given.
+func (n *OpenBazaarNode) attachDigitalDelivery(fulfillment *pb.OrderFulfillment, contract *pb.RicardianContract) error {
+	if len(fulfillment.DigitalDelivery) > 0 {
+		return nil
+	}
+	for _, listing := range contract.VendorListings {
+		if listing.Slug != fulfillment.Slug || listing.Metadata.ContractType != pb.Listing_Metadata_DIGITAL_GOOD {
+			continue
+		}
+		good, err := n.Datastore.DigitalGoods().Get(listing.Slug)
+		if err != nil {
+			return nil
+		}
+		delivery, err := n.deliverDigitalGood(good)
+		if err != nil {
+			return err
+		}
+		fulfillment.DigitalDelivery = []*pb.OrderFulfillment_DigitalDelivery{delivery}
+		return nil
+	}
+	return nil
+}
+
+// Check the payments for an order with enough confirmations add up to its total
+func (n *OpenBazaarNode) paymentConfirmed(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord, minConfirmations uint32) (bool, error) {
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return false, err
+	}
+	var confirmed uint64
+	for _, r := range records {
+		if r.Value <= 0 {
+			continue
+		}
+		txid, err := chainhash.NewHashFromStr(r.Txid)
+		if err != nil {
+			return false, err
+		}
+		confirmations, err := wal.GetConfirmations(*txid)
+		if err != nil || confirmations < minConfirmations {
+			continue
+		}
+		confirmed += uint64(r.Value)
+	}
+	return confirmed >= contract.BuyerOrder.Payment.Amount, nil
+}
+
+// Fulfill a paid order if every item in it is a digital good set to auto-fulfill and its
+// payment is confirmed. Orders which arrived while we were offline are confirmed first.
+func (n *OpenBazaarNode) autoFulfillOrder(orderID string) error {
+	contract, state, funded, records, _, err := n.Datastore.Sales().GetByOrderId(orderID)
+	if err != nil {
+		return err
+	}
+	if !funded || (state != pb.OrderState_PENDING && state != pb.OrderState_FUNDED) {
+		return nil
+	}
+	for _, listing := range contract.VendorListings {
+		if listing.Metadata.ContractType != pb.Listing_Metadata_DIGITAL_GOOD {
+			return nil
+		}
+		autoFulfill, err := n.Datastore.DigitalGoods().GetAutoFulfill(listing.Slug)
+		if err != nil || !autoFulfill {
+			return nil
+		}
+	}
+	confirmed, err := n.paymentConfirmed(contract, records, DigitalDeliveryConfirmations)
+	if err != nil || !confirmed {
+		return err
+	}
+	if state == pb.OrderState_PENDING {
+		if err := n.ConfirmOfflineOrder(contract, records); err != nil {
+			return err
+		}
+		contract, _, _, records, _, err = n.Datastore.Sales().GetByOrderId(orderID)
+		if err != nil {
+			return err
+		}
+	}
+	for _, listing := range contract.VendorListings {
+		fulfillment := &pb.OrderFulfillment{OrderId: orderID, Slug: listing.Slug}
+		if err := n.FulfillOrder(fulfillment, contract, records); err != nil {
+			return err
+		}
+	}
+	log.Infof("Delivered digital goods for order %s", orderID)
+	return nil
+}
+
+// Fulfill all paid orders of auto-fulfilled digital goods
+func (n *OpenBazaarNode) FulfillDigitalOrders() {
+	for _, state := range []pb.OrderState{pb.OrderState_PENDING, pb.OrderState_FUNDED} {
+		orders, err := n.Datastore.Sales().GetStale(state, time.Now())
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		for _, order := range orders {
+			if !order.Funded {
+				continue
+			}
+			autoProcessLock.Lock()
+			err := n.autoFulfillOrder(order.OrderId)
+			autoProcessLock.Unlock()
+			if err != nil {
+				log.Errorf("Error delivering digital goods for order %s: %s", order.OrderId, err)
+			}
+		}
+	}
+}
+
+// Periodically deliver digital goods for paid orders. This should be run in a separate goroutine.
+func (n *OpenBazaarNode) RunDigitalDeliveries() {
+	n.FulfillDigitalOrders()
+	t := time.NewTicker(DigitalDeliveryInterval)
+	for range t.C {
+		n.FulfillDigitalOrders()
+	}
+}
+
+// Fetch and decrypt a file delivered for one of our purchases, checking it against the
+// checksum the vendor sent with it
+func (n *OpenBazaarNode) FetchDigitalDelivery(orderID, hash string) (*pb.OrderFulfillment_DigitalDelivery, []byte, error) {
+	contract, _, _, _, _, err := n.Datastore.Purchases().GetByOrderId(orderID)
+	if err != nil {
+		return nil, nil, ErrDigitalDeliveryNotFound
+	}
+	var found *pb.OrderFulfillment_DigitalDelivery
+	for _, fulfillment := range contract.VendorOrderFulfillment {
+		for _, d := range fulfillment.DigitalDelivery {
+			if d.Hash != "" && d.Hash == hash {
+				found = d
+			}
+		}
+	}
+	if found == nil {
+		return nil, nil, ErrDigitalDeliveryNotFound
+	}
+	ciphertext, err := ipfs.Cat(n.Context, found.Hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := decryptDigitalGood(found.Key, ciphertext)
+	if err != nil {
+		return nil, nil, err
+	}
+	h := sha256.Sum256(data)
+	if hex.EncodeToString(h[:]) != found.Checksum {
+		return nil, nil, errors.New("Delivered file does not match its checksum")
+	}
+	return found, data, nil
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncryptDigitalGood(t *testing.T) {
+	data := []byte("It was a bright cold day in April")
+	key, ciphertext, err := encryptDigitalGood(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(key) != 32 || bytes.Contains(ciphertext, data) {
+		t.Error("File was not encrypted with a 256 bit key")
+	}
+	plaintext, err := decryptDigitalGood(key, ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plaintext, data) {
+		t.Error("Decrypted file does not match the original")
+	}
+
+	otherKey, _, err := encryptDigitalGood(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(key, otherKey) {
+		t.Error("Each copy of a file should be encrypted with a new key")
+	}
+	if _, err := decryptDigitalGood(otherKey, ciphertext); err == nil {
+		t.Error("File decrypted with the wrong key")
+	}
+	if _, err := decryptDigitalGood(key, ciphertext[:4]); err == nil {
+		t.Error("Truncated ciphertext should fail to decrypt")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"strconv"
 	"sync"
 	"time"
@@ -13,6 +14,7 @@ import (
 	mh "gx/ipfs/QmbZ6Cee2uHjG7hf19qLHppgKDRtaG4CVtMzdmK9VCVqLu/go-multihash"
 
 	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
 	"github.com/OpenBazaar/openbazaar-go/pb"
 	"github.com/OpenBazaar/spvwallet"
 	"github.com/btcsuite/btcd/txscript"
@@ -29,6 +31,13 @@ var DisputeWg = new(sync.WaitGroup)
 var ErrCaseNotFound = errors.New("Case not found")
 
 func (n *OpenBazaarNode) OpenDispute(orderID string, contract *pb.RicardianContract, records []*spvwallet.TransactionRecord, claim string) error {
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
+	}
+	if contract.BuyerOrder.Payment.Moderator == "" {
+		return errors.New("Order does not have a moderator")
+	}
 	var isPurchase bool
 	if n.IpfsNode.Identity.Pretty() == contract.BuyerOrder.BuyerID.PeerID {
 		isPurchase = true
@@ -58,7 +67,7 @@ func (n *OpenBazaarNode) OpenDispute(orderID string, contract *pb.RicardianContr
 	dispute.Outpoints = outpoints
 
 	// Add payout address
-	dispute.PayoutAddress = n.Wallet.CurrentAddress(spvwallet.EXTERNAL).EncodeAddress()
+	dispute.PayoutAddress = wal.CurrentAddress(spvwallet.EXTERNAL).EncodeAddress()
 
 	// Serialize contract
 	ser, err := proto.Marshal(contract)
@@ -77,10 +86,12 @@ func (n *OpenBazaarNode) OpenDispute(orderID string, contract *pb.RicardianContr
 	contract.Dispute = dispute
 	contract.Signatures = append(contract.Signatures, rc.Signatures[0])
 
-	// Send to moderator
-	err = n.SendDisputeOpen(contract.BuyerOrder.Payment.Moderator, nil, rc)
-	if err != nil {
-		return err
+	// Send to moderator, or every moderator of a panel
+	for _, mod := range OrderModerators(contract.BuyerOrder.Payment) {
+		err = n.SendDisputeOpen(mod, nil, rc)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Send to counterparty
@@ -188,6 +199,10 @@ func (n *OpenBazaarNode) ProcessDisputeOpen(rc *pb.RicardianContract, peerID str
 	if len(contract.VendorListings) == 0 || contract.BuyerOrder == nil || contract.BuyerOrder.Payment == nil {
 		return errors.New("Serialized contract is malformatted")
 	}
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
+	}
 
 	orderId, err := n.CalcOrderId(contract.BuyerOrder)
 	if err != nil {
@@ -195,7 +210,7 @@ func (n *OpenBazaarNode) ProcessDisputeOpen(rc *pb.RicardianContract, peerID str
 	}
 
 	// Figure out what role we have in this dispute and process it
-	if contract.BuyerOrder.Payment.Moderator == n.IpfsNode.Identity.Pretty() { // Moderator
+	if isOrderModerator(contract.BuyerOrder.Payment, n.IpfsNode.Identity.Pretty()) { // Moderator
 		validationErrors := n.ValidateCaseContract(contract)
 		var err error
 		if contract.VendorListings[0].VendorID.PeerID == peerID {
@@ -229,7 +244,7 @@ func (n *OpenBazaarNode) ProcessDisputeOpen(rc *pb.RicardianContract, peerID str
 			return err
 		}
 		// Check this order is currently in a state which can be disputed
-		if state == pb.OrderState_COMPLETE || state == pb.OrderState_DISPUTED || state == pb.OrderState_DECIDED || state == pb.OrderState_RESOLVED || state == pb.OrderState_REFUNDED || state == pb.OrderState_CANCELED || state == pb.OrderState_REJECTED {
+		if state == pb.OrderState_COMPLETE || state == pb.OrderState_DISPUTED || state == pb.OrderState_DECIDED || state == pb.OrderState_RESOLVED || state == pb.OrderState_REFUNDED || state == pb.OrderState_PARTIALLY_REFUNDED || state == pb.OrderState_CANCELED || state == pb.OrderState_REJECTED {
 			return errors.New("Contact can no longer be disputed")
 		}
 
@@ -241,7 +256,7 @@ func (n *OpenBazaarNode) ProcessDisputeOpen(rc *pb.RicardianContract, peerID str
 		}
 		update.SerializedContract = ser
 		update.OrderId = orderId
-		update.PayoutAddress = n.Wallet.CurrentAddress(spvwallet.EXTERNAL).EncodeAddress()
+		update.PayoutAddress = wal.CurrentAddress(spvwallet.EXTERNAL).EncodeAddress()
 
 		var outpoints []*pb.Outpoint
 		for _, r := range records {
@@ -254,9 +269,11 @@ func (n *OpenBazaarNode) ProcessDisputeOpen(rc *pb.RicardianContract, peerID str
 		update.Outpoints = outpoints
 
 		// Send the message
-		err = n.SendDisputeUpdate(myContract.BuyerOrder.Payment.Moderator, update)
-		if err != nil {
-			return err
+		for _, mod := range OrderModerators(myContract.BuyerOrder.Payment) {
+			err = n.SendDisputeUpdate(mod, update)
+			if err != nil {
+				return err
+			}
 		}
 
 		// Append the dispute and signature
@@ -278,7 +295,7 @@ func (n *OpenBazaarNode) ProcessDisputeOpen(rc *pb.RicardianContract, peerID str
 			return err
 		}
 		// Check this order is currently in a state which can be disputed
-		if state == pb.OrderState_COMPLETE || state == pb.OrderState_DISPUTED || state == pb.OrderState_DECIDED || state == pb.OrderState_RESOLVED || state == pb.OrderState_REFUNDED || state == pb.OrderState_CANCELED || state == pb.OrderState_REJECTED {
+		if state == pb.OrderState_COMPLETE || state == pb.OrderState_DISPUTED || state == pb.OrderState_DECIDED || state == pb.OrderState_RESOLVED || state == pb.OrderState_REFUNDED || state == pb.OrderState_PARTIALLY_REFUNDED || state == pb.OrderState_CANCELED || state == pb.OrderState_REJECTED {
 			return errors.New("Contact can no longer be disputed")
 		}
 
@@ -290,7 +307,7 @@ func (n *OpenBazaarNode) ProcessDisputeOpen(rc *pb.RicardianContract, peerID str
 		}
 		update.SerializedContract = ser
 		update.OrderId = orderId
-		update.PayoutAddress = n.Wallet.CurrentAddress(spvwallet.EXTERNAL).EncodeAddress()
+		update.PayoutAddress = wal.CurrentAddress(spvwallet.EXTERNAL).EncodeAddress()
 
 		var outpoints []*pb.Outpoint
 		for _, r := range records {
@@ -303,9 +320,11 @@ func (n *OpenBazaarNode) ProcessDisputeOpen(rc *pb.RicardianContract, peerID str
 		update.Outpoints = outpoints
 
 		// Send the message
-		err = n.SendDisputeUpdate(myContract.BuyerOrder.Payment.Moderator, update)
-		if err != nil {
-			return err
+		for _, mod := range OrderModerators(myContract.BuyerOrder.Payment) {
+			err = n.SendDisputeUpdate(mod, update)
+			if err != nil {
+				return err
+			}
 		}
 
 		// Append the dispute and signature
@@ -332,6 +351,12 @@ func (n *OpenBazaarNode) ProcessDisputeOpen(rc *pb.RicardianContract, peerID str
 }
 
 func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPercentage float32, resolution string) error {
+	return n.closeDispute(orderId, buyerPercentage, vendorPercentage, resolution, n.IpfsNode.Identity.Pretty())
+}
+
+// Sign and send the resolution of a dispute. proposedBy is the party whose split was
+// agreed, or ourselves if we decided the dispute.
+func (n *OpenBazaarNode) closeDispute(orderId string, buyerPercentage, vendorPercentage float32, resolution, proposedBy string) error {
 	if buyerPercentage+vendorPercentage != 100 {
 		return errors.New("Payout percentages must sum to 100")
 	}
@@ -343,6 +368,18 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 	if state != pb.OrderState_DISPUTED {
 		return errors.New("A dispute for this order is not open")
 	}
+	coinContract := buyerContract
+	if coinContract == nil {
+		coinContract = vendorContract
+	}
+	panel := coinContract.BuyerOrder.Payment.ModeratorPanel
+	if len(panel) > 0 && panel[0] != n.IpfsNode.Identity.Pretty() {
+		return errors.New("Only the coordinating moderator of the panel can close the dispute")
+	}
+	wal, err := n.WalletForContract(coinContract)
+	if err != nil {
+		return err
+	}
 
 	d := new(pb.DisputeResolution)
 
@@ -356,8 +393,8 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 	// Add orderId
 	d.OrderId = orderId
 
-	// Set self (moderator) as the party that made the resolution proposal
-	d.ProposedBy = n.IpfsNode.Identity.Pretty()
+	// Set the party that made the resolution proposal
+	d.ProposedBy = proposedBy
 
 	// Sign buyer rating key
 	if buyerContract != nil {
@@ -409,7 +446,7 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 		if len(vendorContract.VendorOrderFulfillment) > 0 && vendorContract.VendorOrderFulfillment[0].Payout != nil {
 			feePerByte = vendorContract.VendorOrderFulfillment[0].Payout.PayoutFeePerByte
 		} else {
-			feePerByte = n.Wallet.GetFeePerByte(spvwallet.NORMAL)
+			feePerByte = wal.GetFeePerByte(spvwallet.NORMAL)
 		}
 		buyerId = vendorContract.BuyerOrder.BuyerID.PeerID
 		buyerKey, err = libp2p.UnmarshalPublicKey(vendorContract.BuyerOrder.BuyerID.Pubkeys.Identity)
@@ -430,7 +467,7 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 		if len(vendorContract.VendorOrderFulfillment) > 0 && vendorContract.VendorOrderFulfillment[0].Payout != nil {
 			feePerByte = vendorContract.VendorOrderFulfillment[0].Payout.PayoutFeePerByte
 		} else {
-			feePerByte = n.Wallet.GetFeePerByte(spvwallet.NORMAL)
+			feePerByte = wal.GetFeePerByte(spvwallet.NORMAL)
 		}
 		buyerId = vendorContract.BuyerOrder.BuyerID.PeerID
 		buyerKey, err = libp2p.UnmarshalPublicKey(vendorContract.BuyerOrder.BuyerID.Pubkeys.Identity)
@@ -471,7 +508,7 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 	var outputs []spvwallet.TransactionOutput
 	var modAddr btcutil.Address
 	var modValue uint64
-	modAddr = n.Wallet.CurrentAddress(spvwallet.EXTERNAL)
+	modAddr = wal.CurrentAddress(spvwallet.EXTERNAL)
 	modValue, err = n.GetModeratorFee(totalOut)
 	var modOutputScript []byte
 	if err != nil {
@@ -494,7 +531,7 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 	var buyerValue uint64
 	var buyerOutputScript []byte
 	if buyerPayout {
-		buyerAddr, err = btcutil.DecodeAddress(buyerPayoutAddress, n.Wallet.Params())
+		buyerAddr, err = btcutil.DecodeAddress(buyerPayoutAddress, wal.Params())
 		if err != nil {
 			return err
 		}
@@ -513,7 +550,7 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 	var vendorValue uint64
 	var vendorOutputScript []byte
 	if vendorPayout {
-		vendorAddr, err = btcutil.DecodeAddress(vendorPayoutAddress, n.Wallet.Params())
+		vendorAddr, err = btcutil.DecodeAddress(vendorPayoutAddress, wal.Params())
 		if err != nil {
 			return err
 		}
@@ -552,7 +589,7 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 	}
 
 	// Calculate total fee
-	txFee := n.Wallet.EstimateFee(inputs, outputs, feePerByte)
+	txFee := wal.EstimateFee(inputs, outputs, feePerByte)
 
 	// Subtract fee from each output in proportion to output value
 	var outs []spvwallet.TransactionOutput
@@ -573,7 +610,7 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 	if err != nil {
 		return err
 	}
-	mPrivKey := n.Wallet.MasterPrivateKey()
+	mPrivKey := wal.MasterPrivateKey()
 	if err != nil {
 		return err
 	}
@@ -582,7 +619,7 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 		return err
 	}
 	hdKey := hd.NewExtendedKey(
-		n.Wallet.Params().HDPrivateKeyID[:],
+		wal.Params().HDPrivateKeyID[:],
 		mECKey.Serialize(),
 		chaincodeBytes,
 		parentFP,
@@ -600,7 +637,7 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 	if err != nil {
 		return err
 	}
-	sigs, err := n.Wallet.CreateMultisigSignature(inputs, outs, moderatorKey, redeemScriptBytes, 0)
+	sigs, err := wal.CreateMultisigSignature(inputs, outs, moderatorKey, redeemScriptBytes, 0)
 	if err != nil {
 		return err
 	}
@@ -647,10 +684,25 @@ func (n *OpenBazaarNode) CloseDispute(orderId string, buyerPercentage, vendorPer
 		return err
 	}
 
+	// The rest of a panel must endorse the resolution before the funds can be released
+	if len(panel) > 1 {
+		for _, mod := range panel[1:] {
+			err = n.SendDisputeClose(mod, nil, rc)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	err = n.Datastore.Cases().MarkAsClosed(orderId, d)
 	if err != nil {
 		return err
 	}
+
+	// Publish the new resolution count
+	if err := n.updateProfileCounts(); err != nil {
+		log.Errorf("Failed to update profile counts: %s", err.Error())
+	}
 	return nil
 }
 
@@ -810,19 +862,24 @@ func (n *OpenBazaarNode) ValidateCaseContract(contract *pb.RicardianContract) []
 
 	// Verify the redeem script matches all the bitcoin keys
 	if contract.BuyerOrder.Payment != nil {
+		wal, err := n.WalletForContract(contract)
+		if err != nil {
+			validationErrors = append(validationErrors, "We do not run a wallet for the coin used to pay for this order")
+			return validationErrors
+		}
 		chaincode, err := hex.DecodeString(contract.BuyerOrder.Payment.Chaincode)
 		if err != nil {
 			validationErrors = append(validationErrors, "Error validating bitcoin address and redeem script")
 			return validationErrors
 		}
 		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mECKey, err := n.Wallet.MasterPublicKey().ECPubKey()
+		mECKey, err := wal.MasterPublicKey().ECPubKey()
 		if err != nil {
 			validationErrors = append(validationErrors, "Error validating bitcoin address and redeem script")
 			return validationErrors
 		}
 		hdKey := hd.NewExtendedKey(
-			n.Wallet.Params().HDPublicKeyID[:],
+			wal.Params().HDPublicKeyID[:],
 			mECKey.SerializeCompressed(),
 			chaincode,
 			parentFP,
@@ -836,7 +893,7 @@ func (n *OpenBazaarNode) ValidateCaseContract(contract *pb.RicardianContract) []
 		}
 
 		hdKey = hd.NewExtendedKey(
-			n.Wallet.Params().HDPublicKeyID[:],
+			wal.Params().HDPublicKeyID[:],
 			contract.BuyerOrder.BuyerID.Pubkeys.Bitcoin,
 			chaincode,
 			parentFP,
@@ -850,7 +907,7 @@ func (n *OpenBazaarNode) ValidateCaseContract(contract *pb.RicardianContract) []
 			return validationErrors
 		}
 		hdKey = hd.NewExtendedKey(
-			n.Wallet.Params().HDPublicKeyID[:],
+			wal.Params().HDPublicKeyID[:],
 			contract.VendorListings[0].VendorID.Pubkeys.Bitcoin,
 			chaincode,
 			parentFP,
@@ -862,7 +919,19 @@ func (n *OpenBazaarNode) ValidateCaseContract(contract *pb.RicardianContract) []
 			validationErrors = append(validationErrors, "Error validating bitcoin address and redeem script")
 			return validationErrors
 		}
-		addr, redeemScript, err := n.Wallet.GenerateMultisigScript([]hd.ExtendedKey{*buyerKey, *vendorKey, *moderatorKey}, 2)
+		payment := contract.BuyerOrder.Payment
+		timeout := EscrowTimeoutBlocks(contract.VendorListings, payment.Moderator, payment.Coin)
+		var addr btcutil.Address
+		var redeemScript []byte
+		if len(payment.ModeratorPanel) > 0 {
+			addr, redeemScript, err = n.panelEscrowScript(wal, *buyerKey, *vendorKey, payment.ModeratorPanel, chaincode, timeout, false)
+		} else {
+			addr, redeemScript, err = n.generateEscrowScript(wal, []hd.ExtendedKey{*buyerKey, *vendorKey, *moderatorKey}, *vendorKey, timeout)
+		}
+		if err != nil {
+			validationErrors = append(validationErrors, "Error validating bitcoin address and redeem script")
+			return validationErrors
+		}
 
 		if contract.BuyerOrder.Payment.Address != addr.EncodeAddress() {
 			validationErrors = append(validationErrors, "The calculated bitcoin address doesn't match the address in the order")
@@ -884,16 +953,20 @@ func (n *OpenBazaarNode) ValidateDisputeResolution(contract *pb.RicardianContrac
 	if contract.DisputeResolution.Payout == nil || len(contract.DisputeResolution.Payout.Sigs) == 0 {
 		return errors.New("DisputeResolution contains invalid payout")
 	}
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
+	}
 	checkWeOwnAddress := func(scriptPubKey string) error {
 		scriptBytes, err := hex.DecodeString(scriptPubKey)
 		if err != nil {
 			return err
 		}
-		_, addrs, _, err := txscript.ExtractPkScriptAddrs(scriptBytes, n.Wallet.Params())
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(scriptBytes, wal.Params())
 		if err != nil {
 			return err
 		}
-		if !n.Wallet.HasKey(addrs[0]) {
+		if !wal.HasKey(addrs[0]) {
 			return errors.New("Moderator payout sends coins to an address we don't control")
 		}
 		return nil
@@ -947,58 +1020,13 @@ func (n *OpenBazaarNode) verifySignatureOnDisputeResolution(contract *pb.Ricardi
 }
 
 func (n *OpenBazaarNode) ReleaseFunds(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
-	// Create inputs
-	var inputs []spvwallet.TransactionInput
-	for _, o := range contract.DisputeResolution.Payout.Inputs {
-		decodedHash, err := hex.DecodeString(o.Hash)
-		if err != nil {
-			return err
-		}
-		input := spvwallet.TransactionInput{
-			OutpointHash:  decodedHash,
-			OutpointIndex: o.Index,
-		}
-		inputs = append(inputs, input)
-	}
-
-	if len(inputs) == 0 {
-		return errors.New("Transaction has no inputs")
-	}
-
-	// Create outputs
-	var outputs []spvwallet.TransactionOutput
-	if contract.DisputeResolution.Payout.BuyerOutput != nil {
-		decodedScript, err := hex.DecodeString(contract.DisputeResolution.Payout.BuyerOutput.Script)
-		if err != nil {
-			return err
-		}
-		output := spvwallet.TransactionOutput{
-			ScriptPubKey: decodedScript,
-			Value:        int64(contract.DisputeResolution.Payout.BuyerOutput.Amount),
-		}
-		outputs = append(outputs, output)
-	}
-	if contract.DisputeResolution.Payout.VendorOutput != nil {
-		decodedScript, err := hex.DecodeString(contract.DisputeResolution.Payout.VendorOutput.Script)
-		if err != nil {
-			return err
-		}
-		output := spvwallet.TransactionOutput{
-			ScriptPubKey: decodedScript,
-			Value:        int64(contract.DisputeResolution.Payout.VendorOutput.Amount),
-		}
-		outputs = append(outputs, output)
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
 	}
-	if contract.DisputeResolution.Payout.ModeratorOutput != nil {
-		decodedScript, err := hex.DecodeString(contract.DisputeResolution.Payout.ModeratorOutput.Script)
-		if err != nil {
-			return err
-		}
-		output := spvwallet.TransactionOutput{
-			ScriptPubKey: decodedScript,
-			Value:        int64(contract.DisputeResolution.Payout.ModeratorOutput.Amount),
-		}
-		outputs = append(outputs, output)
+	inputs, outputs, err := payoutTransaction(contract.DisputeResolution.Payout)
+	if err != nil {
+		return err
 	}
 
 	// Create signing key
@@ -1007,7 +1035,7 @@ func (n *OpenBazaarNode) ReleaseFunds(contract *pb.RicardianContract, records []
 	if err != nil {
 		return err
 	}
-	mPrivKey := n.Wallet.MasterPrivateKey()
+	mPrivKey := wal.MasterPrivateKey()
 	if err != nil {
 		return err
 	}
@@ -1016,7 +1044,7 @@ func (n *OpenBazaarNode) ReleaseFunds(contract *pb.RicardianContract, records []
 		return err
 	}
 	hdKey := hd.NewExtendedKey(
-		n.Wallet.Params().HDPrivateKeyID[:],
+		wal.Params().HDPrivateKeyID[:],
 		mECKey.Serialize(),
 		chaincodeBytes,
 		parentFP,
@@ -1034,11 +1062,25 @@ func (n *OpenBazaarNode) ReleaseFunds(contract *pb.RicardianContract, records []
 	if err != nil {
 		return err
 	}
-	mySigs, err := n.Wallet.CreateMultisigSignature(inputs, outputs, signingKey, redeemScriptBytes, 0)
+	mySigs, err := wal.CreateMultisigSignature(inputs, outputs, signingKey, redeemScriptBytes, 0)
 	if err != nil {
 		return err
 	}
 
+	// A panel's payout needs the signatures of a majority of its moderators
+	if panel := contract.BuyerOrder.Payment.ModeratorPanel; len(panel) > 0 {
+		panelSigs := panelPayoutSignatures(contract)
+		required := bitcoin.PanelMajority(len(panel))
+		if signed := countPanelSignatures(panelSigs); signed < required {
+			return fmt.Errorf("Only %d of the %d moderator signatures needed to release the funds have been received", signed, required)
+		}
+		tx, err := bitcoin.MultisignPanel(inputs, outputs, mySigs, panelSigs, redeemScriptBytes)
+		if err != nil {
+			return err
+		}
+		return wal.Broadcast(tx)
+	}
+
 	var moderatorSigs []spvwallet.Signature
 	for _, sig := range contract.DisputeResolution.Payout.Sigs {
 		s := spvwallet.Signature{
@@ -1048,7 +1090,7 @@ func (n *OpenBazaarNode) ReleaseFunds(contract *pb.RicardianContract, records []
 		moderatorSigs = append(moderatorSigs, s)
 	}
 
-	_, err = n.Wallet.Multisign(inputs, outputs, mySigs, moderatorSigs, redeemScriptBytes, 0, true)
+	_, err = wal.Multisign(inputs, outputs, mySigs, moderatorSigs, redeemScriptBytes, 0, true)
 	if err != nil {
 		return err
 	}
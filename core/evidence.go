@@ -0,0 +1,220 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	gonet "net"
+	"net/http"
+	"time"
+
+	ma "gx/ipfs/QmSWLfmj5frN9xVLMMN846dMDriy5wN5jeghUm7aTW3DAG/go-multiaddr"
+	peer "gx/ipfs/QmWUswjn261LSyVxWAEpMVtPdy8zmKBJJfBpG3Qdpa8ZsE/go-libp2p-peer"
+	mh "gx/ipfs/QmbZ6Cee2uHjG7hf19qLHppgKDRtaG4CVtMzdmK9VCVqLu/go-multihash"
+
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/net"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	// The largest evidence file which may be attached to a case
+	MaxEvidenceSize = 5 << 20
+
+	// The most evidence files each party may attach to a case
+	MaxEvidenceFiles = 20
+
+	MaxEvidenceNameLength = 256
+)
+
+var ErrEvidenceNotFound = errors.New("Evidence not found")
+
+// Attach a file to the dispute of one of our orders. The file is encrypted to each of the
+// order's moderators, stored with the offline message storage and announced to them along
+// with its hash so they can check it hasn't been tampered with. Returns the evidence hash.
+func (n *OpenBazaarNode) SubmitDisputeEvidence(orderID, name, mediaType string, data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", errors.New("Evidence file is empty")
+	}
+	if len(data) > MaxEvidenceSize {
+		return "", errors.New("Evidence file is too large")
+	}
+	if name == "" || len(name) > MaxEvidenceNameLength {
+		return "", errors.New("Evidence name is missing or too long")
+	}
+	contract, state, _, _, _, err := n.Datastore.Purchases().GetByOrderId(orderID)
+	if err != nil {
+		contract, state, _, _, _, err = n.Datastore.Sales().GetByOrderId(orderID)
+		if err != nil {
+			return "", err
+		}
+	}
+	if state != pb.OrderState_DISPUTED {
+		return "", errors.New("Evidence can only be submitted while a dispute is open")
+	}
+
+	h := sha256.Sum256(data)
+	hash := hex.EncodeToString(h[:])
+	ts, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return "", err
+	}
+	for _, mod := range OrderModerators(contract.BuyerOrder.Payment) {
+		pid, err := peer.IDB58Decode(mod)
+		if err != nil {
+			return "", err
+		}
+		ciphertext, err := n.EncryptMessage(pid, nil, data)
+		if err != nil {
+			return "", err
+		}
+		addr, err := n.MessageStorage.Store(pid, ciphertext)
+		if err != nil {
+			return "", err
+		}
+		evidence := &pb.DisputeEvidence{
+			OrderId:     orderID,
+			SubmittedBy: n.IpfsNode.Identity.Pretty(),
+			Name:        name,
+			MediaType:   mediaType,
+			Size:        uint64(len(data)),
+			Hash:        hash,
+			Address:     addr.String(),
+			Timestamp:   ts,
+		}
+		if err := n.SendDisputeEvidence(mod, evidence); err != nil {
+			return "", err
+		}
+	}
+	return hash, nil
+}
+
+// Save the description of an evidence file the buyer or vendor has attached to one of our cases
+func (n *OpenBazaarNode) ProcessDisputeEvidence(evidence *pb.DisputeEvidence, peerID string) error {
+	buyerContract, vendorContract, _, _, state, _, _, _, _, _, err := n.Datastore.Cases().GetCaseMetadata(evidence.OrderId)
+	if err != nil {
+		return ErrCaseNotFound
+	}
+	contract := buyerContract
+	if contract == nil {
+		contract = vendorContract
+	}
+	if contract == nil || contract.BuyerOrder == nil || len(contract.VendorListings) == 0 {
+		return errors.New("Case is missing the order")
+	}
+	if peerID != contract.BuyerOrder.BuyerID.PeerID && peerID != contract.VendorListings[0].VendorID.PeerID {
+		return errors.New("Peer ID doesn't match either buyer or vendor")
+	}
+	if state != pb.OrderState_DISPUTED {
+		return errors.New("A dispute for this order is not open")
+	}
+	if hash, err := hex.DecodeString(evidence.Hash); err != nil || len(hash) != sha256.Size {
+		return errors.New("Evidence hash is invalid")
+	}
+	if evidence.Size > MaxEvidenceSize || evidence.Name == "" || len(evidence.Name) > MaxEvidenceNameLength {
+		return errors.New("Evidence file is invalid")
+	}
+	existing, err := n.Datastore.Cases().GetEvidence(evidence.OrderId)
+	if err != nil {
+		return err
+	}
+	submitted := 0
+	for _, e := range existing {
+		if e.SubmittedBy == peerID {
+			submitted++
+		}
+	}
+	if submitted >= MaxEvidenceFiles {
+		return errors.New("Too many evidence files have been submitted")
+	}
+	evidence.SubmittedBy = peerID
+	if err := n.Datastore.Cases().PutEvidence(evidence.OrderId, evidence); err != nil {
+		return err
+	}
+
+	notif := notifications.DisputeUpdateNotification{OrderId: evidence.OrderId}
+	n.Broadcast <- notif
+	n.Datastore.Notifications().Put(notif, time.Now())
+	return nil
+}
+
+// Fetch and decrypt an evidence file attached to one of our cases, checking it against the
+// hash it was submitted with
+func (n *OpenBazaarNode) FetchDisputeEvidence(caseID, hash string) (*pb.DisputeEvidence, []byte, error) {
+	evidence, err := n.Datastore.Cases().GetEvidence(caseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var found *pb.DisputeEvidence
+	for _, e := range evidence {
+		if e.Hash == hash {
+			found = e
+			break
+		}
+	}
+	if found == nil {
+		return nil, nil, ErrEvidenceNotFound
+	}
+	ciphertext, err := n.fetchEvidenceFile(found.Address)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := net.Decrypt(n.IpfsNode.PrivateKey, ciphertext)
+	if err != nil {
+		return nil, nil, err
+	}
+	h := sha256.Sum256(data)
+	if hex.EncodeToString(h[:]) != found.Hash {
+		return nil, nil, errors.New("Evidence file does not match its hash")
+	}
+	return found, data, nil
+}
+
+// Download an encrypted evidence file from the address the message storage returned for
+// it. Self-hosted files are on IPFS while Dropbox returns the file's URL encoded in a
+// multihash followed by the https protocol, the same as offline message pointers.
+func (n *OpenBazaarNode) fetchEvidenceFile(address string) ([]byte, error) {
+	addr, err := ma.NewMultiaddr(address)
+	if err != nil {
+		return nil, err
+	}
+	protocols := addr.Protocols()
+	if len(protocols) == 1 && protocols[0].Code == ma.P_IPFS {
+		return ipfs.Cat(n.Context, address)
+	}
+	if len(protocols) != 2 || protocols[0].Code != ma.P_IPFS || protocols[1].Code != ma.P_HTTPS {
+		return nil, errors.New("Unsupported evidence address")
+	}
+	enc, err := addr.ValueForProtocol(ma.P_IPFS)
+	if err != nil {
+		return nil, err
+	}
+	encodedURL, err := mh.FromB58String(enc)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := mh.Decode(encodedURL)
+	if err != nil {
+		return nil, err
+	}
+	dial := gonet.Dial
+	if n.TorDialer != nil {
+		dial = n.TorDialer.Dial
+	}
+	client := &http.Client{Transport: &http.Transport{Dial: dial}, Timeout: time.Minute}
+	resp, err := client.Get(string(decoded.Digest))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Evidence file could not be downloaded: %s", resp.Status)
+	}
+	// Allow for the encryption overhead on top of the largest evidence file
+	return ioutil.ReadAll(io.LimitReader(resp.Body, MaxEvidenceSize+1<<10))
+}
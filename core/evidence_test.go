@@ -0,0 +1,42 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ma "gx/ipfs/QmSWLfmj5frN9xVLMMN846dMDriy5wN5jeghUm7aTW3DAG/go-multiaddr"
+	mh "gx/ipfs/QmbZ6Cee2uHjG7hf19qLHppgKDRtaG4CVtMzdmK9VCVqLu/go-multihash"
+)
+
+func TestFetchEvidenceFileFromURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ciphertext"))
+	}))
+	defer server.Close()
+
+	// Encode the URL the same way as the Dropbox storage
+	b, err := mh.Encode([]byte(server.URL), mh.SHA1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := mh.Cast(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ma.NewMultiaddr("/ipfs/" + m.B58String() + "/https/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := &OpenBazaarNode{}
+	data, err := n.fetchEvidenceFile(addr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "ciphertext" {
+		t.Errorf("Fetched the wrong evidence file: %s", data)
+	}
+	if _, err := n.fetchEvidenceFile("/ip4/127.0.0.1/tcp/4001"); err == nil {
+		t.Error("Expected an error for an unsupported address")
+	}
+}
@@ -18,16 +18,23 @@ import (
 )
 
 func (n *OpenBazaarNode) FulfillOrder(fulfillment *pb.OrderFulfillment, contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
+	}
 	if fulfillment.Slug == "" && len(contract.VendorListings) == 1 {
 		fulfillment.Slug = contract.VendorListings[0].Slug
 	} else if fulfillment.Slug == "" && len(contract.VendorListings) > 1 {
 		return errors.New("Slug must be specified when an order contains multiple items")
 	}
+	if err := n.attachDigitalDelivery(fulfillment, contract); err != nil {
+		return err
+	}
 	rc := new(pb.RicardianContract)
 	if contract.BuyerOrder.Payment.Method == pb.Order_Payment_MODERATED {
 		payout := new(pb.OrderFulfillment_Payout)
-		payout.PayoutAddress = n.Wallet.CurrentAddress(spvwallet.EXTERNAL).EncodeAddress()
-		payout.PayoutFeePerByte = n.Wallet.GetFeePerByte(spvwallet.NORMAL)
+		payout.PayoutAddress = wal.CurrentAddress(spvwallet.EXTERNAL).EncodeAddress()
+		payout.PayoutFeePerByte = wal.GetFeePerByte(spvwallet.NORMAL)
 		var ins []spvwallet.TransactionInput
 		var outValue int64
 		for _, r := range records {
@@ -42,13 +49,13 @@ func (n *OpenBazaarNode) FulfillOrder(fulfillment *pb.OrderFulfillment, contract
 			}
 		}
 
-		refundAddress, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, n.Wallet.Params())
+		payoutAddress, err := btcutil.DecodeAddress(payout.PayoutAddress, wal.Params())
 		if err != nil {
 			return err
 		}
 		var output spvwallet.TransactionOutput
 
-		outputScript, err := txscript.PayToAddrScript(refundAddress)
+		outputScript, err := txscript.PayToAddrScript(payoutAddress)
 		if err != nil {
 			return err
 		}
@@ -60,7 +67,7 @@ func (n *OpenBazaarNode) FulfillOrder(fulfillment *pb.OrderFulfillment, contract
 			return err
 		}
 		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := n.Wallet.MasterPrivateKey()
+		mPrivKey := wal.MasterPrivateKey()
 		if err != nil {
 			return err
 		}
@@ -69,7 +76,7 @@ func (n *OpenBazaarNode) FulfillOrder(fulfillment *pb.OrderFulfillment, contract
 			return err
 		}
 		hdKey := hd.NewExtendedKey(
-			n.Wallet.Params().HDPrivateKeyID[:],
+			wal.Params().HDPrivateKeyID[:],
 			mECKey.Serialize(),
 			chaincode,
 			parentFP,
@@ -83,7 +90,7 @@ func (n *OpenBazaarNode) FulfillOrder(fulfillment *pb.OrderFulfillment, contract
 		}
 		redeemScript, err := hex.DecodeString(contract.BuyerOrder.Payment.RedeemScript)
 
-		signatures, err := n.Wallet.CreateMultisigSignature(ins, []spvwallet.TransactionOutput{output}, vendorKey, redeemScript, payout.PayoutFeePerByte)
+		signatures, err := wal.CreateMultisigSignature(ins, []spvwallet.TransactionOutput{output}, vendorKey, redeemScript, payout.PayoutFeePerByte)
 		if err != nil {
 			return err
 		}
@@ -221,7 +228,11 @@ func (n *OpenBazaarNode) ValidateOrderFulfillment(fulfillment *pb.OrderFulfillme
 		if fulfillment.Payout == nil {
 			return errors.New("Payout object for multisig is nil")
 		}
-		_, err := btcutil.DecodeAddress(fulfillment.Payout.PayoutAddress, n.Wallet.Params())
+		wal, err := n.WalletForContract(contract)
+		if err != nil {
+			return err
+		}
+		_, err = btcutil.DecodeAddress(fulfillment.Payout.PayoutAddress, wal.Params())
 		if err != nil {
 			return errors.New("Invalid payout address")
 		}
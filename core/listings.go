@@ -57,6 +57,7 @@ type listingData struct {
 	Slug          string    `json:"slug"`
 	Title         string    `json:"title"`
 	Categories    []string  `json:"categories"`
+	Tags          []string  `json:"tags"`
 	ContractType  string    `json:"contractType"`
 	Description   string    `json:"description"`
 	Thumbnail     thumbnail `json:"thumbnail"`
@@ -66,6 +67,8 @@ type listingData struct {
 	Language      string    `json:"language"`
 	AverageRating float32   `json:"averageRating"`
 	RatingCount   uint32    `json:"ratingCount"`
+
+	AcceptedCurrencies []string `json:"acceptedCurrencies"`
 }
 
 func (n *OpenBazaarNode) GenerateSlug(title string) (string, error) {
@@ -100,10 +103,21 @@ func (n *OpenBazaarNode) SignListing(listing *pb.Listing) (*pb.SignedListing, er
 
 	sl := new(pb.SignedListing)
 
+	// Use our default escrow timeout if the listing doesn't set one
+	if listing.Metadata != nil && listing.Metadata.EscrowTimeoutHours == 0 {
+		listing.Metadata.EscrowTimeoutHours = n.OrderTimeouts.EscrowTimeoutHours
+	}
+
 	// Check the listing data is correct for continuing
 	if err := validateListing(listing); err != nil {
 		return sl, err
 	}
+	if listing.Metadata.Format == pb.Listing_Metadata_AUCTION && auctionClosed(listing) {
+		return sl, errors.New("Auction close time must be in the future")
+	}
+	if listing.Metadata.ContractType == pb.Listing_Metadata_CROWD_FUND && crowdFundClosed(listing) {
+		return sl, errors.New("Crowdfund deadline must be in the future")
+	}
 
 	// Set listing version
 	listing.Metadata.Version = ListingVersion
@@ -137,8 +151,14 @@ func (n *OpenBazaarNode) SignListing(listing *pb.Listing) (*pb.SignedListing, er
 	sig, err := ecPrivKey.Sign([]byte(id.PeerID))
 	id.BitcoinSig = sig.Serialize()
 
-	// Set crypto currency
-	listing.Metadata.AcceptedCurrency = strings.ToUpper(n.Wallet.CurrencyCode())
+	// Set crypto currencies. Listings accept every coin we run a wallet for unless the
+	// vendor limited them. The first entry is kept in acceptedCurrency for older clients.
+	currencies, err := n.acceptedListingCurrencies(listing.Metadata.AcceptedCurrencies)
+	if err != nil {
+		return sl, err
+	}
+	listing.Metadata.AcceptedCurrencies = currencies
+	listing.Metadata.AcceptedCurrency = currencies[0]
 
 	// Update coupon db
 	n.Datastore.Coupons().Delete(listing.Slug)
@@ -232,7 +252,11 @@ func (n *OpenBazaarNode) UpdateListingIndex(listing *pb.SignedListing) error {
 	if err != nil {
 		return err
 	}
-	return n.updateListingOnDisk(index, ld, false)
+	if err := n.updateListingOnDisk(index, ld, false); err != nil {
+		return err
+	}
+	go n.UpdateTagPointers()
+	return nil
 }
 
 func (n *OpenBazaarNode) extractListingData(listing *pb.SignedListing) (listingData, error) {
@@ -277,6 +301,7 @@ func (n *OpenBazaarNode) extractListingData(listing *pb.SignedListing) (listingD
 		Slug:         listing.Listing.Slug,
 		Title:        listing.Listing.Item.Title,
 		Categories:   listing.Listing.Item.Categories,
+		Tags:         listing.Listing.Item.Tags,
 		ContractType: listing.Listing.Metadata.ContractType.String(),
 		Description:  listing.Listing.Item.Description[:descriptionLength],
 		Thumbnail:    thumbnail{listing.Listing.Item.Images[0].Tiny, listing.Listing.Item.Images[0].Small, listing.Listing.Item.Images[0].Medium},
@@ -284,6 +309,8 @@ func (n *OpenBazaarNode) extractListingData(listing *pb.SignedListing) (listingD
 		ShipsTo:      shipsTo,
 		FreeShipping: freeShipping,
 		Language:     listing.Listing.Metadata.Language,
+
+		AcceptedCurrencies: ListingCurrencies(listing.Listing),
 	}
 	return ld, nil
 }
@@ -545,6 +572,9 @@ func (n *OpenBazaarNode) DeleteListing(slug string) error {
 		return err
 	}
 
+	// Retire the pointers of tags no other listing uses
+	go n.UpdateTagPointers()
+
 	return n.updateProfileCounts()
 }
 
@@ -663,7 +693,7 @@ func validateListing(listing *pb.Listing) (err error) {
 	if listing.Metadata == nil {
 		return errors.New("Missing required field: Metadata")
 	}
-	if listing.Metadata.ContractType > pb.Listing_Metadata_SERVICE {
+	if listing.Metadata.ContractType > pb.Listing_Metadata_CROWD_FUND {
 		return errors.New("Invalid contract type")
 	}
 	if listing.Metadata.Format > pb.Listing_Metadata_AUCTION {
@@ -684,6 +714,54 @@ func validateListing(listing *pb.Listing) (err error) {
 	if len(listing.Metadata.Language) > WordMaxCharacters {
 		return fmt.Errorf("Language is longer than the max of %d characters", WordMaxCharacters)
 	}
+	if listing.Metadata.EscrowTimeoutHours > 0 && listing.Metadata.EscrowTimeoutHours < MinEscrowTimeoutHours {
+		return fmt.Errorf("Escrow timeout must be at least %d hours", MinEscrowTimeoutHours)
+	}
+	if listing.Metadata.EscrowTimeoutHours > MaxEscrowTimeoutHours() {
+		return fmt.Errorf("Escrow timeout must be no more than %d hours", MaxEscrowTimeoutHours())
+	}
+
+	// Auction
+	if listing.Metadata.Format == pb.Listing_Metadata_AUCTION {
+		if listing.Auction == nil {
+			return errors.New("Missing required field: Auction")
+		}
+		if listing.Auction.Closes == nil {
+			return errors.New("Missing required field: Auction close time")
+		}
+		if !time.Unix(listing.Auction.Closes.Seconds, 0).Before(time.Unix(listing.Metadata.Expiry.Seconds, 0)) {
+			return errors.New("Auction must close before the listing expires")
+		}
+		if len(listing.Coupons) > 0 {
+			return errors.New("Auction listings cannot contain coupons")
+		}
+	} else if listing.Auction != nil {
+		return errors.New("Only auction listings may contain auction terms")
+	}
+
+	// Crowdfund
+	if listing.Metadata.ContractType == pb.Listing_Metadata_CROWD_FUND {
+		if listing.CrowdFund == nil {
+			return errors.New("Missing required field: CrowdFund")
+		}
+		if listing.CrowdFund.Goal == 0 {
+			return errors.New("Crowdfund goal must be greater than zero")
+		}
+		if listing.CrowdFund.Deadline == nil {
+			return errors.New("Missing required field: Crowdfund deadline")
+		}
+		if !time.Unix(listing.CrowdFund.Deadline.Seconds, 0).Before(time.Unix(listing.Metadata.Expiry.Seconds, 0)) {
+			return errors.New("Crowdfund deadline must be before the listing expires")
+		}
+		if time.Unix(listing.CrowdFund.Deadline.Seconds, 0).After(maxCrowdFundDeadline(time.Now())) {
+			return errors.New("Crowdfund deadline is too far in the future")
+		}
+		if listing.Metadata.Format == pb.Listing_Metadata_AUCTION {
+			return errors.New("Crowdfund listings cannot be auctions")
+		}
+	} else if listing.CrowdFund != nil {
+		return errors.New("Only crowdfund listings may contain crowdfund terms")
+	}
 
 	// Item
 	if listing.Item.Title == "" {
@@ -3,7 +3,6 @@ package core
 import (
 	"crypto/sha256"
 	"errors"
-	ma "gx/ipfs/QmSWLfmj5frN9xVLMMN846dMDriy5wN5jeghUm7aTW3DAG/go-multiaddr"
 	multihash "gx/ipfs/QmbZ6Cee2uHjG7hf19qLHppgKDRtaG4CVtMzdmK9VCVqLu/go-multihash"
 	"io/ioutil"
 	"os"
@@ -57,6 +56,7 @@ func (n *OpenBazaarNode) SetSelfAsModerator(moderator *pb.Moderator) error {
 			return err
 		}
 		moderator.AcceptedCurrency = strings.ToUpper(n.Wallet.CurrencyCode())
+		moderator.AcceptedCurrencies = n.WalletCurrencies()
 		profile.Moderator = true
 		profile.ModeratorInfo = moderator
 		err = n.UpdateProfile(&profile)
@@ -69,15 +69,7 @@ func (n *OpenBazaarNode) SetSelfAsModerator(moderator *pb.Moderator) error {
 	pointers, err := n.Datastore.Pointers().GetByPurpose(ipfs.MODERATOR)
 	ctx := context.Background()
 	if err != nil || len(pointers) == 0 {
-		b, err := multihash.Encode([]byte(n.IpfsNode.Identity.Pretty()), multihash.SHA1)
-		if err != nil {
-			return err
-		}
-		mhc, err := multihash.Cast(b)
-		if err != nil {
-			return err
-		}
-		addr, err := ma.NewMultiaddr("/ipfs/" + mhc.B58String())
+		addr, err := n.selfPointerAddress()
 		if err != nil {
 			return err
 		}
@@ -129,7 +121,15 @@ func (n *OpenBazaarNode) GetModeratorFee(transactionTotal uint64) (uint64, error
 	if err != nil {
 		return 0, err
 	}
+	return n.moderatorFee(profile, transactionTotal)
+}
 
+// Return the fee the moderator with the given profile charges to resolve a dispute
+func (n *OpenBazaarNode) moderatorFee(profile *pb.Profile, transactionTotal uint64) (uint64, error) {
+	if profile.ModeratorInfo == nil || profile.ModeratorInfo.Fee == nil {
+		return 0, errors.New("Profile has no moderator fee")
+	}
+	var err error
 	switch profile.ModeratorInfo.Fee.FeeType {
 	case pb.Moderator_Fee_PERCENTAGE:
 		return uint64(float64(transactionTotal) * (float64(profile.ModeratorInfo.Fee.Percentage) / 100)), nil
@@ -0,0 +1,138 @@
+package core
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/ipfs/go-ipfs/routing/dht"
+	"golang.org/x/net/context"
+)
+
+const moderatorFetchWorkers = 8
+
+var ErrUnknownFeeType = errors.New("Unknown fee type")
+
+// ModeratorQuery filters the moderator directory. Empty fields match every moderator.
+// MaxPercentage limits the percentage part of a moderator's fee and MaxFixedFee the
+// fixed part. A fixed fee is only comparable when it is priced in MaxFixedFeeCurrency,
+// so moderators charging a fixed fee in another currency don't match a MaxFixedFee.
+type ModeratorQuery struct {
+	Language            string
+	Currency            string
+	FeeType             string
+	MaxPercentage       float32
+	MaxFixedFee         uint64
+	MaxFixedFeeCurrency string
+}
+
+// Find the moderators which have published a pointer and return the profiles of those
+// matching the query, the moderators who have resolved the most disputes first.
+func (n *OpenBazaarNode) SearchModerators(query ModeratorQuery) ([]pb.PeerAndProfile, error) {
+	if query.FeeType != "" {
+		if _, ok := pb.Moderator_Fee_FeeType_value[strings.ToUpper(query.FeeType)]; !ok {
+			return nil, ErrUnknownFeeType
+		}
+	}
+	routing, ok := n.IpfsNode.Routing.(*dht.IpfsDHT)
+	if !ok {
+		return nil, errors.New("Moderators can't be found while offline")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	pointers, err := ipfs.FindPointers(routing, ctx, ModeratorPointerID, 64)
+	if err != nil {
+		return nil, err
+	}
+	var mods []string
+	found := make(map[string]bool)
+	for _, p := range pointers {
+		id, err := ExtractIDFromPointer(p)
+		if err != nil || found[id] {
+			continue
+		}
+		found[id] = true
+		mods = append(mods, id)
+	}
+
+	profiles := make([]*pb.Profile, len(mods))
+	sem := make(chan struct{}, moderatorFetchWorkers)
+	var wg sync.WaitGroup
+	for i, mod := range mods {
+		wg.Add(1)
+		go func(i int, peerID string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			profile, err := n.FetchProfile(peerID)
+			if err != nil {
+				log.Warningf("Failed to fetch profile for moderator %s: %s", peerID, err.Error())
+				return
+			}
+			profiles[i] = &profile
+		}(i, mod)
+	}
+	wg.Wait()
+
+	results := []pb.PeerAndProfile{}
+	for i, profile := range profiles {
+		if profile == nil || !profile.Moderator || !moderatorMatches(profile.ModeratorInfo, query) {
+			continue
+		}
+		results = append(results, pb.PeerAndProfile{PeerId: mods[i], Profile: profile})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return disputesResolved(results[i].Profile) > disputesResolved(results[j].Profile)
+	})
+	return results, nil
+}
+
+func moderatorMatches(info *pb.Moderator, query ModeratorQuery) bool {
+	if info == nil || info.Fee == nil {
+		return false
+	}
+	if query.Language != "" {
+		speaks := false
+		for _, l := range info.Languages {
+			if strings.EqualFold(l, query.Language) {
+				speaks = true
+				break
+			}
+		}
+		if !speaks {
+			return false
+		}
+	}
+	if query.Currency != "" && !moderatorAcceptsCurrency(info, query.Currency) {
+		return false
+	}
+	fee := info.Fee
+	if query.FeeType != "" && !strings.EqualFold(fee.FeeType.String(), query.FeeType) {
+		return false
+	}
+	hasPercentage := fee.FeeType == pb.Moderator_Fee_PERCENTAGE || fee.FeeType == pb.Moderator_Fee_FIXED_PLUS_PERCENTAGE
+	if query.MaxPercentage > 0 && hasPercentage && fee.Percentage > query.MaxPercentage {
+		return false
+	}
+	hasFixed := fee.FeeType == pb.Moderator_Fee_FIXED || fee.FeeType == pb.Moderator_Fee_FIXED_PLUS_PERCENTAGE
+	if query.MaxFixedFee > 0 && hasFixed {
+		if fee.FixedFee == nil || !strings.EqualFold(fee.FixedFee.CurrencyCode, query.MaxFixedFeeCurrency) {
+			return false
+		}
+		if fee.FixedFee.Amount > query.MaxFixedFee {
+			return false
+		}
+	}
+	return true
+}
+
+func disputesResolved(profile *pb.Profile) uint32 {
+	if profile.Stats == nil {
+		return 0
+	}
+	return profile.Stats.DisputesResolved
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+)
+
+func TestModeratorMatches(t *testing.T) {
+	mod := &pb.Moderator{
+		Languages:          []string{"en", "es"},
+		AcceptedCurrencies: []string{"BTC", "BCH"},
+		Fee: &pb.Moderator_Fee{
+			FeeType:    pb.Moderator_Fee_FIXED_PLUS_PERCENTAGE,
+			Percentage: 5,
+			FixedFee:   &pb.Moderator_Price{CurrencyCode: "USD", Amount: 200},
+		},
+	}
+	if moderatorMatches(nil, ModeratorQuery{}) {
+		t.Error("A profile without moderator info should not match")
+	}
+	if !moderatorMatches(mod, ModeratorQuery{}) {
+		t.Error("An empty query should match every moderator")
+	}
+	if !moderatorMatches(mod, ModeratorQuery{Language: "ES", Currency: "bch", FeeType: "fixed_plus_percentage"}) {
+		t.Error("Moderator should match its language, currency and fee type")
+	}
+	if moderatorMatches(mod, ModeratorQuery{Language: "fr"}) {
+		t.Error("Moderator does not speak French")
+	}
+	if moderatorMatches(mod, ModeratorQuery{Currency: "LTC"}) {
+		t.Error("Moderator does not accept LTC")
+	}
+	if moderatorMatches(mod, ModeratorQuery{FeeType: "PERCENTAGE"}) {
+		t.Error("Moderator does not charge a percentage only fee")
+	}
+	if !moderatorMatches(mod, ModeratorQuery{MaxPercentage: 5}) || moderatorMatches(mod, ModeratorQuery{MaxPercentage: 4}) {
+		t.Error("Incorrect maximum percentage filtering")
+	}
+	if !moderatorMatches(mod, ModeratorQuery{MaxFixedFee: 200, MaxFixedFeeCurrency: "usd"}) {
+		t.Error("Fixed fee is within the maximum")
+	}
+	if moderatorMatches(mod, ModeratorQuery{MaxFixedFee: 199, MaxFixedFeeCurrency: "USD"}) {
+		t.Error("Fixed fee exceeds the maximum")
+	}
+	if moderatorMatches(mod, ModeratorQuery{MaxFixedFee: 1000, MaxFixedFeeCurrency: "EUR"}) {
+		t.Error("Fixed fees in another currency can't be compared")
+	}
+	mod.Fee = &pb.Moderator_Fee{FeeType: pb.Moderator_Fee_PERCENTAGE, Percentage: 1}
+	if !moderatorMatches(mod, ModeratorQuery{MaxFixedFee: 1, MaxFixedFeeCurrency: "USD"}) {
+		t.Error("A percentage fee has no fixed part to limit")
+	}
+}
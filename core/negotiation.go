@@ -0,0 +1,275 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+/* Disputes can be settled by agreement. The buyer, vendor or moderator proposes how to split
+   the escrowed funds and the others either accept the proposal or counter it with one of
+   their own. Once both the buyer and vendor have accepted a proposal the moderator signs it
+   as the dispute resolution. */
+
+var ErrProposalNotFound = errors.New("Proposal not found")
+
+// The proposals made while negotiating a dispute, as recorded by one of its parties
+type disputeNegotiation struct {
+	contract  *pb.RicardianContract
+	proposals []*pb.DisputeProposal
+	moderator bool
+	save      func(proposals []*pb.DisputeProposal) error
+}
+
+// Load the negotiation of a dispute from our case if we are the moderator, otherwise from our order
+func (n *OpenBazaarNode) loadDisputeNegotiation(orderID string) (*disputeNegotiation, error) {
+	buyerContract, vendorContract, _, _, state, _, _, _, _, _, err := n.Datastore.Cases().GetCaseMetadata(orderID)
+	if err == nil {
+		contract := buyerContract
+		if contract == nil {
+			contract = vendorContract
+		}
+		if contract != nil && contract.BuyerOrder.Payment.Moderator == n.IpfsNode.Identity.Pretty() {
+			if state != pb.OrderState_DISPUTED {
+				return nil, errors.New("A dispute for this order is not open")
+			}
+			proposals, err := n.Datastore.Cases().GetProposals(orderID)
+			if err != nil {
+				return nil, err
+			}
+			return &disputeNegotiation{
+				contract:  contract,
+				proposals: proposals,
+				moderator: true,
+				save: func(proposals []*pb.DisputeProposal) error {
+					return n.Datastore.Cases().PutProposals(orderID, proposals)
+				},
+			}, nil
+		}
+	}
+
+	var store orderStore = n.Datastore.Purchases()
+	contract, state, _, _, _, err := store.GetByOrderId(orderID)
+	if err != nil {
+		store = n.Datastore.Sales()
+		contract, state, _, _, _, err = store.GetByOrderId(orderID)
+		if err != nil {
+			return nil, ErrCaseNotFound
+		}
+	}
+	if state != pb.OrderState_DISPUTED {
+		return nil, errors.New("A dispute for this order is not open")
+	}
+	return &disputeNegotiation{
+		contract:  contract,
+		proposals: contract.DisputeProposals,
+		save: func(proposals []*pb.DisputeProposal) error {
+			contract.DisputeProposals = proposals
+			return store.Put(orderID, *contract, state, false)
+		},
+	}, nil
+}
+
+func (d *disputeNegotiation) parties() (buyer, vendor, moderator string) {
+	return d.contract.BuyerOrder.BuyerID.PeerID, d.contract.VendorListings[0].VendorID.PeerID, d.contract.BuyerOrder.Payment.Moderator
+}
+
+func (d *disputeNegotiation) find(proposalID string) *pb.DisputeProposal {
+	for _, p := range d.proposals {
+		if p.ProposalId == proposalID {
+			return p
+		}
+	}
+	return nil
+}
+
+func hasAcceptedProposal(p *pb.DisputeProposal, peerID string) bool {
+	for _, id := range p.AcceptedBy {
+		if id == peerID {
+			return true
+		}
+	}
+	return false
+}
+
+func validateSplit(buyerPercentage, vendorPercentage float32) error {
+	if buyerPercentage < 0 || vendorPercentage < 0 || buyerPercentage+vendorPercentage != 100 {
+		return errors.New("Payout percentages must be positive and sum to 100")
+	}
+	return nil
+}
+
+// Propose a split of the funds in dispute, optionally countering an earlier proposal.
+// Returns the ID of the new proposal.
+func (n *OpenBazaarNode) ProposeDisputeSplit(orderID string, buyerPercentage, vendorPercentage float32, resolution, counters string) (string, error) {
+	if err := validateSplit(buyerPercentage, vendorPercentage); err != nil {
+		return "", err
+	}
+	neg, err := n.loadDisputeNegotiation(orderID)
+	if err != nil {
+		return "", err
+	}
+	if counters != "" && neg.find(counters) == nil {
+		return "", ErrProposalNotFound
+	}
+	ts, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return "", err
+	}
+	proposal := &pb.DisputeProposal{
+		OrderId:          orderID,
+		ProposedBy:       n.IpfsNode.Identity.Pretty(),
+		BuyerPercentage:  buyerPercentage,
+		VendorPercentage: vendorPercentage,
+		Resolution:       resolution,
+		Counters:         counters,
+		Timestamp:        ts,
+	}
+	ser, err := proto.Marshal(proposal)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(ser)
+	proposal.ProposalId = hex.EncodeToString(h[:])
+
+	buyer, vendor, moderator := neg.parties()
+	for _, peerID := range []string{buyer, vendor, moderator} {
+		if peerID == n.IpfsNode.Identity.Pretty() {
+			continue
+		}
+		if err := n.SendDisputeProposal(peerID, proposal); err != nil {
+			return "", err
+		}
+	}
+
+	// Proposing a split means agreeing to it
+	if !neg.moderator {
+		proposal.AcceptedBy = []string{n.IpfsNode.Identity.Pretty()}
+	}
+	if err := neg.save(append(neg.proposals, proposal)); err != nil {
+		return "", err
+	}
+	return proposal.ProposalId, nil
+}
+
+// Accept a split proposed by another party to the dispute
+func (n *OpenBazaarNode) AcceptDisputeProposal(orderID, proposalID string) error {
+	neg, err := n.loadDisputeNegotiation(orderID)
+	if err != nil {
+		return err
+	}
+	if neg.moderator {
+		return errors.New("Moderators close the dispute rather than accepting a proposal")
+	}
+	proposal := neg.find(proposalID)
+	if proposal == nil {
+		return ErrProposalNotFound
+	}
+	if hasAcceptedProposal(proposal, n.IpfsNode.Identity.Pretty()) {
+		return nil
+	}
+
+	acceptance := &pb.DisputeProposalAcceptance{
+		OrderId:    orderID,
+		ProposalId: proposalID,
+	}
+	buyer, vendor, moderator := neg.parties()
+	for _, peerID := range []string{buyer, vendor, moderator} {
+		if peerID == n.IpfsNode.Identity.Pretty() {
+			continue
+		}
+		if err := n.SendDisputeAcceptance(peerID, acceptance); err != nil {
+			return err
+		}
+	}
+	proposal.AcceptedBy = append(proposal.AcceptedBy, n.IpfsNode.Identity.Pretty())
+	return neg.save(neg.proposals)
+}
+
+// Record a split proposed by another party to the dispute
+func (n *OpenBazaarNode) ProcessDisputeProposal(proposal *pb.DisputeProposal, peerID string) error {
+	neg, err := n.loadDisputeNegotiation(proposal.OrderId)
+	if err != nil {
+		return err
+	}
+	buyer, vendor, moderator := neg.parties()
+	if peerID != buyer && peerID != vendor && peerID != moderator {
+		return errors.New("Proposal is not from a party to the dispute")
+	}
+	if err := validateSplit(proposal.BuyerPercentage, proposal.VendorPercentage); err != nil {
+		return err
+	}
+	if proposal.ProposalId == "" || neg.find(proposal.ProposalId) != nil {
+		return errors.New("Proposal ID is missing or already used")
+	}
+	if proposal.Counters != "" && neg.find(proposal.Counters) == nil {
+		return ErrProposalNotFound
+	}
+	proposal.ProposedBy = peerID
+	proposal.AcceptedBy = nil
+	if peerID != moderator {
+		proposal.AcceptedBy = []string{peerID}
+	}
+	if err := neg.save(append(neg.proposals, proposal)); err != nil {
+		return err
+	}
+
+	notif := notifications.DisputeProposalNotification{
+		OrderId:          proposal.OrderId,
+		ProposalId:       proposal.ProposalId,
+		ProposedBy:       peerID,
+		BuyerPercentage:  proposal.BuyerPercentage,
+		VendorPercentage: proposal.VendorPercentage,
+	}
+	n.Broadcast <- notif
+	n.Datastore.Notifications().Put(notif, time.Now())
+	return nil
+}
+
+// Record the buyer or vendor accepting a proposal. If we are the moderator and both have
+// now accepted it, the proposal is signed as the dispute resolution.
+func (n *OpenBazaarNode) ProcessDisputeAcceptance(acceptance *pb.DisputeProposalAcceptance, peerID string) error {
+	neg, err := n.loadDisputeNegotiation(acceptance.OrderId)
+	if err != nil {
+		return err
+	}
+	buyer, vendor, _ := neg.parties()
+	if peerID != buyer && peerID != vendor {
+		return errors.New("Only the buyer and vendor can accept a proposal")
+	}
+	proposal := neg.find(acceptance.ProposalId)
+	if proposal == nil {
+		return ErrProposalNotFound
+	}
+	if hasAcceptedProposal(proposal, peerID) {
+		return nil
+	}
+	proposal.AcceptedBy = append(proposal.AcceptedBy, peerID)
+	if err := neg.save(neg.proposals); err != nil {
+		return err
+	}
+
+	notif := notifications.DisputeProposalAcceptedNotification{
+		OrderId:    acceptance.OrderId,
+		ProposalId: acceptance.ProposalId,
+		AcceptedBy: peerID,
+	}
+	n.Broadcast <- notif
+	n.Datastore.Notifications().Put(notif, time.Now())
+
+	if !neg.moderator || !hasAcceptedProposal(proposal, buyer) || !hasAcceptedProposal(proposal, vendor) {
+		return nil
+	}
+	resolution := proposal.Resolution
+	if resolution == "" {
+		resolution = fmt.Sprintf("The buyer and vendor agreed to split the funds %g%% to the buyer and %g%% to the vendor", proposal.BuyerPercentage, proposal.VendorPercentage)
+	}
+	return n.closeDispute(acceptance.OrderId, proposal.BuyerPercentage, proposal.VendorPercentage, resolution, proposal.ProposedBy)
+}
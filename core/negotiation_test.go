@@ -0,0 +1,41 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+)
+
+func TestValidateSplit(t *testing.T) {
+	if err := validateSplit(70, 30); err != nil {
+		t.Error(err)
+	}
+	if err := validateSplit(100, 0); err != nil {
+		t.Error(err)
+	}
+	if err := validateSplit(70, 20); err == nil {
+		t.Error("A split must sum to 100")
+	}
+	if err := validateSplit(110, -10); err == nil {
+		t.Error("A split can't contain negative percentages")
+	}
+}
+
+func TestDisputeNegotiationFind(t *testing.T) {
+	neg := &disputeNegotiation{
+		proposals: []*pb.DisputeProposal{
+			{ProposalId: "p1", AcceptedBy: []string{"buyer"}},
+			{ProposalId: "p2", Counters: "p1", AcceptedBy: []string{"vendor"}},
+		},
+	}
+	p := neg.find("p2")
+	if p == nil || p.Counters != "p1" {
+		t.Fatal("Failed to find proposal")
+	}
+	if neg.find("p3") != nil {
+		t.Error("Found a proposal which doesn't exist")
+	}
+	if !hasAcceptedProposal(p, "vendor") || hasAcceptedProposal(p, "buyer") {
+		t.Error("Incorrect acceptance")
+	}
+}
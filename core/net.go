@@ -1,6 +1,8 @@
 package core
 
 import (
+	"errors"
+	"fmt"
 	libp2p "gx/ipfs/QmPGxZ1DP2w45WcogpW1h43BvseXbfke9N91qotpoQcUeS/go-libp2p-crypto"
 	peer "gx/ipfs/QmWUswjn261LSyVxWAEpMVtPdy8zmKBJJfBpG3Qdpa8ZsE/go-libp2p-peer"
 	multihash "gx/ipfs/QmbZ6Cee2uHjG7hf19qLHppgKDRtaG4CVtMzdmK9VCVqLu/go-multihash"
@@ -123,6 +125,7 @@ func (n *OpenBazaarNode) Follow(peerId string) error {
 	if err != nil {
 		return err
 	}
+	go n.IndexPeerListings(peerId)
 	return nil
 }
 
@@ -140,6 +143,10 @@ func (n *OpenBazaarNode) Unfollow(peerId string) error {
 	if err != nil {
 		return err
 	}
+	err = n.Datastore.SearchIndex().DeleteAll(peerId)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -308,6 +315,54 @@ func (n *OpenBazaarNode) SendDisputeClose(peerId string, k *libp2p.PubKey, resol
 	return nil
 }
 
+func (n *OpenBazaarNode) SendDisputeEndorsement(peerId string, k *libp2p.PubKey, endorsement *pb.DisputeEndorsement) error {
+	a, err := ptypes.MarshalAny(endorsement)
+	if err != nil {
+		return err
+	}
+	m := pb.Message{
+		MessageType: pb.Message_DISPUTE_ENDORSEMENT,
+		Payload:     a,
+	}
+	return n.sendMessage(peerId, k, m)
+}
+
+func (n *OpenBazaarNode) SendDisputeEvidence(peerId string, evidence *pb.DisputeEvidence) error {
+	a, err := ptypes.MarshalAny(evidence)
+	if err != nil {
+		return err
+	}
+	m := pb.Message{
+		MessageType: pb.Message_DISPUTE_EVIDENCE,
+		Payload:     a,
+	}
+	return n.sendMessage(peerId, nil, m)
+}
+
+func (n *OpenBazaarNode) SendDisputeProposal(peerId string, proposal *pb.DisputeProposal) error {
+	a, err := ptypes.MarshalAny(proposal)
+	if err != nil {
+		return err
+	}
+	m := pb.Message{
+		MessageType: pb.Message_DISPUTE_PROPOSAL,
+		Payload:     a,
+	}
+	return n.sendMessage(peerId, nil, m)
+}
+
+func (n *OpenBazaarNode) SendDisputeAcceptance(peerId string, acceptance *pb.DisputeProposalAcceptance) error {
+	a, err := ptypes.MarshalAny(acceptance)
+	if err != nil {
+		return err
+	}
+	m := pb.Message{
+		MessageType: pb.Message_DISPUTE_ACCEPTANCE,
+		Payload:     a,
+	}
+	return n.sendMessage(peerId, nil, m)
+}
+
 func (n *OpenBazaarNode) SendChat(peerId string, chatMessage *pb.Chat) error {
 	a, err := ptypes.MarshalAny(chatMessage)
 	if err != nil {
@@ -350,3 +405,48 @@ func (n *OpenBazaarNode) SendModeratorRemove(peerId string) error {
 	}
 	return nil
 }
+
+func (n *OpenBazaarNode) SendBid(peerId string, bid *pb.Bid) (*pb.BidAck, error) {
+	p, err := peer.IDB58Decode(peerId)
+	if err != nil {
+		return nil, err
+	}
+	a, err := ptypes.MarshalAny(bid)
+	if err != nil {
+		return nil, err
+	}
+	m := pb.Message{
+		MessageType: pb.Message_BID,
+		Payload:     a,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resp, err := n.Service.SendRequest(ctx, p, &m)
+	if err != nil {
+		return nil, err
+	}
+	if resp.MessageType == pb.Message_ERROR {
+		return nil, fmt.Errorf("Vendor rejected bid, reason: %s", string(resp.Payload.Value))
+	}
+	if resp.MessageType != pb.Message_BID_ACK {
+		return nil, errors.New("Vendor responded to the bid with an incorrect message type")
+	}
+	ack := new(pb.BidAck)
+	err = proto.Unmarshal(resp.Payload.Value, ack)
+	if err != nil {
+		return nil, errors.New("Error parsing the vendor's response")
+	}
+	return ack, nil
+}
+
+func (n *OpenBazaarNode) SendBidAck(peerId string, ack *pb.BidAck) error {
+	a, err := ptypes.MarshalAny(ack)
+	if err != nil {
+		return err
+	}
+	m := pb.Message{
+		MessageType: pb.Message_BID_ACK,
+		Payload:     a,
+	}
+	return n.sendMessage(peerId, nil, m)
+}
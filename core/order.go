@@ -42,20 +42,23 @@ type item struct {
 	Shipping    shippingOption `json:"shipping"`
 	Memo        string         `json:"memo"`
 	Coupons     []string       `json:"coupons"`
+	Bid         uint64         `json:"bid"`
 }
 
 type PurchaseData struct {
-	ShipTo               string  `json:"shipTo"`
-	Address              string  `json:"address"`
-	City                 string  `json:"city"`
-	State                string  `json:"state"`
-	PostalCode           string  `json:"postalCode"`
-	CountryCode          string  `json:"countryCode"`
-	AddressNotes         string  `json:"addressNotes"`
-	Moderator            string  `json:"moderator"`
-	Items                []item  `json:"items"`
-	AlternateContactInfo string  `json:"alternateContactInfo"`
-	RefundAddress        *string `json:"refundAddress"` //optional, can be left out of json
+	ShipTo               string   `json:"shipTo"`
+	Address              string   `json:"address"`
+	City                 string   `json:"city"`
+	State                string   `json:"state"`
+	PostalCode           string   `json:"postalCode"`
+	CountryCode          string   `json:"countryCode"`
+	AddressNotes         string   `json:"addressNotes"`
+	Moderator            string   `json:"moderator"`
+	ModeratorPanel       []string `json:"moderatorPanel"` //optional, the first moderator coordinates disputes
+	Items                []item   `json:"items"`
+	AlternateContactInfo string   `json:"alternateContactInfo"`
+	RefundAddress        *string  `json:"refundAddress"` //optional, can be left out of json
+	PaymentCoin          string   `json:"paymentCoin"`   //optional, defaults to the primary wallet
 }
 
 func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAddress string, paymentAmount uint64, vendorOnline bool, err error) {
@@ -63,28 +66,48 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 	if err != nil {
 		return "", "", 0, false, err
 	}
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return "", "", 0, false, err
+	}
+
+	// The first moderator of a panel coordinates disputes and is the order's moderator
+	if len(data.ModeratorPanel) > 0 {
+		if data.Moderator == "" {
+			data.Moderator = data.ModeratorPanel[0]
+		} else if data.Moderator != data.ModeratorPanel[0] {
+			return "", "", 0, false, errors.New("The order's moderator must be the first member of the panel")
+		}
+	}
 
 	// Add payment data and send to vendor
-	if data.Moderator != "" { // Moderated payment
-		payment := new(pb.Order_Payment)
+	if data.Moderator != "" || IsCrowdFund(contract) { // Moderated payment or crowdfund pledge
+		payment := contract.BuyerOrder.Payment
 		payment.Method = pb.Order_Payment_MODERATED
 		payment.Moderator = data.Moderator
-		ipnsPath := ipfspath.FromString(data.Moderator + "/profile")
-		profileBytes, err := ipfs.ResolveThenCat(n.Context, ipnsPath)
-		if err != nil {
-			return "", "", 0, false, errors.New("Moderator could not be found")
-		}
-		profile := new(pb.Profile)
-		err = jsonpb.UnmarshalString(string(profileBytes), profile)
-		if err != nil {
-			return "", "", 0, false, err
-		}
-		moderatorKeyBytes, err := hex.DecodeString(profile.BitcoinPubkey)
-		if err != nil {
+		payment.ModeratorPanel = data.ModeratorPanel
+		if err := validateModeratorPanel(contract); err != nil {
 			return "", "", 0, false, err
 		}
-		if !profile.Moderator || profile.ModeratorInfo == nil || strings.ToLower(profile.ModeratorInfo.AcceptedCurrency) != strings.ToLower(n.Wallet.CurrencyCode()) {
-			return "", "", 0, false, errors.New("Moderator is not capabale of moderating this transaction")
+		var moderatorKeyBytes []byte
+		if data.Moderator != "" && len(data.ModeratorPanel) == 0 {
+			ipnsPath := ipfspath.FromString(data.Moderator + "/profile")
+			profileBytes, err := ipfs.ResolveThenCat(n.Context, ipnsPath)
+			if err != nil {
+				return "", "", 0, false, errors.New("Moderator could not be found")
+			}
+			profile := new(pb.Profile)
+			err = jsonpb.UnmarshalString(string(profileBytes), profile)
+			if err != nil {
+				return "", "", 0, false, err
+			}
+			moderatorKeyBytes, err = hex.DecodeString(profile.BitcoinPubkey)
+			if err != nil {
+				return "", "", 0, false, err
+			}
+			if !profile.Moderator || !moderatorAcceptsCurrency(profile.ModeratorInfo, wal.CurrencyCode()) {
+				return "", "", 0, false, errors.New("Moderator is not capabale of moderating this transaction")
+			}
 		}
 		total, err := n.CalculateOrderTotal(contract)
 		if err != nil {
@@ -93,7 +116,8 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 		payment.Amount = total
 
 		/* Generate a payment address using the first child key derived from the buyers's,
-		   vendors's and moderator's masterPubKey and a random chaincode. */
+		   vendors's and moderator's masterPubKey and a random chaincode. Crowdfund pledges
+		   without a moderator use a 2 of 2 address between the buyer and vendor. */
 		chaincode := make([]byte, 32)
 		_, err = rand.Read(chaincode)
 		if err != nil {
@@ -101,7 +125,7 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 		}
 		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
 		hdKey := hd.NewExtendedKey(
-			n.Wallet.Params().HDPublicKeyID[:],
+			wal.Params().HDPublicKeyID[:],
 			contract.VendorListings[0].VendorID.Pubkeys.Bitcoin,
 			chaincode,
 			parentFP,
@@ -114,7 +138,7 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 			return "", "", 0, false, err
 		}
 		hdKey = hd.NewExtendedKey(
-			n.Wallet.Params().HDPublicKeyID[:],
+			wal.Params().HDPublicKeyID[:],
 			contract.BuyerOrder.BuyerID.Pubkeys.Bitcoin,
 			chaincode,
 			parentFP,
@@ -126,21 +150,37 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 		if err != nil {
 			return "", "", 0, false, err
 		}
-		hdKey = hd.NewExtendedKey(
-			n.Wallet.Params().HDPublicKeyID[:],
-			moderatorKeyBytes,
-			chaincode,
-			parentFP,
-			0,
-			0,
-			false)
+		keys := []hd.ExtendedKey{*buyerKey, *vendorKey}
+		if moderatorKeyBytes != nil {
+			hdKey = hd.NewExtendedKey(
+				wal.Params().HDPublicKeyID[:],
+				moderatorKeyBytes,
+				chaincode,
+				parentFP,
+				0,
+				0,
+				false)
 
-		moderatorKey, err := hdKey.Child(0)
+			moderatorKey, err := hdKey.Child(0)
+			if err != nil {
+				return "", "", 0, false, err
+			}
+			keys = append(keys, *moderatorKey)
+		}
+
+		var addr btcutil.Address
+		var redeemScript []byte
+		timeout, err := OrderEscrowTimeout(contract)
 		if err != nil {
 			return "", "", 0, false, err
 		}
-
-		addr, redeemScript, err := n.Wallet.GenerateMultisigScript([]hd.ExtendedKey{*buyerKey, *vendorKey, *moderatorKey}, 2)
+		if len(payment.ModeratorPanel) > 0 {
+			addr, redeemScript, err = n.panelEscrowScript(wal, *buyerKey, *vendorKey, payment.ModeratorPanel, chaincode, timeout, true)
+		} else if isUnmoderatedPledge(contract) {
+			addr, redeemScript, err = generatePledgeScript(wal, *buyerKey, *vendorKey, timeout)
+		} else {
+			addr, redeemScript, err = n.generateEscrowScript(wal, keys, *vendorKey, timeout)
+		}
 		if err != nil {
 			return "", "", 0, false, err
 		}
@@ -148,13 +188,13 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 		payment.RedeemScript = hex.EncodeToString(redeemScript)
 		payment.Chaincode = hex.EncodeToString(chaincode)
 		contract.BuyerOrder.Payment = payment
-		contract.BuyerOrder.RefundFee = n.Wallet.GetFeePerByte(spvwallet.NORMAL)
+		contract.BuyerOrder.RefundFee = wal.GetFeePerByte(spvwallet.NORMAL)
 
 		script, err := txscript.PayToAddrScript(addr)
 		if err != nil {
 			return "", "", 0, false, err
 		}
-		n.Wallet.AddWatchedScript(script)
+		wal.AddWatchedScript(script)
 
 		contract, err = n.SignOrder(contract)
 		if err != nil {
@@ -225,7 +265,7 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 			return orderId, contract.VendorOrderConfirmation.PaymentAddress, contract.BuyerOrder.Payment.Amount, true, nil
 		}
 	} else { // Direct payment
-		payment := new(pb.Order_Payment)
+		payment := contract.BuyerOrder.Payment
 		payment.Method = pb.Order_Payment_ADDRESS_REQUEST
 		total, err := n.CalculateOrderTotal(contract)
 		if err != nil {
@@ -253,7 +293,7 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 			}
 			parentFP := []byte{0x00, 0x00, 0x00, 0x00}
 			hdKey := hd.NewExtendedKey(
-				n.Wallet.Params().HDPublicKeyID[:],
+				wal.Params().HDPublicKeyID[:],
 				contract.VendorListings[0].VendorID.Pubkeys.Bitcoin,
 				chaincode,
 				parentFP,
@@ -266,7 +306,7 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 				return "", "", 0, false, err
 			}
 			hdKey = hd.NewExtendedKey(
-				n.Wallet.Params().HDPublicKeyID[:],
+				wal.Params().HDPublicKeyID[:],
 				contract.BuyerOrder.BuyerID.Pubkeys.Bitcoin,
 				chaincode,
 				parentFP,
@@ -278,7 +318,7 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 			if err != nil {
 				return "", "", 0, false, err
 			}
-			addr, redeemScript, err := n.Wallet.GenerateMultisigScript([]hd.ExtendedKey{*buyerKey, *vendorKey}, 1)
+			addr, redeemScript, err := wal.GenerateMultisigScript([]hd.ExtendedKey{*buyerKey, *vendorKey}, 1)
 			if err != nil {
 				return "", "", 0, false, err
 			}
@@ -290,7 +330,7 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 			if err != nil {
 				return "", "", 0, false, err
 			}
-			n.Wallet.AddWatchedScript(script)
+			wal.AddWatchedScript(script)
 
 			contract, err = n.SignOrder(contract)
 			if err != nil {
@@ -347,7 +387,7 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 			if err != nil {
 				return "", "", 0, false, err
 			}
-			addr, err := btcutil.DecodeAddress(contract.VendorOrderConfirmation.PaymentAddress, n.Wallet.Params())
+			addr, err := btcutil.DecodeAddress(contract.VendorOrderConfirmation.PaymentAddress, wal.Params())
 			if err != nil {
 				return "", "", 0, false, err
 			}
@@ -355,7 +395,7 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 			if err != nil {
 				return "", "", 0, false, err
 			}
-			n.Wallet.AddWatchedScript(script)
+			wal.AddWatchedScript(script)
 			orderId, err := n.CalcOrderId(contract.BuyerOrder)
 			if err != nil {
 				return "", "", 0, false, err
@@ -369,10 +409,15 @@ func (n *OpenBazaarNode) Purchase(data *PurchaseData) (orderId string, paymentAd
 func (n *OpenBazaarNode) createContractWithOrder(data *PurchaseData) (*pb.RicardianContract, error) {
 	contract := new(pb.RicardianContract)
 	order := new(pb.Order)
+	wal, err := n.WalletForCurrency(data.PaymentCoin)
+	if err != nil {
+		return nil, err
+	}
+	order.Payment = &pb.Order_Payment{Coin: strings.ToUpper(wal.CurrencyCode())}
 	if data.RefundAddress != nil {
 		order.RefundAddress = *(data.RefundAddress)
 	} else {
-		order.RefundAddress = n.Wallet.CurrentAddress(spvwallet.INTERNAL).EncodeAddress()
+		order.RefundAddress = wal.CurrentAddress(spvwallet.INTERNAL).EncodeAddress()
 	}
 	shipping := &pb.Order_Shipping{
 		ShipTo:     data.ShipTo,
@@ -481,8 +526,8 @@ func (n *OpenBazaarNode) createContractWithOrder(data *PurchaseData) (*pb.Ricard
 			listing = addedListings[item.ListingHash]
 		}
 
-		if strings.ToLower(listing.Metadata.AcceptedCurrency) != strings.ToLower(n.Wallet.CurrencyCode()) {
-			return nil, fmt.Errorf("Contract only accepts %s, our wallet uses %s", listing.Metadata.AcceptedCurrency, n.Wallet.CurrencyCode())
+		if !listingsAcceptCurrency([]*pb.Listing{listing}, order.Payment.Coin) {
+			return nil, fmt.Errorf("Contract only accepts %s, not %s", strings.Join(ListingCurrencies(listing), ", "), order.Payment.Coin)
 		}
 
 		// Remove any duplicate coupons
@@ -536,9 +581,21 @@ func (n *OpenBazaarNode) createContractWithOrder(data *PurchaseData) (*pb.Ricard
 		i.ShippingOption = so
 		i.Memo = item.Memo
 		i.CouponCodes = coupons
+		if listing.Metadata.Format == pb.Listing_Metadata_AUCTION {
+			i.Bid = item.Bid
+		}
 		order.Items = append(order.Items, i)
 	}
 
+	// Include the vendor's store-wide promotions so they can check the discount we took.
+	// Vendors who aren't running any won't have published them.
+	if len(contract.VendorListings) > 0 && contract.VendorListings[0].VendorID != nil {
+		sp, err := n.FetchPromotions(contract.VendorListings[0].VendorID.PeerID)
+		if err == nil {
+			contract.VendorPromotions = sp
+		}
+	}
+
 	contract.BuyerOrder = order
 	return contract, nil
 }
@@ -552,6 +609,10 @@ func (n *OpenBazaarNode) EstimateOrderTotal(data *PurchaseData) (uint64, error)
 }
 
 func (n *OpenBazaarNode) CancelOfflineOrder(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
+	}
 	orderId, err := n.CalcOrderId(contract.BuyerOrder)
 	if err != nil {
 		return err
@@ -582,7 +643,7 @@ func (n *OpenBazaarNode) CancelOfflineOrder(contract *pb.RicardianContract, reco
 		return err
 	}
 	parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-	mPrivKey := n.Wallet.MasterPrivateKey()
+	mPrivKey := wal.MasterPrivateKey()
 	if err != nil {
 		return err
 	}
@@ -591,7 +652,7 @@ func (n *OpenBazaarNode) CancelOfflineOrder(contract *pb.RicardianContract, reco
 		return err
 	}
 	hdKey := hd.NewExtendedKey(
-		n.Wallet.Params().HDPrivateKeyID[:],
+		wal.Params().HDPrivateKeyID[:],
 		mECKey.Serialize(),
 		chaincode,
 		parentFP,
@@ -604,11 +665,11 @@ func (n *OpenBazaarNode) CancelOfflineOrder(contract *pb.RicardianContract, reco
 		return err
 	}
 	redeemScript, err := hex.DecodeString(contract.BuyerOrder.Payment.RedeemScript)
-	refundAddress, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, n.Wallet.Params())
+	refundAddress, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, wal.Params())
 	if err != nil {
 		return err
 	}
-	_, err = n.Wallet.SweepAddress(utxos, &refundAddress, buyerKey, &redeemScript, spvwallet.NORMAL)
+	_, err = wal.SweepAddress(utxos, &refundAddress, buyerKey, &redeemScript, spvwallet.NORMAL)
 	if err != nil {
 		return err
 	}
@@ -633,11 +694,15 @@ func (n *OpenBazaarNode) CalcOrderId(order *pb.Order) (string, error) {
 }
 
 func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (uint64, error) {
-	if n.ExchangeRates != nil {
-		n.ExchangeRates.GetLatestRate("") // Refresh the exchange rates
+	coin := ContractCoin(contract)
+	if rates := n.ExchangeRatesForCurrency(coin); rates != nil {
+		rates.GetLatestRate("") // Refresh the exchange rates
 	}
 	var total uint64
 	physicalGoods := make(map[string]*pb.Listing)
+	if err := verifyContractPromotions(contract); err != nil {
+		return 0, err
+	}
 
 	// Calculate the price of each item
 	for _, item := range contract.BuyerOrder.Items {
@@ -649,7 +714,11 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 		if l.Metadata.ContractType == pb.Listing_Metadata_PHYSICAL_GOOD {
 			physicalGoods[item.ListingHash] = l
 		}
-		satoshis, err := n.getPriceInSatoshi(l.Metadata.PricingCurrency, l.Item.Price)
+		price := l.Item.Price
+		if l.Metadata.Format == pb.Listing_Metadata_AUCTION {
+			price = item.Bid
+		}
+		satoshis, err := n.getPriceInCoinUnits(coin, l.Metadata.PricingCurrency, price)
 		if err != nil {
 			return 0, err
 		}
@@ -663,7 +732,7 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 			if selectedSku == i {
 				skuExists = true
 				if sku.Surcharge != 0 {
-					satoshis, err := n.getPriceInSatoshi(l.Metadata.PricingCurrency, uint64(sku.Surcharge))
+					satoshis, err := n.getPriceInCoinUnits(coin, l.Metadata.PricingCurrency, uint64(sku.Surcharge))
 					if err != nil {
 						return 0, err
 					}
@@ -680,19 +749,37 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 		}
 		// Subtract any coupons
 		for _, couponCode := range item.CouponCodes {
-			for _, vendorCoupon := range l.Coupons {
-				multihash, err := EncodeMultihash([]byte(couponCode))
+			vendorCoupon, err := findCoupon(l, couponCode)
+			if err != nil {
+				return 0, err
+			}
+			if vendorCoupon == nil {
+				continue
+			}
+			if couponExpired(vendorCoupon, contract.BuyerOrder.Timestamp) {
+				return 0, ErrCouponExpired
+			}
+			if vendorCoupon.MinimumPurchase > 0 {
+				minimum, err := n.getPriceInCoinUnits(coin, l.Metadata.PricingCurrency, vendorCoupon.MinimumPurchase)
 				if err != nil {
 					return 0, err
 				}
-				if multihash.B58String() == vendorCoupon.GetHash() {
-					if discount := vendorCoupon.GetPriceDiscount(); discount > 0 {
-						itemTotal -= discount
-					} else if discount := vendorCoupon.GetPercentDiscount(); discount > 0 {
-						itemTotal -= uint64((float32(itemTotal) * (discount / 100)))
-					}
+				if itemTotal*uint64(item.Quantity) < minimum {
+					return 0, ErrCouponMinimumPurchase
 				}
 			}
+			if discount := vendorCoupon.GetPriceDiscount(); discount > 0 {
+				itemTotal -= discount
+			} else if discount := vendorCoupon.GetPercentDiscount(); discount > 0 {
+				itemTotal -= uint64((float32(itemTotal) * (discount / 100)))
+			}
+		}
+		// Apply any store-wide promotion
+		promo := findPromotion(contract.VendorPromotions, l, contract.BuyerOrder.Timestamp)
+		if promo != nil {
+			if discount := promo.GetPercentDiscount(); discount > 0 {
+				itemTotal -= uint64((float32(itemTotal) * (discount / 100)))
+			}
 		}
 		// Apply tax
 		for _, tax := range l.Taxes {
@@ -703,7 +790,7 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 				}
 			}
 		}
-		itemTotal *= uint64(item.Quantity)
+		itemTotal *= uint64(chargedQuantity(promo, item.Quantity))
 		total += itemTotal
 	}
 
@@ -719,8 +806,8 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 	var shippingTotal uint64
 	for _, item := range contract.BuyerOrder.Items {
 		listing, ok := physicalGoods[item.ListingHash]
-		if !ok {
-			return 0, fmt.Errorf("Listing %s not found in contract", item.ListingHash)
+		if !ok { // Only physical goods have shipping
+			continue
 		}
 		var itemShipping uint64
 		// Check selected option exists
@@ -753,7 +840,7 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 		if !ok {
 			return 0, errors.New("Shipping service not found in listing")
 		}
-		shippingSatoshi, err := n.getPriceInSatoshi(listing.Metadata.PricingCurrency, service.Price)
+		shippingSatoshi, err := n.getPriceInCoinUnits(coin, listing.Metadata.PricingCurrency, service.Price)
 		if err != nil {
 			return 0, err
 		}
@@ -779,7 +866,7 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 				switch option.ShippingRules.RuleType {
 				case pb.Listing_ShippingOption_ShippingRules_QUANTITY_DISCOUNT:
 					if item.Quantity >= rule.MinRange && item.Quantity <= rule.MaxRange {
-						rulePrice, err := n.getPriceInSatoshi(listing.Metadata.PricingCurrency, rule.Price)
+						rulePrice, err := n.getPriceInCoinUnits(coin, listing.Metadata.PricingCurrency, rule.Price)
 						if err != nil {
 							return 0, err
 						}
@@ -788,7 +875,7 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 				case pb.Listing_ShippingOption_ShippingRules_FLAT_FEE_QUANTITY_RANGE:
 					if item.Quantity >= rule.MinRange && item.Quantity <= rule.MaxRange {
 						itemShipping -= shippingPrice
-						rulePrice, err := n.getPriceInSatoshi(listing.Metadata.PricingCurrency, rule.Price)
+						rulePrice, err := n.getPriceInCoinUnits(coin, listing.Metadata.PricingCurrency, rule.Price)
 						if err != nil {
 							return 0, err
 						}
@@ -798,7 +885,7 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 					weight := listing.Item.Grams * float32(item.Quantity)
 					if uint32(weight) >= rule.MinRange && uint32(weight) <= rule.MaxRange {
 						itemShipping -= shippingPrice
-						rulePrice, err := n.getPriceInSatoshi(listing.Metadata.PricingCurrency, rule.Price)
+						rulePrice, err := n.getPriceInCoinUnits(coin, listing.Metadata.PricingCurrency, rule.Price)
 						if err != nil {
 							return 0, err
 						}
@@ -806,7 +893,7 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 					}
 				case pb.Listing_ShippingOption_ShippingRules_COMBINED_SHIPPING_ADD:
 					itemShipping -= shippingPrice
-					rulePrice, err := n.getPriceInSatoshi(listing.Metadata.PricingCurrency, rule.Price)
+					rulePrice, err := n.getPriceInCoinUnits(coin, listing.Metadata.PricingCurrency, rule.Price)
 					rulePrice += uint64(float32(rulePrice) * shippingTaxPercentage)
 					shippingSatoshi += uint64(float32(shippingSatoshi) * shippingTaxPercentage)
 					if err != nil {
@@ -822,7 +909,7 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 
 				case pb.Listing_ShippingOption_ShippingRules_COMBINED_SHIPPING_SUBTRACT:
 					itemShipping -= shippingPrice
-					rulePrice, err := n.getPriceInSatoshi(listing.Metadata.PricingCurrency, rule.Price)
+					rulePrice, err := n.getPriceInCoinUnits(coin, listing.Metadata.PricingCurrency, rule.Price)
 					rulePrice += uint64(float32(rulePrice) * shippingTaxPercentage)
 					shippingSatoshi += uint64(float32(shippingSatoshi) * shippingTaxPercentage)
 					if err != nil {
@@ -868,16 +955,25 @@ func (n *OpenBazaarNode) CalculateOrderTotal(contract *pb.RicardianContract) (ui
 }
 
 func (n *OpenBazaarNode) getPriceInSatoshi(currencyCode string, amount uint64) (uint64, error) {
-	if strings.ToLower(currencyCode) == strings.ToLower(n.Wallet.CurrencyCode()) {
+	return n.getPriceInCoinUnits(n.Wallet.CurrencyCode(), currencyCode, amount)
+}
+
+// Convert a price into the smallest unit of the coin the order is paid with
+func (n *OpenBazaarNode) getPriceInCoinUnits(coin, currencyCode string, amount uint64) (uint64, error) {
+	if coin == "" {
+		coin = n.Wallet.CurrencyCode()
+	}
+	if strings.ToLower(currencyCode) == strings.ToLower(coin) {
 		return amount, nil
 	}
-	exchangeRate, err := n.ExchangeRates.GetExchangeRate(currencyCode)
+	rates := n.ExchangeRatesForCurrency(coin)
+	exchangeRate, err := rates.GetExchangeRate(currencyCode)
 	if err != nil {
 		return 0, err
 	}
 	formatedAmount := float64(amount) / 100
 	btc := formatedAmount / exchangeRate
-	satoshis := btc * float64(n.ExchangeRates.UnitsPerCoin())
+	satoshis := btc * float64(rates.UnitsPerCoin())
 	return uint64(satoshis), nil
 }
 
@@ -943,7 +1039,35 @@ func (n *OpenBazaarNode) ValidateOrder(contract *pb.RicardianContract) error {
 	if contract.BuyerOrder.Timestamp == nil {
 		return errors.New("Order is missing a timestamp")
 	}
-	if contract.BuyerOrder.Payment.Method == pb.Order_Payment_MODERATED {
+	if coin := ContractCoin(contract); coin != "" {
+		if _, err := n.WalletForCurrency(coin); err != nil {
+			return fmt.Errorf("Vendor does not accept payment in %s", coin)
+		}
+		if !listingsAcceptCurrency(contract.VendorListings, coin) {
+			return fmt.Errorf("Listing does not accept payment in %s", coin)
+		}
+	}
+	if IsCrowdFund(contract) {
+		if contract.BuyerOrder.Payment.Method != pb.Order_Payment_MODERATED {
+			return errors.New("Crowdfund pledges must be paid into escrow")
+		}
+		// The goal is tracked in units of the vendor's primary coin
+		if coin := ContractCoin(contract); coin != "" && !strings.EqualFold(coin, n.Wallet.CurrencyCode()) {
+			return fmt.Errorf("Crowdfund pledges must be paid in %s", strings.ToUpper(n.Wallet.CurrencyCode()))
+		}
+		for _, listing := range contract.VendorListings {
+			if crowdFundClosed(listing) {
+				return errors.New("Crowdfund has passed its deadline")
+			}
+		}
+	} else {
+		for _, listing := range contract.VendorListings {
+			if listing.Metadata.ContractType == pb.Listing_Metadata_CROWD_FUND {
+				return errors.New("Crowdfund pledges cannot be combined with other items")
+			}
+		}
+	}
+	if contract.BuyerOrder.Payment.Method == pb.Order_Payment_MODERATED && (contract.BuyerOrder.Payment.Moderator != "" || !IsCrowdFund(contract)) {
 		_, err := mh.FromB58String(contract.BuyerOrder.Payment.Moderator)
 		if err != nil {
 			return errors.New("Invalid moderator")
@@ -962,6 +1086,9 @@ func (n *OpenBazaarNode) ValidateOrder(contract *pb.RicardianContract) error {
 		if !validMod {
 			return errors.New("Invalid moderator")
 		}
+		if err := validateModeratorPanel(contract); err != nil {
+			return err
+		}
 	}
 
 	// Validate that the hash of the items in the contract match claimed hash in the order
@@ -1004,6 +1131,21 @@ collectListings:
 		}
 	}
 
+	// Validate auction items match a winning bid no other order has used
+	orderId, err := n.CalcOrderId(contract.BuyerOrder)
+	if err != nil {
+		return err
+	}
+	for _, item := range contract.BuyerOrder.Items {
+		listing := listingMap[item.ListingHash]
+		if listing.Metadata.Format != pb.Listing_Metadata_AUCTION {
+			continue
+		}
+		if _, err := n.validateWinningBid(listing, item, contract.BuyerOrder.BuyerID.PeerID, orderId); err != nil {
+			return err
+		}
+	}
+
 	// Validate no duplicate coupons
 	for _, item := range contract.BuyerOrder.Items {
 		couponMap := make(map[string]bool)
@@ -1015,6 +1157,14 @@ collectListings:
 		}
 	}
 
+	// Validate the store-wide promotions
+	if err := n.validateOrderPromotions(contract); err != nil {
+		return err
+	}
+	if err := validateOrderTime(contract, listingMap, time.Now()); err != nil {
+		return err
+	}
+
 	// Validate the selected variants
 	type inventory struct {
 		Slug    string
@@ -1150,7 +1300,7 @@ collectListings:
 	}
 
 	// Validate the buyers's signature on the order
-	err := verifySignaturesOnOrder(contract)
+	err = verifySignaturesOnOrder(contract)
 	if err != nil {
 		return err
 	}
@@ -1158,17 +1308,21 @@ collectListings:
 }
 
 func (n *OpenBazaarNode) ValidateDirectPaymentAddress(order *pb.Order) error {
+	wal, err := n.WalletForCurrency(order.Payment.GetCoin())
+	if err != nil {
+		return err
+	}
 	chaincode, err := hex.DecodeString(order.Payment.Chaincode)
 	if err != nil {
 		return err
 	}
 	parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-	mECKey, err := n.Wallet.MasterPublicKey().ECPubKey()
+	mECKey, err := wal.MasterPublicKey().ECPubKey()
 	if err != nil {
 		return err
 	}
 	hdKey := hd.NewExtendedKey(
-		n.Wallet.Params().HDPublicKeyID[:],
+		wal.Params().HDPublicKeyID[:],
 		mECKey.SerializeCompressed(),
 		chaincode,
 		parentFP,
@@ -1181,7 +1335,7 @@ func (n *OpenBazaarNode) ValidateDirectPaymentAddress(order *pb.Order) error {
 		return err
 	}
 	hdKey = hd.NewExtendedKey(
-		n.Wallet.Params().HDPublicKeyID[:],
+		wal.Params().HDPublicKeyID[:],
 		order.BuyerID.Pubkeys.Bitcoin,
 		chaincode,
 		parentFP,
@@ -1193,7 +1347,7 @@ func (n *OpenBazaarNode) ValidateDirectPaymentAddress(order *pb.Order) error {
 	if err != nil {
 		return err
 	}
-	addr, redeemScript, err := n.Wallet.GenerateMultisigScript([]hd.ExtendedKey{*buyerKey, *vendorKey}, 1)
+	addr, redeemScript, err := wal.GenerateMultisigScript([]hd.ExtendedKey{*buyerKey, *vendorKey}, 1)
 	if order.Payment.Address != addr.EncodeAddress() {
 		return errors.New("Invalid payment address")
 	}
@@ -1203,20 +1357,28 @@ func (n *OpenBazaarNode) ValidateDirectPaymentAddress(order *pb.Order) error {
 	return nil
 }
 
-func (n *OpenBazaarNode) ValidateModeratedPaymentAddress(order *pb.Order) error {
-	ipnsPath := ipfspath.FromString(order.Payment.Moderator + "/profile")
-	profileBytes, err := ipfs.ResolveThenCat(n.Context, ipnsPath)
+func (n *OpenBazaarNode) ValidateModeratedPaymentAddress(order *pb.Order, timeout uint32) error {
+	wal, err := n.WalletForCurrency(order.Payment.GetCoin())
 	if err != nil {
 		return err
 	}
-	profile := new(pb.Profile)
-	err = jsonpb.UnmarshalString(string(profileBytes), profile)
-	if err != nil {
-		return err
-	}
-	moderatorBytes, err := hex.DecodeString(profile.BitcoinPubkey)
-	if err != nil {
-		return err
+	// Crowdfund pledges without a moderator use a 2 of 2 address
+	var moderatorBytes []byte
+	if order.Payment.Moderator != "" && len(order.Payment.ModeratorPanel) == 0 {
+		ipnsPath := ipfspath.FromString(order.Payment.Moderator + "/profile")
+		profileBytes, err := ipfs.ResolveThenCat(n.Context, ipnsPath)
+		if err != nil {
+			return err
+		}
+		profile := new(pb.Profile)
+		err = jsonpb.UnmarshalString(string(profileBytes), profile)
+		if err != nil {
+			return err
+		}
+		moderatorBytes, err = hex.DecodeString(profile.BitcoinPubkey)
+		if err != nil {
+			return err
+		}
 	}
 
 	chaincode, err := hex.DecodeString(order.Payment.Chaincode)
@@ -1224,12 +1386,12 @@ func (n *OpenBazaarNode) ValidateModeratedPaymentAddress(order *pb.Order) error
 		return err
 	}
 	parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-	mECKey, err := n.Wallet.MasterPublicKey().ECPubKey()
+	mECKey, err := wal.MasterPublicKey().ECPubKey()
 	if err != nil {
 		return err
 	}
 	hdKey := hd.NewExtendedKey(
-		n.Wallet.Params().HDPublicKeyID[:],
+		wal.Params().HDPublicKeyID[:],
 		mECKey.SerializeCompressed(),
 		chaincode,
 		parentFP,
@@ -1242,7 +1404,7 @@ func (n *OpenBazaarNode) ValidateModeratedPaymentAddress(order *pb.Order) error
 		return err
 	}
 	hdKey = hd.NewExtendedKey(
-		n.Wallet.Params().HDPublicKeyID[:],
+		wal.Params().HDPublicKeyID[:],
 		order.BuyerID.Pubkeys.Bitcoin,
 		chaincode,
 		parentFP,
@@ -1254,20 +1416,35 @@ func (n *OpenBazaarNode) ValidateModeratedPaymentAddress(order *pb.Order) error
 	if err != nil {
 		return err
 	}
-	hdKey = hd.NewExtendedKey(
-		n.Wallet.Params().HDPublicKeyID[:],
-		moderatorBytes,
-		chaincode,
-		parentFP,
-		0,
-		0,
-		false)
+	keys := []hd.ExtendedKey{*buyerKey, *vendorKey}
+	if moderatorBytes != nil {
+		hdKey = hd.NewExtendedKey(
+			wal.Params().HDPublicKeyID[:],
+			moderatorBytes,
+			chaincode,
+			parentFP,
+			0,
+			0,
+			false)
 
-	ModeratorKey, err := hdKey.Child(0)
+		ModeratorKey, err := hdKey.Child(0)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, *ModeratorKey)
+	}
+	var addr btcutil.Address
+	var redeemScript []byte
+	if len(order.Payment.ModeratorPanel) > 0 {
+		addr, redeemScript, err = n.panelEscrowScript(wal, *buyerKey, *vendorKey, order.Payment.ModeratorPanel, chaincode, timeout, true)
+	} else if moderatorBytes == nil {
+		addr, redeemScript, err = generatePledgeScript(wal, *buyerKey, *vendorKey, timeout)
+	} else {
+		addr, redeemScript, err = n.generateEscrowScript(wal, keys, *vendorKey, timeout)
+	}
 	if err != nil {
 		return err
 	}
-	addr, redeemScript, err := n.Wallet.GenerateMultisigScript([]hd.ExtendedKey{*buyerKey, *vendorKey, *ModeratorKey}, 2)
 	if order.Payment.Address != addr.EncodeAddress() {
 		return errors.New("Invalid payment address")
 	}
@@ -1334,6 +1511,44 @@ func validateVersionNumber(listing *pb.Listing) error {
 	return nil
 }
 
+// How far from the time we receive an order its timestamp may be when its discounts depend on it
+const MaxOrderClockSkew = time.Minute * 10
+
+// Time-limited promotions and coupons are judged by the order's timestamp so that the buyer
+// and vendor calculate the same total. The buyer sets it, so an order which gets such a
+// discount must be received close to the time it says it was placed. Offline orders which
+// arrive later have to be placed again.
+func validateOrderTime(contract *pb.RicardianContract, listings map[string]*pb.Listing, received time.Time) error {
+	timeLimited := false
+	for _, item := range contract.BuyerOrder.Items {
+		listing, ok := listings[item.ListingHash]
+		if !ok {
+			continue
+		}
+		promo := findPromotion(contract.VendorPromotions, listing, contract.BuyerOrder.Timestamp)
+		if promo != nil && (promo.Start != nil || promo.End != nil) {
+			timeLimited = true
+		}
+		for _, code := range item.CouponCodes {
+			coupon, err := findCoupon(listing, code)
+			if err == nil && coupon != nil && coupon.Expiry != nil {
+				timeLimited = true
+			}
+		}
+	}
+	if !timeLimited {
+		return nil
+	}
+	if contract.BuyerOrder.Timestamp == nil {
+		return errors.New("Order is missing a timestamp")
+	}
+	placed := time.Unix(contract.BuyerOrder.Timestamp.Seconds, 0)
+	if placed.Before(received.Add(-MaxOrderClockSkew)) || placed.After(received.Add(MaxOrderClockSkew)) {
+		return errors.New("Order was placed too long before it was received to apply its discounts")
+	}
+	return nil
+}
+
 func (n *OpenBazaarNode) ValidatePaymentAmount(requestedAmount, paymentAmount uint64) bool {
 	settings, _ := n.Datastore.Settings().Get()
 	bufferPercent := float32(0)
@@ -0,0 +1,216 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/golang/protobuf/ptypes"
+)
+
+/* Vendors can automate their sales with order rules kept in their settings. Reject rules are
+   applied when an order arrives, accept rules confirm offline orders once they are paid and
+   fulfill rules fulfill paid orders of services and digital goods. Every action taken is
+   recorded on the sale. */
+
+const (
+	OrderRuleAccept  = "ACCEPT"
+	OrderRuleReject  = "REJECT"
+	OrderRuleFulfill = "FULFILL"
+)
+
+// Serializes the automatic confirmation and fulfillment of sales so none is processed twice
+var autoProcessLock sync.Mutex
+
+// Check the order rules a vendor is saving in their settings
+func ValidateOrderRules(rules []repo.OrderRule) error {
+	for _, rule := range rules {
+		switch rule.Action {
+		case OrderRuleAccept, OrderRuleReject:
+		case OrderRuleFulfill:
+			if len(rule.ContractTypes) == 0 {
+				return errors.New("Fulfill rules must be limited to services or digital goods")
+			}
+			for _, ct := range rule.ContractTypes {
+				if ct != pb.Listing_Metadata_SERVICE.String() && ct != pb.Listing_Metadata_DIGITAL_GOOD.String() {
+					return errors.New("Fulfill rules must be limited to services or digital goods")
+				}
+			}
+		default:
+			return errors.New("Unknown order rule action: " + rule.Action)
+		}
+		for _, ct := range rule.ContractTypes {
+			if _, ok := pb.Listing_Metadata_ContractType_value[ct]; !ok {
+				return errors.New("Unknown contract type in order rule: " + ct)
+			}
+		}
+		for _, country := range append(append([]string{}, rule.ShipsTo...), rule.NotShipsTo...) {
+			if _, ok := pb.CountryCode_value[country]; !ok {
+				return errors.New("Unknown country in order rule: " + country)
+			}
+		}
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the most an order paid with the given coin may total for the rule to match it.
+// Returns false if the rule limits totals but not for this coin.
+func orderRuleMaxTotal(rule repo.OrderRule, coin, primaryCoin string) (uint64, bool) {
+	if coin == "" {
+		coin = primaryCoin
+	}
+	for code, limit := range rule.MaxTotals {
+		if strings.EqualFold(code, coin) {
+			return limit, true
+		}
+	}
+	if strings.EqualFold(coin, primaryCoin) {
+		return rule.MaxTotal, true
+	}
+	return 0, rule.MaxTotal == 0 && len(rule.MaxTotals) == 0
+}
+
+// Check whether a rule applies to an order. primaryCoin is the currency code of our
+// primary wallet, which the rule's MaxTotal is in.
+func orderRuleMatches(rule repo.OrderRule, contract *pb.RicardianContract, primaryCoin string) bool {
+	if !rule.Enabled {
+		return false
+	}
+	if len(rule.ContractTypes) > 0 {
+		for _, listing := range contract.VendorListings {
+			if !containsString(rule.ContractTypes, listing.Metadata.ContractType.String()) {
+				return false
+			}
+		}
+	}
+	// Totals are compared in the coin the order is paid with, so each coin has its own limit
+	maxTotal, ok := orderRuleMaxTotal(rule, ContractCoin(contract), primaryCoin)
+	if !ok || (maxTotal > 0 && contract.BuyerOrder.Payment.Amount > maxTotal) {
+		return false
+	}
+	if len(rule.ShipsTo) > 0 || len(rule.NotShipsTo) > 0 {
+		if contract.BuyerOrder.Shipping == nil {
+			return false
+		}
+		country := contract.BuyerOrder.Shipping.Country.String()
+		if len(rule.ShipsTo) > 0 && !containsString(rule.ShipsTo, country) {
+			return false
+		}
+		if containsString(rule.NotShipsTo, country) {
+			return false
+		}
+	}
+	return true
+}
+
+// Return the first of our order rules taking one of the given actions which applies to the order
+func (n *OpenBazaarNode) MatchOrderRule(contract *pb.RicardianContract, actions ...string) *repo.OrderRule {
+	settings, err := n.Datastore.Settings().Get()
+	if err != nil || settings.OrderRules == nil {
+		return nil
+	}
+	for _, rule := range *settings.OrderRules {
+		if containsString(actions, rule.Action) && orderRuleMatches(rule, contract, n.Wallet.CurrencyCode()) {
+			r := rule
+			return &r
+		}
+	}
+	return nil
+}
+
+func (n *OpenBazaarNode) recordRuleDecision(orderID string, rule *repo.OrderRule, actionErr error) {
+	ts, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	decision := &pb.OrderRuleDecision{
+		Rule:      rule.Name,
+		Action:    rule.Action,
+		Timestamp: ts,
+	}
+	if actionErr != nil {
+		decision.Error = actionErr.Error()
+		log.Errorf("Order rule %q failed to %s order %s: %s", rule.Name, rule.Action, orderID, actionErr)
+	}
+	if err := n.Datastore.Sales().AddRuleDecision(orderID, decision); err != nil {
+		log.Error(err)
+	}
+}
+
+// Apply our reject rules to an order as it arrives. Orders sent while we were offline are
+// recorded and rejected; for any other order the caller should return an error to the buyer.
+// Returns the rule which rejected the order, or nil if it wasn't rejected.
+func (n *OpenBazaarNode) RejectOrderByRule(contract *pb.RicardianContract, offline bool) *repo.OrderRule {
+	rule := n.MatchOrderRule(contract, OrderRuleAccept, OrderRuleReject)
+	if rule == nil || rule.Action != OrderRuleReject {
+		return nil
+	}
+	orderID, err := n.CalcOrderId(contract.BuyerOrder)
+	if err != nil {
+		log.Error(err)
+		return rule
+	}
+	if !offline || contract.BuyerOrder.Payment.Method == pb.Order_Payment_ADDRESS_REQUEST {
+		log.Infof("Order %s rejected by order rule %q", orderID, rule.Name)
+		return rule
+	}
+	if err := n.Datastore.Sales().Put(orderID, *contract, pb.OrderState_PENDING, false); err != nil {
+		log.Error(err)
+		return rule
+	}
+	n.recordRuleDecision(orderID, rule, n.RejectOfflineOrder(contract, nil))
+	return rule
+}
+
+// Apply our accept and fulfill rules to a sale which has just been paid. Digital goods set
+// to auto-fulfill are delivered as well.
+func (n *OpenBazaarNode) ProcessFundedSale(orderID string) {
+	autoProcessLock.Lock()
+	defer autoProcessLock.Unlock()
+
+	contract, state, funded, records, _, err := n.Datastore.Sales().GetByOrderId(orderID)
+	if err != nil || !funded {
+		return
+	}
+	if state == pb.OrderState_PENDING {
+		if rule := n.MatchOrderRule(contract, OrderRuleAccept, OrderRuleReject); rule != nil && rule.Action == OrderRuleAccept {
+			err := n.ConfirmOfflineOrder(contract, records)
+			n.recordRuleDecision(orderID, rule, err)
+			if err != nil {
+				return
+			}
+			contract, state, _, records, _, err = n.Datastore.Sales().GetByOrderId(orderID)
+			if err != nil {
+				return
+			}
+		}
+	}
+	if state == pb.OrderState_FUNDED {
+		if rule := n.MatchOrderRule(contract, OrderRuleFulfill); rule != nil {
+			var err error
+			for _, listing := range contract.VendorListings {
+				if err = n.FulfillOrder(&pb.OrderFulfillment{OrderId: orderID, Slug: listing.Slug}, contract, records); err != nil {
+					break
+				}
+			}
+			n.recordRuleDecision(orderID, rule, err)
+			return
+		}
+	}
+	if err := n.autoFulfillOrder(orderID); err != nil {
+		log.Errorf("Error delivering digital goods for order %s: %s", orderID, err)
+	}
+}
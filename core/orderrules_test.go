@@ -0,0 +1,100 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+func ruleContract(contractType pb.Listing_Metadata_ContractType, amount uint64, country pb.CountryCode) *pb.RicardianContract {
+	return &pb.RicardianContract{
+		VendorListings: []*pb.Listing{
+			{Metadata: &pb.Listing_Metadata{ContractType: contractType}},
+		},
+		BuyerOrder: &pb.Order{
+			Payment:  &pb.Order_Payment{Amount: amount},
+			Shipping: &pb.Order_Shipping{Country: country},
+		},
+	}
+}
+
+func TestValidateOrderRules(t *testing.T) {
+	valid := []repo.OrderRule{
+		{Action: OrderRuleAccept, MaxTotal: 10000},
+		{Action: OrderRuleReject, NotShipsTo: []string{"UNITED_STATES", "CANADA"}},
+		{Action: OrderRuleFulfill, ContractTypes: []string{"SERVICE", "DIGITAL_GOOD"}},
+	}
+	if err := ValidateOrderRules(valid); err != nil {
+		t.Error(err)
+	}
+	invalid := []repo.OrderRule{
+		{Action: "SHIP"},
+		{Action: OrderRuleFulfill},
+		{Action: OrderRuleFulfill, ContractTypes: []string{"PHYSICAL_GOOD"}},
+		{Action: OrderRuleAccept, ContractTypes: []string{"EBOOK"}},
+		{Action: OrderRuleReject, ShipsTo: []string{"ATLANTIS"}},
+	}
+	for _, rule := range invalid {
+		if err := ValidateOrderRules([]repo.OrderRule{rule}); err == nil {
+			t.Errorf("Expected rule %+v to be invalid", rule)
+		}
+	}
+}
+
+func TestOrderRuleMatches(t *testing.T) {
+	small := repo.OrderRule{Enabled: true, Action: OrderRuleAccept, MaxTotal: 10000}
+	if !orderRuleMatches(small, ruleContract(pb.Listing_Metadata_PHYSICAL_GOOD, 10000, pb.CountryCode_UNITED_STATES), "BTC") {
+		t.Error("Order at the maximum total should match")
+	}
+	if orderRuleMatches(small, ruleContract(pb.Listing_Metadata_PHYSICAL_GOOD, 10001, pb.CountryCode_UNITED_STATES), "BTC") {
+		t.Error("Order above the maximum total should not match")
+	}
+	small.Enabled = false
+	if orderRuleMatches(small, ruleContract(pb.Listing_Metadata_PHYSICAL_GOOD, 1, pb.CountryCode_UNITED_STATES), "BTC") {
+		t.Error("Disabled rules should not match")
+	}
+
+	unsupported := repo.OrderRule{Enabled: true, Action: OrderRuleReject, NotShipsTo: []string{"UNITED_STATES", "CANADA"}}
+	if orderRuleMatches(unsupported, ruleContract(pb.Listing_Metadata_PHYSICAL_GOOD, 1, pb.CountryCode_CANADA), "BTC") {
+		t.Error("Order to a supported country should not match")
+	}
+	if !orderRuleMatches(unsupported, ruleContract(pb.Listing_Metadata_PHYSICAL_GOOD, 1, pb.CountryCode_GERMANY), "BTC") {
+		t.Error("Order to an unsupported country should match")
+	}
+
+	services := repo.OrderRule{Enabled: true, Action: OrderRuleFulfill, ContractTypes: []string{"SERVICE"}}
+	if !orderRuleMatches(services, ruleContract(pb.Listing_Metadata_SERVICE, 1, pb.CountryCode_NA), "BTC") {
+		t.Error("Service order should match")
+	}
+	contract := ruleContract(pb.Listing_Metadata_SERVICE, 1, pb.CountryCode_NA)
+	contract.VendorListings = append(contract.VendorListings, &pb.Listing{Metadata: &pb.Listing_Metadata{ContractType: pb.Listing_Metadata_PHYSICAL_GOOD}})
+	if orderRuleMatches(services, contract, "BTC") {
+		t.Error("Order containing a physical good should not match")
+	}
+}
+
+func TestOrderRuleMaxTotalPerCoin(t *testing.T) {
+	rule := repo.OrderRule{Enabled: true, Action: OrderRuleAccept, MaxTotal: 10000, MaxTotals: map[string]uint64{"LTC": 500000}}
+	contract := ruleContract(pb.Listing_Metadata_PHYSICAL_GOOD, 400000, pb.CountryCode_UNITED_STATES)
+	if orderRuleMatches(rule, contract, "BTC") {
+		t.Error("Order without a coin should be held to the primary coin's limit")
+	}
+	contract.BuyerOrder.Payment.Coin = "ltc"
+	if !orderRuleMatches(rule, contract, "BTC") {
+		t.Error("Order paid in LTC should be held to the LTC limit")
+	}
+	contract.BuyerOrder.Payment.Amount = 500001
+	if orderRuleMatches(rule, contract, "BTC") {
+		t.Error("Order above the LTC limit should not match")
+	}
+	contract.BuyerOrder.Payment.Coin = "BCH"
+	contract.BuyerOrder.Payment.Amount = 1
+	if orderRuleMatches(rule, contract, "BTC") {
+		t.Error("Order paid with a coin the rule has no limit for should not match")
+	}
+	unlimited := repo.OrderRule{Enabled: true, Action: OrderRuleAccept}
+	if !orderRuleMatches(unlimited, contract, "BTC") {
+		t.Error("Rule without limits should match any coin")
+	}
+}
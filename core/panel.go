@@ -0,0 +1,497 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	libp2p "gx/ipfs/QmPGxZ1DP2w45WcogpW1h43BvseXbfke9N91qotpoQcUeS/go-libp2p-crypto"
+
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/txscript"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+	ipfspath "github.com/ipfs/go-ipfs/path"
+)
+
+// Return the moderators of an order's escrow. For a panel escrow the first of them is the
+// moderator who coordinates disputes and proposes the resolution.
+func OrderModerators(payment *pb.Order_Payment) []string {
+	if len(payment.ModeratorPanel) > 0 {
+		return payment.ModeratorPanel
+	}
+	if payment.Moderator != "" {
+		return []string{payment.Moderator}
+	}
+	return nil
+}
+
+func isOrderModerator(payment *pb.Order_Payment, peerID string) bool {
+	for _, mod := range OrderModerators(payment) {
+		if mod == peerID {
+			return true
+		}
+	}
+	return false
+}
+
+// Check the moderator panel chosen for an order can be used with its listings
+func validateModeratorPanel(contract *pb.RicardianContract) error {
+	payment := contract.BuyerOrder.Payment
+	panel := payment.ModeratorPanel
+	if len(panel) == 0 {
+		return nil
+	}
+	if IsCrowdFund(contract) {
+		return errors.New("Crowdfund pledges cannot use a moderator panel")
+	}
+	if len(panel) < 2 || len(panel) > bitcoin.MaxPanelSize {
+		return fmt.Errorf("A moderator panel must have between 2 and %d moderators", bitcoin.MaxPanelSize)
+	}
+	if payment.Moderator != panel[0] {
+		return errors.New("The order's moderator must be the first member of the panel")
+	}
+	available := make(map[string]bool)
+	for _, listing := range contract.VendorListings {
+		for _, mod := range listing.Moderators {
+			available[mod] = true
+		}
+	}
+	seen := make(map[string]bool)
+	for _, mod := range panel {
+		if seen[mod] {
+			return errors.New("Moderator panel contains duplicate moderators")
+		}
+		seen[mod] = true
+		if !available[mod] {
+			return errors.New("Invalid moderator")
+		}
+	}
+	return nil
+}
+
+// Derive the escrow key of the given master public key for an order's chaincode
+func escrowPublicKey(wal bitcoin.BitcoinWallet, masterPubKey []byte, chaincode []byte) (*hd.ExtendedKey, error) {
+	hdKey := hd.NewExtendedKey(
+		wal.Params().HDPublicKeyID[:],
+		masterPubKey,
+		chaincode,
+		[]byte{0x00, 0x00, 0x00, 0x00},
+		0,
+		0,
+		false)
+	return hdKey.Child(0)
+}
+
+// Derive our private escrow key for an order's chaincode
+func escrowSigningKey(wal bitcoin.BitcoinWallet, chaincode []byte) (*hd.ExtendedKey, error) {
+	mECKey, err := wal.MasterPrivateKey().ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+	hdKey := hd.NewExtendedKey(
+		wal.Params().HDPrivateKeyID[:],
+		mECKey.Serialize(),
+		chaincode,
+		[]byte{0x00, 0x00, 0x00, 0x00},
+		0,
+		0,
+		true)
+	return hdKey.Child(0)
+}
+
+// Generate the escrow address of an order moderated by a panel. If checkModerators is set
+// every member must currently be a moderator accepting the wallet's coin.
+func (n *OpenBazaarNode) panelEscrowScript(wal bitcoin.BitcoinWallet, buyerKey, vendorKey hd.ExtendedKey, panel []string, chaincode []byte, timeout uint32, checkModerators bool) (btc.Address, []byte, error) {
+	var moderatorKeys []hd.ExtendedKey
+	for _, mod := range panel {
+		var masterKey []byte
+		if mod == n.IpfsNode.Identity.Pretty() && !checkModerators {
+			mECKey, err := wal.MasterPublicKey().ECPubKey()
+			if err != nil {
+				return nil, nil, err
+			}
+			masterKey = mECKey.SerializeCompressed()
+		} else {
+			profileBytes, err := ipfs.ResolveThenCat(n.Context, ipfspath.FromString(mod+"/profile"))
+			if err != nil {
+				return nil, nil, fmt.Errorf("Moderator %s could not be found", mod)
+			}
+			profile := new(pb.Profile)
+			if err := jsonpb.UnmarshalString(string(profileBytes), profile); err != nil {
+				return nil, nil, err
+			}
+			if checkModerators && (!profile.Moderator || !moderatorAcceptsCurrency(profile.ModeratorInfo, wal.CurrencyCode())) {
+				return nil, nil, fmt.Errorf("Moderator %s is not capable of moderating this transaction", mod)
+			}
+			masterKey, err = hex.DecodeString(profile.BitcoinPubkey)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		key, err := escrowPublicKey(wal, masterKey, chaincode)
+		if err != nil {
+			return nil, nil, err
+		}
+		moderatorKeys = append(moderatorKeys, *key)
+	}
+	return bitcoin.PanelEscrowScript(buyerKey, vendorKey, moderatorKeys, timeout, wal.Params())
+}
+
+// Rebuild the inputs and outputs of a dispute payout
+func payoutTransaction(payout *pb.DisputeResolution_Payout) ([]spvwallet.TransactionInput, []spvwallet.TransactionOutput, error) {
+	var inputs []spvwallet.TransactionInput
+	for _, o := range payout.Inputs {
+		decodedHash, err := hex.DecodeString(o.Hash)
+		if err != nil {
+			return nil, nil, err
+		}
+		inputs = append(inputs, spvwallet.TransactionInput{
+			OutpointHash:  decodedHash,
+			OutpointIndex: o.Index,
+		})
+	}
+	if len(inputs) == 0 {
+		return nil, nil, errors.New("Transaction has no inputs")
+	}
+	var outputs []spvwallet.TransactionOutput
+	for _, out := range []*pb.DisputeResolution_Payout_Output{payout.BuyerOutput, payout.VendorOutput, payout.ModeratorOutput} {
+		if out == nil {
+			continue
+		}
+		decodedScript, err := hex.DecodeString(out.Script)
+		if err != nil {
+			return nil, nil, err
+		}
+		outputs = append(outputs, spvwallet.TransactionOutput{
+			ScriptPubKey: decodedScript,
+			Value:        int64(out.Amount),
+		})
+	}
+	return inputs, outputs, nil
+}
+
+// Check the payout of a resolution proposed by the coordinating moderator of a panel only
+// spends the order's escrow, pays the buyer and vendor at their payout addresses and takes
+// no more than the coordinating moderator's fee and the network fee.
+func (n *OpenBazaarNode) validatePanelPayout(wal bitcoin.BitcoinWallet, contract *pb.RicardianContract, payout *pb.DisputeResolution_Payout, buyerAddress, vendorAddress string, outpoints []*pb.Outpoint) error {
+	escrow := make(map[string]uint64)
+	for _, o := range outpoints {
+		escrow[o.Hash+":"+strconv.Itoa(int(o.Index))] = o.Value
+	}
+	var totalIn uint64
+	for _, in := range payout.Inputs {
+		key := in.Hash + ":" + strconv.Itoa(int(in.Index))
+		value, ok := escrow[key]
+		if !ok {
+			return errors.New("Payout spends coins which are not in the order's escrow")
+		}
+		delete(escrow, key)
+		totalIn += value
+	}
+	checkOutput := func(out *pb.DisputeResolution_Payout_Output, address, party string) error {
+		if out == nil {
+			return nil
+		}
+		addr, err := btc.DecodeAddress(address, wal.Params())
+		if err != nil {
+			return fmt.Errorf("Payout pays the %s without a valid payout address", party)
+		}
+		script, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return err
+		}
+		if out.Script != hex.EncodeToString(script) {
+			return fmt.Errorf("Payout does not pay the %s's payout address", party)
+		}
+		return nil
+	}
+	if err := checkOutput(payout.BuyerOutput, buyerAddress, "buyer"); err != nil {
+		return err
+	}
+	if err := checkOutput(payout.VendorOutput, vendorAddress, "vendor"); err != nil {
+		return err
+	}
+	var totalOut uint64
+	for _, out := range []*pb.DisputeResolution_Payout_Output{payout.BuyerOutput, payout.VendorOutput, payout.ModeratorOutput} {
+		if out != nil {
+			totalOut += out.Amount
+		}
+	}
+	if totalOut > totalIn {
+		return errors.New("Payout spends more than the escrow holds")
+	}
+	if payout.ModeratorOutput != nil {
+		profile, err := n.FetchProfile(contract.BuyerOrder.Payment.Moderator)
+		if err != nil {
+			return err
+		}
+		fee, err := n.moderatorFee(&profile, totalIn)
+		if err != nil {
+			return err
+		}
+		if payout.ModeratorOutput.Amount > fee {
+			return errors.New("Payout pays the coordinating moderator more than its fee")
+		}
+	}
+
+	// The coordinating moderator pays the fee rate the buyer or vendor asked for, so allow
+	// the highest of those and our own priority rate, plus a satoshi per output for rounding
+	inputs, outputs, err := payoutTransaction(payout)
+	if err != nil {
+		return err
+	}
+	feePerByte := wal.GetFeePerByte(spvwallet.PRIOIRTY)
+	if contract.BuyerOrder.RefundFee > feePerByte {
+		feePerByte = contract.BuyerOrder.RefundFee
+	}
+	for _, f := range contract.VendorOrderFulfillment {
+		if f.Payout != nil && f.Payout.PayoutFeePerByte > feePerByte {
+			feePerByte = f.Payout.PayoutFeePerByte
+		}
+	}
+	if totalIn-totalOut > wal.EstimateFee(inputs, outputs, feePerByte)+uint64(len(outputs)) {
+		return errors.New("Payout pays too high a network fee")
+	}
+	return nil
+}
+
+// Check the signatures a panel moderator has made on a dispute payout with its escrow key
+func verifyPanelPayoutSignatures(contract *pb.RicardianContract, moderator string, sigs []*pb.BitcoinSignature) error {
+	payment := contract.BuyerOrder.Payment
+	index := -1
+	for i, mod := range payment.ModeratorPanel {
+		if mod == moderator {
+			index = i
+		}
+	}
+	if index < 0 {
+		return errors.New("Moderator is not on the order's panel")
+	}
+	redeemScript, err := hex.DecodeString(payment.RedeemScript)
+	if err != nil {
+		return err
+	}
+	keys, err := bitcoin.PanelModeratorKeys(redeemScript, len(payment.ModeratorPanel))
+	if err != nil {
+		return err
+	}
+	inputs, outputs, err := payoutTransaction(contract.DisputeResolution.Payout)
+	if err != nil {
+		return err
+	}
+	var payoutSigs []spvwallet.Signature
+	for _, sig := range sigs {
+		payoutSigs = append(payoutSigs, spvwallet.Signature{InputIndex: sig.InputIndex, Signature: sig.Signature})
+	}
+	return bitcoin.VerifyPayoutSignatures(inputs, outputs, payoutSigs, keys[index], redeemScript)
+}
+
+// Return the signatures each panel moderator has made on a dispute payout, in panel order.
+// The coordinating moderator's signatures come with the resolution and the others' with
+// their endorsements.
+func panelPayoutSignatures(contract *pb.RicardianContract) [][]spvwallet.Signature {
+	toSignatures := func(sigs []*pb.BitcoinSignature) []spvwallet.Signature {
+		var ret []spvwallet.Signature
+		for _, sig := range sigs {
+			ret = append(ret, spvwallet.Signature{InputIndex: sig.InputIndex, Signature: sig.Signature})
+		}
+		return ret
+	}
+	panel := contract.BuyerOrder.Payment.ModeratorPanel
+	sigs := make([][]spvwallet.Signature, len(panel))
+	sigs[0] = toSignatures(contract.DisputeResolution.Payout.Sigs)
+	for i, mod := range panel[1:] {
+		for _, e := range contract.DisputeEndorsements {
+			if e.Moderator == mod {
+				sigs[i+1] = toSignatures(e.Sigs)
+			}
+		}
+	}
+	return sigs
+}
+
+// Return the number of panel moderators who have signed the dispute payout
+func countPanelSignatures(sigs [][]spvwallet.Signature) int {
+	count := 0
+	for _, s := range sigs {
+		if len(s) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// Save a resolution proposed by the coordinating moderator of a panel so we can review
+// and endorse it
+func (n *OpenBazaarNode) ProcessPanelResolution(rc *pb.RicardianContract, peerID string) error {
+	if rc.DisputeResolution == nil {
+		return errors.New("Dispute resolution is nil")
+	}
+	orderID := rc.DisputeResolution.OrderId
+	buyerContract, vendorContract, _, _, state, _, _, _, _, _, err := n.Datastore.Cases().GetCaseMetadata(orderID)
+	if err != nil {
+		return ErrCaseNotFound
+	}
+	contract := buyerContract
+	if contract == nil {
+		contract = vendorContract
+	}
+	if contract == nil || contract.BuyerOrder == nil || contract.BuyerOrder.Payment == nil {
+		return errors.New("Case is missing the order")
+	}
+	payment := contract.BuyerOrder.Payment
+	if len(payment.ModeratorPanel) == 0 || !isOrderModerator(payment, n.IpfsNode.Identity.Pretty()) {
+		return errors.New("We are not on the moderator panel of this order")
+	}
+	if peerID != payment.Moderator {
+		return errors.New("Only the coordinating moderator can propose a resolution")
+	}
+	if state != pb.OrderState_DISPUTED {
+		return errors.New("A dispute for this order is not open")
+	}
+	proposal := &pb.RicardianContract{
+		BuyerOrder:        contract.BuyerOrder,
+		DisputeResolution: rc.DisputeResolution,
+		Signatures:        rc.Signatures,
+	}
+	if err := n.verifySignatureOnDisputeResolution(proposal); err != nil {
+		return err
+	}
+	if rc.DisputeResolution.Payout == nil {
+		return errors.New("DisputeResolution contains invalid payout")
+	}
+	if err := n.Datastore.Cases().MarkAsDecided(orderID, rc.DisputeResolution); err != nil {
+		return err
+	}
+	notif := notifications.DisputeUpdateNotification{OrderId: orderID}
+	n.Broadcast <- notif
+	n.Datastore.Notifications().Put(notif, time.Now())
+	return nil
+}
+
+// Sign the payout of the resolution proposed by the coordinating moderator of a panel
+// and send the signatures to the buyer and vendor
+func (n *OpenBazaarNode) EndorseDisputeResolution(orderID string) error {
+	buyerContract, vendorContract, _, _, state, _, _, _, _, resolution, err := n.Datastore.Cases().GetCaseMetadata(orderID)
+	if err != nil {
+		return ErrCaseNotFound
+	}
+	if state != pb.OrderState_DECIDED || resolution == nil || resolution.Payout == nil {
+		return errors.New("No resolution is waiting to be endorsed")
+	}
+	contract := buyerContract
+	if contract == nil {
+		contract = vendorContract
+	}
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
+	}
+	_, _, buyerAddress, vendorAddress, buyerOutpoints, vendorOutpoints, _, err := n.Datastore.Cases().GetPayoutDetails(orderID)
+	if err != nil {
+		return err
+	}
+	if err := n.validatePanelPayout(wal, contract, resolution.Payout, buyerAddress, vendorAddress, append(buyerOutpoints, vendorOutpoints...)); err != nil {
+		return err
+	}
+	inputs, outputs, err := payoutTransaction(resolution.Payout)
+	if err != nil {
+		return err
+	}
+	chaincode, err := hex.DecodeString(contract.BuyerOrder.Payment.Chaincode)
+	if err != nil {
+		return err
+	}
+	signingKey, err := escrowSigningKey(wal, chaincode)
+	if err != nil {
+		return err
+	}
+	redeemScript, err := hex.DecodeString(contract.BuyerOrder.Payment.RedeemScript)
+	if err != nil {
+		return err
+	}
+	sigs, err := wal.CreateMultisigSignature(inputs, outputs, signingKey, redeemScript, 0)
+	if err != nil {
+		return err
+	}
+	endorsement := &pb.DisputeEndorsement{
+		OrderId:   orderID,
+		Moderator: n.IpfsNode.Identity.Pretty(),
+	}
+	for _, sig := range sigs {
+		endorsement.Sigs = append(endorsement.Sigs, &pb.BitcoinSignature{InputIndex: sig.InputIndex, Signature: sig.Signature})
+	}
+
+	for _, id := range []*pb.ID{contract.BuyerOrder.BuyerID, contract.VendorListings[0].VendorID} {
+		k, err := libp2p.UnmarshalPublicKey(id.Pubkeys.Identity)
+		if err != nil {
+			return err
+		}
+		if err := n.SendDisputeEndorsement(id.PeerID, &k, endorsement); err != nil {
+			return err
+		}
+	}
+
+	if err := n.Datastore.Cases().MarkAsClosed(orderID, resolution); err != nil {
+		return err
+	}
+	if err := n.updateProfileCounts(); err != nil {
+		log.Errorf("Failed to update profile counts: %s", err.Error())
+	}
+	return nil
+}
+
+// Save the payout signatures a panel moderator has endorsed a dispute resolution with
+func (n *OpenBazaarNode) ProcessDisputeEndorsement(endorsement *pb.DisputeEndorsement, peerID string) error {
+	var store orderStore = n.Datastore.Sales()
+	contract, state, _, _, _, err := store.GetByOrderId(endorsement.OrderId)
+	if err != nil {
+		store = n.Datastore.Purchases()
+		contract, state, _, _, _, err = store.GetByOrderId(endorsement.OrderId)
+		if err != nil {
+			return err
+		}
+	}
+	payment := contract.BuyerOrder.Payment
+	if len(payment.ModeratorPanel) == 0 || !isOrderModerator(payment, peerID) || peerID == payment.Moderator {
+		return errors.New("Endorsement is not from a moderator on the order's panel")
+	}
+	if len(endorsement.Sigs) == 0 {
+		return errors.New("Endorsement contains no signatures")
+	}
+	if contract.DisputeResolution == nil || contract.DisputeResolution.Payout == nil {
+		return errors.New("No dispute resolution has been received for the endorsement")
+	}
+	if err := verifyPanelPayoutSignatures(contract, peerID, endorsement.Sigs); err != nil {
+		return err
+	}
+	endorsement.Moderator = peerID
+	var endorsements []*pb.DisputeEndorsement
+	for _, e := range contract.DisputeEndorsements {
+		if e.Moderator != peerID {
+			endorsements = append(endorsements, e)
+		}
+	}
+	contract.DisputeEndorsements = append(endorsements, endorsement)
+	if err := store.Put(endorsement.OrderId, *contract, state, false); err != nil {
+		return err
+	}
+
+	notif := notifications.DisputeEndorsementNotification{
+		OrderId:    endorsement.OrderId,
+		Moderator:  peerID,
+		Signatures: len(contract.DisputeEndorsements) + 1,
+		Required:   bitcoin.PanelMajority(len(payment.ModeratorPanel)),
+	}
+	n.Broadcast <- notif
+	n.Datastore.Notifications().Put(notif, time.Now())
+	return nil
+}
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+)
+
+func panelContract(moderator string, panel []string) *pb.RicardianContract {
+	return &pb.RicardianContract{
+		VendorListings: []*pb.Listing{
+			{
+				Metadata:   &pb.Listing_Metadata{ContractType: pb.Listing_Metadata_PHYSICAL_GOOD},
+				Moderators: []string{"mod1", "mod2", "mod3"},
+			},
+		},
+		BuyerOrder: &pb.Order{
+			Payment: &pb.Order_Payment{Moderator: moderator, ModeratorPanel: panel},
+		},
+	}
+}
+
+func TestValidateModeratorPanel(t *testing.T) {
+	if err := validateModeratorPanel(panelContract("mod1", nil)); err != nil {
+		t.Error("An order without a panel should be valid")
+	}
+	if err := validateModeratorPanel(panelContract("mod1", []string{"mod1", "mod2", "mod3"})); err != nil {
+		t.Error(err)
+	}
+	if err := validateModeratorPanel(panelContract("mod1", []string{"mod1"})); err == nil {
+		t.Error("A panel needs more than one moderator")
+	}
+	if err := validateModeratorPanel(panelContract("mod2", []string{"mod1", "mod2"})); err == nil {
+		t.Error("The order's moderator must coordinate the panel")
+	}
+	if err := validateModeratorPanel(panelContract("mod1", []string{"mod1", "mod1"})); err == nil {
+		t.Error("A panel can't contain duplicate moderators")
+	}
+	if err := validateModeratorPanel(panelContract("mod1", []string{"mod1", "mod4"})); err == nil {
+		t.Error("Panel moderators must be offered by the listing")
+	}
+}
+
+func TestPanelPayoutSignatures(t *testing.T) {
+	contract := panelContract("mod1", []string{"mod1", "mod2", "mod3"})
+	contract.DisputeResolution = &pb.DisputeResolution{
+		Payout: &pb.DisputeResolution_Payout{
+			Sigs: []*pb.BitcoinSignature{{InputIndex: 0, Signature: []byte{0x01}}},
+		},
+	}
+	contract.DisputeEndorsements = []*pb.DisputeEndorsement{
+		{Moderator: "mod3", Sigs: []*pb.BitcoinSignature{{InputIndex: 0, Signature: []byte{0x03}}}},
+	}
+	sigs := panelPayoutSignatures(contract)
+	if len(sigs) != 3 {
+		t.Fatal("Expected signatures for each panel moderator")
+	}
+	if sigs[0][0].Signature[0] != 0x01 || sigs[1] != nil || sigs[2][0].Signature[0] != 0x03 {
+		t.Error("Signatures are not in panel order")
+	}
+	if countPanelSignatures(sigs) != 2 {
+		t.Error("Expected two moderators to have signed")
+	}
+}
+
+type panelTestWallet struct {
+	refundTestWallet
+}
+
+func (w *panelTestWallet) GetFeePerByte(feeLevel spvwallet.FeeLevel) uint64 {
+	return 100
+}
+
+func panelTestScript(t *testing.T, address string) string {
+	addr, err := btcutil.DecodeAddress(address, &chaincfg.TestNet3Params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(script)
+}
+
+func TestValidatePanelPayout(t *testing.T) {
+	n := &OpenBazaarNode{}
+	wal := &panelTestWallet{refundTestWallet{fee: 2000}}
+	contract := panelContract("mod1", []string{"mod1", "mod2", "mod3"})
+	buyerAddress, vendorAddress := refundTestAddress(t, 1), refundTestAddress(t, 2)
+	escrow := []*pb.Outpoint{{Hash: hex.EncodeToString(bytes.Repeat([]byte{0xaa}, 32)), Index: 0, Value: 60000}, {Hash: hex.EncodeToString(bytes.Repeat([]byte{0xbb}, 32)), Index: 1, Value: 40000}}
+	payout := func(buyerAmount uint64, buyerAddress string, inputs ...*pb.Outpoint) *pb.DisputeResolution_Payout {
+		return &pb.DisputeResolution_Payout{
+			Inputs:       inputs,
+			BuyerOutput:  &pb.DisputeResolution_Payout_Output{Script: panelTestScript(t, buyerAddress), Amount: buyerAmount},
+			VendorOutput: &pb.DisputeResolution_Payout_Output{Script: panelTestScript(t, vendorAddress), Amount: 30000},
+		}
+	}
+	if err := n.validatePanelPayout(wal, contract, payout(68000, buyerAddress, escrow...), buyerAddress, vendorAddress, escrow); err != nil {
+		t.Error(err)
+	}
+	if err := n.validatePanelPayout(wal, contract, payout(68000, refundTestAddress(t, 3), escrow...), buyerAddress, vendorAddress, escrow); err == nil {
+		t.Error("Accepted a payout to an address other than the buyer's")
+	}
+	other := &pb.Outpoint{Hash: hex.EncodeToString(bytes.Repeat([]byte{0xcc}, 32)), Index: 0, Value: 60000}
+	if err := n.validatePanelPayout(wal, contract, payout(68000, buyerAddress, escrow[1], other), buyerAddress, vendorAddress, escrow); err == nil {
+		t.Error("Accepted a payout spending coins outside the escrow")
+	}
+	if err := n.validatePanelPayout(wal, contract, payout(8000, buyerAddress, escrow[1], escrow[1]), buyerAddress, vendorAddress, escrow); err == nil {
+		t.Error("Accepted a payout spending an escrow input twice")
+	}
+	if err := n.validatePanelPayout(wal, contract, payout(71000, buyerAddress, escrow...), buyerAddress, vendorAddress, escrow); err == nil {
+		t.Error("Accepted a payout spending more than the escrow holds")
+	}
+	if err := n.validatePanelPayout(wal, contract, payout(50000, buyerAddress, escrow...), buyerAddress, vendorAddress, escrow); err == nil {
+		t.Error("Accepted a payout with an excessive network fee")
+	}
+}
+
+func TestVerifyPanelPayoutSignatures(t *testing.T) {
+	var keys []*hd.ExtendedKey
+	for i := byte(1); i <= 5; i++ {
+		key, err := hd.NewMaster(bytes.Repeat([]byte{i}, 32), &chaincfg.TestNet3Params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+	_, redeemScript, err := bitcoin.PanelEscrowScript(*keys[0], *keys[1], []hd.ExtendedKey{*keys[2], *keys[3], *keys[4]}, 0, &chaincfg.TestNet3Params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract := panelContract("mod1", []string{"mod1", "mod2", "mod3"})
+	contract.BuyerOrder.Payment.RedeemScript = hex.EncodeToString(redeemScript)
+	contract.DisputeResolution = &pb.DisputeResolution{
+		Payout: &pb.DisputeResolution_Payout{
+			Inputs:      []*pb.Outpoint{{Hash: hex.EncodeToString(bytes.Repeat([]byte{0xaa}, 32)), Index: 0}},
+			BuyerOutput: &pb.DisputeResolution_Payout_Output{Script: panelTestScript(t, refundTestAddress(t, 1)), Amount: 90000},
+		},
+	}
+	sign := func(key *hd.ExtendedKey) []*pb.BitcoinSignature {
+		tx := new(wire.MsgTx)
+		ch, _ := chainhash.NewHashFromStr(contract.DisputeResolution.Payout.Inputs[0].Hash)
+		tx.TxIn = append(tx.TxIn, wire.NewTxIn(wire.NewOutPoint(ch, 0), []byte{}))
+		script, _ := hex.DecodeString(contract.DisputeResolution.Payout.BuyerOutput.Script)
+		tx.TxOut = append(tx.TxOut, wire.NewTxOut(90000, script))
+		signingKey, _ := key.ECPrivKey()
+		sig, err := txscript.RawTxInSignature(tx, 0, redeemScript, txscript.SigHashAll, signingKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []*pb.BitcoinSignature{{InputIndex: 0, Signature: sig}}
+	}
+	if err := verifyPanelPayoutSignatures(contract, "mod2", sign(keys[3])); err != nil {
+		t.Error(err)
+	}
+	if err := verifyPanelPayoutSignatures(contract, "mod3", sign(keys[3])); err == nil {
+		t.Error("Accepted another moderator's signature")
+	}
+	if err := verifyPanelPayoutSignatures(contract, "mod4", sign(keys[3])); err == nil {
+		t.Error("Accepted signatures from a moderator who is not on the panel")
+	}
+}
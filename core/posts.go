@@ -0,0 +1,486 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	multihash "gx/ipfs/QmbZ6Cee2uHjG7hf19qLHppgKDRtaG4CVtMzdmK9VCVqLu/go-multihash"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	ipnspath "github.com/ipfs/go-ipfs/path"
+	dht "github.com/ipfs/go-ipfs/routing/dht"
+	"github.com/kennygrant/sanitize"
+	"golang.org/x/net/context"
+)
+
+const (
+	PostTitleMaxCharacters    = 280
+	PostLongFormMaxCharacters = 50000
+	PostMaxChannels           = 10
+	PostMaxImages             = 30
+	ChannelMaxCharacters      = 40
+)
+
+// The maximum number of stores whose posts are fetched from the network at the same time
+const postFetchWorkers = 8
+
+// Channel names are used as file names so they are restricted to a safe alphabet
+var channelRegexp = regexp.MustCompile("^[a-z0-9-]+$")
+
+// PostData is the summary of a post kept in the feed and channel indexes. PeerId is
+// only set when posts from several stores are aggregated.
+type PostData struct {
+	PeerId    string    `json:"peerId,omitempty"`
+	Hash      string    `json:"hash"`
+	Slug      string    `json:"slug"`
+	PostType  string    `json:"postType"`
+	Title     string    `json:"title"`
+	Channels  []string  `json:"channels"`
+	Thumbnail thumbnail `json:"thumbnail"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type postsByTimestamp []PostData
+
+func (p postsByTimestamp) Len() int           { return len(p) }
+func (p postsByTimestamp) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p postsByTimestamp) Less(i, j int) bool { return p[i].Timestamp.After(p[j].Timestamp) }
+
+// Channels are matched case insensitively
+func normalizeChannel(channel string) string {
+	return strings.ToLower(strings.TrimSpace(channel))
+}
+
+// Return the multihash pointers to stores posting in the given channel are published at
+func ChannelPointerID(channel string) (multihash.Multihash, error) {
+	// Prefixed so a channel and a tag with the same name don't share a key
+	h, err := EncodeMultihash([]byte("channel:" + normalizeChannel(channel)))
+	if err != nil {
+		return nil, err
+	}
+	return *h, nil
+}
+
+func validateChannel(channel string) error {
+	if len(channel) == 0 || len(channel) > ChannelMaxCharacters {
+		return fmt.Errorf("Channel names must be between 1 and %d characters", ChannelMaxCharacters)
+	}
+	if !channelRegexp.MatchString(channel) {
+		return errors.New("Channel names may only contain letters, numbers and dashes")
+	}
+	return nil
+}
+
+// Slugs are used as file names in the feed directory alongside its index
+func validatePostSlug(slug string) error {
+	if slug == "" || slug == "index" || strings.ContainsAny(slug, "/\\") || strings.Contains(slug, "..") {
+		return errors.New("Invalid post slug")
+	}
+	return nil
+}
+
+func validatePost(post *pb.Post) error {
+	if err := validatePostSlug(post.Slug); err != nil {
+		return err
+	}
+	if post.Title == "" {
+		return errors.New("Post must have a title")
+	}
+	if len(post.Title) > PostTitleMaxCharacters {
+		return fmt.Errorf("Title is longer than the max of %d characters", PostTitleMaxCharacters)
+	}
+	if len(post.LongForm) > PostLongFormMaxCharacters {
+		return fmt.Errorf("Post is longer than the max of %d characters", PostLongFormMaxCharacters)
+	}
+	if len(post.Images) > PostMaxImages {
+		return fmt.Errorf("Number of post images is greater than the max of %d", PostMaxImages)
+	}
+	for _, img := range post.Images {
+		_, err := multihash.FromB58String(img.Tiny)
+		if err != nil {
+			return errors.New("Tiny image hashes must be multihashes")
+		}
+		_, err = multihash.FromB58String(img.Small)
+		if err != nil {
+			return errors.New("Small image hashes must be multihashes")
+		}
+		_, err = multihash.FromB58String(img.Medium)
+		if err != nil {
+			return errors.New("Medium image hashes must be multihashes")
+		}
+	}
+	if len(post.Channels) > PostMaxChannels {
+		return fmt.Errorf("Number of channels is greater than the max of %d", PostMaxChannels)
+	}
+	for _, channel := range post.Channels {
+		if err := validateChannel(channel); err != nil {
+			return err
+		}
+	}
+	if post.PostType == pb.Post_RESTOCK || post.PostType == pb.Post_NEW_LISTING {
+		if len(post.ListingSlugs) == 0 {
+			return errors.New("Restock and new listing posts must reference a listing")
+		}
+	}
+	return nil
+}
+
+func (n *OpenBazaarNode) postPath(slug string) string {
+	return path.Join(n.RepoPath, "root", "feed", slug+".json")
+}
+
+func (n *OpenBazaarNode) generatePostSlug(title string) string {
+	l := TitleMaxCharacters
+	if len(title) < TitleMaxCharacters {
+		l = len(title)
+	}
+	slugBase := url.QueryEscape(sanitize.Path(strings.ToLower(title[:l])))
+	slugToTry := slugBase
+	for counter := 1; ; counter++ {
+		if _, err := os.Stat(n.postPath(slugToTry)); os.IsNotExist(err) {
+			return slugToTry
+		}
+		slugToTry = slugBase + fmt.Sprintf("-%d", counter)
+	}
+}
+
+// Add our ID and a timestamp to the post and sign it with our identity key
+func (n *OpenBazaarNode) SignPost(post *pb.Post) (*pb.SignedPost, error) {
+	for i, channel := range post.Channels {
+		post.Channels[i] = normalizeChannel(channel)
+	}
+	if err := validatePost(post); err != nil {
+		return nil, err
+	}
+	for _, slug := range post.ListingSlugs {
+		if _, err := os.Stat(path.Join(n.RepoPath, "root", "listings", slug+".json")); os.IsNotExist(err) {
+			return nil, fmt.Errorf("Listing %s does not exist", slug)
+		}
+	}
+
+	id := new(pb.ID)
+	id.PeerID = n.IpfsNode.Identity.Pretty()
+	pubkey, err := n.IpfsNode.PrivateKey.GetPublic().Bytes()
+	if err != nil {
+		return nil, err
+	}
+	id.Pubkeys = &pb.ID_Pubkeys{Identity: pubkey}
+	profile, err := n.GetProfile()
+	if err == nil {
+		id.BlockchainID = profile.Handle
+	}
+	post.VendorID = id
+
+	ts, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	post.Timestamp = ts
+
+	serializedPost, err := proto.Marshal(post)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := n.IpfsNode.PrivateKey.Sign(serializedPost)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SignedPost{Post: post, Signature: sig}, nil
+}
+
+// Sign a post and publish it to our feed. If the post has no slug one is generated from
+// its title, otherwise any existing post with the same slug is replaced.
+func (n *OpenBazaarNode) SavePost(post *pb.Post) (string, error) {
+	if post.Slug == "" {
+		post.Slug = n.generatePostSlug(post.Title)
+	}
+	sp, err := n.SignPost(post)
+	if err != nil {
+		return "", err
+	}
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: false,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	out, err := m.MarshalToString(sp)
+	if err != nil {
+		return "", err
+	}
+	postPath := n.postPath(post.Slug)
+	if err := ioutil.WriteFile(postPath, []byte(out), os.ModePerm); err != nil {
+		return "", err
+	}
+	hash, err := ipfs.GetHashOfFile(n.Context, postPath)
+	if err != nil {
+		return "", err
+	}
+
+	pd := PostData{
+		Hash:      hash,
+		Slug:      post.Slug,
+		PostType:  post.PostType.String(),
+		Title:     post.Title,
+		Channels:  post.Channels,
+		Timestamp: time.Now(),
+	}
+	if len(post.Images) > 0 {
+		pd.Thumbnail = thumbnail{post.Images[0].Tiny, post.Images[0].Small, post.Images[0].Medium}
+	}
+	index, err := n.GetFeedIndex()
+	if err != nil {
+		return "", err
+	}
+	for i, d := range index {
+		if d.Slug == post.Slug {
+			index = append(index[:i], index[i+1:]...)
+			break
+		}
+	}
+	index = append(index, pd)
+	if err := n.writeFeedIndexes(index); err != nil {
+		return "", err
+	}
+	go n.UpdateChannelPointers()
+	return post.Slug, nil
+}
+
+// Remove a post from our feed
+func (n *OpenBazaarNode) DeletePost(slug string) error {
+	if err := validatePostSlug(slug); err != nil {
+		return err
+	}
+	if err := os.Remove(n.postPath(slug)); err != nil {
+		return err
+	}
+	index, err := n.GetFeedIndex()
+	if err != nil {
+		return err
+	}
+	for i, d := range index {
+		if d.Slug == slug {
+			index = append(index[:i], index[i+1:]...)
+			break
+		}
+	}
+	if err := n.writeFeedIndexes(index); err != nil {
+		return err
+	}
+	// Retire the pointers of channels no other post uses
+	go n.UpdateChannelPointers()
+	return nil
+}
+
+func (n *OpenBazaarNode) GetPostFromSlug(slug string) (*pb.SignedPost, error) {
+	if err := validatePostSlug(slug); err != nil {
+		return nil, err
+	}
+	file, err := ioutil.ReadFile(n.postPath(slug))
+	if err != nil {
+		return nil, err
+	}
+	sp := new(pb.SignedPost)
+	if err := jsonpb.UnmarshalString(string(file), sp); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+func (n *OpenBazaarNode) GetFeedIndex() ([]PostData, error) {
+	index := []PostData{}
+	file, err := ioutil.ReadFile(path.Join(n.RepoPath, "root", "feed", "index.json"))
+	if os.IsNotExist(err) {
+		return index, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(file, &index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// Write the feed index and an index for each channel our posts are in. The channel
+// indexes let other nodes fetch just the posts in a channel they are browsing.
+func (n *OpenBazaarNode) writeFeedIndexes(index []PostData) error {
+	sort.Sort(postsByTimestamp(index))
+	channels := make(map[string][]PostData)
+	for _, pd := range index {
+		for _, channel := range pd.Channels {
+			channels[channel] = append(channels[channel], pd)
+		}
+	}
+	writeIndex := func(p string, data []PostData) error {
+		j, err := json.MarshalIndent(data, "", "    ")
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(p, j, os.ModePerm)
+	}
+	if err := writeIndex(path.Join(n.RepoPath, "root", "feed", "index.json"), index); err != nil {
+		return err
+	}
+
+	channelDir := path.Join(n.RepoPath, "root", "channel")
+	files, err := ioutil.ReadDir(channelDir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if _, ok := channels[strings.TrimSuffix(f.Name(), ".json")]; !ok {
+			os.Remove(path.Join(channelDir, f.Name()))
+		}
+	}
+	for channel, data := range channels {
+		if err := writeIndex(path.Join(channelDir, channel+".json"), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Publish a pointer for each channel we have posted in and retire the pointers of
+// channels we no longer have posts in.
+func (n *OpenBazaarNode) UpdateChannelPointers() {
+	index, err := n.GetFeedIndex()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	channels := make(map[string]bool)
+	for _, pd := range index {
+		for _, channel := range pd.Channels {
+			channels[channel] = true
+		}
+	}
+	n.syncNamedPointers(ipfs.CHANNEL, channels, ChannelPointerID)
+}
+
+// Fetch the given file from the root directory of each peer and merge the posts it
+// lists, newest first. Peers which can't be reached are skipped.
+func (n *OpenBazaarNode) fetchPosts(peerIDs []string, file string) []PostData {
+	var posts []PostData
+	var lock sync.Mutex
+	sem := make(chan struct{}, postFetchWorkers)
+	var wg sync.WaitGroup
+	for _, pid := range peerIDs {
+		wg.Add(1)
+		go func(pid string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			b, err := ipfs.ResolveThenCat(n.Context, ipnspath.FromString(path.Join(pid, file)))
+			if err != nil {
+				return
+			}
+			var index []PostData
+			if err := json.Unmarshal(b, &index); err != nil {
+				return
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			for _, pd := range index {
+				pd.PeerId = pid
+				posts = append(posts, pd)
+			}
+		}(pid)
+	}
+	wg.Wait()
+	if posts == nil {
+		posts = []PostData{}
+	}
+	sort.Sort(postsByTimestamp(posts))
+	return posts
+}
+
+// Return the posts of every store we follow, newest first
+func (n *OpenBazaarNode) GetFeed() ([]PostData, error) {
+	following, err := n.Datastore.Following().Get("", -1)
+	if err != nil {
+		return nil, err
+	}
+	return n.fetchPosts(following, path.Join("feed", "index.json")), nil
+}
+
+// Return the posts in a channel from every store publishing to it, newest first
+func (n *OpenBazaarNode) GetChannel(channel string) ([]PostData, error) {
+	channel = normalizeChannel(channel)
+	if err := validateChannel(channel); err != nil {
+		return nil, err
+	}
+	routing, ok := n.IpfsNode.Routing.(*dht.IpfsDHT)
+	if !ok {
+		return nil, errors.New("Channels can't be discovered while offline")
+	}
+	id, err := ChannelPointerID(channel)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	peerInfoList, err := ipfs.FindPointers(routing, ctx, id, TagPointerPrefixLength)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool)
+	var peers []string
+	for _, p := range peerInfoList {
+		pid, err := ExtractIDFromPointer(p)
+		if err != nil || found[pid] {
+			continue
+		}
+		found[pid] = true
+		peers = append(peers, pid)
+	}
+	return n.fetchPosts(peers, path.Join("channel", channel+".json")), nil
+}
+
+func verifySignaturesOnPost(sp *pb.SignedPost) error {
+	if sp.Post == nil || sp.Post.VendorID == nil || sp.Post.VendorID.Pubkeys == nil {
+		return errors.New("Post is missing the vendor ID")
+	}
+	if err := verifySignature(sp.Post, sp.Post.VendorID.Pubkeys.Identity, sp.Signature, sp.Post.VendorID.PeerID); err != nil {
+		switch err.(type) {
+		case invalidSigError:
+			return errors.New("Vendor's signature on post failed to verify")
+		case matchKeyError:
+			return errors.New("Public key in post does not match reported vendor ID")
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// Fetch one of a peer's posts and check it was signed by that peer
+func (n *OpenBazaarNode) FetchPost(peerID string, slug string) (*pb.SignedPost, error) {
+	b, err := ipfs.ResolveThenCat(n.Context, ipnspath.FromString(path.Join(peerID, "feed", slug+".json")))
+	if err != nil {
+		return nil, err
+	}
+	sp := new(pb.SignedPost)
+	if err := jsonpb.UnmarshalString(string(b), sp); err != nil {
+		return nil, err
+	}
+	if err := verifySignaturesOnPost(sp); err != nil {
+		return nil, err
+	}
+	if sp.Post.VendorID.PeerID != peerID {
+		return nil, errors.New("Post was not signed by the requested peer")
+	}
+	return sp, nil
+}
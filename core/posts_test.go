@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+)
+
+func TestValidatePostSlug(t *testing.T) {
+	for _, slug := range []string{"", "index", "../listings/index", "a/b", "a\\b"} {
+		if err := validatePostSlug(slug); err == nil {
+			t.Errorf("Accepted invalid slug %q", slug)
+		}
+	}
+	if err := validatePostSlug("spring-restock"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidatePost(t *testing.T) {
+	post := &pb.Post{
+		Slug:     "spring-restock",
+		Title:    "Back in stock",
+		Channels: []string{"vintage-clothing"},
+	}
+	if err := validatePost(post); err != nil {
+		t.Error(err)
+	}
+	post.Channels = []string{"Not a channel!"}
+	if err := validatePost(post); err == nil {
+		t.Error("Accepted an invalid channel name")
+	}
+	post.Channels = nil
+	post.PostType = pb.Post_RESTOCK
+	if err := validatePost(post); err == nil {
+		t.Error("Accepted a restock post without listings")
+	}
+}
+
+func TestChannelPointerID(t *testing.T) {
+	a, err := ChannelPointerID("Vintage")
+	if err != nil {
+		t.Error(err)
+	}
+	b, _ := TagPointerID("vintage")
+	if a.B58String() == b.B58String() {
+		t.Error("Channel and tag with the same name returned the same pointer ID")
+	}
+}
@@ -153,6 +153,7 @@ func (n *OpenBazaarNode) appendCountsToProfile(profile *pb.Profile) (*pb.Profile
 	profile.Stats.ListingCount = uint32(n.GetListingCount())
 	profile.Stats.FollowerCount = uint32(n.Datastore.Followers().Count())
 	profile.Stats.FollowingCount = uint32(n.Datastore.Following().Count())
+	profile.Stats.DisputesResolved = uint32(n.Datastore.Cases().CountResolved())
 
 	ts, err := ptypes.TimestampProto(time.Now())
 	if err != nil {
@@ -0,0 +1,281 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	ipnspath "github.com/ipfs/go-ipfs/path"
+)
+
+/* Store-wide promotions let a vendor discount many listings at once without re-signing each
+   of them. The promotions are kept in a single document signed by the vendor and published
+   at root/promotions.json. Buyers include the document in their order so the vendor can check
+   the discount they were offered. */
+
+const (
+	PromotionsMaxCount          = 100
+	PromotionTitleMaxCharacters = 140
+)
+
+var ErrPromotionsNotFound = errors.New("Promotions not found")
+
+func (n *OpenBazaarNode) promotionsPath() string {
+	return path.Join(n.RepoPath, "root", "promotions.json")
+}
+
+func validatePromotions(promotions []*pb.Promotion) error {
+	if len(promotions) > PromotionsMaxCount {
+		return fmt.Errorf("Number of promotions is greater than the max of %d", PromotionsMaxCount)
+	}
+	ids := make(map[string]bool)
+	for _, promo := range promotions {
+		if promo.Id == "" {
+			return errors.New("Promotions must have an ID")
+		}
+		if ids[promo.Id] {
+			return errors.New("Duplicate promotion ID: " + promo.Id)
+		}
+		ids[promo.Id] = true
+		if len(promo.Title) > PromotionTitleMaxCharacters {
+			return fmt.Errorf("Promotion title is longer than the max of %d characters", PromotionTitleMaxCharacters)
+		}
+		switch d := promo.Discount.(type) {
+		case *pb.Promotion_PercentDiscount:
+			if d.PercentDiscount <= 0 || d.PercentDiscount >= 100 {
+				return errors.New("Percent discount must be between 0 and 100")
+			}
+		case *pb.Promotion_BuyXGetY_:
+			if d.BuyXGetY == nil || d.BuyXGetY.Buy == 0 || d.BuyXGetY.Free == 0 {
+				return errors.New("Buy X get Y promotions must set both buy and free")
+			}
+		default:
+			return errors.New("Promotion must have a discount")
+		}
+		if promo.Start != nil && promo.End != nil && promo.End.Seconds <= promo.Start.Seconds {
+			return errors.New("Promotion must end after it starts")
+		}
+	}
+	return nil
+}
+
+// Sign a set of promotions, replacing whatever we were running before
+func (n *OpenBazaarNode) SignPromotions(promotions []*pb.Promotion) (*pb.SignedPromotions, error) {
+	if err := validatePromotions(promotions); err != nil {
+		return nil, err
+	}
+	id := new(pb.ID)
+	id.PeerID = n.IpfsNode.Identity.Pretty()
+	pubkey, err := n.IpfsNode.PrivateKey.GetPublic().Bytes()
+	if err != nil {
+		return nil, err
+	}
+	id.Pubkeys = &pb.ID_Pubkeys{Identity: pubkey}
+	profile, err := n.GetProfile()
+	if err == nil {
+		id.BlockchainID = profile.Handle
+	}
+	ts, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	p := &pb.Promotions{
+		VendorID:   id,
+		Promotions: promotions,
+		Timestamp:  ts,
+	}
+	ser, err := proto.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := n.IpfsNode.PrivateKey.Sign(ser)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SignedPromotions{Promotions: p, Signature: sig}, nil
+}
+
+// Sign and publish our promotions. Saving an empty list ends all promotions but still
+// publishes a document so orders made under the old one can be told apart.
+func (n *OpenBazaarNode) SavePromotions(promotions []*pb.Promotion) (*pb.SignedPromotions, error) {
+	sp, err := n.SignPromotions(promotions)
+	if err != nil {
+		return nil, err
+	}
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: false,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	out, err := m.MarshalToString(sp)
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(n.promotionsPath(), []byte(out), os.ModePerm); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+// Return our current promotions
+func (n *OpenBazaarNode) GetPromotions() (*pb.SignedPromotions, error) {
+	b, err := ioutil.ReadFile(n.promotionsPath())
+	if err != nil {
+		return nil, ErrPromotionsNotFound
+	}
+	sp := new(pb.SignedPromotions)
+	if err := jsonpb.UnmarshalString(string(b), sp); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+func verifySignaturesOnPromotions(sp *pb.SignedPromotions) error {
+	if sp.Promotions == nil || sp.Promotions.VendorID == nil || sp.Promotions.VendorID.Pubkeys == nil {
+		return errors.New("Promotions are missing the vendor ID")
+	}
+	if err := verifySignature(sp.Promotions, sp.Promotions.VendorID.Pubkeys.Identity, sp.Signature, sp.Promotions.VendorID.PeerID); err != nil {
+		switch err.(type) {
+		case invalidSigError:
+			return errors.New("Vendor's signature on promotions failed to verify")
+		case matchKeyError:
+			return errors.New("Public key in promotions does not match reported vendor ID")
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// Fetch a peer's promotions and check they were signed by that peer
+func (n *OpenBazaarNode) FetchPromotions(peerID string) (*pb.SignedPromotions, error) {
+	b, err := ipfs.ResolveThenCat(n.Context, ipnspath.FromString(path.Join(peerID, "promotions.json")))
+	if err != nil {
+		return nil, err
+	}
+	sp := new(pb.SignedPromotions)
+	if err := jsonpb.UnmarshalString(string(b), sp); err != nil {
+		return nil, err
+	}
+	if err := verifySignaturesOnPromotions(sp); err != nil {
+		return nil, err
+	}
+	if sp.Promotions.VendorID.PeerID != peerID {
+		return nil, errors.New("Promotions were not signed by the requested peer")
+	}
+	return sp, nil
+}
+
+// Check whether a promotion was running when an order was placed
+func promotionActive(promo *pb.Promotion, orderTime *timestamp.Timestamp) bool {
+	placed := time.Now().Unix()
+	if orderTime != nil {
+		placed = orderTime.Seconds
+	}
+	if promo.Start != nil && placed < promo.Start.Seconds {
+		return false
+	}
+	if promo.End != nil && placed > promo.End.Seconds {
+		return false
+	}
+	return true
+}
+
+// A promotion which names no categories or listings covers the whole store
+func promotionCovers(promo *pb.Promotion, listing *pb.Listing) bool {
+	if len(promo.Categories) == 0 && len(promo.Slugs) == 0 {
+		return true
+	}
+	for _, slug := range promo.Slugs {
+		if slug == listing.Slug {
+			return true
+		}
+	}
+	if listing.Item != nil {
+		for _, category := range promo.Categories {
+			for _, c := range listing.Item.Categories {
+				if strings.EqualFold(strings.TrimSpace(c), strings.TrimSpace(category)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Return the promotion applied to a listing in an order. Only one promotion applies to
+// each item: the first in the vendor's list which covers it and was running at the time.
+func findPromotion(sp *pb.SignedPromotions, listing *pb.Listing, orderTime *timestamp.Timestamp) *pb.Promotion {
+	if sp == nil || sp.Promotions == nil {
+		return nil
+	}
+	for _, promo := range sp.Promotions.Promotions {
+		if promotionActive(promo, orderTime) && promotionCovers(promo, listing) {
+			return promo
+		}
+	}
+	return nil
+}
+
+// Return the number of items in an order the buyer pays for once a buy X get Y promotion
+// has been applied
+func chargedQuantity(promo *pb.Promotion, quantity uint32) uint32 {
+	if promo == nil || promo.GetBuyXGetY() == nil {
+		return quantity
+	}
+	bxgy := promo.GetBuyXGetY()
+	return quantity - (quantity/(bxgy.Buy+bxgy.Free))*bxgy.Free
+}
+
+// Check the promotions included in an order were signed by the vendor of its listings
+func verifyContractPromotions(contract *pb.RicardianContract) error {
+	if contract.VendorPromotions == nil {
+		return nil
+	}
+	if err := verifySignaturesOnPromotions(contract.VendorPromotions); err != nil {
+		return err
+	}
+	for _, listing := range contract.VendorListings {
+		if listing.VendorID == nil || listing.VendorID.PeerID != contract.VendorPromotions.Promotions.VendorID.PeerID {
+			return errors.New("Promotions were not signed by the vendor")
+		}
+	}
+	return nil
+}
+
+// Check the promotions a buyer included in an order are the ones we are running. Orders
+// carrying promotions we have since replaced are rejected and have to be placed again.
+func (n *OpenBazaarNode) validateOrderPromotions(contract *pb.RicardianContract) error {
+	if contract.VendorPromotions == nil {
+		return nil
+	}
+	if err := verifyContractPromotions(contract); err != nil {
+		return err
+	}
+	if contract.VendorPromotions.Promotions.VendorID.PeerID != n.IpfsNode.Identity.Pretty() {
+		return errors.New("Promotions were not signed by the vendor")
+	}
+	current, err := n.GetPromotions()
+	if err != nil {
+		return errors.New("Order includes promotions the vendor is not running")
+	}
+	included := contract.VendorPromotions.Promotions.Timestamp
+	if included == nil || current.Promotions.Timestamp == nil {
+		return errors.New("Promotions are missing a timestamp")
+	}
+	if included.Seconds != current.Promotions.Timestamp.Seconds || included.Nanos != current.Promotions.Timestamp.Nanos {
+		return errors.New("Order includes promotions the vendor is no longer running")
+	}
+	return nil
+}
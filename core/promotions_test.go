@@ -0,0 +1,104 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/golang/protobuf/ptypes"
+)
+
+func TestValidatePromotions(t *testing.T) {
+	valid := []*pb.Promotion{
+		{Id: "tenoff", Discount: &pb.Promotion_PercentDiscount{PercentDiscount: 10}},
+		{Id: "b2g1", Discount: &pb.Promotion_BuyXGetY_{BuyXGetY: &pb.Promotion_BuyXGetY{Buy: 2, Free: 1}}},
+	}
+	if err := validatePromotions(valid); err != nil {
+		t.Error(err)
+	}
+	invalid := [][]*pb.Promotion{
+		{{Discount: &pb.Promotion_PercentDiscount{PercentDiscount: 10}}},
+		{{Id: "free", Discount: &pb.Promotion_PercentDiscount{PercentDiscount: 100}}},
+		{{Id: "b0g1", Discount: &pb.Promotion_BuyXGetY_{BuyXGetY: &pb.Promotion_BuyXGetY{Free: 1}}}},
+		{{Id: "none"}},
+		{valid[0], valid[0]},
+	}
+	for _, promotions := range invalid {
+		if err := validatePromotions(promotions); err == nil {
+			t.Error("Invalid promotions passed validation")
+		}
+	}
+}
+
+func TestFindPromotion(t *testing.T) {
+	start, _ := ptypes.TimestampProto(time.Now().Add(-time.Hour))
+	end, _ := ptypes.TimestampProto(time.Now().Add(time.Hour))
+	later, _ := ptypes.TimestampProto(time.Now().Add(time.Hour * 2))
+	shirts := &pb.Promotion{Id: "shirts", Categories: []string{"Shirts"}, Start: start, End: end}
+	mug := &pb.Promotion{Id: "mug", Slugs: []string{"mug"}}
+	sp := &pb.SignedPromotions{Promotions: &pb.Promotions{Promotions: []*pb.Promotion{shirts, mug}}}
+
+	tshirt := &pb.Listing{Slug: "tshirt", Item: &pb.Listing_Item{Categories: []string{"shirts"}}}
+	if findPromotion(sp, tshirt, nil) != shirts {
+		t.Error("Failed to find promotion by category")
+	}
+	if findPromotion(sp, tshirt, later) != nil {
+		t.Error("Found a promotion which had ended")
+	}
+	if findPromotion(sp, &pb.Listing{Slug: "mug", Item: &pb.Listing_Item{}}, nil) != mug {
+		t.Error("Failed to find promotion by slug")
+	}
+	if findPromotion(sp, &pb.Listing{Slug: "hat", Item: &pb.Listing_Item{}}, nil) != nil {
+		t.Error("Found a promotion for a listing it doesn't cover")
+	}
+	storeWide := &pb.Promotion{Id: "all"}
+	sp.Promotions.Promotions = append(sp.Promotions.Promotions, storeWide)
+	if findPromotion(sp, &pb.Listing{Slug: "hat", Item: &pb.Listing_Item{}}, nil) != storeWide {
+		t.Error("Store-wide promotion should cover every listing")
+	}
+}
+
+func TestChargedQuantity(t *testing.T) {
+	b2g1 := &pb.Promotion{Discount: &pb.Promotion_BuyXGetY_{BuyXGetY: &pb.Promotion_BuyXGetY{Buy: 2, Free: 1}}}
+	tests := []struct {
+		quantity uint32
+		charged  uint32
+	}{
+		{1, 1},
+		{2, 2},
+		{3, 2},
+		{5, 4},
+		{6, 4},
+	}
+	for _, test := range tests {
+		if q := chargedQuantity(b2g1, test.quantity); q != test.charged {
+			t.Errorf("Charged for %d of %d items, expected %d", q, test.quantity, test.charged)
+		}
+	}
+	if chargedQuantity(nil, 3) != 3 {
+		t.Error("Items without a promotion should all be charged")
+	}
+}
+
+func TestValidateOrderTime(t *testing.T) {
+	start, _ := ptypes.TimestampProto(time.Now().Add(-time.Hour * 24))
+	sale := &pb.Promotion{Id: "sale", Start: start, Discount: &pb.Promotion_PercentDiscount{PercentDiscount: 10}}
+	contract := &pb.RicardianContract{
+		VendorPromotions: &pb.SignedPromotions{Promotions: &pb.Promotions{Promotions: []*pb.Promotion{sale}}},
+		BuyerOrder:       &pb.Order{Items: []*pb.Order_Item{{ListingHash: "QmListing"}}},
+	}
+	listings := map[string]*pb.Listing{"QmListing": {Slug: "tshirt", Item: &pb.Listing_Item{}}}
+
+	contract.BuyerOrder.Timestamp, _ = ptypes.TimestampProto(time.Now().Add(-time.Minute))
+	if err := validateOrderTime(contract, listings, time.Now()); err != nil {
+		t.Error(err)
+	}
+	contract.BuyerOrder.Timestamp, _ = ptypes.TimestampProto(time.Now().Add(-time.Hour))
+	if err := validateOrderTime(contract, listings, time.Now()); err == nil {
+		t.Error("Expected an error for an order using a promotion received long after it was placed")
+	}
+	sale.Start = nil
+	if err := validateOrderTime(contract, listings, time.Now()); err != nil {
+		t.Error("Promotions without a start or end should not depend on the order's timestamp")
+	}
+}
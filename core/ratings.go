@@ -0,0 +1,574 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	crypto "gx/ipfs/QmPGxZ1DP2w45WcogpW1h43BvseXbfke9N91qotpoQcUeS/go-libp2p-crypto"
+	peer "gx/ipfs/QmWUswjn261LSyVxWAEpMVtPdy8zmKBJJfBpG3Qdpa8ZsE/go-libp2p-peer"
+	mh "gx/ipfs/QmbZ6Cee2uHjG7hf19qLHppgKDRtaG4CVtMzdmK9VCVqLu/go-multihash"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	ipnspath "github.com/ipfs/go-ipfs/path"
+	"github.com/ipfs/go-ipfs/routing/dht"
+	"golang.org/x/net/context"
+)
+
+// The maximum number of ratings fetched from the network at the same time
+const ratingFetchWorkers = 8
+
+// An entry in root/ratings/index.json. Response is the hash of the vendor's
+// reply to the rating, if there is one.
+type ratingIndexEntry struct {
+	Hash     string `json:"hash"`
+	Slug     string `json:"slug"`
+	Response string `json:"response,omitempty"`
+}
+
+type RatingAverages struct {
+	Overall         float32 `json:"overall"`
+	Quality         float32 `json:"quality"`
+	Description     float32 `json:"description"`
+	DeliverySpeed   float32 `json:"deliverySpeed"`
+	CustomerService float32 `json:"customerService"`
+}
+
+type RatingEntry struct {
+	Hash     string          `json:"hash"`
+	Slug     string          `json:"slug"`
+	Rating   json.RawMessage `json:"rating"`
+	Response json.RawMessage `json:"response,omitempty"`
+
+	responseHash string
+}
+
+// RatingSummary holds the averages over every verified rating of a store or listing
+// along with one page of the ratings themselves. Ratings which fail to verify are
+// counted in Invalid and excluded from everything else.
+type RatingSummary struct {
+	PeerId  string         `json:"peerId"`
+	Slug    string         `json:"slug,omitempty"`
+	Count   int            `json:"count"`
+	Invalid int            `json:"invalid"`
+	Average RatingAverages `json:"average"`
+	Ratings []RatingEntry  `json:"ratings"`
+}
+
+// Returned when the moderator's key can't be found. The rating may still be valid
+// so it is not cached.
+type moderatorKeyError struct {
+	err error
+}
+
+func (e moderatorKeyError) Error() string {
+	return "Moderator key not found: " + e.err.Error()
+}
+
+type verifiedRating struct {
+	entry  RatingEntry
+	rating *pb.Rating
+}
+
+// Return the verified ratings of a store, or of one of its listings if a slug is given.
+// Ratings are cached after they have been fetched and verified so each one is only
+// downloaded once. The page of ratings returned starts after offsetId, newest first.
+func (n *OpenBazaarNode) GetRatings(peerID, slug, offsetId string, limit int) (*RatingSummary, error) {
+	index, err := n.fetchRatingIndex(peerID)
+	if err != nil {
+		return nil, err
+	}
+	// The index is published by the vendor so it may list the same rating more than once
+	var entries []ratingIndexEntry
+	seen := make(map[string]bool)
+	for _, entry := range index {
+		if seen[entry.Hash] || (slug != "" && entry.Slug != slug) {
+			continue
+		}
+		seen[entry.Hash] = true
+		entries = append(entries, entry)
+	}
+
+	results := make([]*verifiedRating, len(entries))
+	errs := make([]error, len(entries))
+	sem := make(chan struct{}, ratingFetchWorkers)
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		wg.Add(1)
+		go func(i int, entry ratingIndexEntry) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = n.getCachedRating(peerID, entry)
+		}(i, entry)
+	}
+	wg.Wait()
+
+	summary := &RatingSummary{PeerId: peerID, Slug: slug}
+	var verified []*verifiedRating
+	for i, vr := range results {
+		if errs[i] != nil {
+			log.Warningf("Failed to fetch rating %s: %s", entries[i].Hash, errs[i].Error())
+			continue
+		}
+		if vr == nil {
+			summary.Invalid++
+			continue
+		}
+		verified = append(verified, vr)
+	}
+	verified = dedupeRatings(verified)
+	summary.Count = len(verified)
+	summary.Average = averageRatings(verified)
+
+	sort.SliceStable(verified, func(i, j int) bool {
+		return ratingTime(verified[i].rating).After(ratingTime(verified[j].rating))
+	})
+	start := 0
+	if offsetId != "" {
+		for i, vr := range verified {
+			if vr.entry.Hash == offsetId {
+				start = i + 1
+				break
+			}
+		}
+	}
+	summary.Ratings = []RatingEntry{}
+	for _, vr := range verified[start:] {
+		if limit >= 0 && len(summary.Ratings) >= limit {
+			break
+		}
+		summary.Ratings = append(summary.Ratings, vr.entry)
+	}
+
+	// Responses are only fetched for the ratings being returned
+	var responses sync.WaitGroup
+	for i := range summary.Ratings {
+		if summary.Ratings[i].responseHash == "" {
+			continue
+		}
+		responses.Add(1)
+		go func(entry *RatingEntry) {
+			defer responses.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			response, err := n.getCachedRatingResponse(peerID, entry)
+			if err != nil {
+				log.Warningf("Failed to fetch response to rating %s: %s", entry.Hash, err.Error())
+				return
+			}
+			entry.Response = response
+		}(&summary.Ratings[i])
+	}
+	responses.Wait()
+	return summary, nil
+}
+
+func (n *OpenBazaarNode) fetchRatingIndex(peerID string) ([]ratingIndexEntry, error) {
+	var b []byte
+	var err error
+	if peerID == n.IpfsNode.Identity.Pretty() {
+		b, err = ioutil.ReadFile(path.Join(n.RepoPath, "root", "ratings", "index.json"))
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+	} else {
+		b, err = ipfs.ResolveThenCat(n.Context, ipnspath.FromString(path.Join(peerID, "ratings", "index.json")))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var index []ratingIndexEntry
+	if err := json.Unmarshal(b, &index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// Return the rating from the cache, fetching and verifying it if it isn't cached yet.
+// Returns nil without an error if the rating failed to verify.
+func (n *OpenBazaarNode) getCachedRating(peerID string, entry ratingIndexEntry) (*verifiedRating, error) {
+	cached, err := n.Datastore.Ratings().Get(entry.Hash, repo.CachedRatingKindRating)
+	if err != nil {
+		b, err := ipfs.Cat(n.Context, entry.Hash)
+		if err != nil {
+			return nil, err
+		}
+		cached = repo.CachedRating{
+			Hash:      entry.Hash,
+			Kind:      repo.CachedRatingKindRating,
+			PeerId:    peerID,
+			Slug:      entry.Slug,
+			Rating:    b,
+			Timestamp: time.Now(),
+		}
+		rating := new(pb.Rating)
+		if err := jsonpb.UnmarshalString(string(b), rating); err != nil {
+			cached.Error = err.Error()
+		} else if err := verifyRating(rating, peerID, n.moderatorPublicKey); err != nil {
+			if _, ok := err.(moderatorKeyError); ok {
+				return nil, err
+			}
+			cached.Error = err.Error()
+		} else if rating.RatingData.VendorSig.Metadata.ListingSlug != entry.Slug {
+			cached.Error = "Rating is for a different listing than its index entry"
+		} else {
+			cached.Valid = true
+		}
+		if err := n.Datastore.Ratings().Put(cached); err != nil {
+			return nil, err
+		}
+	}
+	if !cached.Valid || cached.PeerId != peerID {
+		return nil, nil
+	}
+	rating := new(pb.Rating)
+	if err := jsonpb.UnmarshalString(string(cached.Rating), rating); err != nil {
+		return nil, err
+	}
+	return &verifiedRating{
+		entry:  RatingEntry{Hash: entry.Hash, Slug: entry.Slug, Rating: cached.Rating, responseHash: entry.Response},
+		rating: rating,
+	}, nil
+}
+
+// Return the vendor's response to a rating from the cache, fetching and verifying it
+// if it isn't cached yet. Responses are kept in the ratings cache as their own kind so a
+// rating's hash can't be passed off as a response or the other way round.
+// Returns nil without an error if the response failed to verify.
+func (n *OpenBazaarNode) getCachedRatingResponse(peerID string, entry *RatingEntry) (json.RawMessage, error) {
+	cached, err := n.Datastore.Ratings().Get(entry.responseHash, repo.CachedRatingKindResponse)
+	if err != nil {
+		b, err := ipfs.Cat(n.Context, entry.responseHash)
+		if err != nil {
+			return nil, err
+		}
+		cached = repo.CachedRating{
+			Hash:      entry.responseHash,
+			Kind:      repo.CachedRatingKindResponse,
+			PeerId:    peerID,
+			Slug:      entry.Slug,
+			Rating:    b,
+			Timestamp: time.Now(),
+		}
+		srr := new(pb.SignedRatingResponse)
+		if err := jsonpb.UnmarshalString(string(b), srr); err != nil {
+			cached.Error = err.Error()
+		} else if err := verifyRatingResponse(srr, peerID, entry.Hash); err != nil {
+			cached.Error = err.Error()
+		} else {
+			cached.Valid = true
+		}
+		if err := n.Datastore.Ratings().Put(cached); err != nil {
+			return nil, err
+		}
+	}
+	if !cached.Valid || cached.PeerId != peerID {
+		return nil, nil
+	}
+	return cached.Rating, nil
+}
+
+// Look up a moderator's identity key so its signature on a rating can be checked
+func (n *OpenBazaarNode) moderatorPublicKey(peerID string) (crypto.PubKey, error) {
+	routing, ok := n.IpfsNode.Routing.(*dht.IpfsDHT)
+	if !ok {
+		return nil, errors.New("Moderator keys can't be found while offline")
+	}
+	pid, err := peer.IDB58Decode(peerID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	return routing.GetPublicKey(ctx, pid)
+}
+
+// Check the rating was signed with its rating key, that the rating key was signed by
+// the given vendor and, if the rating followed a dispute, that the moderator signed it.
+func verifyRating(rating *pb.Rating, vendorID string, moderatorKey func(peerID string) (crypto.PubKey, error)) error {
+	rd := rating.RatingData
+	if rd == nil || rd.VendorID == nil || rd.VendorID.Pubkeys == nil || rd.VendorSig == nil || rd.VendorSig.Metadata == nil {
+		return errors.New("Rating is missing required fields")
+	}
+	if err := validateRatingRanges(rd); err != nil {
+		return err
+	}
+	if err := verifyRatingKeySignature(rating); err != nil {
+		return err
+	}
+	if rd.VendorID.PeerID != vendorID {
+		return errors.New("Rating is for a different vendor")
+	}
+	if !bytes.Equal(rd.VendorSig.Metadata.RatingKey, rd.RatingKey) {
+		return errors.New("Rating key was not signed by the vendor")
+	}
+	if err := verifySignature(rd.VendorSig.Metadata, rd.VendorID.Pubkeys.Identity, rd.VendorSig.Signature, vendorID); err != nil {
+		switch err.(type) {
+		case invalidSigError:
+			return errors.New("Invalid vendor signature on rating")
+		case matchKeyError:
+			return errors.New("Public key in rating does not match the vendor ID")
+		default:
+			return err
+		}
+	}
+	if rd.ModeratorID == nil && len(rd.ModeratorSig) == 0 {
+		return nil
+	}
+	if rd.ModeratorID == nil || len(rd.ModeratorSig) == 0 {
+		return errors.New("Rating is missing the moderator's signature")
+	}
+	pubkey, err := moderatorKey(rd.ModeratorID.PeerID)
+	if err != nil {
+		return moderatorKeyError{err}
+	}
+	pid, err := peer.IDB58Decode(rd.ModeratorID.PeerID)
+	if err != nil {
+		return err
+	}
+	if !pid.MatchesPublicKey(pubkey) {
+		return errors.New("Public key does not match the moderator ID")
+	}
+	valid, err := pubkey.Verify(rd.RatingKey, rd.ModeratorSig)
+	if err != nil || !valid {
+		return errors.New("Invalid moderator signature on rating")
+	}
+	return nil
+}
+
+func verifyRatingKeySignature(rating *pb.Rating) error {
+	pubkey, err := btcec.ParsePubKey(rating.RatingData.RatingKey, btcec.S256())
+	if err != nil {
+		return err
+	}
+	signature, err := btcec.ParseSignature(rating.Signature, btcec.S256())
+	if err != nil {
+		return err
+	}
+	ser, err := proto.Marshal(rating.RatingData)
+	if err != nil {
+		return err
+	}
+	hashed := sha256.Sum256(ser)
+	if !signature.Verify(hashed[:], pubkey) {
+		return errors.New("Invalid rating signature on rating")
+	}
+	return nil
+}
+
+func validateRatingRanges(rd *pb.Rating_RatingData) error {
+	for _, score := range []uint32{rd.Overall, rd.Quality, rd.Description, rd.DeliverySpeed, rd.CustomerService} {
+		if score < RatingMin || score > RatingMax {
+			return errors.New("Rating not within valid range")
+		}
+	}
+	return nil
+}
+
+// Keep one rating per rating key. Each key is issued for a single order, so any further
+// ratings made with it, such as a buyer rating the same order again, only count once. The
+// newest of them is kept.
+func dedupeRatings(ratings []*verifiedRating) []*verifiedRating {
+	latest := make(map[string]*verifiedRating)
+	for _, vr := range ratings {
+		key := string(vr.rating.RatingData.RatingKey)
+		if prev, ok := latest[key]; !ok || ratingTime(vr.rating).After(ratingTime(prev.rating)) {
+			latest[key] = vr
+		}
+	}
+	var ret []*verifiedRating
+	for _, vr := range ratings {
+		if latest[string(vr.rating.RatingData.RatingKey)] == vr {
+			ret = append(ret, vr)
+		}
+	}
+	return ret
+}
+
+func averageRatings(ratings []*verifiedRating) RatingAverages {
+	var avg RatingAverages
+	if len(ratings) == 0 {
+		return avg
+	}
+	for _, vr := range ratings {
+		rd := vr.rating.RatingData
+		avg.Overall += float32(rd.Overall)
+		avg.Quality += float32(rd.Quality)
+		avg.Description += float32(rd.Description)
+		avg.DeliverySpeed += float32(rd.DeliverySpeed)
+		avg.CustomerService += float32(rd.CustomerService)
+	}
+	count := float32(len(ratings))
+	avg.Overall /= count
+	avg.Quality /= count
+	avg.Description /= count
+	avg.DeliverySpeed /= count
+	avg.CustomerService /= count
+	return avg
+}
+
+func ratingTime(rating *pb.Rating) time.Time {
+	if rating.RatingData.Timestamp == nil {
+		return time.Time{}
+	}
+	return time.Unix(rating.RatingData.Timestamp.Seconds, int64(rating.RatingData.Timestamp.Nanos))
+}
+
+func (n *OpenBazaarNode) ratingResponsePath(ratingHash string) string {
+	return path.Join(n.RepoPath, "root", "ratings", "response_"+ratingHash)
+}
+
+// Sign a public reply to one of our ratings and publish it next to the rating. Any
+// earlier reply to the same rating is replaced.
+func (n *OpenBazaarNode) RespondToRating(ratingHash string, response string) (*pb.SignedRatingResponse, error) {
+	if response == "" {
+		return nil, errors.New("Response must not be empty")
+	}
+	if len(response) > ReviewMaxCharacters {
+		return nil, fmt.Errorf("Response is longer than the max of %d characters", ReviewMaxCharacters)
+	}
+	if _, err := mh.FromB58String(ratingHash); err != nil {
+		return nil, errors.New("Invalid rating hash")
+	}
+	index, err := n.fetchRatingIndex(n.IpfsNode.Identity.Pretty())
+	if err != nil {
+		return nil, err
+	}
+	pos := -1
+	for i, entry := range index {
+		if entry.Hash == ratingHash {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		return nil, errors.New("Rating not found")
+	}
+
+	id := new(pb.ID)
+	id.PeerID = n.IpfsNode.Identity.Pretty()
+	pubkey, err := n.IpfsNode.PrivateKey.GetPublic().Bytes()
+	if err != nil {
+		return nil, err
+	}
+	id.Pubkeys = &pb.ID_Pubkeys{Identity: pubkey}
+	profile, err := n.GetProfile()
+	if err == nil {
+		id.BlockchainID = profile.Handle
+	}
+	ts, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	rr := &pb.RatingResponse{
+		RatingHash: ratingHash,
+		VendorID:   id,
+		Response:   response,
+		Timestamp:  ts,
+	}
+	ser, err := proto.Marshal(rr)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := n.IpfsNode.PrivateKey.Sign(ser)
+	if err != nil {
+		return nil, err
+	}
+	srr := &pb.SignedRatingResponse{RatingResponse: rr, Signature: sig}
+
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: false,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	out, err := m.MarshalToString(srr)
+	if err != nil {
+		return nil, err
+	}
+	responsePath := n.ratingResponsePath(ratingHash)
+	if err := ioutil.WriteFile(responsePath, []byte(out), os.ModePerm); err != nil {
+		return nil, err
+	}
+	hash, err := ipfs.GetHashOfFile(n.Context, responsePath)
+	if err != nil {
+		return nil, err
+	}
+	index[pos].Response = hash
+	if err := n.writeRatingIndex(index); err != nil {
+		return nil, err
+	}
+	return srr, nil
+}
+
+// Remove our reply to a rating
+func (n *OpenBazaarNode) DeleteRatingResponse(ratingHash string) error {
+	if _, err := mh.FromB58String(ratingHash); err != nil {
+		return errors.New("Invalid rating hash")
+	}
+	index, err := n.fetchRatingIndex(n.IpfsNode.Identity.Pretty())
+	if err != nil {
+		return err
+	}
+	found := false
+	for i, entry := range index {
+		if entry.Hash == ratingHash && entry.Response != "" {
+			index[i].Response = ""
+			found = true
+		}
+	}
+	if !found {
+		return errors.New("Response not found")
+	}
+	if err := os.Remove(n.ratingResponsePath(ratingHash)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return n.writeRatingIndex(index)
+}
+
+func (n *OpenBazaarNode) writeRatingIndex(index []ratingIndexEntry) error {
+	j, err := json.MarshalIndent(index, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path.Join(n.RepoPath, "root", "ratings", "index.json"), j, os.ModePerm)
+}
+
+// Check a response was signed by the vendor and is a reply to the given rating
+func verifyRatingResponse(srr *pb.SignedRatingResponse, vendorID string, ratingHash string) error {
+	rr := srr.RatingResponse
+	if rr == nil || rr.VendorID == nil || rr.VendorID.Pubkeys == nil {
+		return errors.New("Rating response is missing the vendor ID")
+	}
+	if rr.VendorID.PeerID != vendorID {
+		return errors.New("Rating response is from a different vendor")
+	}
+	if rr.RatingHash != ratingHash {
+		return errors.New("Rating response is for a different rating")
+	}
+	if err := verifySignature(rr, rr.VendorID.Pubkeys.Identity, srr.Signature, vendorID); err != nil {
+		switch err.(type) {
+		case invalidSigError:
+			return errors.New("Invalid vendor signature on rating response")
+		case matchKeyError:
+			return errors.New("Public key in rating response does not match the vendor ID")
+		default:
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package core
+
+import (
+	"crypto/sha256"
+	"errors"
+	crypto "gx/ipfs/QmPGxZ1DP2w45WcogpW1h43BvseXbfke9N91qotpoQcUeS/go-libp2p-crypto"
+	peer "gx/ipfs/QmWUswjn261LSyVxWAEpMVtPdy8zmKBJJfBpG3Qdpa8ZsE/go-libp2p-peer"
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+type testRatingKeys struct {
+	vendorKey    crypto.PrivKey
+	vendorID     string
+	moderatorKey crypto.PrivKey
+	moderatorID  string
+	ratingKey    *btcec.PrivateKey
+}
+
+func newTestRatingKeys(t *testing.T) *testRatingKeys {
+	keys := new(testRatingKeys)
+	var err error
+	var pub crypto.PubKey
+	keys.vendorKey, pub, err = crypto.GenerateKeyPair(crypto.Ed25519, 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, _ := peer.IDFromPublicKey(pub)
+	keys.vendorID = pid.Pretty()
+	keys.moderatorKey, pub, err = crypto.GenerateKeyPair(crypto.Ed25519, 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, _ = peer.IDFromPublicKey(pub)
+	keys.moderatorID = pid.Pretty()
+	keys.ratingKey, err = btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func (k *testRatingKeys) moderatorPublicKey(peerID string) (crypto.PubKey, error) {
+	if peerID != k.moderatorID {
+		return nil, errors.New("not found")
+	}
+	return k.moderatorKey.GetPublic(), nil
+}
+
+// Build a rating signed the same way as CompleteOrder and the vendor's order fulfillment
+func (k *testRatingKeys) newRating(t *testing.T, overall uint32, moderated bool) *pb.Rating {
+	vendorPub, _ := k.vendorKey.GetPublic().Bytes()
+	metadata := &pb.RatingSignature_TransactionMetadata{
+		ListingSlug: "slug",
+		RatingKey:   k.ratingKey.PubKey().SerializeCompressed(),
+	}
+	ser, _ := proto.Marshal(metadata)
+	vendorSig, err := k.vendorKey.Sign(ser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rd := &pb.Rating_RatingData{
+		RatingKey:       metadata.RatingKey,
+		VendorID:        &pb.ID{PeerID: k.vendorID, Pubkeys: &pb.ID_Pubkeys{Identity: vendorPub}},
+		VendorSig:       &pb.RatingSignature{Metadata: metadata, Signature: vendorSig},
+		Overall:         overall,
+		Quality:         4,
+		Description:     3,
+		DeliverySpeed:   5,
+		CustomerService: 2,
+	}
+	if moderated {
+		rd.ModeratorID = &pb.ID{PeerID: k.moderatorID}
+		rd.ModeratorSig, err = k.moderatorKey.Sign(rd.RatingKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return k.signRating(t, rd)
+}
+
+func (k *testRatingKeys) signRating(t *testing.T, rd *pb.Rating_RatingData) *pb.Rating {
+	ser, _ := proto.Marshal(rd)
+	hashed := sha256.Sum256(ser)
+	sig, err := k.ratingKey.Sign(hashed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pb.Rating{RatingData: rd, Signature: sig.Serialize()}
+}
+
+func TestVerifyRating(t *testing.T) {
+	keys := newTestRatingKeys(t)
+	if err := verifyRating(keys.newRating(t, 5, false), keys.vendorID, keys.moderatorPublicKey); err != nil {
+		t.Error(err)
+	}
+	if err := verifyRating(keys.newRating(t, 5, true), keys.vendorID, keys.moderatorPublicKey); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVerifyRatingRejectsTampering(t *testing.T) {
+	keys := newTestRatingKeys(t)
+	other := newTestRatingKeys(t)
+
+	rating := keys.newRating(t, 5, false)
+	rating.RatingData.Overall = 1
+	if err := verifyRating(rating, keys.vendorID, keys.moderatorPublicKey); err == nil {
+		t.Error("Expected an error for a rating changed after it was signed")
+	}
+
+	if err := verifyRating(keys.newRating(t, 5, false), other.vendorID, keys.moderatorPublicKey); err == nil {
+		t.Error("Expected an error for a rating of a different vendor")
+	}
+
+	rating = keys.newRating(t, 5, false)
+	rating.RatingData.VendorSig.Signature = []byte("bad signature")
+	rating = keys.signRating(t, rating.RatingData)
+	if err := verifyRating(rating, keys.vendorID, keys.moderatorPublicKey); err == nil {
+		t.Error("Expected an error for an invalid vendor signature")
+	}
+
+	rating = keys.newRating(t, 5, false)
+	rating.RatingData.VendorSig = other.newRating(t, 5, false).RatingData.VendorSig
+	rating = keys.signRating(t, rating.RatingData)
+	if err := verifyRating(rating, keys.vendorID, keys.moderatorPublicKey); err == nil {
+		t.Error("Expected an error for a rating key the vendor didn't sign")
+	}
+
+	rating = keys.newRating(t, 5, true)
+	rating.RatingData.ModeratorSig, _ = other.moderatorKey.Sign(rating.RatingData.RatingKey)
+	rating = keys.signRating(t, rating.RatingData)
+	if err := verifyRating(rating, keys.vendorID, keys.moderatorPublicKey); err == nil {
+		t.Error("Expected an error for an invalid moderator signature")
+	}
+
+	rating = keys.newRating(t, 6, false)
+	if err := verifyRating(rating, keys.vendorID, keys.moderatorPublicKey); err == nil {
+		t.Error("Expected an error for a rating out of range")
+	}
+}
+
+func TestVerifyRatingModeratorKeyNotFound(t *testing.T) {
+	keys := newTestRatingKeys(t)
+	rating := keys.newRating(t, 5, true)
+	notFound := func(string) (crypto.PubKey, error) { return nil, errors.New("offline") }
+	err := verifyRating(rating, keys.vendorID, notFound)
+	if _, ok := err.(moderatorKeyError); !ok {
+		t.Error("Expected a moderator key error so the rating isn't cached as invalid")
+	}
+}
+
+func TestAverageRatings(t *testing.T) {
+	keys := newTestRatingKeys(t)
+	avg := averageRatings([]*verifiedRating{
+		{rating: keys.newRating(t, 5, false)},
+		{rating: keys.newRating(t, 2, false)},
+	})
+	if avg.Overall != 3.5 || avg.Quality != 4 || avg.Description != 3 || avg.DeliverySpeed != 5 || avg.CustomerService != 2 {
+		t.Error("Returned incorrect averages")
+	}
+	if averageRatings(nil).Overall != 0 {
+		t.Error("Averages without ratings should be zero")
+	}
+}
+
+func TestDedupeRatings(t *testing.T) {
+	keys := newTestRatingKeys(t)
+	first := keys.newRating(t, 1, false)
+	first.RatingData.Timestamp = &timestamp.Timestamp{Seconds: 1000}
+	again := keys.newRating(t, 5, false)
+	again.RatingData.Timestamp = &timestamp.Timestamp{Seconds: 2000}
+	other := newTestRatingKeys(t).newRating(t, 3, false)
+	ratings := dedupeRatings([]*verifiedRating{
+		{entry: RatingEntry{Hash: "first"}, rating: first},
+		{entry: RatingEntry{Hash: "other"}, rating: other},
+		{entry: RatingEntry{Hash: "again"}, rating: again},
+	})
+	if len(ratings) != 2 || ratings[0].entry.Hash != "other" || ratings[1].entry.Hash != "again" {
+		t.Error("Expected one rating per rating key, keeping the newest")
+	}
+	if avg := averageRatings(ratings); avg.Overall != 4 {
+		t.Error("Duplicate ratings were counted in the averages")
+	}
+}
+
+func (k *testRatingKeys) newRatingResponse(t *testing.T, ratingHash string) *pb.SignedRatingResponse {
+	vendorPub, _ := k.vendorKey.GetPublic().Bytes()
+	rr := &pb.RatingResponse{
+		RatingHash: ratingHash,
+		VendorID:   &pb.ID{PeerID: k.vendorID, Pubkeys: &pb.ID_Pubkeys{Identity: vendorPub}},
+		Response:   "Sorry to hear that, a replacement is on its way",
+	}
+	ser, _ := proto.Marshal(rr)
+	sig, err := k.vendorKey.Sign(ser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pb.SignedRatingResponse{RatingResponse: rr, Signature: sig}
+}
+
+func TestVerifyRatingResponse(t *testing.T) {
+	keys := newTestRatingKeys(t)
+	other := newTestRatingKeys(t)
+	if err := verifyRatingResponse(keys.newRatingResponse(t, "QmRating"), keys.vendorID, "QmRating"); err != nil {
+		t.Error(err)
+	}
+	if err := verifyRatingResponse(keys.newRatingResponse(t, "QmRating"), keys.vendorID, "QmOtherRating"); err == nil {
+		t.Error("Expected an error for a response to a different rating")
+	}
+	if err := verifyRatingResponse(other.newRatingResponse(t, "QmRating"), keys.vendorID, "QmRating"); err == nil {
+		t.Error("Expected an error for a response from a different vendor")
+	}
+	srr := keys.newRatingResponse(t, "QmRating")
+	srr.RatingResponse.Response = "Changed after signing"
+	if err := verifyRatingResponse(srr, keys.vendorID, "QmRating"); err == nil {
+		t.Error("Expected an error for a response changed after it was signed")
+	}
+}
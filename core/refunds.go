@@ -6,6 +6,7 @@ import (
 
 	"time"
 
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
 	"github.com/OpenBazaar/openbazaar-go/pb"
 	"github.com/OpenBazaar/spvwallet"
 	"github.com/btcsuite/btcd/txscript"
@@ -15,7 +16,32 @@ import (
 	"github.com/golang/protobuf/ptypes"
 )
 
-func (n *OpenBazaarNode) RefundOrder(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+// Outputs below this many satoshis after fees would be rejected by the network as dust
+const refundDustLimit = 546
+
+var ErrRefundOfferNotFound = errors.New("No refund offer for this order")
+
+var ErrRefundOfferPending = errors.New("The buyer has not yet answered the last refund offer for this order")
+
+var ErrRefundExceedsPayment = errors.New("A partial refund must be less than the amount paid. Refund the whole order instead.")
+
+// Refund an order. An amount of zero refunds everything the buyer paid. Otherwise the given
+// amount is refunded for the given items and, for moderated orders, the remainder of the
+// escrow is released to us in the same transaction once the buyer accepts it. If items are
+// given and the amount is zero the refund is the share of the payment those items make up.
+// A new partial refund can't be offered while the buyer hasn't answered the last one.
+func (n *OpenBazaarNode) RefundOrder(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord, amount uint64, items []uint32) error {
+	if amount > 0 && len(items) == 0 {
+		return errors.New("A partial refund must list the refunded items")
+	}
+	if len(items) > 0 && IsRefundOffer(contract) {
+		return ErrRefundOfferPending
+	}
+	requested := amount
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
+	}
 	refundMsg := new(pb.Refund)
 	orderId, err := n.CalcOrderId(contract.BuyerOrder)
 	if err != nil {
@@ -27,40 +53,41 @@ func (n *OpenBazaarNode) RefundOrder(contract *pb.RicardianContract, records []*
 		return err
 	}
 	refundMsg.Timestamp = ts
-	if contract.BuyerOrder.Payment.Method == pb.Order_Payment_MODERATED {
-		var ins []spvwallet.TransactionInput
-		var outValue int64
-		for _, r := range records {
-			if !r.Spent && r.Value > 0 {
-				outpointHash, err := hex.DecodeString(r.Txid)
-				if err != nil {
-					return err
-				}
-				outValue += r.Value
-				in := spvwallet.TransactionInput{OutpointIndex: r.Index, OutpointHash: outpointHash}
-				ins = append(ins, in)
+	if len(items) > 0 {
+		if err := validateRefundItems(contract, items); err != nil {
+			return err
+		}
+		if amount == 0 {
+			amount, err = n.ItemRefundAmount(contract, items)
+			if err != nil {
+				return err
 			}
 		}
-
-		refundAddress, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, n.Wallet.Params())
+		refundMsg.Items = items
+	}
+	if contract.BuyerOrder.Payment.Method == pb.Order_Payment_MODERATED {
+		ins, outValue, err := escrowInputs(records)
 		if err != nil {
 			return err
 		}
-		var output spvwallet.TransactionOutput
-
-		outputScript, err := txscript.PayToAddrScript(refundAddress)
+		if err := checkRefundAmount(requested, outValue); err != nil {
+			return err
+		}
+		if amount > 0 && amount < uint64(outValue) {
+			refundMsg.Amount = amount
+			refundMsg.VendorAddress = wal.CurrentAddress(spvwallet.EXTERNAL).EncodeAddress()
+		}
+		outputs, err := n.RefundOutputs(wal, contract, refundMsg, ins, outValue)
 		if err != nil {
 			return err
 		}
-		output.ScriptPubKey = outputScript
-		output.Value = outValue
 
 		chaincode, err := hex.DecodeString(contract.BuyerOrder.Payment.Chaincode)
 		if err != nil {
 			return err
 		}
 		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := n.Wallet.MasterPrivateKey()
+		mPrivKey := wal.MasterPrivateKey()
 		if err != nil {
 			return err
 		}
@@ -69,7 +96,7 @@ func (n *OpenBazaarNode) RefundOrder(contract *pb.RicardianContract, records []*
 			return err
 		}
 		hdKey := hd.NewExtendedKey(
-			n.Wallet.Params().HDPrivateKeyID[:],
+			wal.Params().HDPrivateKeyID[:],
 			mECKey.Serialize(),
 			chaincode,
 			parentFP,
@@ -83,7 +110,7 @@ func (n *OpenBazaarNode) RefundOrder(contract *pb.RicardianContract, records []*
 		}
 		redeemScript, err := hex.DecodeString(contract.BuyerOrder.Payment.RedeemScript)
 
-		signatures, err := n.Wallet.CreateMultisigSignature(ins, []spvwallet.TransactionOutput{output}, vendorKey, redeemScript, contract.BuyerOrder.RefundFee)
+		signatures, err := wal.CreateMultisigSignature(ins, outputs, vendorKey, redeemScript, contract.BuyerOrder.RefundFee)
 		if err != nil {
 			return err
 		}
@@ -101,11 +128,18 @@ func (n *OpenBazaarNode) RefundOrder(contract *pb.RicardianContract, records []*
 				outValue += r.Value
 			}
 		}
-		refundAddr, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, n.Wallet.Params())
+		if err := checkRefundAmount(requested, outValue); err != nil {
+			return err
+		}
+		if amount > 0 && amount < uint64(outValue) {
+			refundMsg.Amount = amount
+			outValue = int64(amount)
+		}
+		refundAddr, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, wal.Params())
 		if err != nil {
 			return err
 		}
-		_, err = n.Wallet.Spend(outValue, refundAddr, spvwallet.NORMAL)
+		_, err = wal.Spend(outValue, refundAddr, spvwallet.NORMAL)
 		if err != nil {
 			return err
 		}
@@ -116,10 +150,142 @@ func (n *OpenBazaarNode) RefundOrder(contract *pb.RicardianContract, records []*
 		return err
 	}
 	n.SendRefund(contract.BuyerOrder.BuyerID.PeerID, contract)
-	n.Datastore.Sales().Put(orderId, *contract, pb.OrderState_REFUNDED, true)
+	state := RefundState(refundMsg)
+	if IsRefundOffer(contract) {
+		// The order stays as it is until the buyer accepts the offer and the escrow is spent
+		_, state, _, _, _, err = n.Datastore.Sales().GetByOrderId(orderId)
+		if err != nil {
+			return err
+		}
+	}
+	n.Datastore.Sales().Put(orderId, *contract, state, true)
 	return nil
 }
 
+// Check a requested partial refund amount is less than what the buyer paid. Refunds of all
+// the items in an order may add up to the whole payment and are paid as a full refund.
+func checkRefundAmount(requested uint64, paid int64) error {
+	if requested > 0 && requested >= uint64(paid) {
+		return ErrRefundExceedsPayment
+	}
+	return nil
+}
+
+// Return whether the refund in a contract is a partial refund of a moderated order. Those
+// also release the rest of the escrow to the vendor so the buyer must accept them before
+// they are co-signed.
+func IsRefundOffer(contract *pb.RicardianContract) bool {
+	return contract.Refund != nil && contract.Refund.Amount > 0 &&
+		contract.BuyerOrder.Payment.Method == pb.Order_Payment_MODERATED
+}
+
+// Return the order state after the given refund
+func RefundState(refund *pb.Refund) pb.OrderState {
+	if refund.Amount > 0 {
+		return pb.OrderState_PARTIALLY_REFUNDED
+	}
+	return pb.OrderState_REFUNDED
+}
+
+// Return the share of the buyer's payment made up by the given items of the order
+func (n *OpenBazaarNode) ItemRefundAmount(contract *pb.RicardianContract, items []uint32) (uint64, error) {
+	if err := validateRefundItems(contract, items); err != nil {
+		return 0, err
+	}
+	total, err := n.CalculateOrderTotal(contract)
+	if err != nil {
+		return 0, err
+	}
+	if total == 0 {
+		return 0, errors.New("Order total is zero")
+	}
+	subset := proto.Clone(contract).(*pb.RicardianContract)
+	subset.BuyerOrder.Items = nil
+	for _, i := range items {
+		subset.BuyerOrder.Items = append(subset.BuyerOrder.Items, contract.BuyerOrder.Items[i])
+	}
+	itemsTotal, err := n.CalculateOrderTotal(subset)
+	if err != nil {
+		return 0, err
+	}
+	// Prices are recalculated at the current exchange rate so use the ratio rather than the amount
+	return uint64(float64(contract.BuyerOrder.Payment.Amount) * float64(itemsTotal) / float64(total)), nil
+}
+
+// Check the refunded items exist in the order and are listed once
+func validateRefundItems(contract *pb.RicardianContract, items []uint32) error {
+	seen := make(map[uint32]bool)
+	for _, i := range items {
+		if int(i) >= len(contract.BuyerOrder.Items) {
+			return errors.New("Refunded item is not in the order")
+		}
+		if seen[i] {
+			return errors.New("Refunded item is listed more than once")
+		}
+		seen[i] = true
+	}
+	return nil
+}
+
+// Return the unspent escrow outputs of an order and their total value
+func escrowInputs(records []*spvwallet.TransactionRecord) ([]spvwallet.TransactionInput, int64, error) {
+	var ins []spvwallet.TransactionInput
+	var outValue int64
+	for _, r := range records {
+		if !r.Spent && r.Value > 0 {
+			outpointHash, err := hex.DecodeString(r.Txid)
+			if err != nil {
+				return nil, 0, err
+			}
+			outValue += r.Value
+			in := spvwallet.TransactionInput{OutpointIndex: r.Index, OutpointHash: outpointHash}
+			ins = append(ins, in)
+		}
+	}
+	return ins, outValue, nil
+}
+
+// Build the outputs of the transaction spending the escrow of a moderated order for the given
+// refund. A full refund sends everything to the buyer. A partial refund sends the amount to the
+// buyer and the remainder to the vendor's address in the refund. Both sides use this so that
+// they sign the same transaction.
+func (n *OpenBazaarNode) RefundOutputs(wal bitcoin.BitcoinWallet, contract *pb.RicardianContract, refund *pb.Refund, ins []spvwallet.TransactionInput, outValue int64) ([]spvwallet.TransactionOutput, error) {
+	refundAddress, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, wal.Params())
+	if err != nil {
+		return nil, err
+	}
+	buyerScript, err := txscript.PayToAddrScript(refundAddress)
+	if err != nil {
+		return nil, err
+	}
+	if refund.Amount == 0 {
+		return []spvwallet.TransactionOutput{{ScriptPubKey: buyerScript, Value: outValue}}, nil
+	}
+	if refund.Amount >= uint64(outValue) {
+		return nil, errors.New("Refund amount exceeds the funds in escrow")
+	}
+	vendorAddress, err := btcutil.DecodeAddress(refund.VendorAddress, wal.Params())
+	if err != nil {
+		return nil, err
+	}
+	vendorScript, err := txscript.PayToAddrScript(vendorAddress)
+	if err != nil {
+		return nil, err
+	}
+	outputs := []spvwallet.TransactionOutput{
+		{ScriptPubKey: buyerScript, Value: int64(refund.Amount)},
+		{ScriptPubKey: vendorScript, Value: outValue - int64(refund.Amount)},
+	}
+	// The fee is split evenly between the outputs
+	feePerOutput := int64(wal.EstimateFee(ins, outputs, contract.BuyerOrder.RefundFee)) / int64(len(outputs))
+	for _, o := range outputs {
+		if o.Value-feePerOutput < refundDustLimit {
+			return nil, errors.New("Refund leaves an output too small to spend")
+		}
+	}
+	return outputs, nil
+}
+
 func (n *OpenBazaarNode) SignRefund(contract *pb.RicardianContract) (*pb.RicardianContract, error) {
 	serializedRefund, err := proto.Marshal(contract.Refund)
 	if err != nil {
@@ -160,3 +326,104 @@ func (n *OpenBazaarNode) VerifySignaturesOnRefund(contract *pb.RicardianContract
 	}
 	return nil
 }
+
+// Check a refund we received for an order in the given state. Partial refunds must list the
+// refunded items and cover their share of the payment.
+func (n *OpenBazaarNode) ValidateRefund(contract *pb.RicardianContract, state pb.OrderState, refund *pb.Refund) error {
+	switch state {
+	case pb.OrderState_COMPLETE, pb.OrderState_DISPUTED, pb.OrderState_DECIDED, pb.OrderState_RESOLVED,
+		pb.OrderState_REFUNDED, pb.OrderState_PARTIALLY_REFUNDED, pb.OrderState_CANCELED,
+		pb.OrderState_REJECTED, pb.OrderState_PAYMENT_FINALIZED:
+		return errors.New("Order can no longer be refunded")
+	}
+	if err := validateRefundItems(contract, refund.Items); err != nil {
+		return err
+	}
+	if refund.Amount == 0 {
+		return nil
+	}
+	if len(refund.Items) == 0 {
+		return errors.New("Partial refund does not list the refunded items")
+	}
+	expected, err := n.ItemRefundAmount(contract, refund.Items)
+	if err != nil {
+		return err
+	}
+	if !n.ValidatePaymentAmount(expected, refund.Amount) {
+		return errors.New("Refund amount is less than the price of the refunded items")
+	}
+	return nil
+}
+
+// Add our signatures to the vendor's on a refund of a moderated order and broadcast the
+// transaction spending the escrow
+func (n *OpenBazaarNode) CoSignRefund(contract *pb.RicardianContract, refund *pb.Refund, records []*spvwallet.TransactionRecord) error {
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
+	}
+	ins, outValue, err := escrowInputs(records)
+	if err != nil {
+		return err
+	}
+	outputs, err := n.RefundOutputs(wal, contract, refund, ins, outValue)
+	if err != nil {
+		return err
+	}
+
+	chaincode, err := hex.DecodeString(contract.BuyerOrder.Payment.Chaincode)
+	if err != nil {
+		return err
+	}
+	parentFP := []byte{0x00, 0x00, 0x00, 0x00}
+	mPrivKey := wal.MasterPrivateKey()
+	mECKey, err := mPrivKey.ECPrivKey()
+	if err != nil {
+		return err
+	}
+	hdKey := hd.NewExtendedKey(
+		wal.Params().HDPrivateKeyID[:],
+		mECKey.Serialize(),
+		chaincode,
+		parentFP,
+		0,
+		0,
+		true)
+
+	buyerKey, err := hdKey.Child(0)
+	if err != nil {
+		return err
+	}
+	redeemScript, err := hex.DecodeString(contract.BuyerOrder.Payment.RedeemScript)
+	if err != nil {
+		return err
+	}
+
+	buyerSignatures, err := wal.CreateMultisigSignature(ins, outputs, buyerKey, redeemScript, contract.BuyerOrder.RefundFee)
+	if err != nil {
+		return err
+	}
+	var vendorSignatures []spvwallet.Signature
+	for _, s := range refund.Sigs {
+		sig := spvwallet.Signature{InputIndex: s.InputIndex, Signature: s.Signature}
+		vendorSignatures = append(vendorSignatures, sig)
+	}
+	_, err = wal.Multisign(ins, outputs, buyerSignatures, vendorSignatures, redeemScript, contract.BuyerOrder.RefundFee, true)
+	return err
+}
+
+// Accept the partial refund the vendor offered for one of our moderated purchases,
+// releasing the escrow between us and the vendor as the offer describes
+func (n *OpenBazaarNode) AcceptRefund(orderId string) error {
+	contract, state, _, records, _, err := n.Datastore.Purchases().GetByOrderId(orderId)
+	if err != nil || !IsRefundOffer(contract) {
+		return ErrRefundOfferNotFound
+	}
+	if err := n.ValidateRefund(contract, state, contract.Refund); err != nil {
+		return err
+	}
+	if err := n.CoSignRefund(contract, contract.Refund, records); err != nil {
+		return err
+	}
+	return n.Datastore.Purchases().Put(orderId, *contract, RefundState(contract.Refund), false)
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+)
+
+type refundTestWallet struct {
+	bitcoin.BitcoinWallet
+	fee uint64
+}
+
+func (w *refundTestWallet) Params() *chaincfg.Params {
+	return &chaincfg.TestNet3Params
+}
+
+func (w *refundTestWallet) EstimateFee(ins []spvwallet.TransactionInput, outs []spvwallet.TransactionOutput, feePerByte uint64) uint64 {
+	return w.fee
+}
+
+func refundTestAddress(t *testing.T, b byte) string {
+	hash := make([]byte, 20)
+	hash[0] = b
+	addr, err := btcutil.NewAddressPubKeyHash(hash, &chaincfg.TestNet3Params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr.EncodeAddress()
+}
+
+func refundTestContract(t *testing.T) *pb.RicardianContract {
+	return &pb.RicardianContract{
+		BuyerOrder: &pb.Order{
+			RefundAddress: refundTestAddress(t, 1),
+			Items:         []*pb.Order_Item{{}, {}, {}},
+			Payment:       &pb.Order_Payment{Amount: 100000},
+		},
+	}
+}
+
+func TestValidateRefundItems(t *testing.T) {
+	contract := refundTestContract(t)
+	if err := validateRefundItems(contract, []uint32{0, 2}); err != nil {
+		t.Error(err)
+	}
+	if err := validateRefundItems(contract, []uint32{3}); err == nil {
+		t.Error("Expected an error for an item outside the order")
+	}
+	if err := validateRefundItems(contract, []uint32{1, 1}); err == nil {
+		t.Error("Expected an error for a duplicate item")
+	}
+}
+
+func TestRefundState(t *testing.T) {
+	if RefundState(&pb.Refund{}) != pb.OrderState_REFUNDED {
+		t.Error("A refund without an amount refunds the whole order")
+	}
+	if RefundState(&pb.Refund{Amount: 1000}) != pb.OrderState_PARTIALLY_REFUNDED {
+		t.Error("A refund with an amount is partial")
+	}
+}
+
+func TestRefundOutputs(t *testing.T) {
+	n := &OpenBazaarNode{}
+	wal := &refundTestWallet{fee: 2000}
+	contract := refundTestContract(t)
+
+	outs, err := n.RefundOutputs(wal, contract, &pb.Refund{}, nil, 100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outs) != 1 || outs[0].Value != 100000 {
+		t.Error("A full refund should send everything to the buyer")
+	}
+
+	refund := &pb.Refund{Amount: 30000, VendorAddress: refundTestAddress(t, 2)}
+	outs, err = n.RefundOutputs(wal, contract, refund, nil, 100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outs) != 2 || outs[0].Value != 30000 || outs[1].Value != 70000 {
+		t.Error("A partial refund should split the escrow between the buyer and vendor")
+	}
+
+	refund.Amount = 100000
+	if _, err := n.RefundOutputs(wal, contract, refund, nil, 100000); err == nil {
+		t.Error("Expected an error when the refund exceeds the escrow")
+	}
+	refund.Amount = 1200
+	if _, err := n.RefundOutputs(wal, contract, refund, nil, 100000); err == nil {
+		t.Error("Expected an error when the refund output is dust after fees")
+	}
+	refund.Amount = 30000
+	refund.VendorAddress = ""
+	if _, err := n.RefundOutputs(wal, contract, refund, nil, 100000); err == nil {
+		t.Error("Expected an error for a partial refund without a vendor address")
+	}
+}
+
+func TestValidateRefund(t *testing.T) {
+	n := &OpenBazaarNode{}
+	contract := refundTestContract(t)
+
+	if err := n.ValidateRefund(contract, pb.OrderState_FUNDED, &pb.Refund{}); err != nil {
+		t.Error(err)
+	}
+	if err := n.ValidateRefund(contract, pb.OrderState_DISPUTED, &pb.Refund{}); err == nil {
+		t.Error("Expected an error for a refund of a disputed order")
+	}
+	if err := n.ValidateRefund(contract, pb.OrderState_COMPLETE, &pb.Refund{}); err == nil {
+		t.Error("Expected an error for a refund of a completed order")
+	}
+	if err := n.ValidateRefund(contract, pb.OrderState_FUNDED, &pb.Refund{Amount: 1}); err == nil {
+		t.Error("Expected an error for a partial refund without items")
+	}
+	if err := n.ValidateRefund(contract, pb.OrderState_FUNDED, &pb.Refund{Items: []uint32{3}}); err == nil {
+		t.Error("Expected an error for a refunded item outside the order")
+	}
+}
+
+func TestIsRefundOffer(t *testing.T) {
+	contract := refundTestContract(t)
+	contract.Refund = &pb.Refund{Amount: 1000}
+	if IsRefundOffer(contract) {
+		t.Error("A partial refund of a direct order is paid by the vendor and needs no acceptance")
+	}
+	contract.BuyerOrder.Payment.Method = pb.Order_Payment_MODERATED
+	if !IsRefundOffer(contract) {
+		t.Error("A partial refund of a moderated order must be accepted by the buyer")
+	}
+	contract.Refund.Amount = 0
+	if IsRefundOffer(contract) {
+		t.Error("A full refund of a moderated order needs no acceptance")
+	}
+}
+
+func TestCheckRefundAmount(t *testing.T) {
+	if err := checkRefundAmount(0, 100000); err != nil {
+		t.Error(err)
+	}
+	if err := checkRefundAmount(99999, 100000); err != nil {
+		t.Error(err)
+	}
+	if err := checkRefundAmount(100000, 100000); err != ErrRefundExceedsPayment {
+		t.Error("Expected an error for a partial refund of the whole payment")
+	}
+	if err := checkRefundAmount(200000, 100000); err != ErrRefundExceedsPayment {
+		t.Error("Expected an error for a partial refund larger than the payment")
+	}
+}
+
+func TestRefundOrderWithPendingOffer(t *testing.T) {
+	n := &OpenBazaarNode{}
+	contract := refundTestContract(t)
+	contract.BuyerOrder.Payment.Method = pb.Order_Payment_MODERATED
+	contract.Refund = &pb.Refund{Amount: 1000, Items: []uint32{0}}
+	if err := n.RefundOrder(contract, nil, 2000, []uint32{1}); err != ErrRefundOfferPending {
+		t.Errorf("Expected ErrRefundOfferPending, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"os"
+	"path"
+
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+)
+
+// Left in the data directory by a restore from a database-only backup. While it exists
+// the node fetches the root directory it last published instead of publishing the
+// empty directory created by the restore.
+const RestoreRootMarker = "restore-root"
+
+func (n *OpenBazaarNode) NeedsRootRestore() bool {
+	_, err := os.Stat(path.Join(n.RepoPath, RestoreRootMarker))
+	return err == nil
+}
+
+// Rebuild the root directory from the copy published at our peer ID
+func (n *OpenBazaarNode) RestoreRoot() error {
+	tmp := path.Join(n.RepoPath, "root.restore")
+	os.RemoveAll(tmp)
+	if err := ipfs.Get(n.Context, path.Join("/ipns", n.IpfsNode.Identity.Pretty()), tmp); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+	root := path.Join(n.RepoPath, "root")
+	if err := os.RemoveAll(root); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, root); err != nil {
+		return err
+	}
+	log.Notice("Restored root directory from the network")
+	return os.Remove(path.Join(n.RepoPath, RestoreRootMarker))
+}
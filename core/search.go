@@ -0,0 +1,174 @@
+package core
+
+import (
+	"encoding/json"
+	"path"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+// How often the node checks followed stores for new or changed listings
+const SearchIndexInterval = time.Minute * 30
+
+// SearchResults are the listings matching a search along with facet counts which let
+// the client narrow the search by category, shipping region or contract type.
+type SearchResults struct {
+	Total   int                  `json:"total"`
+	Results []repo.SearchListing `json:"results"`
+	Facets  SearchFacets         `json:"facets"`
+}
+
+type SearchFacets struct {
+	Categories    map[string]int `json:"categories"`
+	ShipsTo       map[string]int `json:"shipsTo"`
+	ContractTypes map[string]int `json:"contractTypes"`
+}
+
+func newSearchListing(peerID string, ld listingData) repo.SearchListing {
+	return repo.SearchListing{
+		PeerId:        peerID,
+		Hash:          ld.Hash,
+		Slug:          ld.Slug,
+		Title:         ld.Title,
+		Categories:    ld.Categories,
+		Tags:          ld.Tags,
+		ContractType:  ld.ContractType,
+		Description:   ld.Description,
+		Thumbnail:     repo.SearchThumbnail{Tiny: ld.Thumbnail.Tiny, Small: ld.Thumbnail.Small, Medium: ld.Thumbnail.Medium},
+		Price:         repo.SearchPrice{CurrencyCode: ld.Price.CurrencyCode, Amount: ld.Price.Amount},
+		ShipsTo:       ld.ShipsTo,
+		FreeShipping:  ld.FreeShipping,
+		Language:      ld.Language,
+		AverageRating: ld.AverageRating,
+		RatingCount:   ld.RatingCount,
+	}
+}
+
+// Bring a peer's entries in the search index in line with its listing index. Only
+// listings which have changed are rewritten and removed listings are deleted.
+func (n *OpenBazaarNode) reindexListings(peerID string, index []listingData) error {
+	current, err := n.Datastore.SearchIndex().Get(peerID)
+	if err != nil {
+		return err
+	}
+	indexed := make(map[string]repo.SearchListing)
+	for _, l := range current {
+		indexed[l.Slug] = l
+	}
+	for _, ld := range index {
+		l, ok := indexed[ld.Slug]
+		delete(indexed, ld.Slug)
+		// Ratings are updated in the listing index without changing the listing hash
+		if ok && l.Hash == ld.Hash && l.AverageRating == ld.AverageRating && l.RatingCount == ld.RatingCount {
+			continue
+		}
+		if err := n.Datastore.SearchIndex().Put(newSearchListing(peerID, ld)); err != nil {
+			return err
+		}
+	}
+	for slug := range indexed {
+		if err := n.Datastore.SearchIndex().Delete(peerID, slug); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update the search index with our own listings
+func (n *OpenBazaarNode) IndexOwnListings() error {
+	index, err := n.getListingIndex()
+	if err != nil {
+		return err
+	}
+	return n.reindexListings(n.IpfsNode.Identity.Pretty(), index)
+}
+
+// Update the search index with a peer's listings. The peer's listing index is only
+// fetched if it has republished since it was last indexed.
+func (n *OpenBazaarNode) IndexPeerListings(peerID string) error {
+	rootHash, err := ipfs.Resolve(n.Context, peerID)
+	if err != nil {
+		return err
+	}
+	indexedHash, err := n.Datastore.SearchIndex().GetRootHash(peerID)
+	if err != nil {
+		return err
+	}
+	if rootHash == indexedHash {
+		return nil
+	}
+	b, err := ipfs.Cat(n.Context, path.Join(rootHash, "listings", "index.json"))
+	if err != nil {
+		return err
+	}
+	var index []listingData
+	if err := json.Unmarshal(b, &index); err != nil {
+		return err
+	}
+	if err := n.reindexListings(peerID, index); err != nil {
+		return err
+	}
+	return n.Datastore.SearchIndex().PutRootHash(peerID, rootHash)
+}
+
+// Update the search index with our own listings and those of every store we follow
+func (n *OpenBazaarNode) UpdateSearchIndex() {
+	if err := n.IndexOwnListings(); err != nil {
+		log.Error(err)
+	}
+	following, err := n.Datastore.Following().Get("", -1)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, peerID := range following {
+		if err := n.IndexPeerListings(peerID); err != nil {
+			log.Debugf("Error indexing listings of %s: %s", peerID, err)
+		}
+	}
+}
+
+// Search the listings of our own store and the stores we follow
+func (n *OpenBazaarNode) Search(query repo.SearchQuery) (*SearchResults, error) {
+	// Our own index is on disk so it's cheap to pick up any edits before searching
+	if err := n.IndexOwnListings(); err != nil {
+		log.Error(err)
+	}
+	listings, err := n.Datastore.SearchIndex().Query(query)
+	if err != nil {
+		return nil, err
+	}
+	if listings == nil {
+		listings = []repo.SearchListing{}
+	}
+	results := &SearchResults{
+		Total:   len(listings),
+		Results: listings,
+		Facets: SearchFacets{
+			Categories:    make(map[string]int),
+			ShipsTo:       make(map[string]int),
+			ContractTypes: make(map[string]int),
+		},
+	}
+	for _, l := range listings {
+		for _, category := range l.Categories {
+			results.Facets.Categories[category]++
+		}
+		for _, region := range l.ShipsTo {
+			results.Facets.ShipsTo[region]++
+		}
+		results.Facets.ContractTypes[l.ContractType]++
+	}
+	return results, nil
+}
+
+// Periodically update the search index. This should be run in a separate goroutine.
+func (n *OpenBazaarNode) RunSearchIndexer() {
+	n.UpdateSearchIndex()
+	t := time.NewTicker(SearchIndexInterval)
+	for range t.C {
+		n.UpdateSearchIndex()
+	}
+}
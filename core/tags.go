@@ -0,0 +1,118 @@
+package core
+
+import (
+	multihash "gx/ipfs/QmbZ6Cee2uHjG7hf19qLHppgKDRtaG4CVtMzdmK9VCVqLu/go-multihash"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/OpenBazaar/openbazaar-go/ipfs"
+	dht "github.com/ipfs/go-ipfs/routing/dht"
+	"golang.org/x/net/context"
+)
+
+// Tag and channel pointers use the full key so a lookup only returns stores using that name
+const TagPointerPrefixLength = 64
+
+// Serializes updates so concurrent edits don't publish the same pointer twice
+var namedPointerLock sync.Mutex
+
+// Tags are matched case insensitively
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// Return the multihash pointers to stores selling under the given tag are published at
+func TagPointerID(tag string) (multihash.Multihash, error) {
+	h, err := EncodeMultihash([]byte(normalizeTag(tag)))
+	if err != nil {
+		return nil, err
+	}
+	return *h, nil
+}
+
+// Publish a pointer for each tag used by our listings and retire the pointers of tags
+// which are no longer used by any listing.
+func (n *OpenBazaarNode) UpdateTagPointers() {
+	index, err := n.getListingIndex()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	tags := make(map[string]bool)
+	for _, l := range index {
+		sl, err := n.GetListingFromSlug(l.Slug)
+		if err != nil {
+			continue
+		}
+		for _, tag := range sl.Listing.Item.Tags {
+			if t := normalizeTag(tag); t != "" {
+				tags[t] = true
+			}
+		}
+	}
+	n.syncNamedPointers(ipfs.TAG, tags, TagPointerID)
+}
+
+// Make sure we have published exactly one pointer of the given purpose for each name.
+// Pointers for names which are no longer wanted are deleted so they stop being
+// republished and expire from the dht.
+func (n *OpenBazaarNode) syncNamedPointers(purpose ipfs.Purpose, names map[string]bool, pointerID func(string) (multihash.Multihash, error)) {
+	namedPointerLock.Lock()
+	defer namedPointerLock.Unlock()
+
+	// Keyed by the dht key of the name
+	wanted := make(map[string]string)
+	for name := range names {
+		id, err := pointerID(name)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		k, err := ipfs.PointerKey(id, TagPointerPrefixLength)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		wanted[k.String()] = name
+	}
+
+	pointers, err := n.Datastore.Pointers().GetByPurpose(purpose)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, p := range pointers {
+		if _, ok := wanted[p.Cid.String()]; ok {
+			delete(wanted, p.Cid.String())
+			continue
+		}
+		if err := n.Datastore.Pointers().Delete(p.Value.ID); err != nil {
+			log.Error(err)
+		}
+	}
+
+	// Pointers can't be published without the dht, such as when running offline
+	if _, ok := n.IpfsNode.Routing.(*dht.IpfsDHT); len(wanted) == 0 || !ok {
+		return
+	}
+	addr, err := n.selfPointerAddress()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	ctx := context.Background()
+	for _, name := range wanted {
+		id, _ := pointerID(name)
+		entropy := []byte(n.IpfsNode.Identity.Pretty() + strconv.Itoa(int(purpose)) + name)
+		pointer, err := ipfs.PublishPointer(n.IpfsNode, ctx, id, TagPointerPrefixLength, addr, entropy)
+		if err != nil {
+			log.Errorf("Error publishing pointer for %s: %s", name, err)
+			continue
+		}
+		pointer.Purpose = purpose
+		if err := n.Datastore.Pointers().Put(pointer); err != nil {
+			log.Error(err)
+		}
+	}
+}
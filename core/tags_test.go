@@ -0,0 +1,23 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestTagPointerID(t *testing.T) {
+	a, err := TagPointerID("Vintage ")
+	if err != nil {
+		t.Error(err)
+	}
+	b, err := TagPointerID("vintage")
+	if err != nil {
+		t.Error(err)
+	}
+	if a.B58String() != b.B58String() {
+		t.Error("Tags were not normalized")
+	}
+	c, _ := TagPointerID("antique")
+	if a.B58String() == c.B58String() {
+		t.Error("Different tags returned the same pointer ID")
+	}
+}
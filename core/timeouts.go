@@ -0,0 +1,367 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/api/notifications"
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/spvwallet"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	btc "github.com/btcsuite/btcutil"
+	hd "github.com/btcsuite/btcutil/hdkeychain"
+)
+
+// How often the node checks for orders which have passed a deadline
+const OrderTimeoutInterval = time.Minute * 10
+
+// Bitcoin and Bitcoin Cash blocks are mined roughly every ten minutes
+const blocksPerHour = 6
+
+// Coins whose block interval differs from bitcoin's
+var coinBlocksPerHour = map[string]uint32{
+	"LTC":  24,
+	"TLTC": 24,
+}
+
+// Return the number of blocks mined per hour on the chain of the given coin
+func BlocksPerHour(coin string) uint32 {
+	if b, ok := coinBlocksPerHour[strings.ToUpper(coin)]; ok {
+		return b
+	}
+	return blocksPerHour
+}
+
+// Return the longest escrow timeout a listing may set. It must fit in an escrow script on
+// the chain with the most blocks per hour since the buyer may pay with any coin.
+func MaxEscrowTimeoutHours() uint32 {
+	max := uint32(blocksPerHour)
+	for _, b := range coinBlocksPerHour {
+		if b > max {
+			max = b
+		}
+	}
+	return bitcoin.MaxTimeoutBlocks / max
+}
+
+// The smallest escrow timeout a listing may set. Buyers need time to open a dispute.
+const MinEscrowTimeoutHours = 72
+
+// The parts of the Sales and Purchases stores needed to enforce deadlines on either side of an order
+type orderStore interface {
+	GetStale(state pb.OrderState, before time.Time) ([]repo.StaleOrder, error)
+	MarkDeadlineNotified(orderID string) error
+	GetByOrderId(orderId string) (*pb.RicardianContract, pb.OrderState, bool, []*spvwallet.TransactionRecord, bool, error)
+	Put(orderID string, contract pb.RicardianContract, state pb.OrderState, read bool) error
+}
+
+// Return the escrow timeout, in blocks of the given coin, that a moderated order for the given
+// listings commits to. If the listings disagree the longest timeout is used. Crowdfund pledges and
+// orders without a moderator have no timeout since the vendor must not be able to claim them.
+func EscrowTimeoutBlocks(listings []*pb.Listing, moderator string, coin string) uint32 {
+	if moderator == "" {
+		return 0
+	}
+	var hours uint32
+	for _, listing := range listings {
+		if listing.Metadata.ContractType == pb.Listing_Metadata_CROWD_FUND {
+			return 0
+		}
+		if listing.Metadata.EscrowTimeoutHours > hours {
+			hours = listing.Metadata.EscrowTimeoutHours
+		}
+	}
+	// Listings published before the timeout was limited for every coin may not fit
+	blocks := uint64(hours) * uint64(BlocksPerHour(coin))
+	if blocks > bitcoin.MaxTimeoutBlocks {
+		return bitcoin.MaxTimeoutBlocks
+	}
+	return uint32(blocks)
+}
+
+// Return the escrow timeout, in blocks, of the payment address of a moderated order. Pledges
+// without a moderator time out back to the buyer, other orders to the vendor.
+func OrderEscrowTimeout(contract *pb.RicardianContract) (uint32, error) {
+	coin := ContractCoin(contract)
+	if isUnmoderatedPledge(contract) {
+		placed := time.Unix(contract.BuyerOrder.Timestamp.Seconds, 0)
+		return PledgeTimeoutBlocks(contract.VendorListings[0], placed, coin)
+	}
+	return EscrowTimeoutBlocks(contract.VendorListings, contract.BuyerOrder.Payment.Moderator, coin), nil
+}
+
+// Generate the escrow address for a moderated order. If the order has an escrow timeout the
+// script includes a branch which lets the vendor claim the funds once the timeout has passed.
+func (n *OpenBazaarNode) generateEscrowScript(wal bitcoin.BitcoinWallet, keys []hd.ExtendedKey, vendorKey hd.ExtendedKey, timeout uint32) (btc.Address, []byte, error) {
+	if timeout == 0 {
+		return wal.GenerateMultisigScript(keys, 2)
+	}
+	return bitcoin.TimelockedMultisigScript(keys, 2, vendorKey, timeout, wal.Params())
+}
+
+// Return the number of blocks remaining until the escrow timeout of an order has passed,
+// measured from the least confirmed funding transaction.
+func (n *OpenBazaarNode) escrowBlocksRemaining(wal bitcoin.BitcoinWallet, timeout uint32, records []*spvwallet.TransactionRecord) (uint32, error) {
+	remaining := uint32(0)
+	for _, r := range records {
+		if r.Value <= 0 {
+			continue
+		}
+		txid, err := chainhash.NewHashFromStr(r.Txid)
+		if err != nil {
+			return 0, err
+		}
+		confirmations, err := wal.GetConfirmations(*txid)
+		if err != nil {
+			return 0, err
+		}
+		if confirmations < timeout && timeout-confirmations > remaining {
+			remaining = timeout - confirmations
+		}
+	}
+	return remaining, nil
+}
+
+// Returns true if a transaction spending from the escrow address has been seen
+func escrowSpent(records []*spvwallet.TransactionRecord) bool {
+	for _, r := range records {
+		if r.Value < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Claim the funds of a fulfilled moderated order through the timeout branch of its escrow
+// script. This is only possible once the buyer has let the escrow timeout pass without
+// completing the order or opening a dispute.
+func (n *OpenBazaarNode) ReleaseFundsAfterTimeout(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+	if isUnmoderatedPledge(contract) {
+		return errors.New("Only the buyer can claim a pledge after its timeout")
+	}
+	if err := n.claimEscrowAfterTimeout(contract, records); err != nil {
+		return err
+	}
+	return n.Datastore.Sales().Put(contract.VendorOrderConfirmation.OrderID, *contract, pb.OrderState_PAYMENT_FINALIZED, false)
+}
+
+// Reclaim one of our crowdfund pledges without a moderator through the timeout branch of its
+// escrow script. This is only possible once the vendor has let the settlement window and
+// the margin after it pass without a release or refund.
+func (n *OpenBazaarNode) ReclaimPledge(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+	if !isUnmoderatedPledge(contract) {
+		return errors.New("Order is not a pledge without a moderator")
+	}
+	if err := n.claimEscrowAfterTimeout(contract, records); err != nil {
+		return err
+	}
+	orderId, err := n.CalcOrderId(contract.BuyerOrder)
+	if err != nil {
+		return err
+	}
+	return n.Datastore.Purchases().Put(orderId, *contract, pb.OrderState_REFUNDED, false)
+}
+
+// Spend the funds of a moderated order to our wallet through the timeout branch of its
+// escrow script, signing with our key for the order
+func (n *OpenBazaarNode) claimEscrowAfterTimeout(contract *pb.RicardianContract, records []*spvwallet.TransactionRecord) error {
+	wal, err := n.WalletForContract(contract)
+	if err != nil {
+		return err
+	}
+	timeout, err := OrderEscrowTimeout(contract)
+	if err != nil {
+		return err
+	}
+	if timeout == 0 {
+		return errors.New("Order does not have an escrow timeout")
+	}
+	remaining, err := n.escrowBlocksRemaining(wal, timeout, records)
+	if err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return errors.New("Escrow timeout has not yet passed")
+	}
+
+	var ins []spvwallet.TransactionInput
+	var outValue int64
+	for _, r := range records {
+		if !r.Spent && r.Value > 0 {
+			outpointHash, err := hex.DecodeString(r.Txid)
+			if err != nil {
+				return err
+			}
+			outValue += r.Value
+			in := spvwallet.TransactionInput{OutpointIndex: r.Index, OutpointHash: outpointHash}
+			ins = append(ins, in)
+		}
+	}
+	if len(ins) == 0 {
+		return errors.New("Escrow has no unspent funds")
+	}
+	outputScript, err := txscript.PayToAddrScript(wal.CurrentAddress(spvwallet.EXTERNAL))
+	if err != nil {
+		return err
+	}
+	output := spvwallet.TransactionOutput{ScriptPubKey: outputScript, Value: outValue}
+
+	chaincode, err := hex.DecodeString(contract.BuyerOrder.Payment.Chaincode)
+	if err != nil {
+		return err
+	}
+	mECKey, err := wal.MasterPrivateKey().ECPrivKey()
+	if err != nil {
+		return err
+	}
+	hdKey := hd.NewExtendedKey(
+		wal.Params().HDPrivateKeyID[:],
+		mECKey.Serialize(),
+		chaincode,
+		[]byte{0x00, 0x00, 0x00, 0x00},
+		0,
+		0,
+		true)
+	key, err := hdKey.Child(0)
+	if err != nil {
+		return err
+	}
+	redeemScript, err := hex.DecodeString(contract.BuyerOrder.Payment.RedeemScript)
+	if err != nil {
+		return err
+	}
+	tx, err := bitcoin.BuildTimeoutTransaction(ins, output, key, redeemScript, timeout, wal.GetFeePerByte(spvwallet.NORMAL))
+	if err != nil {
+		return err
+	}
+	return wal.Broadcast(tx)
+}
+
+// Cancel our sales and purchases which were never funded and warn about those about to expire
+func (n *OpenBazaarNode) expireUnfundedOrders() {
+	expiry := time.Hour * time.Duration(n.OrderTimeouts.UnfundedHours)
+	warning := expiry - time.Hour*time.Duration(n.OrderTimeouts.NotifyBeforeHours)
+	for _, store := range []orderStore{n.Datastore.Sales(), n.Datastore.Purchases()} {
+		for _, state := range []pb.OrderState{pb.OrderState_PENDING, pb.OrderState_CONFIRMED} {
+			stale, err := store.GetStale(state, time.Now().Add(-warning))
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+			for _, order := range stale {
+				if order.Funded {
+					continue
+				}
+				contract, _, _, _, _, err := store.GetByOrderId(order.OrderId)
+				if err != nil {
+					continue
+				}
+				if time.Since(order.LastUpdated) >= expiry {
+					log.Infof("Canceling unfunded order %s", order.OrderId)
+					if err := store.Put(order.OrderId, *contract, pb.OrderState_CANCELED, true); err != nil {
+						log.Error(err)
+					}
+					continue
+				}
+				if order.DeadlineNotified {
+					continue
+				}
+				notif := notifications.OrderExpiringNotification{
+					OrderId: order.OrderId,
+					Title:   contract.VendorListings[0].Item.Title,
+					Expires: order.LastUpdated.Add(expiry),
+				}
+				n.Broadcast <- notif
+				n.Datastore.Notifications().Put(notif, time.Now())
+				store.MarkDeadlineNotified(order.OrderId)
+			}
+		}
+	}
+}
+
+// Warn buyers, and vendors of fulfilled orders, when the escrow timeout of a moderated order
+// is approaching. Once it has passed the vendor claims the funds of fulfilled orders and
+// the buyer records the payout. Buyers reclaim pledges without a moderator which the vendor
+// never settled.
+func (n *OpenBazaarNode) enforceEscrowTimeouts() {
+	checks := []struct {
+		store  orderStore
+		state  pb.OrderState
+		isSale bool
+	}{
+		{n.Datastore.Sales(), pb.OrderState_FULFILLED, true},
+		{n.Datastore.Purchases(), pb.OrderState_FUNDED, false},
+		{n.Datastore.Purchases(), pb.OrderState_FULFILLED, false},
+	}
+	for _, c := range checks {
+		stale, err := c.store.GetStale(c.state, time.Now())
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		for _, order := range stale {
+			contract, _, _, records, _, err := c.store.GetByOrderId(order.OrderId)
+			if err != nil || contract.BuyerOrder.Payment.Method != pb.Order_Payment_MODERATED {
+				continue
+			}
+			coin := ContractCoin(contract)
+			timeout, err := OrderEscrowTimeout(contract)
+			if err != nil || timeout == 0 {
+				continue
+			}
+			wal, err := n.WalletForContract(contract)
+			if err != nil {
+				continue
+			}
+			remaining, err := n.escrowBlocksRemaining(wal, timeout, records)
+			if err != nil {
+				continue
+			}
+			if isUnmoderatedPledge(contract) {
+				if remaining == 0 && !c.isSale && !escrowSpent(records) {
+					if err := n.ReclaimPledge(contract, records); err != nil {
+						log.Errorf("Error reclaiming pledge %s: %s", order.OrderId, err)
+					}
+				}
+				continue
+			}
+			if remaining == 0 && c.state == pb.OrderState_FULFILLED {
+				if c.isSale {
+					if err := n.ReleaseFundsAfterTimeout(contract, records); err != nil {
+						log.Errorf("Error claiming escrow for order %s: %s", order.OrderId, err)
+					}
+				} else if escrowSpent(records) { // The vendor has spent the escrow
+					c.store.Put(order.OrderId, *contract, pb.OrderState_PAYMENT_FINALIZED, false)
+				}
+				continue
+			}
+			if remaining > uint32(n.OrderTimeouts.NotifyBeforeHours)*BlocksPerHour(coin) || order.DeadlineNotified {
+				continue
+			}
+			notif := notifications.EscrowTimeoutNotification{
+				OrderId: order.OrderId,
+				Title:   contract.VendorListings[0].Item.Title,
+				Timeout: time.Now().Add(time.Hour * time.Duration(remaining) / time.Duration(BlocksPerHour(coin))),
+			}
+			n.Broadcast <- notif
+			n.Datastore.Notifications().Put(notif, time.Now())
+			c.store.MarkDeadlineNotified(order.OrderId)
+		}
+	}
+}
+
+// Periodically enforce order deadlines. This should be run in a separate goroutine.
+func (n *OpenBazaarNode) RunOrderTimeouts() {
+	n.expireUnfundedOrders()
+	n.enforceEscrowTimeouts()
+	t := time.NewTicker(OrderTimeoutInterval)
+	for range t.C {
+		n.expireUnfundedOrders()
+		n.enforceEscrowTimeouts()
+	}
+}
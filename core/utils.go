@@ -46,3 +46,17 @@ func ExtractIDFromPointer(pi ps.PeerInfo) (string, error) {
 	}
 	return string(d.Digest), nil
 }
+
+// The address used by pointers, such as moderators and tags, which point to our own
+// peerID. It is the inverse of ExtractIDFromPointer.
+func (n *OpenBazaarNode) selfPointerAddress() (ma.Multiaddr, error) {
+	b, err := mh.Encode([]byte(n.IpfsNode.Identity.Pretty()), mh.SHA1)
+	if err != nil {
+		return nil, err
+	}
+	mhc, err := mh.Cast(b)
+	if err != nil {
+		return nil, err
+	}
+	return ma.NewMultiaddr("/ipfs/" + mhc.B58String())
+}
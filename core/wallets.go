@@ -0,0 +1,128 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+)
+
+// Return the wallet for the given currency code. An empty code returns the primary wallet.
+func (n *OpenBazaarNode) WalletForCurrency(code string) (bitcoin.BitcoinWallet, error) {
+	if n.Multiwallet == nil {
+		if code == "" || strings.EqualFold(code, n.Wallet.CurrencyCode()) {
+			return n.Wallet, nil
+		}
+		return nil, bitcoin.ErrUnsupportedCurrency
+	}
+	return n.Multiwallet.WalletForCurrencyCode(code)
+}
+
+// Return the wallet the order in the contract is paid with
+func (n *OpenBazaarNode) WalletForContract(contract *pb.RicardianContract) (bitcoin.BitcoinWallet, error) {
+	return n.WalletForCurrency(ContractCoin(contract))
+}
+
+// Return the currency codes of all wallets run by this node, primary wallet first
+func (n *OpenBazaarNode) WalletCurrencies() []string {
+	if n.Multiwallet == nil {
+		return []string{strings.ToUpper(n.Wallet.CurrencyCode())}
+	}
+	return n.Multiwallet.CurrencyCodes()
+}
+
+// Return the exchange rates used to price orders paid with the given coin
+func (n *OpenBazaarNode) ExchangeRatesForCurrency(code string) bitcoin.ExchangeRates {
+	if rates, ok := n.CoinExchangeRates[strings.ToUpper(code)]; ok {
+		return rates
+	}
+	return n.ExchangeRates
+}
+
+// Return the coin the buyer picked to pay for the order. Contracts created before
+// orders recorded a coin return an empty string which refers to the primary wallet.
+func ContractCoin(contract *pb.RicardianContract) string {
+	if contract.BuyerOrder == nil || contract.BuyerOrder.Payment == nil {
+		return ""
+	}
+	return contract.BuyerOrder.Payment.Coin
+}
+
+// Return the coins the listing accepts
+func ListingCurrencies(listing *pb.Listing) []string {
+	if listing.Metadata == nil {
+		return nil
+	}
+	if len(listing.Metadata.AcceptedCurrencies) > 0 {
+		return listing.Metadata.AcceptedCurrencies
+	}
+	if listing.Metadata.AcceptedCurrency == "" {
+		return nil
+	}
+	return []string{listing.Metadata.AcceptedCurrency}
+}
+
+// Return whether every listing in the contract accepts the given coin
+func listingsAcceptCurrency(listings []*pb.Listing, code string) bool {
+	for _, listing := range listings {
+		accepted := false
+		for _, c := range ListingCurrencies(listing) {
+			if strings.EqualFold(c, code) {
+				accepted = true
+				break
+			}
+		}
+		if !accepted {
+			return false
+		}
+	}
+	return true
+}
+
+// Return the coins a new listing accepts. Requested codes must match one of our wallets.
+// When none are requested the listing accepts every coin we run a wallet for.
+func (n *OpenBazaarNode) acceptedListingCurrencies(requested []string) ([]string, error) {
+	available := n.WalletCurrencies()
+	if len(requested) == 0 {
+		return available, nil
+	}
+	var currencies []string
+	for _, code := range requested {
+		code = strings.ToUpper(code)
+		found := false
+		for _, c := range available {
+			if c == code {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Listing accepts %s but we do not run a wallet for it", code)
+		}
+		dup := false
+		for _, c := range currencies {
+			if c == code {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			currencies = append(currencies, code)
+		}
+	}
+	return currencies, nil
+}
+
+// Return whether the moderator can moderate orders paid with the given coin
+func moderatorAcceptsCurrency(moderator *pb.Moderator, code string) bool {
+	if moderator == nil {
+		return false
+	}
+	for _, c := range moderator.AcceptedCurrencies {
+		if strings.EqualFold(c, code) {
+			return true
+		}
+	}
+	return strings.EqualFold(moderator.AcceptedCurrency, code)
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
+	"github.com/OpenBazaar/openbazaar-go/pb"
+)
+
+func TestListingCurrencies(t *testing.T) {
+	legacy := &pb.Listing{Metadata: &pb.Listing_Metadata{AcceptedCurrency: "BTC"}}
+	multi := &pb.Listing{Metadata: &pb.Listing_Metadata{AcceptedCurrency: "BTC", AcceptedCurrencies: []string{"BTC", "LTC"}}}
+
+	if c := ListingCurrencies(legacy); len(c) != 1 || c[0] != "BTC" {
+		t.Error("Listings without acceptedCurrencies should fall back to acceptedCurrency")
+	}
+	if !listingsAcceptCurrency([]*pb.Listing{legacy, multi}, "btc") {
+		t.Error("Both listings accept BTC")
+	}
+	if listingsAcceptCurrency([]*pb.Listing{legacy, multi}, "LTC") {
+		t.Error("The legacy listing does not accept LTC")
+	}
+	if !listingsAcceptCurrency([]*pb.Listing{multi}, "LTC") {
+		t.Error("Listing accepts LTC")
+	}
+}
+
+func TestModeratorAcceptsCurrency(t *testing.T) {
+	if moderatorAcceptsCurrency(nil, "BTC") {
+		t.Error("A nil moderator accepts nothing")
+	}
+	mod := &pb.Moderator{AcceptedCurrency: "BTC", AcceptedCurrencies: []string{"BTC", "BCH"}}
+	if !moderatorAcceptsCurrency(mod, "bch") {
+		t.Error("Moderator accepts BCH")
+	}
+	if moderatorAcceptsCurrency(mod, "LTC") {
+		t.Error("Moderator does not accept LTC")
+	}
+}
+
+func TestEscrowTimeoutBlocksByCoin(t *testing.T) {
+	listings := []*pb.Listing{{Metadata: &pb.Listing_Metadata{EscrowTimeoutHours: 100}}}
+	if b := EscrowTimeoutBlocks(listings, "mod", "BTC"); b != 600 {
+		t.Errorf("Expected 600 bitcoin blocks, got %d", b)
+	}
+	if b := EscrowTimeoutBlocks(listings, "mod", "LTC"); b != 2400 {
+		t.Errorf("Expected 2400 litecoin blocks, got %d", b)
+	}
+	if b := EscrowTimeoutBlocks(listings, "mod", ""); b != 600 {
+		t.Errorf("Orders without a coin should use bitcoin blocks, got %d", b)
+	}
+	listings[0].Metadata.EscrowTimeoutHours = MaxEscrowTimeoutHours()
+	if b := EscrowTimeoutBlocks(listings, "mod", "LTC"); b > bitcoin.MaxTimeoutBlocks {
+		t.Errorf("The longest escrow timeout does not fit in a litecoin escrow script: %d blocks", b)
+	}
+	listings[0].Metadata.EscrowTimeoutHours = 10000
+	if b := EscrowTimeoutBlocks(listings, "mod", "LTC"); b != bitcoin.MaxTimeoutBlocks {
+		t.Errorf("Timeouts of older listings should be capped, got %d", b)
+	}
+}
@@ -25,7 +25,7 @@ func Cat(ctx commands.Context, hash string) ([]byte, error) {
 	resp := res.Output()
 	reader := resp.(io.Reader)
 	b := make([]byte, res.Length())
-	_, err = reader.Read(b)
+	_, err = io.ReadFull(reader, b)
 	if err != nil {
 		return nil, err
 	}
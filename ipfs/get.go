@@ -0,0 +1,72 @@
+package ipfs
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-ipfs/commands"
+)
+
+const GetTimeout = 10 * time.Minute
+
+// Download a directory from IPFS given its path and write it to outPath
+func Get(ctx commands.Context, ipfsPath string, outPath string) error {
+	args := []string{"get", ipfsPath}
+	req, cmd, err := NewRequestWithTimeout(ctx, args, GetTimeout)
+	if err != nil {
+		return err
+	}
+	res := commands.NewResponse(req)
+	cmd.Run(req, res)
+	if res.Error() != nil {
+		return res.Error()
+	}
+	reader, ok := res.Output().(io.Reader)
+	if !ok {
+		return errors.New("Unexpected response from get")
+	}
+	tr := tar.NewReader(reader)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		// Entries are prefixed with the last segment of the path being fetched
+		name := hdr.Name
+		if i := strings.Index(name, "/"); i >= 0 {
+			name = name[i+1:]
+		} else {
+			name = ""
+		}
+		target := filepath.Join(outPath, filepath.FromSlash(name))
+		if target != filepath.Clean(outPath) && !strings.HasPrefix(target, filepath.Clean(outPath)+string(filepath.Separator)) {
+			return errors.New("Invalid path in directory")
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, os.ModePerm); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+				return err
+			}
+			f, err := os.Create(target)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -52,8 +52,7 @@ type Pointer struct {
 // entropy is a sequence of bytes that should be deterministic based on the content of the pointer
 // it is hashed and used to fill the remaining 20 bytes of the magic id
 func PublishPointer(node *core.IpfsNode, ctx context.Context, mhKey multihash.Multihash, prefixLen int, addr ma.Multiaddr, entropy []byte) (Pointer, error) {
-	keyhash := createKey(mhKey, prefixLen)
-	k, err := cid.Decode(keyhash.B58String())
+	k, err := PointerKey(mhKey, prefixLen)
 	if err != nil {
 		return Pointer{}, err
 	}
@@ -69,6 +68,12 @@ func PublishPointer(node *core.IpfsNode, ctx context.Context, mhKey multihash.Mu
 	return Pointer{Cid: k, Value: pi}, addPointer(node, ctx, k, pi)
 }
 
+// Return the dht key pointers published under the given multihash are stored at
+func PointerKey(mhKey multihash.Multihash, prefixLen int) (*cid.Cid, error) {
+	keyhash := createKey(mhKey, prefixLen)
+	return cid.Decode(keyhash.B58String())
+}
+
 func RePublishPointer(node *core.IpfsNode, ctx context.Context, pointer Pointer) error {
 	return addPointer(node, ctx, pointer.Cid, pointer.Value)
 }
@@ -55,12 +55,24 @@ func (service *OpenBazaarService) HandlerForMsgType(t pb.Message_MessageType) fu
 		return service.handleDisputeUpdate
 	case pb.Message_DISPUTE_CLOSE:
 		return service.handleDisputeClose
+	case pb.Message_DISPUTE_ENDORSEMENT:
+		return service.handleDisputeEndorsement
+	case pb.Message_DISPUTE_EVIDENCE:
+		return service.handleDisputeEvidence
+	case pb.Message_DISPUTE_PROPOSAL:
+		return service.handleDisputeProposal
+	case pb.Message_DISPUTE_ACCEPTANCE:
+		return service.handleDisputeAcceptance
 	case pb.Message_CHAT:
 		return service.handleChat
 	case pb.Message_MODERATOR_ADD:
 		return service.handleModeratorAdd
 	case pb.Message_MODERATOR_REMOVE:
 		return service.handleModeratorRemove
+	case pb.Message_BID:
+		return service.handleBid
+	case pb.Message_BID_ACK:
+		return service.handleBidAck
 	default:
 		return nil
 	}
@@ -191,6 +203,23 @@ func (service *OpenBazaarService) handleOrder(peer peer.ID, pmes *pb.Message, op
 		log.Error(err)
 		return errorResponse(err.Error()), nil
 	}
+	// Coupons are checked again and recorded together when the sale is confirmed. Offline
+	// orders may already be paid so they are kept and only checked then.
+	if !offline {
+		err = service.node.ValidateCouponRedemptions(contract)
+		if err != nil {
+			log.Error(err)
+			return errorResponse(err.Error()), nil
+		}
+	}
+	wal, err := service.node.WalletForContract(contract)
+	if err != nil {
+		return errorResponse(err.Error()), nil
+	}
+
+	if rule := service.node.RejectOrderByRule(contract, offline); rule != nil {
+		return errorResponse("The vendor is not accepting this order"), nil
+	}
 
 	if contract.BuyerOrder.Payment.Method == pb.Order_Payment_ADDRESS_REQUEST {
 		total, err := service.node.CalculateOrderTotal(contract)
@@ -224,7 +253,7 @@ func (service *OpenBazaarService) handleOrder(peer peer.ID, pmes *pb.Message, op
 			log.Error(err)
 			return errorResponse(err.Error()), err
 		}
-		addr, err := btcutil.DecodeAddress(contract.BuyerOrder.Payment.Address, service.node.Wallet.Params())
+		addr, err := btcutil.DecodeAddress(contract.BuyerOrder.Payment.Address, wal.Params())
 		if err != nil {
 			log.Error(err)
 			return errorResponse(err.Error()), err
@@ -234,7 +263,7 @@ func (service *OpenBazaarService) handleOrder(peer peer.ID, pmes *pb.Message, op
 			log.Error(err)
 			return errorResponse(err.Error()), err
 		}
-		service.node.Wallet.AddWatchedScript(script)
+		wal.AddWatchedScript(script)
 		orderId, err := service.node.CalcOrderId(contract.BuyerOrder)
 		if err != nil {
 			log.Error(err)
@@ -252,12 +281,17 @@ func (service *OpenBazaarService) handleOrder(peer peer.ID, pmes *pb.Message, op
 			log.Error("Calculated a different payment amount")
 			return errorResponse("Calculated a different payment amount"), nil
 		}
-		err = service.node.ValidateModeratedPaymentAddress(contract.BuyerOrder)
+		timeout, err := core.OrderEscrowTimeout(contract)
 		if err != nil {
 			log.Error(err)
 			return errorResponse(err.Error()), err
 		}
-		addr, err := btcutil.DecodeAddress(contract.BuyerOrder.Payment.Address, service.node.Wallet.Params())
+		err = service.node.ValidateModeratedPaymentAddress(contract.BuyerOrder, timeout)
+		if err != nil {
+			log.Error(err)
+			return errorResponse(err.Error()), err
+		}
+		addr, err := btcutil.DecodeAddress(contract.BuyerOrder.Payment.Address, wal.Params())
 		if err != nil {
 			log.Error(err)
 			return errorResponse(err.Error()), err
@@ -267,7 +301,7 @@ func (service *OpenBazaarService) handleOrder(peer peer.ID, pmes *pb.Message, op
 			log.Error(err)
 			return errorResponse(err.Error()), err
 		}
-		service.node.Wallet.AddWatchedScript(script)
+		wal.AddWatchedScript(script)
 		contract, err = service.node.NewOrderConfirmation(contract, false)
 		if err != nil {
 			log.Error(err)
@@ -278,6 +312,12 @@ func (service *OpenBazaarService) handleOrder(peer peer.ID, pmes *pb.Message, op
 			log.Error(err)
 			return errorResponse("Error building order confirmation"), nil
 		}
+		if core.IsCrowdFund(contract) {
+			if err := service.node.RecordPledge(contract); err != nil {
+				log.Error(err)
+				return errorResponse("Error recording pledge"), nil
+			}
+		}
 		service.node.Datastore.Sales().Put(contract.VendorOrderConfirmation.OrderID, *contract, pb.OrderState_CONFIRMED, false)
 		m := pb.Message{
 			MessageType: pb.Message_ORDER_CONFIRMATION,
@@ -285,12 +325,17 @@ func (service *OpenBazaarService) handleOrder(peer peer.ID, pmes *pb.Message, op
 		}
 		return &m, nil
 	} else if contract.BuyerOrder.Payment.Method == pb.Order_Payment_MODERATED && offline {
-		err := service.node.ValidateModeratedPaymentAddress(contract.BuyerOrder)
+		timeout, err := core.OrderEscrowTimeout(contract)
 		if err != nil {
 			log.Error(err)
 			return errorResponse(err.Error()), err
 		}
-		addr, err := btcutil.DecodeAddress(contract.BuyerOrder.Payment.Address, service.node.Wallet.Params())
+		err = service.node.ValidateModeratedPaymentAddress(contract.BuyerOrder, timeout)
+		if err != nil {
+			log.Error(err)
+			return errorResponse(err.Error()), err
+		}
+		addr, err := btcutil.DecodeAddress(contract.BuyerOrder.Payment.Address, wal.Params())
 		if err != nil {
 			log.Error(err)
 			return errorResponse(err.Error()), err
@@ -300,12 +345,18 @@ func (service *OpenBazaarService) handleOrder(peer peer.ID, pmes *pb.Message, op
 			log.Error(err)
 			return errorResponse(err.Error()), err
 		}
-		service.node.Wallet.AddWatchedScript(script)
+		wal.AddWatchedScript(script)
 		orderId, err := service.node.CalcOrderId(contract.BuyerOrder)
 		if err != nil {
 			log.Error(err)
 			return errorResponse(err.Error()), err
 		}
+		if core.IsCrowdFund(contract) {
+			if err := service.node.RecordPledge(contract); err != nil {
+				log.Error(err)
+				return errorResponse("Error recording pledge"), nil
+			}
+		}
 		service.node.Datastore.Sales().Put(orderId, *contract, pb.OrderState_PENDING, false)
 		return nil, nil
 	}
@@ -387,6 +438,10 @@ func (service *OpenBazaarService) handleReject(p peer.ID, pmes *pb.Message, opti
 	if err != nil {
 		return nil, err
 	}
+	wal, err := service.node.WalletForContract(contract)
+	if err != nil {
+		return nil, err
+	}
 
 	if contract.BuyerOrder.Payment.Method != pb.Order_Payment_MODERATED {
 		// Sweep the address into our wallet
@@ -415,7 +470,7 @@ func (service *OpenBazaarService) handleReject(p peer.ID, pmes *pb.Message, opti
 			return nil, err
 		}
 		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := service.node.Wallet.MasterPrivateKey()
+		mPrivKey := wal.MasterPrivateKey()
 		if err != nil {
 			return nil, err
 		}
@@ -424,7 +479,7 @@ func (service *OpenBazaarService) handleReject(p peer.ID, pmes *pb.Message, opti
 			return nil, err
 		}
 		hdKey := hd.NewExtendedKey(
-			service.node.Wallet.Params().HDPrivateKeyID[:],
+			wal.Params().HDPrivateKeyID[:],
 			mECKey.Serialize(),
 			chaincode,
 			parentFP,
@@ -437,11 +492,11 @@ func (service *OpenBazaarService) handleReject(p peer.ID, pmes *pb.Message, opti
 			return nil, err
 		}
 		redeemScript, err := hex.DecodeString(contract.BuyerOrder.Payment.RedeemScript)
-		refundAddress, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, service.node.Wallet.Params())
+		refundAddress, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, wal.Params())
 		if err != nil {
 			return nil, err
 		}
-		_, err = service.node.Wallet.SweepAddress(utxos, &refundAddress, buyerKey, &redeemScript, spvwallet.NORMAL)
+		_, err = wal.SweepAddress(utxos, &refundAddress, buyerKey, &redeemScript, spvwallet.NORMAL)
 		if err != nil {
 			return nil, err
 		}
@@ -460,7 +515,7 @@ func (service *OpenBazaarService) handleReject(p peer.ID, pmes *pb.Message, opti
 			}
 		}
 
-		refundAddress, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, service.node.Wallet.Params())
+		refundAddress, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, wal.Params())
 		if err != nil {
 			return nil, err
 		}
@@ -477,7 +532,7 @@ func (service *OpenBazaarService) handleReject(p peer.ID, pmes *pb.Message, opti
 			return nil, err
 		}
 		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := service.node.Wallet.MasterPrivateKey()
+		mPrivKey := wal.MasterPrivateKey()
 		if err != nil {
 			return nil, err
 		}
@@ -486,7 +541,7 @@ func (service *OpenBazaarService) handleReject(p peer.ID, pmes *pb.Message, opti
 			return nil, err
 		}
 		hdKey := hd.NewExtendedKey(
-			service.node.Wallet.Params().HDPrivateKeyID[:],
+			wal.Params().HDPrivateKeyID[:],
 			mECKey.Serialize(),
 			chaincode,
 			parentFP,
@@ -500,7 +555,7 @@ func (service *OpenBazaarService) handleReject(p peer.ID, pmes *pb.Message, opti
 		}
 		redeemScript, err := hex.DecodeString(contract.BuyerOrder.Payment.RedeemScript)
 
-		buyerSignatures, err := service.node.Wallet.CreateMultisigSignature(ins, []spvwallet.TransactionOutput{output}, buyerKey, redeemScript, contract.BuyerOrder.RefundFee)
+		buyerSignatures, err := wal.CreateMultisigSignature(ins, []spvwallet.TransactionOutput{output}, buyerKey, redeemScript, contract.BuyerOrder.RefundFee)
 		if err != nil {
 			return nil, err
 		}
@@ -509,7 +564,7 @@ func (service *OpenBazaarService) handleReject(p peer.ID, pmes *pb.Message, opti
 			sig := spvwallet.Signature{InputIndex: s.InputIndex, Signature: s.Signature}
 			vendorSignatures = append(vendorSignatures, sig)
 		}
-		_, err = service.node.Wallet.Multisign(ins, []spvwallet.TransactionOutput{output}, buyerSignatures, vendorSignatures, redeemScript, contract.BuyerOrder.RefundFee, true)
+		_, err = wal.Multisign(ins, []spvwallet.TransactionOutput{output}, buyerSignatures, vendorSignatures, redeemScript, contract.BuyerOrder.RefundFee, true)
 		if err != nil {
 			return nil, err
 		}
@@ -539,92 +594,38 @@ func (service *OpenBazaarService) handleRefund(p peer.ID, pmes *pb.Message, opti
 	}
 
 	// Load the order
-	contract, _, _, records, _, err := service.datastore.Purchases().GetByOrderId(rc.Refund.OrderID)
+	contract, state, _, records, _, err := service.datastore.Purchases().GetByOrderId(rc.Refund.OrderID)
 	if err != nil {
 		return nil, err
 	}
-
-	if contract.BuyerOrder.Payment.Method == pb.Order_Payment_MODERATED {
-		var ins []spvwallet.TransactionInput
-		var outValue int64
-		for _, r := range records {
-			if !r.Spent && r.Value > 0 {
-				outpointHash, err := hex.DecodeString(r.Txid)
-				if err != nil {
-					return nil, err
-				}
-				outValue += r.Value
-				in := spvwallet.TransactionInput{OutpointIndex: r.Index, OutpointHash: outpointHash}
-				ins = append(ins, in)
-			}
-		}
-
-		refundAddress, err := btcutil.DecodeAddress(contract.BuyerOrder.RefundAddress, service.node.Wallet.Params())
-		if err != nil {
-			return nil, err
-		}
-		var output spvwallet.TransactionOutput
-		outputScript, err := txscript.PayToAddrScript(refundAddress)
-		if err != nil {
-			return nil, err
+	if err := service.node.ValidateRefund(contract, state, rc.Refund); err != nil {
+		return nil, err
+	}
+	contract.Refund = rc.Refund
+	for _, sig := range rc.Signatures {
+		if sig.Section == pb.Signature_REFUND {
+			contract.Signatures = append(contract.Signatures, sig)
 		}
-		output.ScriptPubKey = outputScript
-		output.Value = outValue
+	}
 
-		chaincode, err := hex.DecodeString(contract.BuyerOrder.Payment.Chaincode)
-		if err != nil {
-			return nil, err
-		}
-		parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-		mPrivKey := service.node.Wallet.MasterPrivateKey()
-		if err != nil {
-			return nil, err
-		}
-		mECKey, err := mPrivKey.ECPrivKey()
-		if err != nil {
-			return nil, err
-		}
-		hdKey := hd.NewExtendedKey(
-			service.node.Wallet.Params().HDPrivateKeyID[:],
-			mECKey.Serialize(),
-			chaincode,
-			parentFP,
-			0,
-			0,
-			true)
+	// A partial refund of a moderated order also pays the vendor so it waits for us to accept it
+	if core.IsRefundOffer(contract) {
+		service.datastore.Purchases().Put(contract.Refund.OrderID, *contract, state, false)
 
-		buyerKey, err := hdKey.Child(0)
-		if err != nil {
-			return nil, err
-		}
-		redeemScript, err := hex.DecodeString(contract.BuyerOrder.Payment.RedeemScript)
-		if err != nil {
-			return nil, err
-		}
+		n := notifications.RefundOfferNotification{OrderId: contract.Refund.OrderID, Amount: contract.Refund.Amount}
+		service.broadcast <- n
+		service.datastore.Notifications().Put(n, time.Now())
+		return nil, nil
+	}
 
-		buyerSignatures, err := service.node.Wallet.CreateMultisigSignature(ins, []spvwallet.TransactionOutput{output}, buyerKey, redeemScript, contract.BuyerOrder.RefundFee)
-		if err != nil {
-			return nil, err
-		}
-		var vendorSignatures []spvwallet.Signature
-		for _, s := range rc.Refund.Sigs {
-			sig := spvwallet.Signature{InputIndex: s.InputIndex, Signature: s.Signature}
-			vendorSignatures = append(vendorSignatures, sig)
-		}
-		_, err = service.node.Wallet.Multisign(ins, []spvwallet.TransactionOutput{output}, buyerSignatures, vendorSignatures, redeemScript, contract.BuyerOrder.RefundFee, true)
-		if err != nil {
+	if contract.BuyerOrder.Payment.Method == pb.Order_Payment_MODERATED {
+		if err := service.node.CoSignRefund(contract, contract.Refund, records); err != nil {
 			return nil, err
 		}
 	}
-	contract.Refund = rc.Refund
-	for _, sig := range contract.Signatures {
-		if sig.Section == pb.Signature_REFUND {
-			contract.Signatures = append(contract.Signatures, sig)
-		}
-	}
 
 	// Set message state to refunded
-	service.datastore.Purchases().Put(contract.Refund.OrderID, *contract, pb.OrderState_REFUNDED, false)
+	service.datastore.Purchases().Put(contract.Refund.OrderID, *contract, core.RefundState(contract.Refund), false)
 
 	// Send notification to websocket
 	n := notifications.RefundNotification{contract.Refund.OrderID}
@@ -644,7 +645,7 @@ func (service *OpenBazaarService) handleOrderFulfillment(p peer.ID, pmes *pb.Mes
 	}
 
 	// Load the order
-	contract, _, _, _, _, err := service.datastore.Purchases().GetByOrderId(rc.VendorOrderFulfillment[0].OrderId)
+	contract, _, _, records, _, err := service.datastore.Purchases().GetByOrderId(rc.VendorOrderFulfillment[0].OrderId)
 	if err != nil {
 		return nil, err
 	}
@@ -670,6 +671,15 @@ func (service *OpenBazaarService) handleOrderFulfillment(p peer.ID, pmes *pb.Mes
 	service.broadcast <- n
 	service.datastore.Notifications().Put(n, time.Now())
 
+	// Crowdfund pledges are released to the vendor once we've checked the goal has been met
+	if core.IsCrowdFund(contract) {
+		if err := service.node.ValidateCrowdFundRelease(contract, rc.VendorOrderFulfillment[0], records); err != nil {
+			log.Errorf("Not releasing crowdfund pledge %s: %s", rc.VendorOrderFulfillment[0].OrderId, err)
+		} else if err := service.node.CompleteOrder(&core.OrderRatings{OrderId: rc.VendorOrderFulfillment[0].OrderId}, contract, records); err != nil {
+			log.Errorf("Error releasing crowdfund pledge: %s", err)
+		}
+	}
+
 	return nil, nil
 }
 
@@ -687,6 +697,10 @@ func (service *OpenBazaarService) handleOrderCompletion(p peer.ID, pmes *pb.Mess
 	if err != nil {
 		return nil, err
 	}
+	wal, err := service.node.WalletForContract(contract)
+	if err != nil {
+		return nil, err
+	}
 
 	contract.BuyerOrderCompletion = rc.BuyerOrderCompletion
 	for _, sig := range rc.Signatures {
@@ -713,7 +727,7 @@ func (service *OpenBazaarService) handleOrderCompletion(p peer.ID, pmes *pb.Mess
 			}
 		}
 
-		payoutAddress, err := btcutil.DecodeAddress(contract.VendorOrderFulfillment[0].Payout.PayoutAddress, service.node.Wallet.Params())
+		payoutAddress, err := btcutil.DecodeAddress(contract.VendorOrderFulfillment[0].Payout.PayoutAddress, wal.Params())
 		if err != nil {
 			return nil, err
 		}
@@ -741,7 +755,7 @@ func (service *OpenBazaarService) handleOrderCompletion(p peer.ID, pmes *pb.Mess
 			buyerSignatures = append(buyerSignatures, sig)
 		}
 
-		_, err = service.node.Wallet.Multisign(ins, []spvwallet.TransactionOutput{output}, buyerSignatures, vendorSignatures, redeemScript, contract.VendorOrderFulfillment[0].Payout.PayoutFeePerByte, true)
+		_, err = wal.Multisign(ins, []spvwallet.TransactionOutput{output}, buyerSignatures, vendorSignatures, redeemScript, contract.VendorOrderFulfillment[0].Payout.PayoutFeePerByte, true)
 		if err != nil {
 			return nil, err
 		}
@@ -849,7 +863,9 @@ func (service *OpenBazaarService) handleDisputeClose(p peer.ID, pmes *pb.Message
 	if err != nil {
 		contract, _, _, _, _, err = service.datastore.Purchases().GetByOrderId(rc.DisputeResolution.OrderId)
 		if err != nil {
-			return nil, err
+			// We may be moderating the order on a panel, in which case this is the
+			// coordinating moderator's proposed resolution
+			return nil, service.node.ProcessPanelResolution(rc, p.Pretty())
 		}
 		isPurchase = true
 	}
@@ -890,6 +906,58 @@ func (service *OpenBazaarService) handleDisputeClose(p peer.ID, pmes *pb.Message
 	return nil, nil
 }
 
+func (service *OpenBazaarService) handleDisputeEndorsement(p peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
+	log.Debugf("Received DISPUTE_ENDORSEMENT message from %s", p.Pretty())
+
+	// Unmarshall
+	endorsement := new(pb.DisputeEndorsement)
+	err := ptypes.UnmarshalAny(pmes.Payload, endorsement)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, service.node.ProcessDisputeEndorsement(endorsement, p.Pretty())
+}
+
+func (service *OpenBazaarService) handleDisputeEvidence(p peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
+	log.Debugf("Received DISPUTE_EVIDENCE message from %s", p.Pretty())
+
+	// Unmarshall
+	evidence := new(pb.DisputeEvidence)
+	err := ptypes.UnmarshalAny(pmes.Payload, evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, service.node.ProcessDisputeEvidence(evidence, p.Pretty())
+}
+
+func (service *OpenBazaarService) handleDisputeProposal(p peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
+	log.Debugf("Received DISPUTE_PROPOSAL message from %s", p.Pretty())
+
+	// Unmarshall
+	proposal := new(pb.DisputeProposal)
+	err := ptypes.UnmarshalAny(pmes.Payload, proposal)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, service.node.ProcessDisputeProposal(proposal, p.Pretty())
+}
+
+func (service *OpenBazaarService) handleDisputeAcceptance(p peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
+	log.Debugf("Received DISPUTE_ACCEPTANCE message from %s", p.Pretty())
+
+	// Unmarshall
+	acceptance := new(pb.DisputeProposalAcceptance)
+	err := ptypes.UnmarshalAny(pmes.Payload, acceptance)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, service.node.ProcessDisputeAcceptance(acceptance, p.Pretty())
+}
+
 func (service *OpenBazaarService) handleChat(p peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
 	log.Debugf("Received CHAT message from %s", p.Pretty())
 
@@ -986,3 +1054,48 @@ func (service *OpenBazaarService) handleModeratorRemove(peer peer.ID, pmes *pb.M
 	service.datastore.Notifications().Put(n, time.Now())
 	return nil, nil
 }
+
+func (service *OpenBazaarService) handleBid(peer peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
+	log.Debugf("Received BID message from %s", peer.Pretty())
+	errorResponse := func(error string) *pb.Message {
+		a := &any.Any{Value: []byte(error)}
+		m := &pb.Message{
+			MessageType: pb.Message_ERROR,
+			Payload:     a,
+		}
+		return m
+	}
+	bid := new(pb.Bid)
+	err := ptypes.UnmarshalAny(pmes.Payload, bid)
+	if err != nil {
+		return errorResponse("Could not unmarshal bid"), err
+	}
+	ack, err := service.node.ProcessBid(peer.Pretty(), bid)
+	if err != nil {
+		log.Error(err)
+		return errorResponse("Error processing bid"), err
+	}
+	a, err := ptypes.MarshalAny(ack)
+	if err != nil {
+		return errorResponse("Error building bid ack"), err
+	}
+	m := pb.Message{
+		MessageType: pb.Message_BID_ACK,
+		Payload:     a,
+	}
+	return &m, nil
+}
+
+func (service *OpenBazaarService) handleBidAck(peer peer.ID, pmes *pb.Message, options interface{}) (*pb.Message, error) {
+	log.Debugf("Received BID_ACK message from %s", peer.Pretty())
+	ack := new(pb.BidAck)
+	err := ptypes.UnmarshalAny(pmes.Payload, ack)
+	if err != nil {
+		return nil, err
+	}
+	err = service.node.ProcessAuctionResult(peer.Pretty(), ack)
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
@@ -16,6 +16,7 @@ import (
 	"runtime"
 	"sort"
 	"strconv"
+	"time"
 
 	"bufio"
 	"crypto/rand"
@@ -40,6 +41,7 @@ import (
 	ret "github.com/OpenBazaar/openbazaar-go/net/retriever"
 	"github.com/OpenBazaar/openbazaar-go/net/service"
 	"github.com/OpenBazaar/openbazaar-go/repo"
+	"github.com/OpenBazaar/openbazaar-go/repo/backup"
 	"github.com/OpenBazaar/openbazaar-go/repo/db"
 	sto "github.com/OpenBazaar/openbazaar-go/storage"
 	"github.com/OpenBazaar/openbazaar-go/storage/dropbox"
@@ -120,6 +122,37 @@ type Start struct {
 	DisableExchangeRates bool     `long:"disableexchangerates" description:"disable the exchange rate service to prevent api queries"`
 	Storage              string   `long:"storage" description:"set the outgoing message storage option [self-hosted, dropbox] default=self-hosted"`
 }
+type Migrate struct {
+	Password string `short:"p" long:"password" description:"the encryption password if the database is encrypted"`
+	DataDir  string `short:"d" long:"datadir" description:"specify the data directory to be used"`
+	Testnet  bool   `short:"t" long:"testnet" description:"use the test network"`
+	DryRun   bool   `long:"dry-run" description:"list the pending migrations without running them"`
+}
+type Backup struct {
+	DataDir        string `short:"d" long:"datadir" description:"specify the data directory to be used"`
+	Testnet        bool   `short:"t" long:"testnet" description:"use the test network"`
+	Output         string `short:"o" long:"output" description:"the file to write the backup to" required:"true"`
+	DatabaseOnly   bool   `long:"dbonly" description:"only back up the database. The rest of the repo can be rebuilt from the mnemonic on restore."`
+	BackupPassword string `long:"backuppassword" description:"the password to encrypt the backup with. Prompted for if not set."`
+}
+type Restore struct {
+	DataDir        string `short:"d" long:"datadir" description:"specify the data directory to be used"`
+	Testnet        bool   `short:"t" long:"testnet" description:"use the test network"`
+	Input          string `short:"i" long:"input" description:"the backup file to restore" required:"true"`
+	Password       string `short:"p" long:"password" description:"the encryption password of the database in the backup, used to read the mnemonic of a database only backup"`
+	Mnemonic       string `short:"m" long:"mnemonic" description:"the mnemonic seed of the node, required to restore a database only backup of an encrypted database"`
+	Force          bool   `short:"f" long:"force" description:"replace an existing repo. It is moved aside rather than deleted."`
+	BackupPassword string `long:"backuppassword" description:"the password the backup was encrypted with. Prompted for if not set."`
+}
+type APIToken struct {
+	Password string   `short:"p" long:"password" description:"the encryption password if the database is encrypted"`
+	DataDir  string   `short:"d" long:"datadir" description:"specify the data directory to be used"`
+	Testnet  bool     `short:"t" long:"testnet" description:"use the test network"`
+	Create   string   `long:"create" description:"create a token with this name"`
+	Scopes   []string `short:"s" long:"scope" description:"a scope to grant the new token [read, orders, listings, chat, wallet-spend]. May be repeated."`
+	Expires  string   `long:"expires" description:"how long until the new token expires, such as 720h. It never expires if not set."`
+	Revoke   string   `long:"revoke" description:"revoke the token with this name"`
+}
 type Opts struct {
 	Version bool `short:"v" long:"version" description:"Print the version number and exit"`
 }
@@ -135,6 +168,10 @@ var restartServer Restart
 var encryptDatabase EncryptDatabase
 var decryptDatabase DecryptDatabase
 var setAPICreds SetAPICreds
+var migrate Migrate
+var backupRepo Backup
+var restoreRepo Restore
+var apiToken APIToken
 var status Status
 var opts Opts
 
@@ -153,7 +190,9 @@ func main() {
 				core.Node.Datastore.Close()
 				repoLockFile := filepath.Join(core.Node.RepoPath, lockfile.LockFile)
 				os.Remove(repoLockFile)
-				core.Node.Wallet.Close()
+				for _, w := range core.Node.Multiwallet.Wallets() {
+					w.Close()
+				}
 				core.Node.IpfsNode.Close()
 			}
 			os.Exit(1)
@@ -191,6 +230,22 @@ func main() {
 		"decrypt your database",
 		"This command decrypts the database containing your bitcoin private keys, identity key, and contracts.\n [Warning] doing so may put your bitcoins at risk.",
 		&decryptDatabase)
+	parser.AddCommand("migrate",
+		"upgrade the database schema",
+		"Migrations are run automatically on start. This command runs them without starting the server, or with --dry-run lists the pending migrations. The database is backed up before it is migrated.",
+		&migrate)
+	parser.AddCommand("backup",
+		"back up the repo",
+		"Writes an encrypted archive of the database, root directory, keys and config. The server must be stopped while backing up.",
+		&backupRepo)
+	parser.AddCommand("restore",
+		"restore the repo from a backup",
+		"Verifies and restores a backup made with the backup command. A database only backup is restored by recreating the keys from the mnemonic and fetching the root directory from the network on the next start.",
+		&restoreRepo)
+	parser.AddCommand("apitoken",
+		"manage API tokens",
+		"Lists the API tokens, or with --create or --revoke creates or revokes one. Tokens grant access to part of the API according to their scopes and are sent in an \"Authorization: Bearer\" header.",
+		&apiToken)
 	if len(os.Args) > 1 && (os.Args[1] == "--version" || os.Args[1] == "-v") {
 		fmt.Println(core.VERSION)
 		return
@@ -329,6 +384,292 @@ func (x *Status) Execute(args []string) error {
 	return nil
 }
 
+func (x *Migrate) Execute(args []string) error {
+	// Set repo path
+	repoPath, err := getRepoPath(x.Testnet)
+	if err != nil {
+		return err
+	}
+	if x.DataDir != "" {
+		repoPath = x.DataDir
+	}
+	if !fsrepo.IsInitialized(repoPath) {
+		return errors.New("Repo is not initialized")
+	}
+	repoLockFile := filepath.Join(repoPath, lockfile.LockFile)
+	if _, err := os.Stat(repoLockFile); !os.IsNotExist(err) {
+		return errors.New("Cannot migrate while the daemon is running")
+	}
+	sqliteDB, err := db.Create(repoPath, x.Password, x.Testnet)
+	if err != nil {
+		return err
+	}
+	defer sqliteDB.Close()
+	if sqliteDB.Config().IsEncrypted() {
+		return encryptedDatabaseError
+	}
+	version, err := sqliteDB.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	pending, err := sqliteDB.PendingMigrations()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Schema version: %d\n", version)
+	if len(pending) == 0 {
+		fmt.Println("Database is up to date")
+		return nil
+	}
+	for _, m := range pending {
+		fmt.Printf("Pending migration %d: %s\n", m.Version, m.Description)
+	}
+	if x.DryRun {
+		return nil
+	}
+	applied, backup, err := sqliteDB.Migrate()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Migrated to schema version %d. The previous database was saved to %s\n", applied[len(applied)-1].Version, backup)
+	return nil
+}
+
+func (x *APIToken) Execute(args []string) error {
+	// Set repo path
+	repoPath, err := getRepoPath(x.Testnet)
+	if err != nil {
+		return err
+	}
+	if x.DataDir != "" {
+		repoPath = x.DataDir
+	}
+	if !fsrepo.IsInitialized(repoPath) {
+		return errors.New("Repo is not initialized")
+	}
+	sqliteDB, err := db.Create(repoPath, x.Password, x.Testnet)
+	if err != nil {
+		return err
+	}
+	defer sqliteDB.Close()
+	if sqliteDB.Config().IsEncrypted() {
+		return encryptedDatabaseError
+	}
+	pending, err := sqliteDB.PendingMigrations()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return errors.New("The database must be migrated first. Run the migrate command.")
+	}
+	switch {
+	case x.Create != "":
+		var expiry time.Time
+		if x.Expires != "" {
+			d, err := time.ParseDuration(x.Expires)
+			if err != nil {
+				return err
+			}
+			expiry = time.Now().Add(d)
+		}
+		secret, err := api.CreateAPIToken(sqliteDB.APITokens(), x.Create, x.Scopes, expiry)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created API token %s. It will not be shown again:\n%s\n", x.Create, secret)
+	case x.Revoke != "":
+		if err := sqliteDB.APITokens().Revoke(x.Revoke); err != nil {
+			return fmt.Errorf("No API token named %s", x.Revoke)
+		}
+		fmt.Printf("Revoked API token %s\n", x.Revoke)
+	default:
+		tokens, err := sqliteDB.APITokens().GetAll()
+		if err != nil {
+			return err
+		}
+		for _, t := range tokens {
+			status := "active"
+			if t.Revoked {
+				status = "revoked"
+			} else if !t.Expiry.IsZero() && t.Expiry.Before(time.Now()) {
+				status = "expired"
+			}
+			expires := "never"
+			if !t.Expiry.IsZero() {
+				expires = t.Expiry.Format(time.RFC3339)
+			}
+			fmt.Printf("%s\t%s\t%s\texpires %s\n", t.Name, strings.Join(t.Scopes, ","), status, expires)
+		}
+	}
+	return nil
+}
+
+func readBackupPassword(confirm bool) string {
+	for {
+		fmt.Print("Enter the backup password: ")
+		bytePassword, _ := terminal.ReadPassword(int(syscall.Stdin))
+		fmt.Println("")
+		pw := string(bytePassword)
+		if pw == "" {
+			fmt.Println("Seriously, enter a password.")
+			continue
+		}
+		if !confirm {
+			return pw
+		}
+		fmt.Print("Confirm your password: ")
+		bytePassword, _ = terminal.ReadPassword(int(syscall.Stdin))
+		fmt.Println("")
+		if string(bytePassword) == pw {
+			return pw
+		}
+		fmt.Println("Passwords don't match. Try again.")
+	}
+}
+
+func (x *Backup) Execute(args []string) error {
+	// Set repo path
+	repoPath, err := getRepoPath(x.Testnet)
+	if err != nil {
+		return err
+	}
+	if x.DataDir != "" {
+		repoPath = x.DataDir
+	}
+	if !fsrepo.IsInitialized(repoPath) {
+		return errors.New("Repo is not initialized")
+	}
+	repoLockFile := filepath.Join(repoPath, lockfile.LockFile)
+	if _, err := os.Stat(repoLockFile); !os.IsNotExist(err) {
+		return errors.New("Cannot back up while the daemon is running")
+	}
+	pw := x.BackupPassword
+	if pw == "" {
+		pw = readBackupPassword(true)
+	}
+	f, err := os.OpenFile(x.Output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	opts := backup.Options{
+		RepoPath:     repoPath,
+		Testnet:      x.Testnet,
+		NodeVersion:  core.VERSION,
+		DatabaseOnly: x.DatabaseOnly,
+	}
+	manifest, err := backup.Create(f, opts, pw)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(x.Output)
+		return err
+	}
+	fmt.Printf("Backed up %d files of node %s to %s\n", len(manifest.Files), manifest.PeerID, x.Output)
+	return nil
+}
+
+func (x *Restore) Execute(args []string) error {
+	// Set repo path
+	repoPath, err := getRepoPath(x.Testnet)
+	if err != nil {
+		return err
+	}
+	if x.DataDir != "" {
+		repoPath = filepath.Clean(x.DataDir)
+	}
+	if fsrepo.IsInitialized(repoPath) && !x.Force {
+		return errors.New("Repo already exists. Use --force to replace it.")
+	}
+	repoLockFile := filepath.Join(repoPath, lockfile.LockFile)
+	if _, err := os.Stat(repoLockFile); !os.IsNotExist(err) {
+		return errors.New("Cannot restore while the daemon is running")
+	}
+	pw := x.BackupPassword
+	if pw == "" {
+		pw = readBackupPassword(false)
+	}
+
+	// Extract next to the repo so it can be moved into place once verified
+	tmpPath := repoPath + ".restore"
+	os.RemoveAll(tmpPath)
+	manifest, err := backup.Extract(x.Input, pw, tmpPath)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpPath)
+	if manifest.Testnet != x.Testnet {
+		return errors.New("The backup is of a node on a different network. Check the --testnet flag.")
+	}
+
+	var mnemonic string
+	if manifest.DatabaseOnly {
+		mnemonic = x.Mnemonic
+		if mnemonic == "" {
+			sqliteDB, err := db.Create(tmpPath, strings.Replace(x.Password, "'", "''", -1), x.Testnet)
+			if err != nil {
+				return err
+			}
+			if sqliteDB.Config().IsEncrypted() {
+				sqliteDB.Close()
+				return errors.New("The database in the backup is encrypted. Use --password or --mnemonic.")
+			}
+			mnemonic, err = sqliteDB.Config().GetMnemonic()
+			sqliteDB.Close()
+			if err != nil {
+				return err
+			}
+		}
+		identityKey, err := repo.IdentityKeyFromMnemonic(mnemonic, 4096)
+		if err != nil {
+			return err
+		}
+		identity, err := ipfs.IdentityFromKey(identityKey)
+		if err != nil {
+			return err
+		}
+		if identity.PeerID != manifest.PeerID {
+			return errors.New("The mnemonic does not belong to the node in the backup")
+		}
+	}
+
+	// Move any existing repo aside rather than deleting it
+	if _, err := os.Stat(repoPath); err == nil {
+		if fsrepo.IsInitialized(repoPath) {
+			old := fmt.Sprintf("%s.old-%d", repoPath, time.Now().Unix())
+			if err := os.Rename(repoPath, old); err != nil {
+				return err
+			}
+			fmt.Printf("Moved the existing repo to %s\n", old)
+		} else if err := os.Remove(repoPath); err != nil {
+			return fmt.Errorf("%s exists and is not empty", repoPath)
+		}
+	}
+
+	if !manifest.DatabaseOnly {
+		if err := os.Rename(tmpPath, repoPath); err != nil {
+			return err
+		}
+		fmt.Printf("Restored node %s to %s\n", manifest.PeerID, repoPath)
+		return nil
+	}
+
+	sqliteDB, err := initializeRepo(repoPath, "", mnemonic, x.Testnet)
+	if err != nil {
+		return err
+	}
+	sqliteDB.Close()
+	dbPath := filepath.FromSlash(backup.DatabasePath(x.Testnet))
+	if err := os.Rename(filepath.Join(tmpPath, dbPath), filepath.Join(repoPath, dbPath)); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(repoPath, core.RestoreRootMarker), []byte{}, 0600); err != nil {
+		return err
+	}
+	fmt.Printf("Restored node %s to %s. The root directory will be fetched from the network when the node is started.\n", manifest.PeerID, repoPath)
+	return nil
+}
+
 func (x *Init) Execute(args []string) error {
 	// Set repo path
 	repoPath, err := getRepoPath(x.Testnet)
@@ -438,6 +779,16 @@ func (x *Start) Execute(args []string) error {
 		}
 	}
 
+	// Bring the database schema up to date
+	applied, backup, err := sqliteDB.Migrate()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if len(applied) > 0 {
+		log.Noticef("Migrated database to schema version %d. The previous database was saved to %s", applied[len(applied)-1].Version, backup)
+	}
+
 	// Create authentication cookie
 	var authCookie http.Cookie
 	authCookie.Name = "OpenBazaar_Auth_Cookie"
@@ -492,6 +843,7 @@ func (x *Start) Execute(args []string) error {
 		log.Error(err)
 		return err
 	}
+	sqliteDB.AuditLog().SetKey(identityKey)
 	identity, err := ipfs.IdentityFromKey(identityKey)
 	if err != nil {
 		return err
@@ -747,6 +1099,53 @@ func (x *Start) Execute(args []string) error {
 		log.Fatal("Unknown wallet type")
 	}
 
+	// Additional wallets for other cryptocurrencies
+	altWalletCfgs, err := repo.GetAdditionalWalletConfigs(path.Join(repoPath, "config"))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	var altWallets []bitcoin.BitcoinWallet
+	for code, cfg := range altWalletCfgs {
+		w, err := bitcoin.NewWallet(cfg.Type, bitcoin.WalletOptions{
+			Mnemonic:    mn,
+			Testnet:     x.Testnet,
+			RepoPath:    repoPath,
+			Binary:      cfg.Binary,
+			TrustedPeer: cfg.TrustedPeer,
+			RPCUser:     cfg.RPCUser,
+			RPCPassword: cfg.RPCPassword,
+			FeeAPI:      cfg.FeeAPI,
+			MaxFee:      uint64(cfg.MaxFee),
+			LowFee:      uint64(cfg.LowFeeDefault),
+			MediumFee:   uint64(cfg.MediumFeeDefault),
+			HighFee:     uint64(cfg.HighFeeDefault),
+			Proxy:       torDialer,
+
+			UseTor:         usingTor && !usingClearnet,
+			TorControlPort: controlPort,
+		})
+		if err != nil {
+			log.Errorf("Failed to create the %s wallet: %s", code, err)
+			return err
+		}
+		if !strings.EqualFold(w.CurrencyCode(), code) && !strings.EqualFold(w.CurrencyCode(), "t"+code) {
+			return fmt.Errorf("Wallet configured for %s uses %s", code, w.CurrencyCode())
+		}
+		altWallets = append(altWallets, w)
+	}
+	multiwallet := bitcoin.NewMultiWallet(wallet, altWallets...)
+
+	// The command notifier is only available if the config file sets its command
+	notifierCommand, err := repo.GetNotifierCommand(path.Join(repoPath, "config"))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if notifierCommand != nil {
+		api.RegisterNotifier("command", api.CommandNotifierFactory(notifierCommand.Command, notifierCommand.Args))
+	}
+
 	// Crosspost gateway
 	gatewayUrlStrings, err := repo.GetCrosspostGateway(path.Join(repoPath, "config"))
 	if err != nil {
@@ -825,9 +1224,26 @@ func (x *Start) Execute(args []string) error {
 		return err
 	}
 
+	// Order timeouts
+	orderTimeouts, err := repo.GetOrderTimeoutConfig(path.Join(repoPath, "config"))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
 	var exchangeRates bitcoin.ExchangeRates
+	coinExchangeRates := make(map[string]bitcoin.ExchangeRates)
 	if !x.DisableExchangeRates {
 		exchangeRates = exchange.NewBitcoinPriceFetcher(torDialer)
+		for _, w := range altWallets {
+			// Testnet coins are priced as their mainnet counterparts
+			code := strings.ToUpper(w.CurrencyCode())
+			coin := code
+			if x.Testnet || x.Regtest {
+				coin = strings.TrimPrefix(coin, "T")
+			}
+			coinExchangeRates[code] = exchange.NewCrossRateFetcher(coin, exchangeRates, exchange.SatoshiPerBTC)
+		}
 	}
 
 	// Set up the ban manager
@@ -856,13 +1272,16 @@ func (x *Start) Execute(args []string) error {
 		RepoPath:          repoPath,
 		Datastore:         sqliteDB,
 		Wallet:            wallet,
+		Multiwallet:       multiwallet,
 		MessageStorage:    storage,
 		Resolver:          bstk.NewBlockStackClient(resolverUrl, torDialer),
 		ExchangeRates:     exchangeRates,
+		CoinExchangeRates: coinExchangeRates,
 		CrosspostGateways: gatewayUrls,
 		TorDialer:         torDialer,
 		UserAgent:         core.USERAGENT,
 		BanManager:        bm,
+		OrderTimeouts:     orderTimeouts,
 	}
 
 	if len(cfg.Addresses.Gateway) <= 0 {
@@ -886,18 +1305,37 @@ func (x *Start) Execute(args []string) error {
 		PR := rep.NewPointerRepublisher(nd, sqliteDB, core.Node.IsModerator)
 		go PR.Run()
 		core.Node.PointerRepublisher = PR
+		go core.Node.RunAuctionCloser()
+		go core.Node.RunCrowdFundSettler()
+		go core.Node.RunOrderTimeouts()
+		go core.Node.RunDigitalDeliveries()
+		go core.Node.RunSearchIndexer()
+		go core.Node.UpdateTagPointers()
 		if !x.DisableWallet {
 			MR.Wait()
-			TL := lis.NewTransactionListener(core.Node.Datastore, core.Node.Broadcast, core.Node.Wallet.Params())
+			for _, w := range multiwallet.Wallets() {
+				TL := lis.NewTransactionListener(core.Node.Datastore, core.Node.Broadcast, w.Params(), core.Node.ProcessFundedSale)
+				w.AddTransactionListener(TL.OnTransactionReceived)
+			}
 			WL := lis.NewWalletListener(core.Node.Datastore, core.Node.Broadcast)
-			wallet.AddTransactionListener(TL.OnTransactionReceived)
 			wallet.AddTransactionListener(WL.OnTransactionReceived)
 			log.Info("Starting bitcoin wallet")
 			su := bitcoin.NewStatusUpdater(wallet, core.Node.Broadcast, nd.Context())
 			go su.Start()
 			go wallet.Start()
+			for _, w := range altWallets {
+				log.Infof("Starting %s wallet", strings.ToUpper(w.CurrencyCode()))
+				go w.Start()
+			}
 		}
 		core.Node.UpdateFollow()
+		if core.Node.NeedsRootRestore() {
+			// Publishing now would replace our store with the empty directory the restore created
+			if err := core.Node.RestoreRoot(); err != nil {
+				log.Errorf("Failed to restore the root directory from the network, it will be retried on the next start: %s", err)
+				return
+			}
+		}
 		core.Node.SeedNode()
 	}()
 
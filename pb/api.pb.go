@@ -12,11 +12,13 @@ It is generated from these files:
 	message.proto
 	moderator.proto
 	orders.proto
+	posts.proto
 	profile.proto
 
 It has these top-level messages:
 	Coupon
 	OrderRespApi
+	OrderRuleDecision
 	CaseRespApi
 	TransactionRecord
 	PeerAndProfile
@@ -31,18 +33,31 @@ It has these top-level messages:
 	OrderFulfillment
 	OrderCompletion
 	Rating
+	RatingResponse
+	SignedRatingResponse
 	Dispute
 	DisputeResolution
+	DisputeEndorsement
+	DisputeProposal
+	DisputeProposalAcceptance
 	Outpoint
 	Refund
 	ID
 	Signature
 	SignedListing
+	Promotion
+	Promotions
+	SignedPromotions
 	Message
 	Envelope
 	Chat
+	Bid
+	BidAck
 	Moderator
 	DisputeUpdate
+	DisputeEvidence
+	Post
+	SignedPost
 	Profile
 */
 package pb
@@ -88,11 +103,12 @@ func (m *Coupon) GetCode() string {
 }
 
 type OrderRespApi struct {
-	Contract     *RicardianContract   `protobuf:"bytes,1,opt,name=contract" json:"contract,omitempty"`
-	State        OrderState           `protobuf:"varint,2,opt,name=state,enum=OrderState" json:"state,omitempty"`
-	Read         bool                 `protobuf:"varint,3,opt,name=read" json:"read,omitempty"`
-	Funded       bool                 `protobuf:"varint,4,opt,name=funded" json:"funded,omitempty"`
-	Transactions []*TransactionRecord `protobuf:"bytes,5,rep,name=transactions" json:"transactions,omitempty"`
+	Contract      *RicardianContract   `protobuf:"bytes,1,opt,name=contract" json:"contract,omitempty"`
+	State         OrderState           `protobuf:"varint,2,opt,name=state,enum=OrderState" json:"state,omitempty"`
+	Read          bool                 `protobuf:"varint,3,opt,name=read" json:"read,omitempty"`
+	Funded        bool                 `protobuf:"varint,4,opt,name=funded" json:"funded,omitempty"`
+	Transactions  []*TransactionRecord `protobuf:"bytes,5,rep,name=transactions" json:"transactions,omitempty"`
+	RuleDecisions []*OrderRuleDecision `protobuf:"bytes,6,rep,name=ruleDecisions" json:"ruleDecisions,omitempty"`
 }
 
 func (m *OrderRespApi) Reset()                    { *m = OrderRespApi{} }
@@ -135,6 +151,54 @@ func (m *OrderRespApi) GetTransactions() []*TransactionRecord {
 	return nil
 }
 
+func (m *OrderRespApi) GetRuleDecisions() []*OrderRuleDecision {
+	if m != nil {
+		return m.RuleDecisions
+	}
+	return nil
+}
+
+// An action taken on a sale by one of the vendor's order rules. Error is set if the action failed.
+type OrderRuleDecision struct {
+	Rule      string                     `protobuf:"bytes,1,opt,name=rule" json:"rule,omitempty"`
+	Action    string                     `protobuf:"bytes,2,opt,name=action" json:"action,omitempty"`
+	Error     string                     `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *OrderRuleDecision) Reset()                    { *m = OrderRuleDecision{} }
+func (m *OrderRuleDecision) String() string            { return proto.CompactTextString(m) }
+func (*OrderRuleDecision) ProtoMessage()               {}
+func (*OrderRuleDecision) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *OrderRuleDecision) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+func (m *OrderRuleDecision) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *OrderRuleDecision) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *OrderRuleDecision) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
 type CaseRespApi struct {
 	Timestamp                      *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
 	BuyerContract                  *RicardianContract         `protobuf:"bytes,2,opt,name=buyerContract" json:"buyerContract,omitempty"`
@@ -146,12 +210,14 @@ type CaseRespApi struct {
 	BuyerOpened                    bool                       `protobuf:"varint,8,opt,name=buyerOpened" json:"buyerOpened,omitempty"`
 	Claim                          string                     `protobuf:"bytes,9,opt,name=claim" json:"claim,omitempty"`
 	Resolution                     *DisputeResolution         `protobuf:"bytes,10,opt,name=resolution" json:"resolution,omitempty"`
+	Evidence                       []*DisputeEvidence         `protobuf:"bytes,11,rep,name=evidence" json:"evidence,omitempty"`
+	Proposals                      []*DisputeProposal         `protobuf:"bytes,12,rep,name=proposals" json:"proposals,omitempty"`
 }
 
 func (m *CaseRespApi) Reset()                    { *m = CaseRespApi{} }
 func (m *CaseRespApi) String() string            { return proto.CompactTextString(m) }
 func (*CaseRespApi) ProtoMessage()               {}
-func (*CaseRespApi) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*CaseRespApi) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 func (m *CaseRespApi) GetTimestamp() *google_protobuf.Timestamp {
 	if m != nil {
@@ -223,6 +289,20 @@ func (m *CaseRespApi) GetResolution() *DisputeResolution {
 	return nil
 }
 
+func (m *CaseRespApi) GetEvidence() []*DisputeEvidence {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+func (m *CaseRespApi) GetProposals() []*DisputeProposal {
+	if m != nil {
+		return m.Proposals
+	}
+	return nil
+}
+
 type TransactionRecord struct {
 	Txid          string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
 	Value         int64  `protobuf:"varint,2,opt,name=value" json:"value,omitempty"`
@@ -232,7 +312,7 @@ type TransactionRecord struct {
 func (m *TransactionRecord) Reset()                    { *m = TransactionRecord{} }
 func (m *TransactionRecord) String() string            { return proto.CompactTextString(m) }
 func (*TransactionRecord) ProtoMessage()               {}
-func (*TransactionRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*TransactionRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *TransactionRecord) GetTxid() string {
 	if m != nil {
@@ -263,7 +343,7 @@ type PeerAndProfile struct {
 func (m *PeerAndProfile) Reset()                    { *m = PeerAndProfile{} }
 func (m *PeerAndProfile) String() string            { return proto.CompactTextString(m) }
 func (*PeerAndProfile) ProtoMessage()               {}
-func (*PeerAndProfile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*PeerAndProfile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *PeerAndProfile) GetPeerId() string {
 	if m != nil {
@@ -288,7 +368,7 @@ type PeerAndProfileWithID struct {
 func (m *PeerAndProfileWithID) Reset()                    { *m = PeerAndProfileWithID{} }
 func (m *PeerAndProfileWithID) String() string            { return proto.CompactTextString(m) }
 func (*PeerAndProfileWithID) ProtoMessage()               {}
-func (*PeerAndProfileWithID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*PeerAndProfileWithID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *PeerAndProfileWithID) GetId() string {
 	if m != nil {
@@ -314,6 +394,7 @@ func (m *PeerAndProfileWithID) GetProfile() *Profile {
 func init() {
 	proto.RegisterType((*Coupon)(nil), "Coupon")
 	proto.RegisterType((*OrderRespApi)(nil), "OrderRespApi")
+	proto.RegisterType((*OrderRuleDecision)(nil), "OrderRuleDecision")
 	proto.RegisterType((*CaseRespApi)(nil), "CaseRespApi")
 	proto.RegisterType((*TransactionRecord)(nil), "TransactionRecord")
 	proto.RegisterType((*PeerAndProfile)(nil), "PeerAndProfile")
@@ -323,38 +404,44 @@ func init() {
 func init() { proto.RegisterFile("api.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 518 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x84, 0x53, 0xdd, 0x8a, 0x13, 0x31,
-	0x14, 0xa6, 0x9d, 0xb6, 0xdb, 0x9e, 0xfe, 0x88, 0x61, 0x91, 0x61, 0x41, 0xad, 0x83, 0x17, 0xbd,
-	0x9a, 0x95, 0x0a, 0xb2, 0x78, 0xb7, 0xb6, 0x0a, 0x0b, 0xc2, 0x2e, 0x71, 0x51, 0xf0, 0x2e, 0x9d,
-	0xa4, 0xdb, 0xc0, 0x34, 0x09, 0x49, 0x66, 0xd1, 0x07, 0xf4, 0x75, 0x7c, 0x06, 0xc9, 0xcf, 0xb4,
-	0x1d, 0xd7, 0xba, 0x77, 0x39, 0xdf, 0xf9, 0xce, 0xff, 0x17, 0x18, 0x10, 0xc5, 0x73, 0xa5, 0xa5,
-	0x95, 0x67, 0x4f, 0x0a, 0x29, 0xac, 0x26, 0x85, 0x35, 0x11, 0x18, 0x49, 0x4d, 0x99, 0xae, 0xad,
-	0xb1, 0xd2, 0x72, 0xcd, 0x4b, 0x16, 0xcd, 0x97, 0x77, 0x52, 0xde, 0x95, 0xec, 0xdc, 0x5b, 0xab,
-	0x6a, 0x7d, 0x6e, 0xf9, 0x96, 0x19, 0x4b, 0xb6, 0x2a, 0x10, 0xb2, 0x37, 0xd0, 0x5b, 0xc8, 0x4a,
-	0x49, 0x81, 0x10, 0x74, 0x36, 0xc4, 0x6c, 0xd2, 0xd6, 0xb4, 0x35, 0x1b, 0x60, 0xff, 0x76, 0x58,
-	0x21, 0x29, 0x4b, 0xdb, 0x01, 0x73, 0xef, 0xec, 0x57, 0x0b, 0x46, 0xd7, 0xae, 0x24, 0x66, 0x46,
-	0x5d, 0x2a, 0x8e, 0x72, 0xe8, 0xd7, 0x3d, 0xf9, 0xe0, 0xe1, 0x1c, 0xe5, 0x98, 0x17, 0x44, 0x53,
-	0x4e, 0xc4, 0x22, 0x7a, 0xf0, 0x8e, 0x83, 0x5e, 0x41, 0xd7, 0x58, 0x62, 0x43, 0xd6, 0xc9, 0x7c,
-	0x98, 0xfb, 0x6c, 0x5f, 0x1c, 0x84, 0x83, 0xc7, 0xd5, 0xd5, 0x8c, 0xd0, 0x34, 0x99, 0xb6, 0x66,
-	0x7d, 0xec, 0xdf, 0xe8, 0x19, 0xf4, 0xd6, 0x95, 0xa0, 0x8c, 0xa6, 0x1d, 0x8f, 0x46, 0x0b, 0xbd,
-	0x83, 0x91, 0xd5, 0x44, 0x18, 0x52, 0x58, 0x2e, 0x85, 0x49, 0xbb, 0xd3, 0xc4, 0xb7, 0x70, 0xbb,
-	0x07, 0x31, 0x2b, 0xa4, 0xa6, 0xb8, 0xc1, 0xcb, 0x7e, 0x27, 0x30, 0x5c, 0x10, 0xc3, 0xea, 0x31,
-	0x2e, 0x60, 0xb0, 0x5b, 0x4e, 0x9c, 0xe3, 0x2c, 0x0f, 0xeb, 0xcb, 0xeb, 0xf5, 0xe5, 0xb7, 0x35,
-	0x03, 0xef, 0xc9, 0xe8, 0x02, 0xc6, 0xab, 0xea, 0x27, 0xd3, 0xf5, 0xac, 0x7e, 0xb0, 0x7f, 0x6f,
-	0xa1, 0x49, 0x44, 0xef, 0x61, 0x72, 0xcf, 0x04, 0x95, 0xfb, 0xd0, 0xe4, 0x68, 0xe8, 0x5f, 0x4c,
-	0xb4, 0x84, 0xe7, 0x8d, 0x64, 0x5f, 0x49, 0xc9, 0x29, 0x71, 0xb3, 0x7d, 0xd4, 0x5a, 0x6a, 0x93,
-	0x76, 0xa6, 0xc9, 0x6c, 0x80, 0xff, 0x4f, 0x42, 0x9f, 0xe0, 0x45, 0x33, 0xef, 0x83, 0x34, 0x5d,
-	0x9f, 0xe6, 0x11, 0xd6, 0xfe, 0xa8, 0xbd, 0x47, 0x8f, 0x7a, 0x72, 0x70, 0xd4, 0x29, 0x0c, 0x7d,
-	0x7f, 0xd7, 0x8a, 0x09, 0x46, 0xd3, 0xbe, 0x77, 0x1d, 0x42, 0xe8, 0x14, 0xba, 0x45, 0x49, 0xf8,
-	0x36, 0x1d, 0x78, 0x0d, 0x06, 0x03, 0xcd, 0x01, 0x34, 0x33, 0xb2, 0xac, 0x5c, 0x0b, 0x29, 0xc4,
-	0xa5, 0x2d, 0xb9, 0x51, 0x95, 0x75, 0x17, 0x8d, 0x1e, 0x7c, 0xc0, 0xca, 0x0a, 0x78, 0xfa, 0x40,
-	0x13, 0xae, 0x29, 0xfb, 0x83, 0xd3, 0x5a, 0xf5, 0xee, 0xed, 0x4a, 0xde, 0x93, 0xb2, 0x0a, 0x02,
-	0x4d, 0x70, 0x30, 0xd0, 0x6b, 0x18, 0x17, 0x52, 0xac, 0xb9, 0xde, 0x92, 0x20, 0x34, 0x77, 0xaa,
-	0x31, 0x6e, 0x82, 0xd9, 0x67, 0x98, 0xdc, 0x30, 0xa6, 0x2f, 0x05, 0xbd, 0x09, 0x1f, 0xd1, 0xe9,
-	0x56, 0x31, 0xa6, 0xaf, 0xea, 0x1a, 0xd1, 0x42, 0x19, 0x9c, 0xc4, 0xbf, 0x1a, 0xf5, 0xd2, 0xcf,
-	0x63, 0x08, 0xae, 0x1d, 0xd9, 0x0a, 0x4e, 0x9b, 0xd9, 0xbe, 0x71, 0xbb, 0xb9, 0x5a, 0xa2, 0x09,
-	0xb4, 0x77, 0x3d, 0xb7, 0x39, 0x3d, 0xa8, 0xd1, 0x3e, 0x56, 0x23, 0x39, 0x52, 0xe3, 0x43, 0xe7,
-	0x7b, 0x5b, 0xad, 0x56, 0x3d, 0x2f, 0xf1, 0xb7, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x84, 0x04,
-	0xba, 0xea, 0x6a, 0x04, 0x00, 0x00,
+	// 619 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x54, 0x5b, 0x6b, 0x13, 0x41,
+	0x14, 0x26, 0xd7, 0x66, 0x4f, 0x2e, 0xda, 0xa1, 0xc8, 0x52, 0x50, 0xe3, 0xe2, 0x43, 0x1f, 0x64,
+	0x2b, 0x11, 0xa4, 0xf8, 0x56, 0x9b, 0x0a, 0x05, 0xa1, 0x65, 0x2c, 0x0a, 0xbe, 0x4d, 0x76, 0x4e,
+	0xda, 0x81, 0xcd, 0xce, 0x32, 0x33, 0x5b, 0xf4, 0x57, 0xf8, 0xc7, 0xfc, 0x4f, 0xca, 0x5c, 0x36,
+	0xc9, 0x5a, 0x63, 0xf1, 0x6d, 0xce, 0x77, 0xbe, 0x73, 0x3f, 0x67, 0x20, 0x62, 0xa5, 0x48, 0x4b,
+	0x25, 0x8d, 0x3c, 0x7c, 0x94, 0xc9, 0xc2, 0x28, 0x96, 0x19, 0x1d, 0x80, 0x91, 0x54, 0x1c, 0x55,
+	0x2d, 0x8d, 0x4b, 0x25, 0x97, 0x22, 0xc7, 0x9a, 0xbd, 0x92, 0x1c, 0x15, 0x33, 0x52, 0x05, 0xe0,
+	0xf9, 0x8d, 0x94, 0x37, 0x39, 0x1e, 0x3b, 0x69, 0x51, 0x2d, 0x8f, 0x8d, 0x58, 0xa1, 0x36, 0x6c,
+	0x55, 0x7a, 0x42, 0xf2, 0x1a, 0xfa, 0x67, 0xb2, 0x2a, 0x65, 0x41, 0x08, 0x74, 0x6f, 0x99, 0xbe,
+	0x8d, 0x5b, 0xd3, 0xd6, 0x51, 0x44, 0xdd, 0xdb, 0x62, 0x99, 0xe4, 0x18, 0xb7, 0x3d, 0x66, 0xdf,
+	0xc9, 0xaf, 0x16, 0x8c, 0x2e, 0x6d, 0x0e, 0x14, 0x75, 0x79, 0x5a, 0x0a, 0x92, 0xc2, 0xa0, 0x4e,
+	0xd2, 0x19, 0x0f, 0x67, 0x24, 0xa5, 0x22, 0x63, 0x8a, 0x0b, 0x56, 0x9c, 0x05, 0x0d, 0x5d, 0x73,
+	0xc8, 0x0b, 0xe8, 0x69, 0xc3, 0x8c, 0xf7, 0x3a, 0x99, 0x0d, 0x53, 0xe7, 0xed, 0x93, 0x85, 0xa8,
+	0xd7, 0xd8, 0xb8, 0x0a, 0x19, 0x8f, 0x3b, 0xd3, 0xd6, 0xd1, 0x80, 0xba, 0x37, 0x79, 0x02, 0xfd,
+	0x65, 0x55, 0x70, 0xe4, 0x71, 0xd7, 0xa1, 0x41, 0x22, 0x6f, 0x61, 0x64, 0x14, 0x2b, 0x34, 0xcb,
+	0x8c, 0x90, 0x85, 0x8e, 0x7b, 0xd3, 0x8e, 0x4b, 0xe1, 0x7a, 0x03, 0x52, 0xcc, 0xa4, 0xe2, 0xb4,
+	0xc1, 0x23, 0x27, 0x30, 0x56, 0x55, 0x8e, 0x73, 0xcc, 0x84, 0x76, 0x86, 0xfd, 0x60, 0xe8, 0x8b,
+	0xdb, 0x52, 0xd1, 0x26, 0x31, 0xf9, 0xd1, 0x82, 0xfd, 0x7b, 0x24, 0x97, 0x73, 0x95, 0x63, 0xdd,
+	0x3f, 0xfb, 0xb6, 0x39, 0xfb, 0x70, 0xa1, 0x83, 0x41, 0x22, 0x07, 0xd0, 0x43, 0xa5, 0xa4, 0x72,
+	0x05, 0x46, 0xd4, 0x0b, 0xe4, 0x04, 0xa2, 0xf5, 0x78, 0x5c, 0x91, 0xc3, 0xd9, 0x61, 0xea, 0x07,
+	0x98, 0xd6, 0x03, 0x4c, 0xaf, 0x6b, 0x06, 0xdd, 0x90, 0x93, 0x9f, 0x5d, 0x18, 0x9e, 0x31, 0x8d,
+	0xf5, 0x48, 0x1a, 0x9e, 0x5a, 0xff, 0xe1, 0xc9, 0x76, 0x65, 0x51, 0x7d, 0x47, 0x55, 0xcf, 0xcd,
+	0x25, 0xfe, 0xf7, 0x89, 0x36, 0x89, 0xe4, 0x1d, 0x4c, 0xee, 0xb0, 0xe0, 0x72, 0x63, 0xda, 0xd9,
+	0x69, 0xfa, 0x07, 0x93, 0xcc, 0xe1, 0x69, 0xc3, 0xd9, 0x67, 0x96, 0x0b, 0xce, 0x6c, 0xab, 0xce,
+	0x6d, 0x67, 0x74, 0xdc, 0x9d, 0x76, 0x8e, 0x22, 0xfa, 0x6f, 0x12, 0xf9, 0x00, 0xcf, 0x9a, 0x7e,
+	0xef, 0xb9, 0xe9, 0x39, 0x37, 0x0f, 0xb0, 0x36, 0x0b, 0xda, 0x7f, 0x70, 0x41, 0xf7, 0xb6, 0x16,
+	0x74, 0x0a, 0x43, 0x97, 0xdf, 0x65, 0x89, 0x05, 0xf2, 0x78, 0xe0, 0x54, 0xdb, 0x90, 0x1d, 0x7b,
+	0x96, 0x33, 0xb1, 0x8a, 0x23, 0x3f, 0x76, 0x27, 0x90, 0x19, 0x80, 0x42, 0x2d, 0xf3, 0xca, 0x2d,
+	0x0a, 0x84, 0xa6, 0xcd, 0x85, 0x2e, 0x2b, 0x83, 0x74, 0xad, 0xa1, 0x5b, 0x2c, 0xf2, 0x0a, 0x06,
+	0x78, 0x27, 0x38, 0x16, 0x19, 0xc6, 0x43, 0xb7, 0xb7, 0x8f, 0x6b, 0x8b, 0xf3, 0x80, 0xd3, 0x35,
+	0x83, 0xa4, 0x10, 0x95, 0x4a, 0x96, 0x52, 0xb3, 0x5c, 0xc7, 0xa3, 0x26, 0xfd, 0x2a, 0x28, 0xe8,
+	0x86, 0x92, 0x64, 0xb0, 0x7f, 0xef, 0x7a, 0x6c, 0xc9, 0xe6, 0x9b, 0xe0, 0xf5, 0x7e, 0xdb, 0xb7,
+	0x2d, 0xe8, 0x8e, 0xe5, 0x95, 0x3f, 0xe5, 0x0e, 0xf5, 0x02, 0x79, 0x09, 0xe3, 0x4c, 0x16, 0x4b,
+	0xa1, 0x56, 0xcc, 0x9f, 0xa4, 0x5d, 0x84, 0x31, 0x6d, 0x82, 0xc9, 0x47, 0x98, 0x5c, 0x21, 0xaa,
+	0xd3, 0x82, 0x5f, 0xf9, 0x3f, 0xcc, 0x5e, 0x4b, 0x89, 0xa8, 0x2e, 0xea, 0x18, 0x41, 0x22, 0x09,
+	0xec, 0x85, 0x6f, 0x2e, 0x6c, 0xe3, 0x20, 0x0d, 0x26, 0xb4, 0x56, 0x24, 0x0b, 0x38, 0x68, 0x7a,
+	0xfb, 0x22, 0xcc, 0xed, 0xc5, 0x9c, 0x4c, 0xa0, 0xbd, 0xce, 0xb9, 0x2d, 0xf8, 0x56, 0x8c, 0xf6,
+	0xae, 0x18, 0x9d, 0x1d, 0x31, 0xde, 0x77, 0xbf, 0xb6, 0xcb, 0xc5, 0xa2, 0xef, 0x0e, 0xe8, 0xcd,
+	0xef, 0x01, 0x00, 0xd9, 0x42, 0x31, 0x69, 0xa5, 0x05, 0x00, 0x00,
 }
@@ -182,18 +182,21 @@ var Signature_Section_value = map[string]int32{
 func (x Signature_Section) String() string {
 	return proto.EnumName(Signature_Section_name, int32(x))
 }
-func (Signature_Section) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{15, 0} }
+func (Signature_Section) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{20, 0} }
 
 type RicardianContract struct {
-	VendorListings          []*Listing          `protobuf:"bytes,1,rep,name=vendorListings" json:"vendorListings,omitempty"`
-	BuyerOrder              *Order              `protobuf:"bytes,2,opt,name=buyerOrder" json:"buyerOrder,omitempty"`
-	VendorOrderConfirmation *OrderConfirmation  `protobuf:"bytes,3,opt,name=vendorOrderConfirmation" json:"vendorOrderConfirmation,omitempty"`
-	VendorOrderFulfillment  []*OrderFulfillment `protobuf:"bytes,4,rep,name=vendorOrderFulfillment" json:"vendorOrderFulfillment,omitempty"`
-	BuyerOrderCompletion    *OrderCompletion    `protobuf:"bytes,5,opt,name=buyerOrderCompletion" json:"buyerOrderCompletion,omitempty"`
-	Dispute                 *Dispute            `protobuf:"bytes,6,opt,name=dispute" json:"dispute,omitempty"`
-	DisputeResolution       *DisputeResolution  `protobuf:"bytes,7,opt,name=disputeResolution" json:"disputeResolution,omitempty"`
-	Refund                  *Refund             `protobuf:"bytes,8,opt,name=refund" json:"refund,omitempty"`
-	Signatures              []*Signature        `protobuf:"bytes,9,rep,name=signatures" json:"signatures,omitempty"`
+	VendorListings          []*Listing            `protobuf:"bytes,1,rep,name=vendorListings" json:"vendorListings,omitempty"`
+	BuyerOrder              *Order                `protobuf:"bytes,2,opt,name=buyerOrder" json:"buyerOrder,omitempty"`
+	VendorOrderConfirmation *OrderConfirmation    `protobuf:"bytes,3,opt,name=vendorOrderConfirmation" json:"vendorOrderConfirmation,omitempty"`
+	VendorOrderFulfillment  []*OrderFulfillment   `protobuf:"bytes,4,rep,name=vendorOrderFulfillment" json:"vendorOrderFulfillment,omitempty"`
+	BuyerOrderCompletion    *OrderCompletion      `protobuf:"bytes,5,opt,name=buyerOrderCompletion" json:"buyerOrderCompletion,omitempty"`
+	Dispute                 *Dispute              `protobuf:"bytes,6,opt,name=dispute" json:"dispute,omitempty"`
+	DisputeResolution       *DisputeResolution    `protobuf:"bytes,7,opt,name=disputeResolution" json:"disputeResolution,omitempty"`
+	Refund                  *Refund               `protobuf:"bytes,8,opt,name=refund" json:"refund,omitempty"`
+	Signatures              []*Signature          `protobuf:"bytes,9,rep,name=signatures" json:"signatures,omitempty"`
+	DisputeEndorsements     []*DisputeEndorsement `protobuf:"bytes,10,rep,name=disputeEndorsements" json:"disputeEndorsements,omitempty"`
+	DisputeProposals        []*DisputeProposal    `protobuf:"bytes,11,rep,name=disputeProposals" json:"disputeProposals,omitempty"`
+	VendorPromotions        *SignedPromotions     `protobuf:"bytes,12,opt,name=vendorPromotions" json:"vendorPromotions,omitempty"`
 }
 
 func (m *RicardianContract) Reset()                    { *m = RicardianContract{} }
@@ -264,6 +267,27 @@ func (m *RicardianContract) GetSignatures() []*Signature {
 	return nil
 }
 
+func (m *RicardianContract) GetDisputeEndorsements() []*DisputeEndorsement {
+	if m != nil {
+		return m.DisputeEndorsements
+	}
+	return nil
+}
+
+func (m *RicardianContract) GetDisputeProposals() []*DisputeProposal {
+	if m != nil {
+		return m.DisputeProposals
+	}
+	return nil
+}
+
+func (m *RicardianContract) GetVendorPromotions() *SignedPromotions {
+	if m != nil {
+		return m.VendorPromotions
+	}
+	return nil
+}
+
 type Listing struct {
 	Slug               string                    `protobuf:"bytes,1,opt,name=slug" json:"slug,omitempty"`
 	VendorID           *ID                       `protobuf:"bytes,2,opt,name=vendorID" json:"vendorID,omitempty"`
@@ -275,6 +299,8 @@ type Listing struct {
 	Moderators         []string                  `protobuf:"bytes,8,rep,name=moderators" json:"moderators,omitempty"`
 	TermsAndConditions string                    `protobuf:"bytes,9,opt,name=termsAndConditions" json:"termsAndConditions,omitempty"`
 	RefundPolicy       string                    `protobuf:"bytes,10,opt,name=refundPolicy" json:"refundPolicy,omitempty"`
+	Auction            *Listing_Auction          `protobuf:"bytes,11,opt,name=auction" json:"auction,omitempty"`
+	CrowdFund          *Listing_CrowdFund        `protobuf:"bytes,12,opt,name=crowdFund" json:"crowdFund,omitempty"`
 }
 
 func (m *Listing) Reset()                    { *m = Listing{} }
@@ -352,14 +378,30 @@ func (m *Listing) GetRefundPolicy() string {
 	return ""
 }
 
+func (m *Listing) GetAuction() *Listing_Auction {
+	if m != nil {
+		return m.Auction
+	}
+	return nil
+}
+
+func (m *Listing) GetCrowdFund() *Listing_CrowdFund {
+	if m != nil {
+		return m.CrowdFund
+	}
+	return nil
+}
+
 type Listing_Metadata struct {
-	Version          uint32                        `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
-	ContractType     Listing_Metadata_ContractType `protobuf:"varint,2,opt,name=contractType,enum=Listing_Metadata_ContractType" json:"contractType,omitempty"`
-	Format           Listing_Metadata_Format       `protobuf:"varint,3,opt,name=format,enum=Listing_Metadata_Format" json:"format,omitempty"`
-	Expiry           *google_protobuf.Timestamp    `protobuf:"bytes,4,opt,name=expiry" json:"expiry,omitempty"`
-	AcceptedCurrency string                        `protobuf:"bytes,5,opt,name=acceptedCurrency" json:"acceptedCurrency,omitempty"`
-	PricingCurrency  string                        `protobuf:"bytes,6,opt,name=pricingCurrency" json:"pricingCurrency,omitempty"`
-	Language         string                        `protobuf:"bytes,7,opt,name=language" json:"language,omitempty"`
+	Version            uint32                        `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	ContractType       Listing_Metadata_ContractType `protobuf:"varint,2,opt,name=contractType,enum=Listing_Metadata_ContractType" json:"contractType,omitempty"`
+	Format             Listing_Metadata_Format       `protobuf:"varint,3,opt,name=format,enum=Listing_Metadata_Format" json:"format,omitempty"`
+	Expiry             *google_protobuf.Timestamp    `protobuf:"bytes,4,opt,name=expiry" json:"expiry,omitempty"`
+	AcceptedCurrency   string                        `protobuf:"bytes,5,opt,name=acceptedCurrency" json:"acceptedCurrency,omitempty"`
+	PricingCurrency    string                        `protobuf:"bytes,6,opt,name=pricingCurrency" json:"pricingCurrency,omitempty"`
+	Language           string                        `protobuf:"bytes,7,opt,name=language" json:"language,omitempty"`
+	EscrowTimeoutHours uint32                        `protobuf:"varint,8,opt,name=escrowTimeoutHours" json:"escrowTimeoutHours,omitempty"`
+	AcceptedCurrencies []string                      `protobuf:"bytes,9,rep,name=acceptedCurrencies" json:"acceptedCurrencies,omitempty"`
 }
 
 func (m *Listing_Metadata) Reset()                    { *m = Listing_Metadata{} }
@@ -416,6 +458,20 @@ func (m *Listing_Metadata) GetLanguage() string {
 	return ""
 }
 
+func (m *Listing_Metadata) GetEscrowTimeoutHours() uint32 {
+	if m != nil {
+		return m.EscrowTimeoutHours
+	}
+	return 0
+}
+
+func (m *Listing_Metadata) GetAcceptedCurrencies() []string {
+	if m != nil {
+		return m.AcceptedCurrencies
+	}
+	return nil
+}
+
 type Listing_Item struct {
 	Title          string                 `protobuf:"bytes,1,opt,name=title" json:"title,omitempty"`
 	Description    string                 `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
@@ -868,6 +924,11 @@ type Listing_Coupon struct {
 	//	*Listing_Coupon_PercentDiscount
 	//	*Listing_Coupon_PriceDiscount
 	Discount isListing_Coupon_Discount `protobuf_oneof:"discount"`
+	// Limits on redeeming the coupon. Zero values mean no limit.
+	Expiry          *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=expiry" json:"expiry,omitempty"`
+	MaxRedemptions  uint32                     `protobuf:"varint,8,opt,name=maxRedemptions" json:"maxRedemptions,omitempty"`
+	MinimumPurchase uint64                     `protobuf:"varint,9,opt,name=minimumPurchase" json:"minimumPurchase,omitempty"`
+	OncePerBuyer    bool                       `protobuf:"varint,10,opt,name=oncePerBuyer" json:"oncePerBuyer,omitempty"`
 }
 
 func (m *Listing_Coupon) Reset()                    { *m = Listing_Coupon{} }
@@ -948,6 +1009,34 @@ func (m *Listing_Coupon) GetPriceDiscount() uint64 {
 	return 0
 }
 
+func (m *Listing_Coupon) GetExpiry() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Expiry
+	}
+	return nil
+}
+
+func (m *Listing_Coupon) GetMaxRedemptions() uint32 {
+	if m != nil {
+		return m.MaxRedemptions
+	}
+	return 0
+}
+
+func (m *Listing_Coupon) GetMinimumPurchase() uint64 {
+	if m != nil {
+		return m.MinimumPurchase
+	}
+	return 0
+}
+
+func (m *Listing_Coupon) GetOncePerBuyer() bool {
+	if m != nil {
+		return m.OncePerBuyer
+	}
+	return false
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Listing_Coupon) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Listing_Coupon_OneofMarshaler, _Listing_Coupon_OneofUnmarshaler, _Listing_Coupon_OneofSizer, []interface{}{
@@ -1054,6 +1143,62 @@ func _Listing_Coupon_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+type Listing_Auction struct {
+	ReservePrice     uint64                     `protobuf:"varint,1,opt,name=reservePrice" json:"reservePrice,omitempty"`
+	MinimumIncrement uint64                     `protobuf:"varint,2,opt,name=minimumIncrement" json:"minimumIncrement,omitempty"`
+	Closes           *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=closes" json:"closes,omitempty"`
+}
+
+func (m *Listing_Auction) Reset()                    { *m = Listing_Auction{} }
+func (m *Listing_Auction) String() string            { return proto.CompactTextString(m) }
+func (*Listing_Auction) ProtoMessage()               {}
+func (*Listing_Auction) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1, 5} }
+
+func (m *Listing_Auction) GetReservePrice() uint64 {
+	if m != nil {
+		return m.ReservePrice
+	}
+	return 0
+}
+
+func (m *Listing_Auction) GetMinimumIncrement() uint64 {
+	if m != nil {
+		return m.MinimumIncrement
+	}
+	return 0
+}
+
+func (m *Listing_Auction) GetCloses() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Closes
+	}
+	return nil
+}
+
+type Listing_CrowdFund struct {
+	Goal     uint64                     `protobuf:"varint,1,opt,name=goal" json:"goal,omitempty"`
+	Deadline *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=deadline" json:"deadline,omitempty"`
+}
+
+func (m *Listing_CrowdFund) Reset()                    { *m = Listing_CrowdFund{} }
+func (m *Listing_CrowdFund) String() string            { return proto.CompactTextString(m) }
+func (*Listing_CrowdFund) ProtoMessage()               {}
+func (*Listing_CrowdFund) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1, 6} }
+
+func (m *Listing_CrowdFund) GetGoal() uint64 {
+	if m != nil {
+		return m.Goal
+	}
+	return 0
+}
+
+func (m *Listing_CrowdFund) GetDeadline() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Deadline
+	}
+	return nil
+}
+
 type Order struct {
 	RefundAddress        string                     `protobuf:"bytes,1,opt,name=refundAddress" json:"refundAddress,omitempty"`
 	RefundFee            uint64                     `protobuf:"varint,2,opt,name=refundFee" json:"refundFee,omitempty"`
@@ -1205,6 +1350,7 @@ type Order_Item struct {
 	ShippingOption *Order_Item_ShippingOption `protobuf:"bytes,4,opt,name=shippingOption" json:"shippingOption,omitempty"`
 	Memo           string                     `protobuf:"bytes,5,opt,name=memo" json:"memo,omitempty"`
 	CouponCodes    []string                   `protobuf:"bytes,6,rep,name=couponCodes" json:"couponCodes,omitempty"`
+	Bid            uint64                     `protobuf:"varint,7,opt,name=bid" json:"bid,omitempty"`
 }
 
 func (m *Order_Item) Reset()                    { *m = Order_Item{} }
@@ -1254,6 +1400,13 @@ func (m *Order_Item) GetCouponCodes() []string {
 	return nil
 }
 
+func (m *Order_Item) GetBid() uint64 {
+	if m != nil {
+		return m.Bid
+	}
+	return 0
+}
+
 type Order_Item_Option struct {
 	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
@@ -1303,13 +1456,15 @@ func (m *Order_Item_ShippingOption) GetService() string {
 }
 
 type Order_Payment struct {
-	Method       Order_Payment_Method `protobuf:"varint,1,opt,name=method,enum=Order_Payment_Method" json:"method,omitempty"`
-	Moderator    string               `protobuf:"bytes,2,opt,name=moderator" json:"moderator,omitempty"`
-	Amount       uint64               `protobuf:"varint,3,opt,name=amount" json:"amount,omitempty"`
-	ExchangeRate uint64               `protobuf:"varint,4,opt,name=exchangeRate" json:"exchangeRate,omitempty"`
-	Chaincode    string               `protobuf:"bytes,6,opt,name=chaincode" json:"chaincode,omitempty"`
-	Address      string               `protobuf:"bytes,7,opt,name=address" json:"address,omitempty"`
-	RedeemScript string               `protobuf:"bytes,8,opt,name=redeemScript" json:"redeemScript,omitempty"`
+	Method         Order_Payment_Method `protobuf:"varint,1,opt,name=method,enum=Order_Payment_Method" json:"method,omitempty"`
+	Moderator      string               `protobuf:"bytes,2,opt,name=moderator" json:"moderator,omitempty"`
+	Amount         uint64               `protobuf:"varint,3,opt,name=amount" json:"amount,omitempty"`
+	ExchangeRate   uint64               `protobuf:"varint,4,opt,name=exchangeRate" json:"exchangeRate,omitempty"`
+	Chaincode      string               `protobuf:"bytes,6,opt,name=chaincode" json:"chaincode,omitempty"`
+	Address        string               `protobuf:"bytes,7,opt,name=address" json:"address,omitempty"`
+	RedeemScript   string               `protobuf:"bytes,8,opt,name=redeemScript" json:"redeemScript,omitempty"`
+	Coin           string               `protobuf:"bytes,9,opt,name=coin" json:"coin,omitempty"`
+	ModeratorPanel []string             `protobuf:"bytes,10,rep,name=moderatorPanel" json:"moderatorPanel,omitempty"`
 }
 
 func (m *Order_Payment) Reset()                    { *m = Order_Payment{} }
@@ -1366,6 +1521,20 @@ func (m *Order_Payment) GetRedeemScript() string {
 	return ""
 }
 
+func (m *Order_Payment) GetCoin() string {
+	if m != nil {
+		return m.Coin
+	}
+	return ""
+}
+
+func (m *Order_Payment) GetModeratorPanel() []string {
+	if m != nil {
+		return m.ModeratorPanel
+	}
+	return nil
+}
+
 type OrderConfirmation struct {
 	OrderID   string                     `protobuf:"bytes,1,opt,name=orderID" json:"orderID,omitempty"`
 	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
@@ -1540,6 +1709,8 @@ type OrderFulfillment struct {
 	// Moderated payments only
 	Payout          *OrderFulfillment_Payout `protobuf:"bytes,6,opt,name=payout" json:"payout,omitempty"`
 	RatingSignature *RatingSignature         `protobuf:"bytes,7,opt,name=ratingSignature" json:"ratingSignature,omitempty"`
+	// Crowdfund pledges only
+	CrowdFund *OrderFulfillment_CrowdFundResult `protobuf:"bytes,8,opt,name=crowdFund" json:"crowdFund,omitempty"`
 }
 
 func (m *OrderFulfillment) Reset()                    { *m = OrderFulfillment{} }
@@ -1596,6 +1767,13 @@ func (m *OrderFulfillment) GetRatingSignature() *RatingSignature {
 	return nil
 }
 
+func (m *OrderFulfillment) GetCrowdFund() *OrderFulfillment_CrowdFundResult {
+	if m != nil {
+		return m.CrowdFund
+	}
+	return nil
+}
+
 type OrderFulfillment_PhysicalDelivery struct {
 	Shipper        string `protobuf:"bytes,1,opt,name=shipper" json:"shipper,omitempty"`
 	TrackingNumber string `protobuf:"bytes,2,opt,name=trackingNumber" json:"trackingNumber,omitempty"`
@@ -1625,6 +1803,13 @@ func (m *OrderFulfillment_PhysicalDelivery) GetTrackingNumber() string {
 type OrderFulfillment_DigitalDelivery struct {
 	Url      string `protobuf:"bytes,1,opt,name=url" json:"url,omitempty"`
 	Password string `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
+	// Files delivered by the node: the IPFS hash of the encrypted file,
+	// the AES-256-GCM key it is encrypted with and the sha256 of the file
+	Hash      string `protobuf:"bytes,3,opt,name=hash" json:"hash,omitempty"`
+	Key       []byte `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Filename  string `protobuf:"bytes,5,opt,name=filename" json:"filename,omitempty"`
+	MediaType string `protobuf:"bytes,6,opt,name=mediaType" json:"mediaType,omitempty"`
+	Checksum  string `protobuf:"bytes,7,opt,name=checksum" json:"checksum,omitempty"`
 }
 
 func (m *OrderFulfillment_DigitalDelivery) Reset()         { *m = OrderFulfillment_DigitalDelivery{} }
@@ -1648,6 +1833,41 @@ func (m *OrderFulfillment_DigitalDelivery) GetPassword() string {
 	return ""
 }
 
+func (m *OrderFulfillment_DigitalDelivery) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *OrderFulfillment_DigitalDelivery) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *OrderFulfillment_DigitalDelivery) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *OrderFulfillment_DigitalDelivery) GetMediaType() string {
+	if m != nil {
+		return m.MediaType
+	}
+	return ""
+}
+
+func (m *OrderFulfillment_DigitalDelivery) GetChecksum() string {
+	if m != nil {
+		return m.Checksum
+	}
+	return ""
+}
+
 type OrderFulfillment_Payout struct {
 	Sigs             []*BitcoinSignature `protobuf:"bytes,1,rep,name=sigs" json:"sigs,omitempty"`
 	PayoutAddress    string              `protobuf:"bytes,2,opt,name=payoutAddress" json:"payoutAddress,omitempty"`
@@ -1680,6 +1900,71 @@ func (m *OrderFulfillment_Payout) GetPayoutFeePerByte() uint64 {
 	return 0
 }
 
+// The confirmed pledges the vendor counted towards the crowdfund's goal
+type OrderFulfillment_CrowdFundResult struct {
+	Raised        uint64                                           `protobuf:"varint,1,opt,name=raised" json:"raised,omitempty"`
+	Contributions []*OrderFulfillment_CrowdFundResult_Contribution `protobuf:"bytes,2,rep,name=contributions" json:"contributions,omitempty"`
+}
+
+func (m *OrderFulfillment_CrowdFundResult) Reset()         { *m = OrderFulfillment_CrowdFundResult{} }
+func (m *OrderFulfillment_CrowdFundResult) String() string { return proto.CompactTextString(m) }
+func (*OrderFulfillment_CrowdFundResult) ProtoMessage()    {}
+func (*OrderFulfillment_CrowdFundResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{7, 3}
+}
+
+func (m *OrderFulfillment_CrowdFundResult) GetRaised() uint64 {
+	if m != nil {
+		return m.Raised
+	}
+	return 0
+}
+
+func (m *OrderFulfillment_CrowdFundResult) GetContributions() []*OrderFulfillment_CrowdFundResult_Contribution {
+	if m != nil {
+		return m.Contributions
+	}
+	return nil
+}
+
+type OrderFulfillment_CrowdFundResult_Contribution struct {
+	Txid  string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+	Index uint32 `protobuf:"varint,2,opt,name=index" json:"index,omitempty"`
+	Value uint64 `protobuf:"varint,3,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *OrderFulfillment_CrowdFundResult_Contribution) Reset() {
+	*m = OrderFulfillment_CrowdFundResult_Contribution{}
+}
+func (m *OrderFulfillment_CrowdFundResult_Contribution) String() string {
+	return proto.CompactTextString(m)
+}
+func (*OrderFulfillment_CrowdFundResult_Contribution) ProtoMessage() {}
+func (*OrderFulfillment_CrowdFundResult_Contribution) Descriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{7, 3, 0}
+}
+
+func (m *OrderFulfillment_CrowdFundResult_Contribution) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *OrderFulfillment_CrowdFundResult_Contribution) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *OrderFulfillment_CrowdFundResult_Contribution) GetValue() uint64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
 type OrderCompletion struct {
 	OrderId    string                     `protobuf:"bytes,1,opt,name=orderId" json:"orderId,omitempty"`
 	Timestamp  *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
@@ -1856,6 +2141,70 @@ func (m *Rating_RatingData) GetReview() string {
 	return ""
 }
 
+type RatingResponse struct {
+	RatingHash string                     `protobuf:"bytes,1,opt,name=ratingHash" json:"ratingHash,omitempty"`
+	VendorID   *ID                        `protobuf:"bytes,2,opt,name=vendorID" json:"vendorID,omitempty"`
+	Response   string                     `protobuf:"bytes,3,opt,name=response" json:"response,omitempty"`
+	Timestamp  *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *RatingResponse) Reset()                    { *m = RatingResponse{} }
+func (m *RatingResponse) String() string            { return proto.CompactTextString(m) }
+func (*RatingResponse) ProtoMessage()               {}
+func (*RatingResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+
+func (m *RatingResponse) GetRatingHash() string {
+	if m != nil {
+		return m.RatingHash
+	}
+	return ""
+}
+
+func (m *RatingResponse) GetVendorID() *ID {
+	if m != nil {
+		return m.VendorID
+	}
+	return nil
+}
+
+func (m *RatingResponse) GetResponse() string {
+	if m != nil {
+		return m.Response
+	}
+	return ""
+}
+
+func (m *RatingResponse) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+type SignedRatingResponse struct {
+	RatingResponse *RatingResponse `protobuf:"bytes,1,opt,name=ratingResponse" json:"ratingResponse,omitempty"`
+	Signature      []byte          `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignedRatingResponse) Reset()                    { *m = SignedRatingResponse{} }
+func (m *SignedRatingResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignedRatingResponse) ProtoMessage()               {}
+func (*SignedRatingResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
+
+func (m *SignedRatingResponse) GetRatingResponse() *RatingResponse {
+	if m != nil {
+		return m.RatingResponse
+	}
+	return nil
+}
+
+func (m *SignedRatingResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type Dispute struct {
 	Timestamp          *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
 	Claim              string                     `protobuf:"bytes,2,opt,name=claim" json:"claim,omitempty"`
@@ -1867,7 +2216,7 @@ type Dispute struct {
 func (m *Dispute) Reset()                    { *m = Dispute{} }
 func (m *Dispute) String() string            { return proto.CompactTextString(m) }
 func (*Dispute) ProtoMessage()               {}
-func (*Dispute) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+func (*Dispute) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{12} }
 
 func (m *Dispute) GetTimestamp() *google_protobuf.Timestamp {
 	if m != nil {
@@ -1916,7 +2265,7 @@ type DisputeResolution struct {
 func (m *DisputeResolution) Reset()                    { *m = DisputeResolution{} }
 func (m *DisputeResolution) String() string            { return proto.CompactTextString(m) }
 func (*DisputeResolution) ProtoMessage()               {}
-func (*DisputeResolution) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
+func (*DisputeResolution) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{13} }
 
 func (m *DisputeResolution) GetTimestamp() *google_protobuf.Timestamp {
 	if m != nil {
@@ -1971,7 +2320,7 @@ type DisputeResolution_Payout struct {
 func (m *DisputeResolution_Payout) Reset()                    { *m = DisputeResolution_Payout{} }
 func (m *DisputeResolution_Payout) String() string            { return proto.CompactTextString(m) }
 func (*DisputeResolution_Payout) ProtoMessage()               {}
-func (*DisputeResolution_Payout) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11, 0} }
+func (*DisputeResolution_Payout) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{13, 0} }
 
 func (m *DisputeResolution_Payout) GetSigs() []*BitcoinSignature {
 	if m != nil {
@@ -2017,7 +2366,7 @@ func (m *DisputeResolution_Payout_Output) Reset()         { *m = DisputeResoluti
 func (m *DisputeResolution_Payout_Output) String() string { return proto.CompactTextString(m) }
 func (*DisputeResolution_Payout_Output) ProtoMessage()    {}
 func (*DisputeResolution_Payout_Output) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{11, 0, 0}
+	return fileDescriptor1, []int{13, 0, 0}
 }
 
 func (m *DisputeResolution_Payout_Output) GetScript() string {
@@ -2034,6 +2383,142 @@ func (m *DisputeResolution_Payout_Output) GetAmount() uint64 {
 	return 0
 }
 
+type DisputeEndorsement struct {
+	OrderId   string              `protobuf:"bytes,1,opt,name=orderId" json:"orderId,omitempty"`
+	Moderator string              `protobuf:"bytes,2,opt,name=moderator" json:"moderator,omitempty"`
+	Sigs      []*BitcoinSignature `protobuf:"bytes,3,rep,name=sigs" json:"sigs,omitempty"`
+}
+
+func (m *DisputeEndorsement) Reset()                    { *m = DisputeEndorsement{} }
+func (m *DisputeEndorsement) String() string            { return proto.CompactTextString(m) }
+func (*DisputeEndorsement) ProtoMessage()               {}
+func (*DisputeEndorsement) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{14} }
+
+func (m *DisputeEndorsement) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *DisputeEndorsement) GetModerator() string {
+	if m != nil {
+		return m.Moderator
+	}
+	return ""
+}
+
+func (m *DisputeEndorsement) GetSigs() []*BitcoinSignature {
+	if m != nil {
+		return m.Sigs
+	}
+	return nil
+}
+
+type DisputeProposal struct {
+	OrderId          string                     `protobuf:"bytes,1,opt,name=orderId" json:"orderId,omitempty"`
+	ProposalId       string                     `protobuf:"bytes,2,opt,name=proposalId" json:"proposalId,omitempty"`
+	ProposedBy       string                     `protobuf:"bytes,3,opt,name=proposedBy" json:"proposedBy,omitempty"`
+	BuyerPercentage  float32                    `protobuf:"fixed32,4,opt,name=buyerPercentage" json:"buyerPercentage,omitempty"`
+	VendorPercentage float32                    `protobuf:"fixed32,5,opt,name=vendorPercentage" json:"vendorPercentage,omitempty"`
+	Resolution       string                     `protobuf:"bytes,6,opt,name=resolution" json:"resolution,omitempty"`
+	Counters         string                     `protobuf:"bytes,7,opt,name=counters" json:"counters,omitempty"`
+	Timestamp        *google_protobuf.Timestamp `protobuf:"bytes,8,opt,name=timestamp" json:"timestamp,omitempty"`
+	AcceptedBy       []string                   `protobuf:"bytes,9,rep,name=acceptedBy" json:"acceptedBy,omitempty"`
+}
+
+func (m *DisputeProposal) Reset()                    { *m = DisputeProposal{} }
+func (m *DisputeProposal) String() string            { return proto.CompactTextString(m) }
+func (*DisputeProposal) ProtoMessage()               {}
+func (*DisputeProposal) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{15} }
+
+func (m *DisputeProposal) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *DisputeProposal) GetProposalId() string {
+	if m != nil {
+		return m.ProposalId
+	}
+	return ""
+}
+
+func (m *DisputeProposal) GetProposedBy() string {
+	if m != nil {
+		return m.ProposedBy
+	}
+	return ""
+}
+
+func (m *DisputeProposal) GetBuyerPercentage() float32 {
+	if m != nil {
+		return m.BuyerPercentage
+	}
+	return 0
+}
+
+func (m *DisputeProposal) GetVendorPercentage() float32 {
+	if m != nil {
+		return m.VendorPercentage
+	}
+	return 0
+}
+
+func (m *DisputeProposal) GetResolution() string {
+	if m != nil {
+		return m.Resolution
+	}
+	return ""
+}
+
+func (m *DisputeProposal) GetCounters() string {
+	if m != nil {
+		return m.Counters
+	}
+	return ""
+}
+
+func (m *DisputeProposal) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *DisputeProposal) GetAcceptedBy() []string {
+	if m != nil {
+		return m.AcceptedBy
+	}
+	return nil
+}
+
+type DisputeProposalAcceptance struct {
+	OrderId    string `protobuf:"bytes,1,opt,name=orderId" json:"orderId,omitempty"`
+	ProposalId string `protobuf:"bytes,2,opt,name=proposalId" json:"proposalId,omitempty"`
+}
+
+func (m *DisputeProposalAcceptance) Reset()                    { *m = DisputeProposalAcceptance{} }
+func (m *DisputeProposalAcceptance) String() string            { return proto.CompactTextString(m) }
+func (*DisputeProposalAcceptance) ProtoMessage()               {}
+func (*DisputeProposalAcceptance) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{16} }
+
+func (m *DisputeProposalAcceptance) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *DisputeProposalAcceptance) GetProposalId() string {
+	if m != nil {
+		return m.ProposalId
+	}
+	return ""
+}
+
 type Outpoint struct {
 	Hash  string `protobuf:"bytes,1,opt,name=hash" json:"hash,omitempty"`
 	Index uint32 `protobuf:"varint,2,opt,name=index" json:"index,omitempty"`
@@ -2043,7 +2528,7 @@ type Outpoint struct {
 func (m *Outpoint) Reset()                    { *m = Outpoint{} }
 func (m *Outpoint) String() string            { return proto.CompactTextString(m) }
 func (*Outpoint) ProtoMessage()               {}
-func (*Outpoint) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{12} }
+func (*Outpoint) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{17} }
 
 func (m *Outpoint) GetHash() string {
 	if m != nil {
@@ -2067,16 +2552,19 @@ func (m *Outpoint) GetValue() uint64 {
 }
 
 type Refund struct {
-	OrderID   string                     `protobuf:"bytes,1,opt,name=orderID" json:"orderID,omitempty"`
-	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
-	Sigs      []*BitcoinSignature        `protobuf:"bytes,3,rep,name=sigs" json:"sigs,omitempty"`
-	Memo      string                     `protobuf:"bytes,4,opt,name=memo" json:"memo,omitempty"`
+	OrderID       string                     `protobuf:"bytes,1,opt,name=orderID" json:"orderID,omitempty"`
+	Timestamp     *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
+	Sigs          []*BitcoinSignature        `protobuf:"bytes,3,rep,name=sigs" json:"sigs,omitempty"`
+	Memo          string                     `protobuf:"bytes,4,opt,name=memo" json:"memo,omitempty"`
+	Amount        uint64                     `protobuf:"varint,5,opt,name=amount" json:"amount,omitempty"`
+	Items         []uint32                   `protobuf:"varint,6,rep,packed,name=items" json:"items,omitempty"`
+	VendorAddress string                     `protobuf:"bytes,7,opt,name=vendorAddress" json:"vendorAddress,omitempty"`
 }
 
 func (m *Refund) Reset()                    { *m = Refund{} }
 func (m *Refund) String() string            { return proto.CompactTextString(m) }
 func (*Refund) ProtoMessage()               {}
-func (*Refund) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{13} }
+func (*Refund) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{18} }
 
 func (m *Refund) GetOrderID() string {
 	if m != nil {
@@ -2106,6 +2594,27 @@ func (m *Refund) GetMemo() string {
 	return ""
 }
 
+func (m *Refund) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Refund) GetItems() []uint32 {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *Refund) GetVendorAddress() string {
+	if m != nil {
+		return m.VendorAddress
+	}
+	return ""
+}
+
 type ID struct {
 	PeerID       string      `protobuf:"bytes,1,opt,name=peerID" json:"peerID,omitempty"`
 	BlockchainID string      `protobuf:"bytes,2,opt,name=blockchainID" json:"blockchainID,omitempty"`
@@ -2116,7 +2625,7 @@ type ID struct {
 func (m *ID) Reset()                    { *m = ID{} }
 func (m *ID) String() string            { return proto.CompactTextString(m) }
 func (*ID) ProtoMessage()               {}
-func (*ID) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{14} }
+func (*ID) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{19} }
 
 func (m *ID) GetPeerID() string {
 	if m != nil {
@@ -2154,7 +2663,7 @@ type ID_Pubkeys struct {
 func (m *ID_Pubkeys) Reset()                    { *m = ID_Pubkeys{} }
 func (m *ID_Pubkeys) String() string            { return proto.CompactTextString(m) }
 func (*ID_Pubkeys) ProtoMessage()               {}
-func (*ID_Pubkeys) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{14, 0} }
+func (*ID_Pubkeys) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{19, 0} }
 
 func (m *ID_Pubkeys) GetIdentity() []byte {
 	if m != nil {
@@ -2178,7 +2687,7 @@ type Signature struct {
 func (m *Signature) Reset()                    { *m = Signature{} }
 func (m *Signature) String() string            { return proto.CompactTextString(m) }
 func (*Signature) ProtoMessage()               {}
-func (*Signature) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{15} }
+func (*Signature) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{20} }
 
 func (m *Signature) GetSection() Signature_Section {
 	if m != nil {
@@ -2203,7 +2712,7 @@ type SignedListing struct {
 func (m *SignedListing) Reset()                    { *m = SignedListing{} }
 func (m *SignedListing) String() string            { return proto.CompactTextString(m) }
 func (*SignedListing) ProtoMessage()               {}
-func (*SignedListing) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{16} }
+func (*SignedListing) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{21} }
 
 func (m *SignedListing) GetListing() *Listing {
 	if m != nil {
@@ -2226,6 +2735,252 @@ func (m *SignedListing) GetSignature() []byte {
 	return nil
 }
 
+// Discounts a vendor offers across their store rather than on a single listing
+type Promotion struct {
+	Id         string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Title      string   `protobuf:"bytes,2,opt,name=title" json:"title,omitempty"`
+	Categories []string `protobuf:"bytes,3,rep,name=categories" json:"categories,omitempty"`
+	Slugs      []string `protobuf:"bytes,4,rep,name=slugs" json:"slugs,omitempty"`
+	// Types that are valid to be assigned to Discount:
+	//	*Promotion_PercentDiscount
+	//	*Promotion_BuyXGetY_
+	Discount isPromotion_Discount       `protobuf_oneof:"discount"`
+	Start    *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=start" json:"start,omitempty"`
+	End      *google_protobuf.Timestamp `protobuf:"bytes,8,opt,name=end" json:"end,omitempty"`
+}
+
+func (m *Promotion) Reset()                    { *m = Promotion{} }
+func (m *Promotion) String() string            { return proto.CompactTextString(m) }
+func (*Promotion) ProtoMessage()               {}
+func (*Promotion) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{22} }
+
+type isPromotion_Discount interface {
+	isPromotion_Discount()
+}
+
+type Promotion_PercentDiscount struct {
+	PercentDiscount float32 `protobuf:"fixed32,5,opt,name=percentDiscount,oneof"`
+}
+type Promotion_BuyXGetY_ struct {
+	BuyXGetY *Promotion_BuyXGetY `protobuf:"bytes,6,opt,name=buyXGetY,oneof"`
+}
+
+func (*Promotion_PercentDiscount) isPromotion_Discount() {}
+func (*Promotion_BuyXGetY_) isPromotion_Discount()       {}
+
+func (m *Promotion) GetDiscount() isPromotion_Discount {
+	if m != nil {
+		return m.Discount
+	}
+	return nil
+}
+
+func (m *Promotion) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Promotion) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Promotion) GetCategories() []string {
+	if m != nil {
+		return m.Categories
+	}
+	return nil
+}
+
+func (m *Promotion) GetSlugs() []string {
+	if m != nil {
+		return m.Slugs
+	}
+	return nil
+}
+
+func (m *Promotion) GetPercentDiscount() float32 {
+	if x, ok := m.GetDiscount().(*Promotion_PercentDiscount); ok {
+		return x.PercentDiscount
+	}
+	return 0
+}
+
+func (m *Promotion) GetBuyXGetY() *Promotion_BuyXGetY {
+	if x, ok := m.GetDiscount().(*Promotion_BuyXGetY_); ok {
+		return x.BuyXGetY
+	}
+	return nil
+}
+
+func (m *Promotion) GetStart() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *Promotion) GetEnd() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Promotion) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Promotion_OneofMarshaler, _Promotion_OneofUnmarshaler, _Promotion_OneofSizer, []interface{}{
+		(*Promotion_PercentDiscount)(nil),
+		(*Promotion_BuyXGetY_)(nil),
+	}
+}
+
+func _Promotion_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Promotion)
+	// discount
+	switch x := m.Discount.(type) {
+	case *Promotion_PercentDiscount:
+		b.EncodeVarint(5<<3 | proto.WireFixed32)
+		b.EncodeFixed32(uint64(math.Float32bits(x.PercentDiscount)))
+	case *Promotion_BuyXGetY_:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BuyXGetY); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Promotion.Discount has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Promotion_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Promotion)
+	switch tag {
+	case 5: // discount.percentDiscount
+		if wire != proto.WireFixed32 {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeFixed32()
+		m.Discount = &Promotion_PercentDiscount{math.Float32frombits(uint32(x))}
+		return true, err
+	case 6: // discount.buyXGetY
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Promotion_BuyXGetY)
+		err := b.DecodeMessage(msg)
+		m.Discount = &Promotion_BuyXGetY_{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Promotion_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Promotion)
+	// discount
+	switch x := m.Discount.(type) {
+	case *Promotion_PercentDiscount:
+		n += proto.SizeVarint(5<<3 | proto.WireFixed32)
+		n += 4
+	case *Promotion_BuyXGetY_:
+		n += proto.SizeVarint(6<<3 | proto.WireBytes)
+		s := proto.Size(x.BuyXGetY)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// For every buy + free of an item ordered, free of them are not charged
+type Promotion_BuyXGetY struct {
+	Buy  uint32 `protobuf:"varint,1,opt,name=buy" json:"buy,omitempty"`
+	Free uint32 `protobuf:"varint,2,opt,name=free" json:"free,omitempty"`
+}
+
+func (m *Promotion_BuyXGetY) Reset()                    { *m = Promotion_BuyXGetY{} }
+func (m *Promotion_BuyXGetY) String() string            { return proto.CompactTextString(m) }
+func (*Promotion_BuyXGetY) ProtoMessage()               {}
+func (*Promotion_BuyXGetY) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{22, 0} }
+
+func (m *Promotion_BuyXGetY) GetBuy() uint32 {
+	if m != nil {
+		return m.Buy
+	}
+	return 0
+}
+
+func (m *Promotion_BuyXGetY) GetFree() uint32 {
+	if m != nil {
+		return m.Free
+	}
+	return 0
+}
+
+type Promotions struct {
+	VendorID   *ID                        `protobuf:"bytes,1,opt,name=vendorID" json:"vendorID,omitempty"`
+	Promotions []*Promotion               `protobuf:"bytes,2,rep,name=promotions" json:"promotions,omitempty"`
+	Timestamp  *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *Promotions) Reset()                    { *m = Promotions{} }
+func (m *Promotions) String() string            { return proto.CompactTextString(m) }
+func (*Promotions) ProtoMessage()               {}
+func (*Promotions) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{23} }
+
+func (m *Promotions) GetVendorID() *ID {
+	if m != nil {
+		return m.VendorID
+	}
+	return nil
+}
+
+func (m *Promotions) GetPromotions() []*Promotion {
+	if m != nil {
+		return m.Promotions
+	}
+	return nil
+}
+
+func (m *Promotions) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+type SignedPromotions struct {
+	Promotions *Promotions `protobuf:"bytes,1,opt,name=promotions" json:"promotions,omitempty"`
+	Signature  []byte      `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignedPromotions) Reset()                    { *m = SignedPromotions{} }
+func (m *SignedPromotions) String() string            { return proto.CompactTextString(m) }
+func (*SignedPromotions) ProtoMessage()               {}
+func (*SignedPromotions) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{24} }
+
+func (m *SignedPromotions) GetPromotions() *Promotions {
+	if m != nil {
+		return m.Promotions
+	}
+	return nil
+}
+
+func (m *SignedPromotions) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*RicardianContract)(nil), "RicardianContract")
 	proto.RegisterType((*Listing)(nil), "Listing")
@@ -2241,6 +2996,8 @@ func init() {
 	proto.RegisterType((*Listing_ShippingOption_ShippingRules_Rule)(nil), "Listing.ShippingOption.ShippingRules.Rule")
 	proto.RegisterType((*Listing_Tax)(nil), "Listing.Tax")
 	proto.RegisterType((*Listing_Coupon)(nil), "Listing.Coupon")
+	proto.RegisterType((*Listing_Auction)(nil), "Listing.Auction")
+	proto.RegisterType((*Listing_CrowdFund)(nil), "Listing.CrowdFund")
 	proto.RegisterType((*Order)(nil), "Order")
 	proto.RegisterType((*Order_Shipping)(nil), "Order.Shipping")
 	proto.RegisterType((*Order_Item)(nil), "Order.Item")
@@ -2256,19 +3013,30 @@ func init() {
 	proto.RegisterType((*OrderFulfillment_PhysicalDelivery)(nil), "OrderFulfillment.PhysicalDelivery")
 	proto.RegisterType((*OrderFulfillment_DigitalDelivery)(nil), "OrderFulfillment.DigitalDelivery")
 	proto.RegisterType((*OrderFulfillment_Payout)(nil), "OrderFulfillment.Payout")
+	proto.RegisterType((*OrderFulfillment_CrowdFundResult)(nil), "OrderFulfillment.CrowdFundResult")
+	proto.RegisterType((*OrderFulfillment_CrowdFundResult_Contribution)(nil), "OrderFulfillment.CrowdFundResult.Contribution")
 	proto.RegisterType((*OrderCompletion)(nil), "OrderCompletion")
 	proto.RegisterType((*Rating)(nil), "Rating")
 	proto.RegisterType((*Rating_RatingData)(nil), "Rating.RatingData")
+	proto.RegisterType((*RatingResponse)(nil), "RatingResponse")
+	proto.RegisterType((*SignedRatingResponse)(nil), "SignedRatingResponse")
 	proto.RegisterType((*Dispute)(nil), "Dispute")
 	proto.RegisterType((*DisputeResolution)(nil), "DisputeResolution")
 	proto.RegisterType((*DisputeResolution_Payout)(nil), "DisputeResolution.Payout")
 	proto.RegisterType((*DisputeResolution_Payout_Output)(nil), "DisputeResolution.Payout.Output")
+	proto.RegisterType((*DisputeEndorsement)(nil), "DisputeEndorsement")
+	proto.RegisterType((*DisputeProposal)(nil), "DisputeProposal")
+	proto.RegisterType((*DisputeProposalAcceptance)(nil), "DisputeProposalAcceptance")
 	proto.RegisterType((*Outpoint)(nil), "Outpoint")
 	proto.RegisterType((*Refund)(nil), "Refund")
 	proto.RegisterType((*ID)(nil), "ID")
 	proto.RegisterType((*ID_Pubkeys)(nil), "ID.Pubkeys")
 	proto.RegisterType((*Signature)(nil), "Signature")
 	proto.RegisterType((*SignedListing)(nil), "SignedListing")
+	proto.RegisterType((*Promotion)(nil), "Promotion")
+	proto.RegisterType((*Promotion_BuyXGetY)(nil), "Promotion.BuyXGetY")
+	proto.RegisterType((*Promotions)(nil), "Promotions")
+	proto.RegisterType((*SignedPromotions)(nil), "SignedPromotions")
 	proto.RegisterEnum("Listing_Metadata_ContractType", Listing_Metadata_ContractType_name, Listing_Metadata_ContractType_value)
 	proto.RegisterEnum("Listing_Metadata_Format", Listing_Metadata_Format_name, Listing_Metadata_Format_value)
 	proto.RegisterEnum("Listing_ShippingOption_ShippingType", Listing_ShippingOption_ShippingType_name, Listing_ShippingOption_ShippingType_value)
@@ -2280,200 +3048,248 @@ func init() {
 func init() { proto.RegisterFile("contracts.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 3108 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x5a, 0x3f, 0x70, 0x1b, 0xc7,
-	0xd5, 0xd7, 0xe1, 0x3f, 0x1e, 0x41, 0x12, 0x5c, 0x51, 0x32, 0x8c, 0xcf, 0x9f, 0x25, 0x61, 0x24,
-	0x7d, 0xb2, 0x2c, 0x9f, 0x65, 0x7e, 0x8d, 0xe6, 0xfb, 0x32, 0xb1, 0x41, 0x1c, 0x28, 0x9e, 0x45,
-	0x91, 0xf0, 0x02, 0xb4, 0xe3, 0x34, 0x9c, 0xe5, 0xdd, 0x12, 0xbc, 0xe8, 0x70, 0x07, 0xdf, 0x1f,
-	0x9a, 0x48, 0x97, 0x2e, 0x93, 0x26, 0x4d, 0x66, 0xdc, 0xa6, 0x4c, 0x9f, 0xce, 0xe9, 0x5c, 0xa5,
-	0xce, 0x4c, 0x66, 0xd2, 0x64, 0x92, 0x49, 0x9d, 0x26, 0x55, 0x8a, 0x14, 0xc9, 0xec, 0xbf, 0xfb,
-	0x07, 0x50, 0x96, 0x92, 0xc9, 0xa4, 0x22, 0xde, 0xef, 0xbd, 0xdd, 0xdb, 0x7d, 0xef, 0xed, 0xfb,
-	0xb3, 0x4b, 0xd8, 0xb4, 0x7c, 0x2f, 0x0a, 0x88, 0x15, 0x85, 0xfa, 0x3c, 0xf0, 0x23, 0xbf, 0x8b,
-	0x2c, 0x3f, 0xf6, 0xa2, 0x60, 0x61, 0xf9, 0x36, 0x55, 0xd8, 0xad, 0xa9, 0xef, 0x4f, 0x5d, 0xfa,
-	0x3e, 0xa7, 0x4e, 0xe3, 0xb3, 0xf7, 0x23, 0x67, 0x46, 0xc3, 0x88, 0xcc, 0xe6, 0x42, 0xa0, 0xf7,
-	0xf7, 0x32, 0x6c, 0x61, 0xc7, 0x22, 0x81, 0xed, 0x10, 0x6f, 0x20, 0x67, 0x44, 0x8f, 0x61, 0xe3,
-	0x82, 0x7a, 0xb6, 0x1f, 0x1c, 0x38, 0x61, 0xe4, 0x78, 0xd3, 0xb0, 0xa3, 0xdd, 0x2e, 0x3f, 0x58,
-	0xdb, 0x69, 0xe8, 0x12, 0xc0, 0x05, 0x3e, 0xba, 0x0f, 0x70, 0x1a, 0x2f, 0x68, 0x70, 0x14, 0xd8,
-	0x34, 0xe8, 0x94, 0x6e, 0x6b, 0x0f, 0xd6, 0x76, 0x6a, 0x3a, 0xa7, 0x70, 0x86, 0x83, 0x0e, 0xe0,
-	0x0d, 0x31, 0x92, 0x93, 0x03, 0xdf, 0x3b, 0x73, 0x82, 0x19, 0x89, 0x1c, 0xdf, 0xeb, 0x94, 0xf9,
-	0x20, 0xa4, 0x2f, 0x71, 0xf0, 0x55, 0x43, 0x90, 0x09, 0x37, 0x33, 0xac, 0xbd, 0xd8, 0x3d, 0x73,
-	0x5c, 0x77, 0x46, 0xbd, 0xa8, 0x53, 0xe1, 0xeb, 0xdd, 0xd2, 0x8b, 0x0c, 0x7c, 0xc5, 0x00, 0x64,
-	0xc0, 0x76, 0xba, 0xcc, 0x81, 0x3f, 0x9b, 0xbb, 0x94, 0xaf, 0xaa, 0xca, 0x57, 0xd5, 0xd6, 0x0b,
-	0x38, 0x5e, 0x29, 0x8d, 0x7a, 0x50, 0xb7, 0x9d, 0x70, 0x1e, 0x47, 0xb4, 0x53, 0xe3, 0x03, 0x1b,
-	0xba, 0x21, 0x68, 0xac, 0x18, 0xe8, 0x23, 0xd8, 0x92, 0x3f, 0x31, 0x0d, 0x7d, 0x37, 0xe6, 0x9f,
-	0xa9, 0xcb, 0xcd, 0x1b, 0x45, 0x0e, 0x5e, 0x16, 0x46, 0xb7, 0xa0, 0x16, 0xd0, 0xb3, 0xd8, 0xb3,
-	0x3b, 0x0d, 0x3e, 0xac, 0xae, 0x63, 0x4e, 0x62, 0x09, 0xa3, 0x87, 0x00, 0xa1, 0x33, 0xf5, 0x48,
-	0x14, 0x07, 0x34, 0xec, 0x34, 0xb9, 0x2e, 0x40, 0x1f, 0x2b, 0x08, 0x67, 0xb8, 0xbd, 0x6f, 0x6e,
-	0x42, 0x5d, 0x9a, 0x11, 0x21, 0xa8, 0x84, 0x6e, 0x3c, 0xed, 0x68, 0xb7, 0xb5, 0x07, 0x4d, 0xcc,
-	0x7f, 0xa3, 0x5b, 0xd0, 0x10, 0x2a, 0x33, 0x0d, 0x69, 0xd7, 0xb2, 0x6e, 0x1a, 0x38, 0x01, 0xd1,
-	0x7b, 0xd0, 0x98, 0xd1, 0x88, 0xd8, 0x24, 0x22, 0xd2, 0x86, 0x5b, 0xca, 0x4d, 0xf4, 0xe7, 0x92,
-	0x81, 0x13, 0x11, 0x74, 0x07, 0x2a, 0x4e, 0x44, 0x67, 0x9d, 0x0a, 0x17, 0x5d, 0x4f, 0x44, 0xcd,
-	0x88, 0xce, 0x30, 0x67, 0xa1, 0x3e, 0x6c, 0x86, 0xe7, 0xce, 0x7c, 0xee, 0x78, 0xd3, 0xa3, 0x39,
-	0xdb, 0x71, 0xd8, 0xa9, 0xf2, 0x3d, 0xbc, 0x91, 0x48, 0x8f, 0x73, 0x7c, 0x5c, 0x94, 0x47, 0x3d,
-	0xa8, 0x46, 0xe4, 0x92, 0x86, 0x9d, 0x1a, 0x1f, 0xd8, 0x4a, 0x06, 0x4e, 0xc8, 0x25, 0x16, 0x2c,
-	0xf4, 0x0e, 0xd4, 0x2d, 0x3f, 0x9e, 0xb3, 0xe9, 0xeb, 0x5c, 0x6a, 0x33, 0x91, 0x1a, 0x70, 0x1c,
-	0x2b, 0x3e, 0x7a, 0x1b, 0x60, 0xe6, 0xdb, 0x34, 0x20, 0x91, 0x1f, 0x84, 0x9d, 0xc6, 0xed, 0xf2,
-	0x83, 0x26, 0xce, 0x20, 0x48, 0x07, 0x14, 0xd1, 0x60, 0x16, 0xf6, 0x3d, 0x7b, 0xe0, 0x7b, 0xb6,
-	0x23, 0x16, 0xdd, 0xe4, 0x6a, 0x5c, 0xc1, 0x41, 0x3d, 0x68, 0x09, 0x53, 0x8d, 0x7c, 0xd7, 0xb1,
-	0x16, 0x1d, 0xe0, 0x92, 0x39, 0xac, 0xfb, 0x75, 0x19, 0x1a, 0x4a, 0x7f, 0xa8, 0x03, 0xf5, 0x0b,
-	0x1a, 0x84, 0xcc, 0x55, 0x98, 0x71, 0xd6, 0xb1, 0x22, 0xd1, 0x2e, 0xb4, 0x54, 0x24, 0x98, 0x2c,
-	0xe6, 0x94, 0xdb, 0x68, 0x63, 0xe7, 0xed, 0x25, 0x13, 0xe8, 0x83, 0x8c, 0x14, 0xce, 0x8d, 0x41,
-	0x8f, 0xa1, 0x76, 0xe6, 0xb3, 0x43, 0xc5, 0x0d, 0xb8, 0xb1, 0xd3, 0x59, 0x1e, 0xbd, 0xc7, 0xf9,
-	0x58, 0xca, 0xa1, 0x1d, 0xa8, 0xd1, 0xcb, 0xb9, 0x13, 0x2c, 0xa4, 0x1d, 0xbb, 0xba, 0x88, 0x34,
-	0xba, 0x8a, 0x34, 0xfa, 0x44, 0x45, 0x1a, 0x2c, 0x25, 0xd1, 0x43, 0x68, 0x13, 0xcb, 0xa2, 0xf3,
-	0x88, 0xda, 0x83, 0x38, 0x08, 0xa8, 0x67, 0x2d, 0xf8, 0xf1, 0x6a, 0xe2, 0x25, 0x1c, 0x3d, 0x80,
-	0xcd, 0x79, 0xe0, 0x58, 0x8e, 0x37, 0x4d, 0x44, 0x6b, 0x5c, 0xb4, 0x08, 0xa3, 0x2e, 0x34, 0x5c,
-	0xe2, 0x4d, 0x63, 0x32, 0xa5, 0xfc, 0x14, 0x35, 0x71, 0x42, 0xf7, 0x46, 0xd0, 0xca, 0xee, 0x1a,
-	0x6d, 0xc1, 0xfa, 0x68, 0xff, 0xf3, 0xb1, 0x39, 0xe8, 0x1f, 0x9c, 0x3c, 0x3d, 0x3a, 0x32, 0xda,
-	0xd7, 0x50, 0x1b, 0x5a, 0x86, 0xf9, 0xd4, 0x9c, 0x28, 0x44, 0x43, 0x6b, 0x50, 0x1f, 0x0f, 0xf1,
-	0xa7, 0xe6, 0x60, 0xd8, 0x2e, 0xa1, 0x0d, 0x80, 0x01, 0x3e, 0xfa, 0xcc, 0x38, 0xd9, 0x3b, 0x3e,
-	0x34, 0xda, 0xe5, 0xde, 0x7d, 0xa8, 0x09, 0x4d, 0xa0, 0x4d, 0x58, 0xdb, 0x33, 0xbf, 0x37, 0x34,
-	0x4e, 0x46, 0x98, 0x89, 0x5e, 0x63, 0xe3, 0xfa, 0xc7, 0x83, 0x89, 0x79, 0x74, 0xd8, 0xd6, 0xba,
-	0x7f, 0xa8, 0x41, 0x85, 0x79, 0x34, 0xda, 0x86, 0x6a, 0xe4, 0x44, 0x2e, 0x95, 0x67, 0x4a, 0x10,
-	0xe8, 0x36, 0xac, 0xd9, 0x34, 0xb4, 0x02, 0x87, 0xbb, 0x2b, 0xb7, 0x59, 0x13, 0x67, 0x21, 0x74,
-	0x1f, 0x36, 0xe6, 0x81, 0x6f, 0xd1, 0x30, 0x74, 0xbc, 0x29, 0xd3, 0x25, 0x37, 0x4d, 0x13, 0x17,
-	0x50, 0x36, 0x3f, 0xd3, 0x08, 0xe5, 0x76, 0xa8, 0x60, 0x41, 0xb0, 0x83, 0xec, 0x85, 0x67, 0x5f,
-	0x72, 0xf5, 0x36, 0x30, 0xff, 0xcd, 0xb0, 0x88, 0x4c, 0xc5, 0x89, 0x68, 0x62, 0xfe, 0x1b, 0xbd,
-	0x0b, 0x35, 0x67, 0x46, 0xa6, 0x54, 0x9d, 0x80, 0xeb, 0xb9, 0xe3, 0xa8, 0x9b, 0x8c, 0x87, 0xa5,
-	0x08, 0x3b, 0x04, 0x16, 0x89, 0xe8, 0xd4, 0x0f, 0x1c, 0x9a, 0x1c, 0x82, 0x14, 0x61, 0x4b, 0x99,
-	0x06, 0x64, 0x26, 0xfc, 0xbe, 0x84, 0x05, 0x81, 0xde, 0x82, 0xa6, 0xa5, 0x1c, 0x5f, 0xfa, 0x79,
-	0x0a, 0x20, 0x1d, 0xea, 0xbe, 0x3c, 0xe2, 0x6b, 0x7c, 0x05, 0xdb, 0xf9, 0x15, 0xc8, 0xf3, 0xad,
-	0x84, 0xd0, 0x3d, 0xa8, 0x84, 0x2f, 0xe2, 0xb0, 0xd3, 0x92, 0xf1, 0x3d, 0x27, 0x3c, 0x7e, 0x11,
-	0x63, 0xce, 0xee, 0x7e, 0xa3, 0x41, 0x4d, 0x0c, 0xe5, 0xaa, 0x20, 0x33, 0xa5, 0x7f, 0xfe, 0xfb,
-	0x15, 0xd4, 0xff, 0x04, 0x1a, 0x17, 0x24, 0x70, 0x88, 0x17, 0x85, 0x9d, 0x32, 0xff, 0xd6, 0x5b,
-	0xab, 0x16, 0xa6, 0x7f, 0x2a, 0x84, 0x70, 0x22, 0xdd, 0xdd, 0x87, 0xba, 0x04, 0x57, 0x7e, 0xfa,
-	0x1d, 0xa8, 0x72, 0x75, 0xca, 0x58, 0xba, 0x52, 0xe1, 0x42, 0xa2, 0xfb, 0x23, 0x0d, 0xca, 0xe3,
-	0x17, 0x31, 0x0b, 0x16, 0x72, 0xf6, 0x81, 0x3f, 0x3b, 0xf5, 0x79, 0x2e, 0x5e, 0xc7, 0x39, 0x8c,
-	0x69, 0x79, 0x1e, 0xf8, 0x76, 0x6c, 0x45, 0x32, 0x4c, 0x37, 0x71, 0x0a, 0x30, 0x6e, 0x18, 0x07,
-	0xd6, 0x39, 0x09, 0xa6, 0xc2, 0x8f, 0xca, 0x38, 0x05, 0xd8, 0x09, 0xfa, 0x22, 0x26, 0x5e, 0xe4,
-	0x44, 0xe2, 0x34, 0x97, 0x71, 0x42, 0x77, 0xbf, 0xd2, 0xa0, 0xca, 0x17, 0xc5, 0xa4, 0xce, 0x1c,
-	0x97, 0x66, 0x36, 0x94, 0xd0, 0x8c, 0xe7, 0x07, 0xce, 0xd4, 0xf1, 0x88, 0x2b, 0x3f, 0x9e, 0xd0,
-	0xcc, 0x2b, 0xdc, 0xe4, 0xbb, 0x4d, 0x2c, 0x08, 0x74, 0x13, 0x6a, 0x33, 0x6a, 0x3b, 0xb1, 0xc8,
-	0x03, 0x4d, 0x2c, 0x29, 0x26, 0x1d, 0xce, 0x88, 0xeb, 0xca, 0xc0, 0x20, 0x08, 0xee, 0xba, 0x8e,
-	0xa7, 0x42, 0x00, 0xff, 0xdd, 0xfd, 0x65, 0x0d, 0x36, 0xf2, 0x59, 0x60, 0xa5, 0xbe, 0x9f, 0x40,
-	0x25, 0x4a, 0xc3, 0xe2, 0xdd, 0x2b, 0x12, 0x48, 0x42, 0xf2, 0xe0, 0xc8, 0x47, 0xa0, 0xfb, 0x50,
-	0x0f, 0xe8, 0x94, 0xbb, 0x26, 0xf3, 0x80, 0x8d, 0x9d, 0x16, 0x4b, 0x0b, 0xac, 0xc2, 0x1a, 0xf8,
-	0x36, 0xc5, 0x8a, 0x89, 0x9e, 0xc1, 0xba, 0xca, 0x3e, 0x38, 0x76, 0x69, 0x28, 0x23, 0xe2, 0xbd,
-	0x6f, 0xfb, 0x14, 0x17, 0xc6, 0xf9, 0xb1, 0xe8, 0xff, 0xa1, 0x11, 0xd2, 0xe0, 0xc2, 0xb1, 0xa8,
-	0xca, 0x79, 0xb7, 0xae, 0x9c, 0x47, 0xc8, 0xe1, 0x64, 0x40, 0x97, 0x40, 0x5d, 0x82, 0x2b, 0x55,
-	0x91, 0x84, 0x8a, 0x52, 0x36, 0x54, 0x3c, 0x82, 0x2d, 0x1a, 0x46, 0xce, 0x8c, 0x44, 0xd4, 0x36,
-	0xa8, 0xeb, 0x5c, 0xd0, 0x60, 0x21, 0x6d, 0xb5, 0xcc, 0xe8, 0xfe, 0xa4, 0x0c, 0xeb, 0xb9, 0x0d,
-	0xa0, 0x8f, 0xa1, 0x11, 0xc4, 0x2e, 0xe5, 0xb9, 0x47, 0xe3, 0x4a, 0xd6, 0x5f, 0x69, 0xe7, 0x3a,
-	0x96, 0xa3, 0x70, 0x32, 0x1e, 0x7d, 0x04, 0xd5, 0x80, 0xab, 0xb0, 0xc4, 0xb7, 0xfe, 0xf0, 0xd5,
-	0x27, 0xc2, 0x62, 0x60, 0x77, 0x02, 0x15, 0x46, 0x32, 0x8f, 0x9c, 0x39, 0x1e, 0x26, 0xde, 0x94,
-	0xca, 0x84, 0x99, 0xd0, 0x9c, 0x47, 0x2e, 0x05, 0xaf, 0x24, 0x79, 0x92, 0x4e, 0x75, 0x54, 0xce,
-	0xe8, 0xa8, 0xf7, 0x33, 0x0d, 0x1a, 0x6a, 0xb9, 0xe8, 0x06, 0x6c, 0x7d, 0x72, 0xdc, 0x3f, 0x9c,
-	0x98, 0x93, 0xcf, 0x4f, 0x0c, 0x73, 0x3c, 0x38, 0x3a, 0x3e, 0x9c, 0xb4, 0xaf, 0xa1, 0xff, 0x82,
-	0x37, 0xf6, 0x0e, 0xfa, 0x93, 0x93, 0xbd, 0xe1, 0xf0, 0x24, 0xe1, 0xe3, 0xfe, 0xe1, 0xd3, 0x61,
-	0x5b, 0x43, 0x6f, 0xc2, 0x8d, 0x84, 0xf9, 0xd9, 0xd0, 0x7c, 0xba, 0x3f, 0x91, 0xac, 0x12, 0x63,
-	0x0d, 0x8e, 0x9e, 0xef, 0x9a, 0x87, 0x43, 0xe3, 0x64, 0xbc, 0x6f, 0x8e, 0x46, 0xe6, 0xe1, 0xd3,
-	0x93, 0xbe, 0x61, 0xb4, 0xcb, 0xe8, 0x6d, 0xe8, 0x2e, 0xb3, 0xc6, 0xc7, 0xbb, 0x13, 0xdc, 0x1f,
-	0x4c, 0xda, 0x95, 0xde, 0x07, 0xd0, 0xca, 0xfa, 0x2d, 0xcb, 0x65, 0x07, 0x47, 0x2c, 0xb7, 0x8d,
-	0xcc, 0xc1, 0xb3, 0xe3, 0x51, 0xfb, 0x5a, 0x31, 0x49, 0x69, 0xdd, 0x9f, 0x6a, 0x50, 0x9e, 0x90,
-	0x4b, 0x56, 0x4f, 0x44, 0xe4, 0x32, 0x31, 0x5a, 0x13, 0x2b, 0x12, 0x3d, 0x02, 0x88, 0xc8, 0x25,
-	0x96, 0x9e, 0x5f, 0x5a, 0xe1, 0xf9, 0x19, 0x3e, 0x8b, 0xa4, 0x11, 0xb9, 0x54, 0xab, 0xe0, 0x5a,
-	0x6b, 0xe0, 0x2c, 0xc4, 0xb2, 0xc6, 0x9c, 0x06, 0x16, 0xf5, 0x22, 0x16, 0xf5, 0x2a, 0x3c, 0x35,
-	0x64, 0x10, 0x1e, 0xaa, 0x45, 0xb9, 0x75, 0x45, 0xae, 0xdc, 0x86, 0xca, 0x39, 0x09, 0xcf, 0x45,
-	0x60, 0xd9, 0xbf, 0x86, 0x39, 0x85, 0xee, 0x42, 0xcb, 0x76, 0x42, 0xde, 0xf2, 0xb0, 0x45, 0x09,
-	0x8f, 0xdd, 0xbf, 0x86, 0x73, 0x28, 0x7a, 0x08, 0x9b, 0xf2, 0x53, 0x86, 0x84, 0x79, 0x60, 0x29,
-	0xed, 0x6b, 0xb8, 0xc8, 0x40, 0xf7, 0x61, 0x9d, 0x5b, 0x3b, 0x91, 0x64, 0xd1, 0xa6, 0xb2, 0xaf,
-	0xe1, 0x3c, 0xbc, 0x5b, 0x83, 0x0a, 0x6b, 0xb1, 0x76, 0x01, 0x1a, 0xea, 0x5b, 0xbd, 0xdf, 0x36,
-	0xa1, 0x2a, 0x1a, 0x9c, 0xbb, 0xb0, 0x2e, 0xaa, 0xb8, 0xbe, 0x6d, 0x07, 0x34, 0x0c, 0xe5, 0x5e,
-	0xf2, 0x20, 0x0b, 0xc8, 0x02, 0xd8, 0xa3, 0xea, 0x38, 0xa6, 0x00, 0x7a, 0x17, 0x1a, 0x61, 0x56,
-	0xa3, 0xac, 0x32, 0xe5, 0xb3, 0xa7, 0x8e, 0x9f, 0x08, 0xa0, 0xff, 0x86, 0x3a, 0x6f, 0x45, 0x4c,
-	0x43, 0x06, 0x1e, 0x5e, 0x9e, 0x2b, 0x0c, 0x3d, 0x81, 0x66, 0xd2, 0xf3, 0xc9, 0x66, 0xe6, 0x65,
-	0xb5, 0x5a, 0x2a, 0x8c, 0xee, 0x40, 0x95, 0x55, 0xe3, 0xaa, 0x84, 0x5e, 0x93, 0x4b, 0xe0, 0x75,
-	0xba, 0xe0, 0xa0, 0x07, 0x50, 0x9f, 0x93, 0x05, 0x6f, 0xb8, 0x44, 0x03, 0xb3, 0x21, 0x85, 0x46,
-	0x02, 0xc5, 0x8a, 0xcd, 0xbc, 0x20, 0x20, 0xec, 0x28, 0x3f, 0xa3, 0x0b, 0x51, 0x3b, 0xb4, 0x70,
-	0x06, 0x41, 0x3b, 0xb0, 0x4d, 0xdc, 0x88, 0x06, 0x1e, 0x89, 0x28, 0x2b, 0xd9, 0x88, 0x15, 0x99,
-	0xde, 0x99, 0x2f, 0x4b, 0xe8, 0x95, 0xbc, 0xee, 0x6f, 0x34, 0x68, 0x24, 0x6e, 0x76, 0x13, 0x6a,
-	0x4c, 0x25, 0x13, 0x5f, 0x2a, 0x5c, 0x52, 0xcc, 0xd1, 0x89, 0xb4, 0x84, 0xc8, 0x4c, 0x8a, 0x64,
-	0x21, 0xd2, 0x62, 0x29, 0x4f, 0xc4, 0x3a, 0xfe, 0x9b, 0xa7, 0x9f, 0x88, 0x44, 0x54, 0x66, 0x25,
-	0x41, 0x70, 0x17, 0xf6, 0xc3, 0x88, 0xb8, 0xdc, 0xd3, 0x44, 0x66, 0xca, 0x20, 0x2c, 0x53, 0xc8,
-	0xde, 0x9b, 0xfb, 0xcc, 0x52, 0xa6, 0x90, 0x4c, 0x96, 0xc8, 0xe5, 0xc7, 0x0f, 0xfd, 0x88, 0xd7,
-	0x5c, 0xbc, 0xea, 0xcf, 0x62, 0xdd, 0x3f, 0x96, 0x64, 0xe1, 0x78, 0x1b, 0xd6, 0x5c, 0x11, 0xfd,
-	0xf6, 0x99, 0xf7, 0x8b, 0x5d, 0x65, 0xa1, 0x5c, 0xde, 0x96, 0x71, 0x4c, 0xd1, 0xe8, 0x51, 0x5a,
-	0x57, 0x89, 0xf2, 0x05, 0x65, 0xcc, 0xb7, 0x54, 0x55, 0xed, 0xc2, 0x46, 0xbe, 0x81, 0x4a, 0xaa,
-	0xfa, 0xcc, 0xa0, 0x42, 0xcb, 0x55, 0x18, 0xc1, 0xd4, 0x39, 0xa3, 0x33, 0x5f, 0xaa, 0x87, 0xff,
-	0x66, 0x7b, 0x10, 0x1d, 0x14, 0xd3, 0x83, 0xaa, 0x3c, 0xb3, 0x50, 0x77, 0xe7, 0xa5, 0x75, 0xda,
-	0x36, 0x54, 0x2f, 0x88, 0x1b, 0x53, 0x69, 0x3a, 0x41, 0x74, 0xbf, 0xfb, 0x4a, 0x89, 0xbf, 0x03,
-	0x75, 0x99, 0x18, 0x95, 0xe1, 0x25, 0xd9, 0xfd, 0x45, 0x09, 0xea, 0xd2, 0x41, 0xd1, 0x7b, 0xac,
-	0x0e, 0x89, 0xce, 0x7d, 0x5b, 0xe6, 0xae, 0x1b, 0x79, 0x07, 0x66, 0xfd, 0xcf, 0xb9, 0x6f, 0x63,
-	0x29, 0xc4, 0xce, 0x6d, 0xd2, 0xf5, 0xa9, 0x32, 0x2b, 0x01, 0x98, 0x0f, 0x92, 0x19, 0x0f, 0x1d,
-	0x22, 0x7b, 0x48, 0x8a, 0xd9, 0x9d, 0x5e, 0x5a, 0xe7, 0x2c, 0xc1, 0x60, 0xe5, 0x5c, 0x15, 0x9c,
-	0xc3, 0x78, 0x99, 0x7c, 0x4e, 0x1c, 0x8f, 0x85, 0x16, 0x59, 0xe7, 0xa4, 0x40, 0xd6, 0x8b, 0xeb,
-	0x79, 0x2f, 0xe6, 0x9d, 0xa4, 0x4d, 0xe9, 0x6c, 0xcc, 0x6b, 0x57, 0x7e, 0x23, 0xc0, 0x3b, 0xc9,
-	0x14, 0xeb, 0x3d, 0x81, 0x9a, 0xd8, 0x07, 0xba, 0x0e, 0x9b, 0x7d, 0xc3, 0xc0, 0xc3, 0xf1, 0xf8,
-	0x04, 0x0f, 0x3f, 0x39, 0x1e, 0x8e, 0x59, 0xe6, 0x02, 0xa8, 0x19, 0x26, 0x1e, 0x0e, 0x26, 0x6d,
-	0x0d, 0xad, 0x43, 0xf3, 0xf9, 0x91, 0x31, 0xc4, 0xfd, 0xc9, 0xd0, 0x68, 0x97, 0x7a, 0x7f, 0xd5,
-	0x60, 0x6b, 0xf9, 0xda, 0xa5, 0x03, 0x75, 0x9f, 0x81, 0xa6, 0xa1, 0x92, 0x87, 0x24, 0xf3, 0xd1,
-	0xa6, 0xf4, 0x3a, 0xd1, 0x86, 0xf5, 0x3b, 0x42, 0xe7, 0x2a, 0x70, 0xaa, 0x7e, 0x27, 0x87, 0xb2,
-	0xc6, 0x30, 0xa0, 0x5f, 0xc4, 0x34, 0x8c, 0xa8, 0xdd, 0x17, 0xca, 0x16, 0xea, 0x2c, 0xc2, 0xe8,
-	0x3b, 0xd0, 0x16, 0x01, 0x66, 0x9c, 0x5e, 0x85, 0x88, 0x92, 0xaa, 0xad, 0xe3, 0x3c, 0x03, 0x2f,
-	0x49, 0xf6, 0x7e, 0xac, 0xc1, 0x9a, 0xb8, 0xbe, 0xa2, 0x3f, 0xa0, 0x56, 0xf4, 0x6f, 0xd9, 0x33,
-	0x6b, 0x66, 0x9c, 0xa9, 0x3a, 0xa1, 0x5b, 0xfa, 0xae, 0x13, 0x59, 0xbe, 0xe3, 0xa5, 0xcb, 0xe2,
-	0xec, 0xde, 0x9f, 0x35, 0xd8, 0x2c, 0x2c, 0x18, 0x7d, 0x94, 0xb9, 0x74, 0xd1, 0xf8, 0x37, 0xef,
-	0x16, 0x37, 0xa5, 0x4f, 0x02, 0xe2, 0x85, 0xc4, 0x62, 0x26, 0x5b, 0x71, 0x0f, 0xc3, 0x7a, 0x02,
-	0x25, 0xca, 0x97, 0xdd, 0xc2, 0x29, 0xd0, 0x5d, 0xc0, 0xf5, 0x15, 0xc3, 0x33, 0x41, 0x69, 0x9c,
-	0xde, 0x13, 0x65, 0x21, 0x9e, 0xd9, 0x54, 0x58, 0x57, 0xd3, 0x26, 0x00, 0xf3, 0xd6, 0xe4, 0xb8,
-	0x30, 0x81, 0x32, 0x17, 0xc8, 0x61, 0xbd, 0x11, 0xb4, 0x8b, 0x8a, 0x60, 0x11, 0xd8, 0xf1, 0xe6,
-	0x71, 0x64, 0x7a, 0x36, 0xbd, 0x94, 0x05, 0x5d, 0x06, 0x79, 0xf9, 0x66, 0x7a, 0x3f, 0xaf, 0x42,
-	0x7b, 0xe9, 0xc2, 0x2f, 0x31, 0xa8, 0x9d, 0x37, 0xa8, 0x9d, 0xdc, 0x82, 0x95, 0x32, 0xb7, 0x60,
-	0x39, 0x23, 0x97, 0x5f, 0xc7, 0xc8, 0x87, 0xd0, 0x9e, 0x9f, 0x2f, 0x42, 0xc7, 0x22, 0x6e, 0x52,
-	0x5e, 0x8b, 0xdb, 0xc9, 0xde, 0xd2, 0xed, 0xa4, 0x3e, 0x2a, 0x48, 0xe2, 0xa5, 0xb1, 0xe8, 0x19,
-	0x6c, 0xda, 0xce, 0xd4, 0x89, 0x32, 0xd3, 0x09, 0xaf, 0xbe, 0xb3, 0x3c, 0x9d, 0x91, 0x17, 0xc4,
-	0xc5, 0x91, 0xe8, 0x31, 0xd4, 0xe6, 0x64, 0xe1, 0xc7, 0x91, 0xbc, 0xae, 0xec, 0xac, 0x58, 0x12,
-	0xe7, 0x63, 0x29, 0x87, 0xfe, 0x0f, 0x36, 0x0b, 0x67, 0x45, 0xa6, 0xfe, 0xe5, 0x43, 0x55, 0x14,
-	0xec, 0x4e, 0xa0, 0x5d, 0xdc, 0x20, 0x0f, 0xd3, 0x2c, 0x98, 0xd3, 0x40, 0x99, 0x41, 0x92, 0x2c,
-	0x22, 0x44, 0x01, 0xb1, 0x5e, 0x38, 0xde, 0xf4, 0x30, 0x9e, 0x9d, 0x52, 0x15, 0x70, 0x0b, 0x68,
-	0xf7, 0x43, 0xd8, 0x2c, 0xec, 0x13, 0xb5, 0xa1, 0x1c, 0x07, 0xae, 0x9c, 0x90, 0xfd, 0x64, 0xb9,
-	0x72, 0x4e, 0xc2, 0xf0, 0x4b, 0x3f, 0xb0, 0x55, 0x87, 0xaa, 0x68, 0xd6, 0x67, 0xd7, 0xc4, 0x2e,
-	0x93, 0x13, 0xa9, 0xbd, 0xf4, 0x44, 0xb2, 0x22, 0x4f, 0xa8, 0xa3, 0x9f, 0x2b, 0x2d, 0xf2, 0x20,
-	0x7a, 0x08, 0x6d, 0x01, 0xec, 0x51, 0x3a, 0xa2, 0xc1, 0xee, 0x22, 0x52, 0x6d, 0xc5, 0x12, 0xde,
-	0xfb, 0x95, 0x06, 0x9b, 0xc5, 0xcb, 0xe4, 0xab, 0x3d, 0xf4, 0x9f, 0x0f, 0x39, 0x1f, 0x00, 0x88,
-	0x6f, 0x8f, 0x5f, 0x1a, 0x78, 0x32, 0x42, 0xe8, 0x0e, 0xd4, 0x85, 0x21, 0x43, 0xe9, 0xb7, 0x75,
-	0x69, 0x69, 0xac, 0xf0, 0xde, 0xd7, 0x15, 0xa8, 0x09, 0x0c, 0xed, 0xa8, 0x42, 0xcf, 0x48, 0x43,
-	0x13, 0x92, 0x03, 0xe4, 0x1f, 0xc6, 0xc1, 0x19, 0xa9, 0x6f, 0x09, 0x45, 0xbf, 0x2f, 0x03, 0xe0,
-	0x9c, 0x70, 0x1a, 0x60, 0xb4, 0x62, 0x80, 0xf9, 0xd6, 0xdb, 0xea, 0x4c, 0xb9, 0x5c, 0x5e, 0x51,
-	0x2e, 0xdf, 0x83, 0xb5, 0x24, 0x18, 0xe5, 0x2b, 0xea, 0x2c, 0x8e, 0x74, 0x68, 0x8a, 0x19, 0xc7,
-	0xce, 0x34, 0x79, 0x22, 0x28, 0xfa, 0x7f, 0x2a, 0x92, 0x8b, 0x7b, 0x6c, 0x48, 0xad, 0x10, 0xf7,
-	0x98, 0x4c, 0xce, 0xa8, 0xf5, 0xd7, 0x31, 0x2a, 0x73, 0x94, 0x0b, 0x1a, 0x10, 0xd7, 0xe5, 0xe9,
-	0x7f, 0x1d, 0x2b, 0x92, 0x71, 0xbe, 0x88, 0x89, 0xcb, 0x2a, 0xc4, 0xa6, 0xe0, 0x48, 0xb2, 0x78,
-	0x05, 0x06, 0x9c, 0x9b, 0xbb, 0x02, 0xbb, 0x0b, 0xeb, 0xb6, 0x3c, 0x50, 0xe3, 0x39, 0xa5, 0x76,
-	0x67, 0x8d, 0xcb, 0xe4, 0x41, 0x96, 0x8f, 0xad, 0x38, 0x8c, 0xfc, 0x19, 0x0d, 0xe4, 0xdd, 0x43,
-	0xa7, 0xc5, 0xe5, 0x8a, 0x30, 0xab, 0x8e, 0x02, 0x7a, 0xe1, 0xd0, 0x2f, 0x3b, 0xeb, 0xa2, 0x42,
-	0x17, 0x54, 0xef, 0x77, 0x1a, 0xd4, 0xe5, 0xb3, 0x47, 0x5e, 0x07, 0xda, 0xeb, 0xe8, 0x60, 0x1b,
-	0xaa, 0x96, 0x4b, 0x9c, 0x99, 0x2a, 0x15, 0x39, 0xb1, 0x7c, 0x50, 0xcb, 0xab, 0x0e, 0xea, 0xff,
-	0x40, 0xd3, 0x8f, 0xa3, 0xb9, 0xef, 0x78, 0x91, 0xf2, 0xf1, 0xa6, 0x7e, 0x24, 0x11, 0x9c, 0xf2,
-	0x90, 0x0e, 0x28, 0xa4, 0x81, 0x43, 0x5c, 0xe7, 0x87, 0xd4, 0x56, 0x37, 0xcb, 0xdc, 0xfe, 0x2d,
-	0xbc, 0x82, 0xd3, 0xfb, 0x4b, 0x05, 0xb6, 0x96, 0x5e, 0x74, 0xfe, 0x85, 0x4d, 0x66, 0x22, 0x42,
-	0x29, 0x1f, 0x11, 0x58, 0x8b, 0x12, 0xf8, 0x73, 0x3f, 0xa4, 0xf6, 0xae, 0x6a, 0x69, 0x32, 0x08,
-	0xef, 0xbf, 0xd2, 0xd7, 0x26, 0xd1, 0xdd, 0x64, 0x10, 0xf4, 0x41, 0x92, 0x08, 0x84, 0x37, 0xbf,
-	0xb9, 0xfc, 0x12, 0x55, 0xcc, 0x04, 0x8f, 0xe1, 0x7a, 0xe2, 0xbf, 0x89, 0xeb, 0x8b, 0x22, 0xbf,
-	0x85, 0x57, 0xb1, 0xba, 0x7f, 0x2a, 0xbd, 0x6e, 0xa0, 0xbd, 0x03, 0x35, 0x9e, 0xe5, 0xd5, 0x8d,
-	0x50, 0xc6, 0x2c, 0x92, 0x81, 0x76, 0x61, 0x4d, 0x3c, 0xc5, 0xc5, 0xd1, 0x3c, 0x8e, 0xe4, 0xa1,
-	0xbe, 0x7d, 0xe5, 0xf2, 0x75, 0x21, 0x87, 0xb3, 0x83, 0x90, 0x01, 0x2d, 0xf9, 0x2c, 0x28, 0x26,
-	0xa9, 0xbc, 0xe2, 0x24, 0xb9, 0x51, 0xe8, 0x63, 0xd8, 0x4c, 0x76, 0x2d, 0x27, 0xaa, 0xbe, 0xe2,
-	0x44, 0xc5, 0x81, 0xdd, 0x27, 0x50, 0x93, 0xb3, 0xb2, 0xc6, 0x56, 0x94, 0xf6, 0xaa, 0xb1, 0xe5,
-	0x54, 0xa6, 0xd9, 0x28, 0x65, 0x9b, 0x8d, 0xde, 0xc7, 0xd0, 0x50, 0x3a, 0x62, 0x95, 0xcc, 0x79,
-	0xda, 0x3c, 0x8a, 0x8b, 0x93, 0x6d, 0xa8, 0x3a, 0xbc, 0x8a, 0x12, 0x2d, 0xa3, 0x20, 0xd2, 0x4e,
-	0x4b, 0xde, 0x7b, 0x71, 0xa2, 0xf7, 0x95, 0x06, 0x35, 0xf1, 0xb4, 0xf8, 0x1f, 0xac, 0x7f, 0x93,
-	0xce, 0xb2, 0x92, 0x76, 0x96, 0xbd, 0x5f, 0x6b, 0x50, 0x32, 0x0d, 0xa6, 0x84, 0x39, 0xcd, 0x2c,
-	0x4a, 0x52, 0x2c, 0xde, 0x9e, 0xba, 0xbe, 0xf5, 0x82, 0x77, 0x50, 0xc9, 0x8d, 0x78, 0x0e, 0x43,
-	0xf7, 0xa0, 0x3e, 0x8f, 0x4f, 0x5f, 0xd0, 0x45, 0x28, 0x9d, 0x66, 0x4d, 0x37, 0x0d, 0x7d, 0x24,
-	0x20, 0xac, 0x78, 0xec, 0xe4, 0x9c, 0x26, 0xeb, 0xe2, 0x6b, 0x68, 0xe1, 0x0c, 0xd2, 0xfd, 0x10,
-	0xea, 0x72, 0x0c, 0x2b, 0x32, 0x1c, 0x9b, 0x8a, 0x86, 0x5c, 0x64, 0xa6, 0x84, 0x66, 0xfa, 0x93,
-	0x83, 0x64, 0x86, 0x53, 0x64, 0xef, 0x6f, 0x1a, 0x34, 0xd3, 0x4a, 0xf7, 0x11, 0x6b, 0x5b, 0x79,
-	0xd1, 0x2d, 0x3b, 0x52, 0x94, 0xbe, 0xdb, 0xea, 0x63, 0xc1, 0xc1, 0x4a, 0x84, 0xd5, 0x48, 0x49,
-	0xa2, 0x64, 0x75, 0x44, 0x28, 0x27, 0x2f, 0xa0, 0xcc, 0x90, 0x75, 0x39, 0x18, 0xad, 0x41, 0xfd,
-	0xc0, 0x1c, 0x4f, 0xcc, 0xc3, 0xa7, 0xed, 0x6b, 0xa8, 0x09, 0xd5, 0x23, 0x6c, 0x0c, 0x71, 0x5b,
-	0x43, 0x37, 0x01, 0xf1, 0x9f, 0x27, 0x83, 0xa3, 0xc3, 0x3d, 0x13, 0x3f, 0xef, 0xf3, 0xa7, 0xac,
-	0x12, 0xba, 0x01, 0x5b, 0x02, 0xdf, 0x3b, 0x3e, 0xd8, 0x33, 0x0f, 0x0e, 0x9e, 0x0f, 0x0f, 0x27,
-	0xed, 0x32, 0xda, 0x86, 0xb6, 0x12, 0x7f, 0x3e, 0x3a, 0x18, 0x72, 0xe1, 0x0a, 0x9b, 0xdc, 0x30,
-	0xc7, 0xa3, 0xe3, 0xc9, 0xb0, 0x5d, 0x65, 0x33, 0x4a, 0xe2, 0x04, 0x0f, 0xc7, 0x47, 0x07, 0xc7,
-	0x5c, 0xa8, 0xc6, 0x1a, 0x4e, 0x3c, 0xe4, 0x0f, 0x6a, 0xf5, 0x1e, 0x85, 0x75, 0xb6, 0x3f, 0x6a,
-	0xab, 0x37, 0xe8, 0x1e, 0xd4, 0x65, 0x3f, 0x21, 0x63, 0x63, 0xfa, 0x4f, 0x07, 0x8a, 0x91, 0xf8,
-	0x75, 0x29, 0xe3, 0xd7, 0xb9, 0x22, 0xa2, 0x5c, 0x28, 0x22, 0x76, 0x2b, 0xdf, 0x2f, 0xcd, 0x4f,
-	0x4f, 0x6b, 0xdc, 0x1f, 0xff, 0xf7, 0x1f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd0, 0xd9, 0xf0, 0x16,
-	0x3c, 0x21, 0x00, 0x00,
+	// 3882 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x5a, 0x4d, 0x6c, 0x24, 0x49,
+	0x56, 0x76, 0xd6, 0x7f, 0x3d, 0xff, 0x95, 0xa3, 0x3d, 0x33, 0x35, 0xb5, 0xcb, 0x4c, 0x77, 0xa9,
+	0xa7, 0xe9, 0xed, 0xe9, 0xcd, 0xed, 0x31, 0x12, 0xb4, 0xf8, 0xdb, 0x29, 0x57, 0x95, 0xdb, 0x35,
+	0xe3, 0xb6, 0x6b, 0xa3, 0xca, 0x3b, 0x3b, 0x48, 0xc8, 0x4a, 0x57, 0x86, 0xcb, 0x49, 0x67, 0x65,
+	0xd6, 0xe4, 0x4f, 0xb7, 0xcd, 0x8d, 0x1b, 0x42, 0x42, 0x1c, 0x16, 0x69, 0x25, 0xae, 0x1c, 0xb8,
+	0x22, 0x71, 0x83, 0x1b, 0x5c, 0xb8, 0x21, 0x71, 0x81, 0x0b, 0x42, 0x42, 0x7b, 0x63, 0x2f, 0x70,
+	0xe1, 0xc0, 0x05, 0xbd, 0x17, 0x11, 0x59, 0x99, 0x59, 0xd5, 0xb6, 0x7b, 0x10, 0xe2, 0x96, 0xef,
+	0x7b, 0x2f, 0x22, 0x23, 0x5f, 0xbc, 0x78, 0x7f, 0x91, 0xb0, 0x3d, 0xf1, 0xbd, 0x28, 0xb0, 0x26,
+	0x51, 0x68, 0xce, 0x03, 0x3f, 0xf2, 0x5b, 0x6c, 0xe2, 0xc7, 0x5e, 0x14, 0x5c, 0x4f, 0x7c, 0x5b,
+	0x68, 0xec, 0xe3, 0xa9, 0xef, 0x4f, 0x5d, 0xf1, 0x03, 0xa2, 0xce, 0xe3, 0x8b, 0x1f, 0x44, 0xce,
+	0x4c, 0x84, 0x91, 0x35, 0x9b, 0x4b, 0x81, 0xf6, 0xdf, 0x95, 0x61, 0x87, 0x3b, 0x13, 0x2b, 0xb0,
+	0x1d, 0xcb, 0xeb, 0xaa, 0x19, 0xd9, 0x33, 0xd8, 0x7a, 0x2d, 0x3c, 0xdb, 0x0f, 0x8e, 0x9c, 0x30,
+	0x72, 0xbc, 0x69, 0xd8, 0x34, 0xee, 0x17, 0x1f, 0xaf, 0xef, 0xd5, 0x4c, 0x05, 0xf0, 0x1c, 0x9f,
+	0x3d, 0x02, 0x38, 0x8f, 0xaf, 0x45, 0x70, 0x12, 0xd8, 0x22, 0x68, 0x16, 0xee, 0x1b, 0x8f, 0xd7,
+	0xf7, 0x2a, 0x26, 0x51, 0x3c, 0xc5, 0x61, 0x47, 0xf0, 0x81, 0x1c, 0x49, 0x64, 0xd7, 0xf7, 0x2e,
+	0x9c, 0x60, 0x66, 0x45, 0x8e, 0xef, 0x35, 0x8b, 0x34, 0x88, 0x99, 0x4b, 0x1c, 0xfe, 0xb6, 0x21,
+	0x6c, 0x00, 0xef, 0xa7, 0x58, 0x07, 0xb1, 0x7b, 0xe1, 0xb8, 0xee, 0x4c, 0x78, 0x51, 0xb3, 0x44,
+	0xeb, 0xdd, 0x31, 0xf3, 0x0c, 0xfe, 0x96, 0x01, 0xac, 0x07, 0xbb, 0x8b, 0x65, 0x76, 0xfd, 0xd9,
+	0xdc, 0x15, 0xb4, 0xaa, 0x32, 0xad, 0xaa, 0x61, 0xe6, 0x70, 0xbe, 0x52, 0x9a, 0xb5, 0xa1, 0x6a,
+	0x3b, 0xe1, 0x3c, 0x8e, 0x44, 0xb3, 0x42, 0x03, 0x6b, 0x66, 0x4f, 0xd2, 0x5c, 0x33, 0xd8, 0xe7,
+	0xb0, 0xa3, 0x1e, 0xb9, 0x08, 0x7d, 0x37, 0xa6, 0xd7, 0x54, 0xd5, 0xc7, 0xf7, 0xf2, 0x1c, 0xbe,
+	0x2c, 0xcc, 0x3e, 0x86, 0x4a, 0x20, 0x2e, 0x62, 0xcf, 0x6e, 0xd6, 0x68, 0x58, 0xd5, 0xe4, 0x44,
+	0x72, 0x05, 0xb3, 0x27, 0x00, 0xa1, 0x33, 0xf5, 0xac, 0x28, 0x0e, 0x44, 0xd8, 0xac, 0x93, 0x2e,
+	0xc0, 0x1c, 0x69, 0x88, 0xa7, 0xb8, 0xac, 0x0f, 0xf7, 0xd4, 0x1b, 0xfa, 0xa8, 0x98, 0x50, 0xa0,
+	0x3a, 0xc2, 0x26, 0xd0, 0xa0, 0x7b, 0x66, 0x6f, 0x89, 0xc7, 0x57, 0xc9, 0xb3, 0xdf, 0x84, 0x86,
+	0x82, 0x87, 0x81, 0x3f, 0xf7, 0x43, 0xcb, 0x0d, 0x9b, 0xeb, 0x34, 0x47, 0xc3, 0xec, 0x65, 0x19,
+	0x7c, 0x49, 0x92, 0xfd, 0x16, 0x34, 0xe4, 0xbe, 0x0c, 0x03, 0x7f, 0xe6, 0xe3, 0x47, 0x86, 0xcd,
+	0x0d, 0xfa, 0xb6, 0x1d, 0x5a, 0xb6, 0xb0, 0x17, 0x0c, 0xbe, 0x24, 0xda, 0xfe, 0xe9, 0x77, 0xa0,
+	0xaa, 0x4c, 0x91, 0x31, 0x28, 0x85, 0x6e, 0x3c, 0x6d, 0x1a, 0xf7, 0x8d, 0xc7, 0x75, 0x4e, 0xcf,
+	0xec, 0x63, 0xa8, 0xc9, 0x31, 0x83, 0x9e, 0xb2, 0xcd, 0xa2, 0x39, 0xe8, 0xf1, 0x04, 0x64, 0xdf,
+	0x87, 0xda, 0x4c, 0x44, 0x96, 0x6d, 0x45, 0x96, 0xb2, 0xc3, 0x1d, 0x6d, 0xea, 0xe6, 0x4b, 0xc5,
+	0xe0, 0x89, 0x08, 0x7b, 0x00, 0x25, 0x27, 0x12, 0xb3, 0x66, 0x89, 0x44, 0x37, 0x13, 0xd1, 0x41,
+	0x24, 0x66, 0x9c, 0x58, 0xac, 0x03, 0xdb, 0xe1, 0xa5, 0x33, 0x9f, 0x3b, 0xde, 0xf4, 0x64, 0x2e,
+	0x3f, 0xa8, 0x4c, 0xea, 0xf8, 0x20, 0x91, 0x1e, 0x65, 0xf8, 0x3c, 0x2f, 0xcf, 0xda, 0x50, 0x8e,
+	0xac, 0x2b, 0x11, 0x36, 0x2b, 0x34, 0x70, 0x23, 0x19, 0x38, 0xb6, 0xae, 0xb8, 0x64, 0xb1, 0xef,
+	0x41, 0x75, 0xe2, 0xc7, 0x73, 0x9c, 0xbe, 0x4a, 0x52, 0xdb, 0x89, 0x54, 0x97, 0x70, 0xae, 0xf9,
+	0xec, 0x23, 0x80, 0x99, 0x6f, 0x8b, 0xc0, 0x8a, 0xfc, 0x20, 0x6c, 0xd6, 0xee, 0x17, 0x1f, 0xd7,
+	0x79, 0x0a, 0x61, 0x26, 0xb0, 0x48, 0x04, 0xb3, 0xb0, 0xe3, 0xd9, 0x5d, 0xdf, 0xb3, 0x1d, 0xb9,
+	0xe8, 0x3a, 0xa9, 0x71, 0x05, 0x87, 0xb5, 0x61, 0x43, 0x9a, 0xdb, 0xd0, 0x77, 0x9d, 0xc9, 0x75,
+	0x13, 0x48, 0x32, 0x83, 0xb1, 0x27, 0x50, 0xb5, 0xe2, 0x09, 0x59, 0xf8, 0xba, 0x3a, 0x48, 0x7a,
+	0x79, 0x1d, 0x89, 0x73, 0x2d, 0xc0, 0x9e, 0x41, 0x7d, 0x12, 0xf8, 0x6f, 0xec, 0x03, 0x34, 0xec,
+	0x0d, 0x75, 0x1e, 0x92, 0x8f, 0xd1, 0x1c, 0xbe, 0x10, 0x6a, 0xfd, 0x59, 0x09, 0x6a, 0x7a, 0x77,
+	0x58, 0x13, 0xaa, 0xaf, 0x45, 0x10, 0xe2, 0xab, 0x70, 0xeb, 0x37, 0xb9, 0x26, 0xd9, 0x3e, 0x6c,
+	0x68, 0x5f, 0x39, 0xbe, 0x9e, 0x0b, 0xb2, 0x80, 0xad, 0xbd, 0x8f, 0x96, 0x36, 0xd8, 0xec, 0xa6,
+	0xa4, 0x78, 0x66, 0x0c, 0x7b, 0x06, 0x95, 0x0b, 0x1f, 0xdd, 0x0e, 0x99, 0xc7, 0xd6, 0x5e, 0x73,
+	0x79, 0xf4, 0x01, 0xf1, 0xb9, 0x92, 0x63, 0x7b, 0x50, 0x11, 0x57, 0x73, 0x27, 0xb8, 0x56, 0x56,
+	0xd2, 0x32, 0xa5, 0x2f, 0x36, 0xb5, 0x2f, 0x36, 0xc7, 0xda, 0x17, 0x73, 0x25, 0xc9, 0x9e, 0x40,
+	0xc3, 0x9a, 0x4c, 0xc4, 0x3c, 0x12, 0x76, 0x37, 0x0e, 0x02, 0xe1, 0x4d, 0xae, 0xc9, 0x01, 0xd5,
+	0xf9, 0x12, 0xce, 0x1e, 0xc3, 0xf6, 0x3c, 0x70, 0x26, 0x8e, 0x37, 0x4d, 0x44, 0x2b, 0x24, 0x9a,
+	0x87, 0x59, 0x0b, 0x6a, 0xae, 0xe5, 0x4d, 0x63, 0x6b, 0x2a, 0xc8, 0xcf, 0xd4, 0x79, 0x42, 0xe3,
+	0xa6, 0x8b, 0x10, 0x35, 0x8a, 0x8b, 0xf1, 0xe3, 0xe8, 0xd0, 0x8f, 0xc9, 0x38, 0x50, 0x81, 0x2b,
+	0x38, 0x28, 0x9f, 0x5b, 0x89, 0xa3, 0x3c, 0x4c, 0x9d, 0xaf, 0xe0, 0xb4, 0x87, 0xb0, 0x91, 0xd6,
+	0x2a, 0xdb, 0x81, 0xcd, 0xe1, 0xe1, 0xd7, 0xa3, 0x41, 0xb7, 0x73, 0x74, 0xf6, 0xe2, 0xe4, 0xa4,
+	0xd7, 0x58, 0x63, 0x0d, 0xd8, 0xe8, 0x0d, 0x5e, 0x0c, 0xc6, 0x1a, 0x31, 0xd8, 0x3a, 0x54, 0x47,
+	0x7d, 0xfe, 0xe3, 0x41, 0xb7, 0xdf, 0x28, 0xb0, 0x2d, 0x80, 0x2e, 0x3f, 0xf9, 0xaa, 0x77, 0x76,
+	0x70, 0x7a, 0xdc, 0x6b, 0x14, 0xdb, 0x8f, 0xa0, 0x22, 0x35, 0xcd, 0xb6, 0x61, 0xfd, 0x60, 0xf0,
+	0x93, 0x7e, 0xef, 0x6c, 0xc8, 0x51, 0x74, 0x0d, 0xc7, 0x75, 0x4e, 0xbb, 0xe3, 0xc1, 0xc9, 0x71,
+	0xc3, 0x68, 0xfd, 0x6b, 0x05, 0x4a, 0x78, 0x1e, 0xd9, 0x2e, 0x94, 0x23, 0x27, 0x72, 0x85, 0xf2,
+	0x08, 0x92, 0x60, 0xf7, 0x61, 0xdd, 0xc6, 0xef, 0x73, 0xe8, 0xb0, 0x91, 0x4d, 0xd4, 0x79, 0x1a,
+	0x62, 0x8f, 0x60, 0x6b, 0x1e, 0xf8, 0x13, 0x11, 0x86, 0x8e, 0x37, 0x45, 0x25, 0xd0, 0xd6, 0xd7,
+	0x79, 0x0e, 0xc5, 0xf9, 0x51, 0xe3, 0x82, 0xf6, 0xb9, 0xc4, 0x25, 0x81, 0x6e, 0xc8, 0x0b, 0x2f,
+	0xde, 0xd0, 0xf6, 0xd5, 0x38, 0x3d, 0x23, 0x16, 0x59, 0x53, 0x79, 0x9e, 0xeb, 0x9c, 0x9e, 0xd9,
+	0xa7, 0x50, 0x71, 0x66, 0xd6, 0x54, 0xe8, 0xf3, 0x7b, 0x2f, 0xe3, 0x4c, 0xcc, 0x01, 0xf2, 0xb8,
+	0x12, 0xc1, 0x23, 0x3c, 0xb1, 0x22, 0x31, 0xf5, 0x03, 0x47, 0x24, 0x47, 0x78, 0x81, 0xe0, 0x52,
+	0xa6, 0x81, 0x35, 0x93, 0xa7, 0xb6, 0xc0, 0x25, 0xc1, 0xbe, 0x0b, 0xf5, 0x89, 0x3e, 0xb6, 0xea,
+	0x94, 0x2e, 0x00, 0x66, 0x42, 0xd5, 0x57, 0x0e, 0x4a, 0xfa, 0xeb, 0xdd, 0xec, 0x0a, 0x94, 0x77,
+	0xd2, 0x42, 0xec, 0x13, 0x28, 0x85, 0xaf, 0x62, 0x74, 0xcf, 0xc5, 0x8c, 0x9b, 0x24, 0xe1, 0xd1,
+	0xab, 0x98, 0x13, 0xbb, 0xf5, 0xb7, 0x06, 0x54, 0xe4, 0x50, 0x52, 0x85, 0x35, 0xd3, 0xfa, 0xa7,
+	0xe7, 0x3b, 0xa8, 0xff, 0x39, 0xd4, 0x5e, 0x5b, 0x81, 0x63, 0x61, 0x30, 0x2a, 0xd2, 0xbb, 0xbe,
+	0xbb, 0x6a, 0x61, 0xe6, 0x8f, 0xa5, 0x10, 0x4f, 0xa4, 0x5b, 0x87, 0x50, 0x55, 0xe0, 0xca, 0x57,
+	0x7f, 0x0f, 0xca, 0xa4, 0x4e, 0x15, 0x09, 0x56, 0x2a, 0x5c, 0x4a, 0xb4, 0xfe, 0xc0, 0x80, 0xe2,
+	0xe8, 0x55, 0x8c, 0xae, 0x4e, 0xcd, 0xde, 0xf5, 0x67, 0xe7, 0x3e, 0x65, 0x43, 0x9b, 0x3c, 0x83,
+	0xa1, 0x96, 0xe7, 0x81, 0x6f, 0xc7, 0x93, 0x48, 0x05, 0x99, 0x3a, 0x5f, 0x00, 0xc8, 0x0d, 0xe3,
+	0x60, 0x72, 0x69, 0x05, 0x53, 0x69, 0x47, 0x45, 0xbe, 0x00, 0xf0, 0x84, 0x7e, 0x13, 0x5b, 0x5e,
+	0xe4, 0x44, 0xd2, 0x5b, 0x14, 0x79, 0x42, 0xb7, 0x7e, 0x66, 0x40, 0x99, 0x16, 0x85, 0x52, 0x17,
+	0x8e, 0x2b, 0x52, 0x1f, 0x94, 0xd0, 0xc8, 0xf3, 0x03, 0x67, 0xea, 0x78, 0x96, 0xab, 0x5e, 0x9e,
+	0xd0, 0x68, 0x15, 0x6e, 0xf2, 0xde, 0x3a, 0x97, 0x04, 0x7b, 0x1f, 0x2a, 0x33, 0x61, 0x3b, 0xb1,
+	0x8c, 0x62, 0x75, 0xae, 0x28, 0x94, 0x0e, 0x67, 0x96, 0xeb, 0x2a, 0xc7, 0x23, 0x09, 0x32, 0x5d,
+	0xc7, 0xd3, 0x2e, 0x86, 0x9e, 0x5b, 0x7f, 0x55, 0x81, 0xad, 0x6c, 0x0c, 0x5b, 0xa9, 0xef, 0xe7,
+	0x50, 0x8a, 0x16, 0x6e, 0xf7, 0xe1, 0x5b, 0xc2, 0x5f, 0x42, 0x92, 0xf3, 0xa5, 0x11, 0xec, 0x11,
+	0x54, 0x03, 0x31, 0x25, 0xd3, 0x44, 0x0b, 0xd8, 0xda, 0xdb, 0x30, 0xbb, 0x32, 0xc7, 0xed, 0xfa,
+	0xb6, 0xe0, 0x9a, 0xc9, 0xbe, 0x84, 0x4d, 0x1d, 0x3b, 0x79, 0xec, 0x8a, 0x50, 0x79, 0xdc, 0x4f,
+	0x6e, 0x7b, 0x15, 0x09, 0xf3, 0xec, 0x58, 0xf6, 0x1b, 0x50, 0x0b, 0x45, 0xf0, 0xda, 0x99, 0x08,
+	0x1d, 0xb1, 0x3f, 0x7e, 0xeb, 0x3c, 0x52, 0x8e, 0x27, 0x03, 0x5a, 0x16, 0x54, 0x15, 0xb8, 0x52,
+	0x15, 0x89, 0xab, 0x28, 0xa4, 0x5d, 0xc5, 0x53, 0xd8, 0x11, 0x61, 0xe4, 0xcc, 0xac, 0x48, 0xd8,
+	0x3d, 0xe1, 0x3a, 0xaf, 0x45, 0x70, 0xad, 0xf6, 0x6a, 0x99, 0xd1, 0xfa, 0xa3, 0x22, 0x6c, 0x66,
+	0x3e, 0x80, 0x7d, 0x01, 0xb5, 0x20, 0x76, 0x05, 0xc5, 0x36, 0x83, 0x94, 0x6c, 0xde, 0xe9, 0xcb,
+	0x4d, 0xae, 0x46, 0xf1, 0x64, 0x3c, 0xfb, 0x1c, 0xca, 0x01, 0xa9, 0xb0, 0x40, 0x9f, 0xfe, 0xe4,
+	0xee, 0x13, 0x71, 0x39, 0xb0, 0x35, 0x86, 0x12, 0x92, 0x68, 0x91, 0x33, 0xc7, 0xe3, 0x96, 0x37,
+	0x15, 0x2a, 0x20, 0x27, 0x34, 0xf1, 0xac, 0x2b, 0xc9, 0x2b, 0x28, 0x9e, 0xa2, 0x17, 0x3a, 0x2a,
+	0xa6, 0x74, 0xd4, 0xfe, 0x53, 0x03, 0x6a, 0x7a, 0xb9, 0xec, 0x3d, 0xd8, 0xf9, 0xd1, 0x69, 0xe7,
+	0x78, 0x3c, 0x18, 0x7f, 0x7d, 0xd6, 0x1b, 0x8c, 0xba, 0x27, 0xa7, 0xc7, 0xe3, 0xc6, 0x1a, 0xfb,
+	0x0e, 0x7c, 0x70, 0x70, 0xd4, 0x19, 0x9f, 0x1d, 0xf4, 0xfb, 0x67, 0x09, 0x9f, 0x77, 0x8e, 0x5f,
+	0xf4, 0x1b, 0x06, 0xfb, 0x10, 0xde, 0x4b, 0x98, 0x5f, 0xf5, 0x07, 0x2f, 0x0e, 0xc7, 0x8a, 0x55,
+	0x40, 0x56, 0xf7, 0xe4, 0xe5, 0xfe, 0xe0, 0xb8, 0xdf, 0x3b, 0x1b, 0x1d, 0x0e, 0x86, 0xc3, 0xc1,
+	0xf1, 0x8b, 0xb3, 0x4e, 0xaf, 0xd7, 0x28, 0xb2, 0x8f, 0xa0, 0xb5, 0xcc, 0x1a, 0x9d, 0xee, 0x8f,
+	0x79, 0xa7, 0x3b, 0x6e, 0x94, 0xda, 0x9f, 0xc1, 0x46, 0xda, 0x6e, 0x31, 0x96, 0x1d, 0x9d, 0x60,
+	0x6c, 0x1b, 0x0e, 0xba, 0x5f, 0x9e, 0x0e, 0x1b, 0x6b, 0xf9, 0x20, 0x65, 0xb4, 0xfe, 0xc4, 0x80,
+	0xe2, 0xd8, 0xba, 0xc2, 0x7c, 0x25, 0xb2, 0xae, 0x92, 0x4d, 0xab, 0x73, 0x4d, 0xb2, 0xa7, 0x00,
+	0x91, 0x75, 0xc5, 0x95, 0xe5, 0x17, 0x56, 0x58, 0x7e, 0x8a, 0x8f, 0x9e, 0x34, 0xb2, 0xae, 0xf4,
+	0x2a, 0x48, 0x6b, 0x35, 0x9e, 0x86, 0x30, 0x6a, 0xcc, 0x45, 0x30, 0x11, 0x5e, 0x84, 0x5e, 0xaf,
+	0x44, 0xa1, 0x21, 0x85, 0xb4, 0x7e, 0x51, 0x80, 0x8a, 0x4c, 0x16, 0xdf, 0x12, 0x2b, 0x77, 0xa1,
+	0x74, 0x69, 0x85, 0x97, 0xd2, 0xb1, 0x1c, 0xae, 0x71, 0xa2, 0xd8, 0x43, 0xd8, 0xb0, 0x9d, 0x90,
+	0x8a, 0x4e, 0x5c, 0x94, 0xb4, 0xd8, 0xc3, 0x35, 0x9e, 0x41, 0xd9, 0x13, 0xd8, 0x56, 0xaf, 0xea,
+	0x29, 0x98, 0x1c, 0x4b, 0xe1, 0xd0, 0xe0, 0x79, 0x06, 0x7b, 0x04, 0x9b, 0xb4, 0xdb, 0x89, 0x24,
+	0x7a, 0x9b, 0xd2, 0xa1, 0xc1, 0xb3, 0x70, 0x2a, 0xb5, 0xaa, 0xde, 0x39, 0xb5, 0x7a, 0x04, 0x5b,
+	0x68, 0x62, 0xc2, 0x16, 0x33, 0x15, 0xed, 0x64, 0x92, 0x93, 0x43, 0x31, 0xad, 0x9a, 0x39, 0x9e,
+	0x33, 0x8b, 0x67, 0x43, 0x72, 0xcf, 0xa1, 0xa0, 0x60, 0x5a, 0xe2, 0x79, 0x18, 0x83, 0x82, 0xef,
+	0x4d, 0xc4, 0x50, 0x04, 0xfb, 0x58, 0x0a, 0x52, 0x64, 0xad, 0xf1, 0x0c, 0xb6, 0x5f, 0x81, 0x12,
+	0x96, 0xe3, 0xfb, 0x00, 0x35, 0xad, 0x95, 0xd6, 0x1f, 0x1b, 0x50, 0x55, 0xc9, 0xaf, 0xcc, 0xa1,
+	0xd1, 0x7b, 0x88, 0x21, 0xd9, 0xbc, 0x41, 0xaf, 0xca, 0x60, 0x98, 0x14, 0xaa, 0x57, 0x0f, 0xbc,
+	0x49, 0x40, 0xe5, 0x96, 0xf2, 0x1f, 0x4b, 0x38, 0x6a, 0x66, 0xe2, 0xfa, 0xa1, 0x08, 0x9b, 0xc5,
+	0xdb, 0x35, 0x23, 0x25, 0x5b, 0x5f, 0x41, 0x3d, 0xc9, 0xae, 0xd1, 0x6b, 0x4d, 0x7d, 0xcb, 0x55,
+	0x0b, 0xa1, 0x67, 0xf6, 0xab, 0x50, 0xb3, 0x85, 0x65, 0xbb, 0x8e, 0xa7, 0x63, 0xe6, 0x4d, 0xd3,
+	0x26, 0xb2, 0xed, 0x3f, 0x07, 0x28, 0xcb, 0xaa, 0xff, 0x21, 0x6c, 0xca, 0xb2, 0xa0, 0x63, 0xdb,
+	0x81, 0x08, 0x43, 0x65, 0x5e, 0x59, 0x10, 0x63, 0xa4, 0x04, 0x0e, 0x84, 0xf6, 0x90, 0x0b, 0x80,
+	0x7d, 0x0a, 0xb5, 0x30, 0x6d, 0xe4, 0x58, 0xea, 0xd0, 0xec, 0x0b, 0x5f, 0x94, 0x08, 0xb0, 0x5f,
+	0x82, 0x2a, 0xd5, 0xe7, 0x83, 0x5e, 0xb3, 0xb4, 0xa8, 0xf7, 0x34, 0xc6, 0x9e, 0x43, 0x3d, 0x69,
+	0x84, 0x34, 0xcb, 0xb7, 0x7e, 0xd2, 0x42, 0x98, 0x3d, 0x80, 0xb2, 0x13, 0x89, 0x99, 0xae, 0xc9,
+	0xd6, 0xd5, 0x12, 0xa8, 0xf0, 0x93, 0x1c, 0xf6, 0x18, 0xaa, 0x73, 0xeb, 0x9a, 0xb6, 0x49, 0x9a,
+	0xe7, 0x96, 0x12, 0x1a, 0x4a, 0x94, 0x6b, 0x36, 0x1e, 0xcc, 0xc0, 0x42, 0xef, 0xfa, 0xa5, 0xb8,
+	0x96, 0xe9, 0xdc, 0x06, 0x4f, 0x21, 0x6c, 0x0f, 0x76, 0x2d, 0x37, 0x12, 0x81, 0x67, 0x45, 0x02,
+	0xb3, 0x68, 0x6b, 0x12, 0x0d, 0xbc, 0x0b, 0x5f, 0xd5, 0x64, 0x2b, 0x79, 0xad, 0x7f, 0x34, 0xa0,
+	0x96, 0x9c, 0xfc, 0xf7, 0xa1, 0x82, 0x2a, 0x19, 0xfb, 0x4a, 0xe1, 0x8a, 0x42, 0xdf, 0x63, 0xa9,
+	0x9d, 0x90, 0xc9, 0x82, 0x26, 0x71, 0xff, 0x27, 0x98, 0x85, 0xc8, 0xf0, 0x43, 0xcf, 0x94, 0x11,
+	0x44, 0x56, 0x24, 0x54, 0xa2, 0x20, 0x09, 0xf2, 0x2a, 0x7e, 0x18, 0x59, 0x2e, 0x1d, 0x7e, 0x99,
+	0x2c, 0xa4, 0x10, 0x0c, 0xde, 0xaa, 0x21, 0x45, 0xc7, 0x78, 0x29, 0x78, 0x2b, 0x26, 0x1e, 0x01,
+	0xf5, 0xf2, 0x63, 0x3f, 0xa2, 0x34, 0x98, 0xca, 0xc8, 0x34, 0xd6, 0xfa, 0xcf, 0x82, 0xca, 0xe5,
+	0xef, 0xc3, 0xba, 0x2b, 0x03, 0xd2, 0x21, 0x3a, 0x24, 0xf9, 0x55, 0x69, 0x28, 0x93, 0x4a, 0xa9,
+	0xd0, 0xa2, 0x69, 0xf6, 0x74, 0x91, 0xea, 0xca, 0x8c, 0x92, 0xa5, 0xb6, 0x6f, 0x29, 0xd1, 0xdd,
+	0x87, 0xad, 0x6c, 0x45, 0x9e, 0x14, 0x72, 0xa9, 0x41, 0xb9, 0x1a, 0x3e, 0x37, 0x02, 0xd5, 0x39,
+	0x13, 0x33, 0x5f, 0xa9, 0x87, 0x9e, 0xf1, 0x1b, 0x64, 0x49, 0x8e, 0x7a, 0xd0, 0xc5, 0x40, 0x1a,
+	0x62, 0x0d, 0x28, 0x9e, 0x3b, 0x36, 0x69, 0xa2, 0xc4, 0xf1, 0xb1, 0xb5, 0x77, 0x63, 0x32, 0xbd,
+	0x0b, 0xe5, 0xd7, 0x96, 0x1b, 0x0b, 0xb5, 0x99, 0x92, 0x68, 0xfd, 0xf6, 0x9d, 0xb2, 0xb3, 0x26,
+	0x54, 0x55, 0xf6, 0xa2, 0x4d, 0x41, 0x91, 0xad, 0x9f, 0x17, 0xa0, 0xaa, 0x4c, 0x96, 0x7d, 0x1f,
+	0x93, 0xc5, 0xe8, 0xd2, 0xb7, 0x55, 0x82, 0xf1, 0x5e, 0xd6, 0xa4, 0xb1, 0x08, 0xbe, 0xf4, 0x6d,
+	0xae, 0x84, 0xf0, 0x24, 0x27, 0x8d, 0x05, 0x9d, 0x0b, 0x27, 0x00, 0x5a, 0xa5, 0x35, 0x23, 0xff,
+	0x2e, 0x43, 0xbc, 0xa2, 0xd0, 0x12, 0xc4, 0xd5, 0xe4, 0x12, 0xb3, 0x00, 0xae, 0xcd, 0xad, 0xc4,
+	0x33, 0x18, 0xd5, 0x32, 0x97, 0x96, 0xe3, 0xa1, 0x57, 0x55, 0xc9, 0xe8, 0x02, 0x48, 0xdb, 0x75,
+	0x35, 0x6b, 0xd7, 0xe4, 0x68, 0x6d, 0x21, 0x66, 0x23, 0x2a, 0x30, 0x9a, 0x35, 0xdd, 0xac, 0x58,
+	0x60, 0x64, 0xfb, 0xbe, 0xe3, 0xa9, 0xe3, 0x45, 0xcf, 0x14, 0x36, 0xf4, 0xc2, 0x87, 0x96, 0x27,
+	0x5c, 0x6a, 0x8c, 0xd5, 0x79, 0x0e, 0x6d, 0x3f, 0x87, 0x8a, 0xd4, 0x01, 0xbb, 0x07, 0xdb, 0x9d,
+	0x5e, 0x8f, 0xf7, 0x47, 0xa3, 0x33, 0xde, 0xff, 0xd1, 0x69, 0x7f, 0x84, 0xa9, 0x09, 0x40, 0xa5,
+	0x37, 0xe0, 0xfd, 0xee, 0xb8, 0x61, 0xb0, 0x4d, 0xa8, 0xbf, 0x3c, 0xe9, 0xf5, 0x79, 0x67, 0xdc,
+	0xef, 0x35, 0x0a, 0xed, 0xff, 0x32, 0x60, 0x67, 0xb9, 0xb3, 0xd9, 0x84, 0xaa, 0x8f, 0xe0, 0xa0,
+	0xa7, 0xb3, 0x03, 0x45, 0x66, 0x7d, 0x57, 0xe1, 0x5d, 0x7c, 0x17, 0x16, 0xb4, 0x72, 0xbf, 0xb4,
+	0x1b, 0xd6, 0x05, 0x6d, 0x06, 0xc5, 0x10, 0x18, 0x88, 0x6f, 0x62, 0x11, 0x46, 0xc2, 0xee, 0xc8,
+	0x8d, 0x92, 0x5b, 0x91, 0x87, 0xb1, 0xe9, 0x27, 0xdd, 0xd5, 0x68, 0xd1, 0x6d, 0x2c, 0xab, 0xa6,
+	0x1f, 0xcf, 0x32, 0xf8, 0x92, 0x64, 0xfb, 0x0f, 0x0d, 0x58, 0xa7, 0x2f, 0xe7, 0xe2, 0xf7, 0xc4,
+	0x24, 0xfa, 0x3f, 0xf9, 0x66, 0xac, 0x56, 0x9d, 0xa9, 0x3e, 0xef, 0x3b, 0xe6, 0xbe, 0x13, 0xe1,
+	0xbe, 0x2e, 0x96, 0x45, 0xec, 0xf6, 0x2f, 0x0c, 0xd8, 0xce, 0x2d, 0x98, 0x7d, 0x9e, 0xea, 0x09,
+	0x1a, 0xf4, 0xce, 0x87, 0xf9, 0x8f, 0x32, 0xc7, 0x81, 0xe5, 0x85, 0x16, 0xc5, 0xf2, 0x15, 0x6d,
+	0x42, 0x2c, 0xfa, 0xb4, 0x28, 0x2d, 0x7b, 0x83, 0x2f, 0x80, 0xd6, 0x35, 0xdc, 0x5b, 0x31, 0x3c,
+	0xe5, 0xe2, 0x46, 0x8b, 0x36, 0x66, 0x1a, 0xa2, 0x38, 0xa9, 0x83, 0x84, 0x9e, 0x36, 0x01, 0xd0,
+	0xd2, 0x13, 0xdb, 0x44, 0x81, 0x22, 0x09, 0x64, 0xb0, 0xf6, 0x10, 0x1a, 0x79, 0x45, 0xa0, 0x3f,
+	0x77, 0xbc, 0x79, 0x1c, 0x0d, 0x3c, 0x5b, 0x5c, 0xa9, 0x8c, 0x3d, 0x85, 0xdc, 0xfc, 0x31, 0xed,
+	0xbf, 0xac, 0x41, 0x63, 0xa9, 0xa7, 0x9e, 0x6c, 0xa8, 0x9d, 0xdd, 0x50, 0x3b, 0x69, 0xd2, 0x16,
+	0x52, 0x4d, 0xda, 0xcc, 0x26, 0x17, 0xdf, 0x65, 0x93, 0x8f, 0xa1, 0x31, 0xbf, 0xbc, 0x0e, 0x9d,
+	0x89, 0xe5, 0x26, 0xf5, 0x93, 0xbc, 0x00, 0x68, 0x2f, 0x5d, 0x00, 0x98, 0xc3, 0x9c, 0x24, 0x5f,
+	0x1a, 0xcb, 0xbe, 0x84, 0x6d, 0xdb, 0x99, 0x3a, 0x51, 0x6a, 0x3a, 0x69, 0xd5, 0x0f, 0x96, 0xa7,
+	0xeb, 0x65, 0x05, 0x79, 0x7e, 0x24, 0x76, 0x0e, 0xe7, 0xd6, 0xb5, 0x1f, 0x47, 0xea, 0x46, 0xa0,
+	0xb9, 0x62, 0x49, 0xc4, 0xe7, 0x4a, 0x8e, 0xfd, 0x3a, 0x6c, 0xe7, 0xce, 0x8a, 0x4a, 0x24, 0x96,
+	0x0f, 0x55, 0x5e, 0x90, 0xfd, 0x30, 0xdd, 0x44, 0x95, 0xb7, 0x03, 0x2b, 0x16, 0xbd, 0xe8, 0xa6,
+	0x8a, 0x30, 0x76, 0xa3, 0x74, 0x4f, 0x75, 0x0c, 0x8d, 0xbc, 0x86, 0x28, 0x46, 0x60, 0x24, 0x11,
+	0x81, 0xde, 0x47, 0x45, 0xa2, 0x4b, 0xc1, 0xde, 0xde, 0x2b, 0xc7, 0x9b, 0x1e, 0xc7, 0xb3, 0x73,
+	0xa1, 0xbd, 0x7d, 0x0e, 0x6d, 0xfd, 0x8d, 0x01, 0xdb, 0x39, 0x4d, 0x61, 0x94, 0x8b, 0x03, 0x57,
+	0xcd, 0x88, 0x8f, 0x18, 0xbb, 0xe7, 0x56, 0x18, 0xbe, 0xf1, 0x03, 0x5b, 0x37, 0x31, 0x34, 0x8d,
+	0x16, 0x43, 0x35, 0x88, 0x4a, 0x4c, 0xf0, 0x19, 0x67, 0x78, 0x25, 0x64, 0xc7, 0x64, 0x83, 0xe3,
+	0x63, 0xa6, 0x45, 0x52, 0xce, 0xb5, 0x48, 0x30, 0x28, 0x09, 0xdb, 0xb1, 0xa8, 0xe4, 0x52, 0xa1,
+	0x23, 0x01, 0x70, 0xe4, 0xe4, 0x52, 0x4c, 0x5e, 0x85, 0xf1, 0x4c, 0x37, 0x49, 0x35, 0x8d, 0x6d,
+	0xa0, 0x8a, 0xdc, 0xa3, 0xc4, 0x9f, 0x18, 0x37, 0xfa, 0x13, 0x4c, 0x78, 0xe5, 0x66, 0x76, 0x32,
+	0x69, 0x56, 0x16, 0xc4, 0xcc, 0x5e, 0x02, 0x07, 0x82, 0x4a, 0x86, 0xeb, 0x48, 0x57, 0xbd, 0x4b,
+	0x78, 0xeb, 0x1f, 0x0c, 0xd8, 0xce, 0x6d, 0x1b, 0x06, 0xd2, 0xc0, 0x72, 0x42, 0x61, 0xab, 0x74,
+	0x5d, 0x51, 0x6c, 0x0c, 0x9b, 0xd4, 0xbc, 0x76, 0xce, 0xe3, 0x28, 0xa9, 0x21, 0xd7, 0xf7, 0xcc,
+	0x5b, 0x0d, 0xc1, 0xec, 0xa6, 0x86, 0xf1, 0xec, 0x24, 0xad, 0x63, 0xd5, 0xca, 0x55, 0x00, 0xee,
+	0x48, 0x74, 0xe5, 0xe8, 0xa3, 0x4d, 0xcf, 0x98, 0x89, 0x38, 0xe4, 0x3f, 0x64, 0xea, 0x25, 0x89,
+	0x45, 0x7e, 0xa2, 0x4a, 0x7a, 0x22, 0xda, 0x68, 0x13, 0xf9, 0xfb, 0xb3, 0xb7, 0x7b, 0x8c, 0x6f,
+	0x1f, 0x02, 0x3e, 0x03, 0x90, 0xda, 0x1c, 0xdd, 0x18, 0x08, 0x52, 0x42, 0xec, 0x01, 0x54, 0xe5,
+	0xc1, 0x0a, 0x95, 0x1f, 0xa9, 0xaa, 0x93, 0xc7, 0x35, 0xde, 0xfe, 0xeb, 0x12, 0x54, 0x24, 0xc6,
+	0xf6, 0x74, 0x1a, 0xdf, 0x5b, 0x84, 0x0a, 0xa6, 0x06, 0x98, 0x3c, 0xe1, 0xf0, 0x94, 0xd4, 0x2d,
+	0xa1, 0xe1, 0x5f, 0x8a, 0x00, 0x3c, 0x23, 0xbc, 0x70, 0xf8, 0x46, 0xde, 0xe1, 0xdf, 0x7a, 0xb9,
+	0x95, 0x2a, 0x86, 0x8a, 0x2b, 0x8a, 0xa1, 0x4f, 0x60, 0x3d, 0x09, 0x0e, 0xd9, 0x7a, 0x29, 0x8d,
+	0x33, 0x13, 0xea, 0x72, 0xc6, 0x91, 0x33, 0x4d, 0x6e, 0x45, 0xf3, 0xfe, 0x68, 0x21, 0x92, 0x89,
+	0x43, 0x38, 0xa4, 0x92, 0x8b, 0x43, 0x28, 0x93, 0xd9, 0xd4, 0xea, 0xbb, 0x6c, 0x2a, 0x1a, 0xca,
+	0x6b, 0x11, 0x60, 0x9f, 0x52, 0xd6, 0xf1, 0x9a, 0x44, 0xce, 0x37, 0xb1, 0xe5, 0x62, 0xfe, 0x5f,
+	0x97, 0x1c, 0x45, 0xe6, 0x7b, 0xce, 0x40, 0xdc, 0x34, 0x84, 0xc7, 0xd6, 0x56, 0xee, 0x69, 0x34,
+	0x17, 0xc2, 0xa6, 0x4b, 0xab, 0x4d, 0x9e, 0x05, 0x31, 0x3f, 0x9a, 0xc4, 0x61, 0xe4, 0xcf, 0x44,
+	0xa0, 0x9a, 0x7d, 0x74, 0x5d, 0xb5, 0xc9, 0xf3, 0x30, 0x1d, 0x50, 0xf1, 0xda, 0x11, 0x6f, 0x9a,
+	0x9b, 0xb2, 0xfe, 0x92, 0x54, 0xfb, 0x2f, 0x0c, 0xd8, 0x52, 0x06, 0x25, 0x42, 0xbc, 0x9c, 0x13,
+	0x8b, 0x5a, 0x30, 0x55, 0xd8, 0xa4, 0x90, 0xdb, 0x77, 0xb9, 0x05, 0xb5, 0x40, 0x4d, 0xa6, 0x9c,
+	0x64, 0x42, 0x67, 0xf5, 0x5c, 0x7a, 0x07, 0x3d, 0xb7, 0x67, 0xb0, 0x2b, 0xef, 0x5f, 0x73, 0xcb,
+	0xfd, 0x35, 0xd8, 0x0a, 0x32, 0x88, 0xb2, 0xfb, 0x6d, 0x33, 0x2b, 0xc8, 0x73, 0x62, 0xb7, 0xa4,
+	0x11, 0xff, 0x6c, 0x40, 0x55, 0xdd, 0x16, 0x67, 0x17, 0x6d, 0xbc, 0x8b, 0x71, 0xec, 0x42, 0x79,
+	0xe2, 0x5a, 0xce, 0x4c, 0xd7, 0x43, 0x44, 0x2c, 0xfb, 0xe4, 0xe2, 0x2a, 0x9f, 0xfc, 0xcb, 0x50,
+	0xf7, 0xe3, 0x68, 0xee, 0x3b, 0x5e, 0xa4, 0x0f, 0x7f, 0xdd, 0x3c, 0x51, 0x08, 0x5f, 0xf0, 0xf0,
+	0x26, 0x2c, 0x14, 0x81, 0x63, 0xb9, 0xce, 0xef, 0x0b, 0x5b, 0xdf, 0x71, 0xd1, 0xc1, 0xd8, 0xe0,
+	0x2b, 0x38, 0xed, 0xff, 0x28, 0xc1, 0xce, 0xd2, 0xed, 0xfe, 0xff, 0xe2, 0x23, 0x53, 0xae, 0xb2,
+	0x90, 0x75, 0x95, 0x58, 0x99, 0xd3, 0xcd, 0xba, 0xb0, 0xf7, 0x75, 0x25, 0x9f, 0x42, 0xc8, 0xd4,
+	0x92, 0x15, 0xa8, 0xa2, 0x3e, 0x85, 0xb0, 0xcf, 0x92, 0x8c, 0x45, 0x1e, 0xf3, 0x0f, 0x97, 0xff,
+	0x4a, 0xc8, 0xa7, 0x2c, 0xcf, 0xe0, 0x5e, 0x72, 0xb0, 0x13, 0x9f, 0x20, 0x6b, 0xdb, 0x0d, 0xbe,
+	0x8a, 0xd5, 0xfa, 0xb7, 0xc2, 0xbb, 0xc6, 0xd4, 0x07, 0x50, 0xa1, 0x74, 0x54, 0x87, 0xb3, 0xd4,
+	0xb6, 0x28, 0x06, 0xdb, 0x87, 0x75, 0xf9, 0x5b, 0x46, 0x1c, 0xcd, 0xe3, 0x48, 0x79, 0xbb, 0xfb,
+	0x6f, 0x5d, 0xbe, 0x29, 0xe5, 0x78, 0x7a, 0x10, 0xeb, 0xc1, 0x86, 0xfa, 0x45, 0x44, 0x4e, 0x52,
+	0xba, 0xe3, 0x24, 0x99, 0x51, 0xec, 0x0b, 0xd8, 0x4e, 0xbe, 0x5a, 0x4d, 0x54, 0xbe, 0xe3, 0x44,
+	0xf9, 0x81, 0xad, 0xe7, 0x50, 0x51, 0xb3, 0x62, 0x3f, 0x47, 0xd6, 0xaf, 0xba, 0x9f, 0x43, 0x54,
+	0xaa, 0xa2, 0x2e, 0xa4, 0x2b, 0xea, 0x76, 0x08, 0x6c, 0xf9, 0xff, 0x8d, 0x1b, 0x82, 0xec, 0xcd,
+	0x75, 0xfb, 0x1d, 0x6b, 0xa9, 0x7f, 0x2a, 0xc0, 0xb6, 0x7a, 0xab, 0xfe, 0xc1, 0xe3, 0x86, 0x57,
+	0x26, 0xc6, 0x6a, 0xb9, 0x89, 0x25, 0xa7, 0x90, 0x5b, 0x8d, 0xf9, 0x31, 0x6c, 0xd3, 0xee, 0x0d,
+	0xf3, 0x1d, 0xee, 0x3c, 0x8c, 0xd9, 0x96, 0xfa, 0x71, 0x64, 0x21, 0x4a, 0xad, 0x68, 0xbe, 0x84,
+	0xe7, 0x8e, 0x48, 0x65, 0xe9, 0x88, 0x60, 0xb6, 0x88, 0x1a, 0x16, 0x41, 0x98, 0x64, 0x8b, 0x8a,
+	0xce, 0x1e, 0xe9, 0xda, 0xbb, 0x1c, 0xe9, 0x8f, 0x00, 0xf4, 0x15, 0xfa, 0xfe, 0xb5, 0xba, 0x54,
+	0x4f, 0x21, 0xed, 0x53, 0xf8, 0x30, 0xa7, 0xd8, 0x0e, 0x31, 0x2d, 0x6f, 0x22, 0xbe, 0xbd, 0x8a,
+	0xdb, 0x5f, 0x40, 0x4d, 0x9f, 0xa4, 0x24, 0xcd, 0x36, 0x52, 0x69, 0xf6, 0xbb, 0x24, 0x75, 0xff,
+	0x6e, 0x40, 0x45, 0xfe, 0x8c, 0xf4, 0xff, 0x58, 0xce, 0x27, 0x6d, 0xb7, 0x52, 0xaa, 0xed, 0xb6,
+	0x38, 0x23, 0xe5, 0x4c, 0xd7, 0x69, 0x37, 0xdd, 0xd1, 0xdd, 0xd4, 0x4d, 0xdc, 0x87, 0xb0, 0x29,
+	0x8d, 0xa2, 0x93, 0xe9, 0x27, 0x65, 0xc1, 0xf6, 0xdf, 0x1b, 0x50, 0x18, 0xf4, 0x70, 0xea, 0xb9,
+	0x48, 0x7d, 0xa8, 0xa2, 0x30, 0x05, 0x3a, 0x77, 0xfd, 0xc9, 0x2b, 0x6a, 0x50, 0x25, 0xb7, 0xc2,
+	0x19, 0x8c, 0x7d, 0x02, 0xd5, 0x79, 0x7c, 0xfe, 0x0a, 0x1b, 0xc0, 0xd2, 0x5d, 0xad, 0x9b, 0x83,
+	0x9e, 0x39, 0x94, 0x10, 0xd7, 0x3c, 0xdc, 0xc3, 0xf3, 0xe4, 0x5b, 0x55, 0xc5, 0x93, 0x42, 0x5a,
+	0x3f, 0x84, 0xaa, 0x1a, 0x83, 0xb6, 0xe9, 0xd8, 0x42, 0x76, 0x40, 0x65, 0xb2, 0x98, 0xd0, 0xb8,
+	0x27, 0x6a, 0x90, 0x8a, 0xbd, 0x9a, 0x6c, 0xff, 0xb7, 0x01, 0xf5, 0x45, 0x19, 0xf9, 0x14, 0xbb,
+	0x82, 0xf2, 0xbf, 0x1d, 0xd9, 0xf0, 0x63, 0x8b, 0xbf, 0xc7, 0xcc, 0x91, 0x50, 0x7f, 0xee, 0x28,
+	0x11, 0xac, 0x02, 0x93, 0x10, 0x8e, 0xc5, 0x4a, 0xa8, 0x26, 0xcf, 0xa1, 0xed, 0x9f, 0x19, 0x78,
+	0x3d, 0x2a, 0xc7, 0xac, 0x43, 0xf5, 0x68, 0x30, 0x1a, 0x0f, 0x8e, 0x5f, 0x34, 0xd6, 0x58, 0x1d,
+	0xca, 0x27, 0xbc, 0xd7, 0xe7, 0x0d, 0x83, 0xbd, 0x0f, 0x8c, 0x1e, 0xcf, 0xba, 0x27, 0xc7, 0x07,
+	0x03, 0xfe, 0xb2, 0x43, 0xbf, 0x73, 0x14, 0xf0, 0xce, 0x4f, 0xe2, 0x07, 0xa7, 0x47, 0x07, 0x83,
+	0xa3, 0xa3, 0x97, 0xfd, 0xe3, 0x71, 0xa3, 0xc8, 0x76, 0xa1, 0xa1, 0xc5, 0x5f, 0x0e, 0x8f, 0xfa,
+	0x24, 0x5c, 0xc2, 0xc9, 0x7b, 0x83, 0xd1, 0xf0, 0x74, 0xdc, 0x6f, 0x94, 0x71, 0x46, 0x45, 0x9c,
+	0xf1, 0xfe, 0xe8, 0xe4, 0xe8, 0x94, 0x84, 0x2a, 0xd8, 0x93, 0xe3, 0x7d, 0xfa, 0xa9, 0xa4, 0xda,
+	0x16, 0xb0, 0x29, 0xd3, 0x1c, 0xfd, 0x17, 0x59, 0x1b, 0xaa, 0xaa, 0xe5, 0xa2, 0xa2, 0xf2, 0xe2,
+	0xd7, 0x47, 0xcd, 0x48, 0xce, 0x4a, 0x21, 0x75, 0x56, 0x32, 0xe9, 0x4d, 0x31, 0x9f, 0xde, 0xfc,
+	0xbc, 0x00, 0xf5, 0xe4, 0xb7, 0x35, 0xb6, 0x05, 0x85, 0xa4, 0x7c, 0x2a, 0xc8, 0xe2, 0x49, 0x5e,
+	0xbe, 0x15, 0xd2, 0x97, 0x6f, 0xd9, 0x7f, 0x3e, 0x8a, 0xab, 0xfe, 0xf9, 0xc0, 0xf6, 0x89, 0x4c,
+	0x56, 0xea, 0x5c, 0x12, 0x6f, 0xbf, 0x76, 0x5b, 0x5b, 0xbe, 0x76, 0xfb, 0x0c, 0x6a, 0xe7, 0xf1,
+	0xf5, 0x4f, 0x5e, 0x88, 0xe8, 0x6b, 0xd5, 0xa3, 0xb8, 0x67, 0x26, 0xab, 0x34, 0xf7, 0x15, 0xeb,
+	0x70, 0x8d, 0x27, 0x62, 0xec, 0x19, 0x5d, 0x09, 0x04, 0xd1, 0x1d, 0x92, 0x76, 0x29, 0xc8, 0x9e,
+	0x42, 0x51, 0x24, 0x2d, 0x89, 0x9b, 0xe4, 0x51, 0xac, 0xf5, 0x0c, 0x6a, 0xfa, 0xbd, 0xd4, 0x0d,
+	0x8f, 0xaf, 0x55, 0x47, 0x0a, 0x1f, 0x51, 0xf1, 0x17, 0x81, 0xd0, 0x57, 0xc7, 0xf4, 0x9c, 0xbe,
+	0x61, 0x6b, 0xff, 0xd4, 0x00, 0x48, 0x3e, 0x20, 0xcc, 0xa4, 0xce, 0xc6, 0xaa, 0xd4, 0xf9, 0x09,
+	0x39, 0x48, 0x25, 0xae, 0xb2, 0x0b, 0x58, 0xa8, 0x80, 0xa7, 0xb8, 0xdf, 0xbe, 0x4b, 0xd5, 0xfe,
+	0x5d, 0x68, 0xe4, 0x7f, 0x65, 0x64, 0x9f, 0x66, 0xde, 0x6c, 0x28, 0x07, 0xb0, 0x10, 0xc8, 0xbc,
+	0xfa, 0xc6, 0xd4, 0x79, 0xbf, 0xf4, 0x3b, 0x85, 0xf9, 0xf9, 0x79, 0x85, 0xd6, 0xf0, 0x2b, 0xff,
+	0x33, 0x00, 0x8e, 0x57, 0xc3, 0x09, 0x1e, 0x2c, 0x00, 0x00,
 }
@@ -18,25 +18,31 @@ var _ = math.Inf
 type Message_MessageType int32
 
 const (
-	Message_PING               Message_MessageType = 0
-	Message_CHAT               Message_MessageType = 1
-	Message_FOLLOW             Message_MessageType = 2
-	Message_UNFOLLOW           Message_MessageType = 3
-	Message_ORDER              Message_MessageType = 4
-	Message_ORDER_REJECT       Message_MessageType = 5
-	Message_ORDER_CANCEL       Message_MessageType = 6
-	Message_ORDER_CONFIRMATION Message_MessageType = 7
-	Message_ORDER_FULFILLMENT  Message_MessageType = 8
-	Message_ORDER_COMPLETION   Message_MessageType = 9
-	Message_DISPUTE_OPEN       Message_MessageType = 10
-	Message_DISPUTE_UPDATE     Message_MessageType = 11
-	Message_DISPUTE_CLOSE      Message_MessageType = 12
-	Message_REFUND             Message_MessageType = 13
-	Message_OFFLINE_ACK        Message_MessageType = 14
-	Message_OFFLINE_RELAY      Message_MessageType = 15
-	Message_MODERATOR_ADD      Message_MessageType = 16
-	Message_MODERATOR_REMOVE   Message_MessageType = 17
-	Message_ERROR              Message_MessageType = 500
+	Message_PING                Message_MessageType = 0
+	Message_CHAT                Message_MessageType = 1
+	Message_FOLLOW              Message_MessageType = 2
+	Message_UNFOLLOW            Message_MessageType = 3
+	Message_ORDER               Message_MessageType = 4
+	Message_ORDER_REJECT        Message_MessageType = 5
+	Message_ORDER_CANCEL        Message_MessageType = 6
+	Message_ORDER_CONFIRMATION  Message_MessageType = 7
+	Message_ORDER_FULFILLMENT   Message_MessageType = 8
+	Message_ORDER_COMPLETION    Message_MessageType = 9
+	Message_DISPUTE_OPEN        Message_MessageType = 10
+	Message_DISPUTE_UPDATE      Message_MessageType = 11
+	Message_DISPUTE_CLOSE       Message_MessageType = 12
+	Message_REFUND              Message_MessageType = 13
+	Message_OFFLINE_ACK         Message_MessageType = 14
+	Message_OFFLINE_RELAY       Message_MessageType = 15
+	Message_MODERATOR_ADD       Message_MessageType = 16
+	Message_MODERATOR_REMOVE    Message_MessageType = 17
+	Message_BID                 Message_MessageType = 18
+	Message_BID_ACK             Message_MessageType = 19
+	Message_DISPUTE_ENDORSEMENT Message_MessageType = 20
+	Message_DISPUTE_EVIDENCE    Message_MessageType = 21
+	Message_DISPUTE_PROPOSAL    Message_MessageType = 22
+	Message_DISPUTE_ACCEPTANCE  Message_MessageType = 23
+	Message_ERROR               Message_MessageType = 500
 )
 
 var Message_MessageType_name = map[int32]string{
@@ -58,28 +64,40 @@ var Message_MessageType_name = map[int32]string{
 	15:  "OFFLINE_RELAY",
 	16:  "MODERATOR_ADD",
 	17:  "MODERATOR_REMOVE",
+	18:  "BID",
+	19:  "BID_ACK",
+	20:  "DISPUTE_ENDORSEMENT",
+	21:  "DISPUTE_EVIDENCE",
+	22:  "DISPUTE_PROPOSAL",
+	23:  "DISPUTE_ACCEPTANCE",
 	500: "ERROR",
 }
 var Message_MessageType_value = map[string]int32{
-	"PING":               0,
-	"CHAT":               1,
-	"FOLLOW":             2,
-	"UNFOLLOW":           3,
-	"ORDER":              4,
-	"ORDER_REJECT":       5,
-	"ORDER_CANCEL":       6,
-	"ORDER_CONFIRMATION": 7,
-	"ORDER_FULFILLMENT":  8,
-	"ORDER_COMPLETION":   9,
-	"DISPUTE_OPEN":       10,
-	"DISPUTE_UPDATE":     11,
-	"DISPUTE_CLOSE":      12,
-	"REFUND":             13,
-	"OFFLINE_ACK":        14,
-	"OFFLINE_RELAY":      15,
-	"MODERATOR_ADD":      16,
-	"MODERATOR_REMOVE":   17,
-	"ERROR":              500,
+	"PING":                0,
+	"CHAT":                1,
+	"FOLLOW":              2,
+	"UNFOLLOW":            3,
+	"ORDER":               4,
+	"ORDER_REJECT":        5,
+	"ORDER_CANCEL":        6,
+	"ORDER_CONFIRMATION":  7,
+	"ORDER_FULFILLMENT":   8,
+	"ORDER_COMPLETION":    9,
+	"DISPUTE_OPEN":        10,
+	"DISPUTE_UPDATE":      11,
+	"DISPUTE_CLOSE":       12,
+	"REFUND":              13,
+	"OFFLINE_ACK":         14,
+	"OFFLINE_RELAY":       15,
+	"MODERATOR_ADD":       16,
+	"MODERATOR_REMOVE":    17,
+	"BID":                 18,
+	"BID_ACK":             19,
+	"DISPUTE_ENDORSEMENT": 20,
+	"DISPUTE_EVIDENCE":    21,
+	"DISPUTE_PROPOSAL":    22,
+	"DISPUTE_ACCEPTANCE":  23,
+	"ERROR":               500,
 }
 
 func (x Message_MessageType) String() string {
@@ -111,6 +129,33 @@ func (x Chat_Flag) String() string {
 }
 func (Chat_Flag) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{2, 0} }
 
+type BidAck_Status int32
+
+const (
+	BidAck_ACCEPTED BidAck_Status = 0
+	BidAck_REJECTED BidAck_Status = 1
+	BidAck_WON      BidAck_Status = 2
+	BidAck_LOST     BidAck_Status = 3
+)
+
+var BidAck_Status_name = map[int32]string{
+	0: "ACCEPTED",
+	1: "REJECTED",
+	2: "WON",
+	3: "LOST",
+}
+var BidAck_Status_value = map[string]int32{
+	"ACCEPTED": 0,
+	"REJECTED": 1,
+	"WON":      2,
+	"LOST":     3,
+}
+
+func (x BidAck_Status) String() string {
+	return proto.EnumName(BidAck_Status_name, int32(x))
+}
+func (BidAck_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{4, 0} }
+
 type Message struct {
 	MessageType Message_MessageType   `protobuf:"varint,1,opt,name=messageType,enum=Message_MessageType" json:"messageType,omitempty"`
 	Payload     *google_protobuf1.Any `protobuf:"bytes,2,opt,name=payload" json:"payload,omitempty"`
@@ -231,52 +276,156 @@ func (m *Chat) GetFlag() Chat_Flag {
 	return Chat_MESSAGE
 }
 
+type Bid struct {
+	ListingHash string                     `protobuf:"bytes,1,opt,name=listingHash" json:"listingHash,omitempty"`
+	Slug        string                     `protobuf:"bytes,2,opt,name=slug" json:"slug,omitempty"`
+	Amount      uint64                     `protobuf:"varint,3,opt,name=amount" json:"amount,omitempty"`
+	Timestamp   *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *Bid) Reset()                    { *m = Bid{} }
+func (m *Bid) String() string            { return proto.CompactTextString(m) }
+func (*Bid) ProtoMessage()               {}
+func (*Bid) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
+
+func (m *Bid) GetListingHash() string {
+	if m != nil {
+		return m.ListingHash
+	}
+	return ""
+}
+
+func (m *Bid) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+func (m *Bid) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Bid) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+type BidAck struct {
+	BidID   string        `protobuf:"bytes,1,opt,name=bidID" json:"bidID,omitempty"`
+	Slug    string        `protobuf:"bytes,2,opt,name=slug" json:"slug,omitempty"`
+	Status  BidAck_Status `protobuf:"varint,3,opt,name=status,enum=BidAck_Status" json:"status,omitempty"`
+	HighBid uint64        `protobuf:"varint,4,opt,name=highBid" json:"highBid,omitempty"`
+	Reason  string        `protobuf:"bytes,5,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *BidAck) Reset()                    { *m = BidAck{} }
+func (m *BidAck) String() string            { return proto.CompactTextString(m) }
+func (*BidAck) ProtoMessage()               {}
+func (*BidAck) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
+
+func (m *BidAck) GetBidID() string {
+	if m != nil {
+		return m.BidID
+	}
+	return ""
+}
+
+func (m *BidAck) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+func (m *BidAck) GetStatus() BidAck_Status {
+	if m != nil {
+		return m.Status
+	}
+	return BidAck_ACCEPTED
+}
+
+func (m *BidAck) GetHighBid() uint64 {
+	if m != nil {
+		return m.HighBid
+	}
+	return 0
+}
+
+func (m *BidAck) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Message)(nil), "Message")
 	proto.RegisterType((*Envelope)(nil), "Envelope")
 	proto.RegisterType((*Chat)(nil), "Chat")
+	proto.RegisterType((*Bid)(nil), "Bid")
+	proto.RegisterType((*BidAck)(nil), "BidAck")
 	proto.RegisterEnum("Message_MessageType", Message_MessageType_name, Message_MessageType_value)
 	proto.RegisterEnum("Chat_Flag", Chat_Flag_name, Chat_Flag_value)
+	proto.RegisterEnum("BidAck_Status", BidAck_Status_name, BidAck_Status_value)
 }
 
 func init() { proto.RegisterFile("message.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 573 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x64, 0x92, 0x4f, 0x6f, 0xd3, 0x4c,
-	0x10, 0xc6, 0xeb, 0xc4, 0xf9, 0x37, 0x4e, 0xdb, 0xed, 0xaa, 0x6f, 0x95, 0xb7, 0x42, 0x25, 0xca,
-	0x29, 0x5c, 0x5c, 0x29, 0x48, 0x88, 0xab, 0xb1, 0xd7, 0xc5, 0x60, 0x7b, 0xa3, 0x89, 0x03, 0x2a,
-	0x97, 0xc8, 0x21, 0xdb, 0x50, 0x48, 0x63, 0x53, 0x3b, 0x48, 0xf9, 0x90, 0x7c, 0x04, 0xbe, 0x05,
-	0x77, 0xd0, 0x6e, 0xbc, 0x24, 0x82, 0x93, 0x77, 0x7e, 0xcf, 0xa3, 0x99, 0xb1, 0x9e, 0x81, 0xe3,
-	0x07, 0x51, 0x14, 0xe9, 0x52, 0xd8, 0xf9, 0x63, 0x56, 0x66, 0x97, 0xff, 0x2f, 0xb3, 0x6c, 0xb9,
-	0x12, 0xd7, 0xaa, 0x9a, 0x6f, 0xee, 0xae, 0xd3, 0xf5, 0xb6, 0x92, 0x9e, 0xfe, 0x2d, 0x95, 0xf7,
-	0x0f, 0xa2, 0x28, 0xd3, 0x87, 0x7c, 0x67, 0x18, 0xfc, 0xaa, 0x43, 0x2b, 0xda, 0x75, 0xa3, 0x2f,
-	0xc0, 0xaa, 0x1a, 0x27, 0xdb, 0x5c, 0xf4, 0x8c, 0xbe, 0x31, 0x3c, 0x19, 0x9d, 0xdb, 0x95, 0xac,
-	0xbf, 0x52, 0xc3, 0x43, 0x23, 0xb5, 0xa1, 0x95, 0xa7, 0xdb, 0x55, 0x96, 0x2e, 0x7a, 0xb5, 0xbe,
-	0x31, 0xb4, 0x46, 0xe7, 0xf6, 0x6e, 0xac, 0xad, 0xc7, 0xda, 0xce, 0x7a, 0x8b, 0xda, 0x44, 0x9f,
-	0x40, 0xe7, 0x51, 0x7c, 0xdd, 0x88, 0xa2, 0x0c, 0x16, 0xbd, 0x7a, 0xdf, 0x18, 0x36, 0x70, 0x0f,
-	0xe8, 0x15, 0xc0, 0x7d, 0x81, 0xa2, 0xc8, 0xb3, 0x75, 0x21, 0x7a, 0x66, 0xdf, 0x18, 0xb6, 0xf1,
-	0x80, 0x0c, 0xbe, 0xd7, 0xc0, 0x3a, 0x58, 0x85, 0xb6, 0xc1, 0x1c, 0x07, 0xf1, 0x0d, 0x39, 0x92,
-	0x2f, 0xf7, 0xb5, 0x93, 0x10, 0x83, 0x02, 0x34, 0x7d, 0x1e, 0x86, 0xfc, 0x3d, 0xa9, 0xd1, 0x2e,
-	0xb4, 0xa7, 0x71, 0x55, 0xd5, 0x69, 0x07, 0x1a, 0x1c, 0x3d, 0x86, 0xc4, 0xa4, 0x04, 0xba, 0xea,
-	0x39, 0x43, 0xf6, 0x86, 0xb9, 0x09, 0x69, 0xec, 0x89, 0xeb, 0xc4, 0x2e, 0x0b, 0x49, 0x93, 0x5e,
-	0x00, 0xad, 0x08, 0x8f, 0xfd, 0x00, 0x23, 0x27, 0x09, 0x78, 0x4c, 0x5a, 0xf4, 0x3f, 0x38, 0xdb,
-	0x71, 0x7f, 0x1a, 0xfa, 0x41, 0x18, 0x46, 0x2c, 0x4e, 0x48, 0x9b, 0x9e, 0x03, 0xd1, 0xf6, 0x68,
-	0x1c, 0x32, 0x65, 0xee, 0xc8, 0xb6, 0x5e, 0x30, 0x19, 0x4f, 0x13, 0x36, 0xe3, 0x63, 0x16, 0x13,
-	0xa0, 0x14, 0x4e, 0x34, 0x99, 0x8e, 0x3d, 0x27, 0x61, 0xc4, 0xa2, 0x67, 0x70, 0xac, 0x99, 0x1b,
-	0xf2, 0x09, 0x23, 0x5d, 0xf9, 0x1b, 0xc8, 0xfc, 0x69, 0xec, 0x91, 0x63, 0x7a, 0x0a, 0x16, 0xf7,
-	0xfd, 0x30, 0x88, 0xd9, 0xcc, 0x71, 0xdf, 0x92, 0x13, 0xe9, 0xd7, 0x00, 0x59, 0xe8, 0xdc, 0x92,
-	0x53, 0x89, 0x22, 0xee, 0x31, 0x74, 0x12, 0x8e, 0x33, 0xc7, 0xf3, 0x08, 0x91, 0x1b, 0xed, 0x11,
-	0xb2, 0x88, 0xbf, 0x63, 0xe4, 0x8c, 0x02, 0x34, 0x18, 0x22, 0x47, 0xf2, 0xb3, 0x3e, 0x58, 0x40,
-	0x9b, 0xad, 0xbf, 0x89, 0x55, 0x96, 0x0b, 0x3a, 0x80, 0x56, 0x15, 0xac, 0x4a, 0xdf, 0x1a, 0xb5,
-	0x75, 0xea, 0xa8, 0x05, 0x7a, 0x01, 0xcd, 0x7c, 0x33, 0xff, 0x22, 0xb6, 0x2a, 0xec, 0x2e, 0x56,
-	0x95, 0x4c, 0xb5, 0xb8, 0x5f, 0xae, 0xd3, 0x72, 0xf3, 0x28, 0x54, 0xaa, 0x5d, 0xdc, 0x83, 0xc1,
-	0x0f, 0x03, 0x4c, 0xf7, 0x53, 0x5a, 0x4a, 0x5b, 0xd5, 0x29, 0x58, 0xa8, 0x21, 0x1d, 0xdc, 0x03,
-	0xda, 0x83, 0x56, 0xb1, 0x99, 0x7f, 0x16, 0x1f, 0x4b, 0xd5, 0xbd, 0x83, 0xba, 0x94, 0x8a, 0x5e,
-	0xad, 0xbe, 0x53, 0xf4, 0x42, 0x2f, 0xa1, 0xf3, 0xe7, 0xaa, 0xd5, 0xbd, 0x58, 0xa3, 0xcb, 0x7f,
-	0x0e, 0x30, 0xd1, 0x0e, 0xdc, 0x9b, 0xe9, 0x15, 0x98, 0x77, 0xab, 0x74, 0xd9, 0x6b, 0xa8, 0x4b,
-	0x07, 0x5b, 0x2e, 0x68, 0xfb, 0xab, 0x74, 0x89, 0x8a, 0x0f, 0x9e, 0x81, 0x29, 0x2b, 0x6a, 0x41,
-	0x2b, 0x62, 0x93, 0x89, 0x73, 0xc3, 0xc8, 0x91, 0x0c, 0x25, 0xb9, 0x55, 0x17, 0x67, 0xc8, 0x8b,
-	0x43, 0xe6, 0x78, 0xa4, 0xf6, 0xca, 0xfc, 0x50, 0xcb, 0xe7, 0xf3, 0xa6, 0x9a, 0xf7, 0xfc, 0x77,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xaa, 0x0a, 0x22, 0x77, 0xa1, 0x03, 0x00, 0x00,
+	// 784 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x54, 0xdd, 0x8e, 0x9b, 0x46,
+	0x14, 0x5e, 0x6c, 0xfc, 0x77, 0xf0, 0x3a, 0xb3, 0x13, 0x67, 0xe3, 0x46, 0x55, 0x6a, 0x71, 0x51,
+	0x6d, 0x6f, 0x88, 0xe4, 0x4a, 0x6d, 0x6f, 0x31, 0x0c, 0x09, 0x2d, 0x30, 0x68, 0xc0, 0x89, 0xd2,
+	0x9b, 0x15, 0x0e, 0x84, 0xa5, 0xf1, 0x02, 0x5d, 0xa0, 0x92, 0x1f, 0xa2, 0xef, 0xd4, 0x9b, 0x3e,
+	0x46, 0x5f, 0xa2, 0xea, 0x03, 0x54, 0x33, 0xc0, 0xda, 0x6a, 0x7b, 0xd5, 0xbb, 0xf9, 0xbe, 0x73,
+	0xf4, 0xcd, 0x77, 0x0e, 0xdf, 0x00, 0x97, 0xf7, 0x49, 0x55, 0x45, 0x69, 0xa2, 0x95, 0x0f, 0x45,
+	0x5d, 0xbc, 0xf8, 0x2c, 0x2d, 0x8a, 0xf4, 0x90, 0xbc, 0x12, 0x68, 0xdf, 0x7c, 0x7c, 0x15, 0xe5,
+	0xc7, 0xae, 0xf4, 0xc5, 0x3f, 0x4b, 0x75, 0x76, 0x9f, 0x54, 0x75, 0x74, 0x5f, 0xb6, 0x0d, 0xea,
+	0x9f, 0x32, 0x4c, 0xdc, 0x56, 0x0d, 0x7f, 0x03, 0x4a, 0x27, 0x1c, 0x1e, 0xcb, 0x64, 0x25, 0xad,
+	0xa5, 0x9b, 0xc5, 0x66, 0xa9, 0x75, 0x65, 0xcd, 0x3d, 0xd5, 0xd8, 0x79, 0x23, 0xd6, 0x60, 0x52,
+	0x46, 0xc7, 0x43, 0x11, 0xc5, 0xab, 0xc1, 0x5a, 0xba, 0x51, 0x36, 0x4b, 0xad, 0xbd, 0x56, 0xeb,
+	0xaf, 0xd5, 0xf4, 0xfc, 0xc8, 0xfa, 0x26, 0xfc, 0x39, 0xcc, 0x1e, 0x92, 0x9f, 0x9b, 0xa4, 0xaa,
+	0xed, 0x78, 0x35, 0x5c, 0x4b, 0x37, 0x23, 0x76, 0x22, 0xf0, 0x4b, 0x80, 0xac, 0x62, 0x49, 0x55,
+	0x16, 0x79, 0x95, 0xac, 0xe4, 0xb5, 0x74, 0x33, 0x65, 0x67, 0x8c, 0xfa, 0xdb, 0x10, 0x94, 0x33,
+	0x2b, 0x78, 0x0a, 0xb2, 0x6f, 0x7b, 0xaf, 0xd1, 0x05, 0x3f, 0x19, 0x6f, 0xf4, 0x10, 0x49, 0x18,
+	0x60, 0x6c, 0x51, 0xc7, 0xa1, 0xef, 0xd0, 0x00, 0xcf, 0x61, 0xba, 0xf3, 0x3a, 0x34, 0xc4, 0x33,
+	0x18, 0x51, 0x66, 0x12, 0x86, 0x64, 0x8c, 0x60, 0x2e, 0x8e, 0xb7, 0x8c, 0x7c, 0x4f, 0x8c, 0x10,
+	0x8d, 0x4e, 0x8c, 0xa1, 0x7b, 0x06, 0x71, 0xd0, 0x18, 0x5f, 0x03, 0xee, 0x18, 0xea, 0x59, 0x36,
+	0x73, 0xf5, 0xd0, 0xa6, 0x1e, 0x9a, 0xe0, 0x67, 0x70, 0xd5, 0xf2, 0xd6, 0xce, 0xb1, 0x6c, 0xc7,
+	0x71, 0x89, 0x17, 0xa2, 0x29, 0x5e, 0x02, 0xea, 0xdb, 0x5d, 0xdf, 0x21, 0xa2, 0x79, 0xc6, 0x65,
+	0x4d, 0x3b, 0xf0, 0x77, 0x21, 0xb9, 0xa5, 0x3e, 0xf1, 0x10, 0x60, 0x0c, 0x8b, 0x9e, 0xd9, 0xf9,
+	0xa6, 0x1e, 0x12, 0xa4, 0xe0, 0x2b, 0xb8, 0xec, 0x39, 0xc3, 0xa1, 0x01, 0x41, 0x73, 0x3e, 0x06,
+	0x23, 0xd6, 0xce, 0x33, 0xd1, 0x25, 0x7e, 0x02, 0x0a, 0xb5, 0x2c, 0xc7, 0xf6, 0xc8, 0xad, 0x6e,
+	0xfc, 0x80, 0x16, 0xbc, 0xbf, 0x27, 0x18, 0x71, 0xf4, 0xf7, 0xe8, 0x09, 0xa7, 0x5c, 0x6a, 0x12,
+	0xa6, 0x87, 0x94, 0xdd, 0xea, 0xa6, 0x89, 0x10, 0x77, 0x74, 0xa2, 0x18, 0x71, 0xe9, 0x5b, 0x82,
+	0xae, 0xf0, 0x04, 0x86, 0x5b, 0xdb, 0x44, 0x18, 0x2b, 0x30, 0xd9, 0xda, 0xa6, 0x50, 0x7c, 0x8a,
+	0x9f, 0xc3, 0xd3, 0xde, 0x01, 0xf1, 0x4c, 0xca, 0x02, 0x22, 0xc6, 0x5a, 0x72, 0x91, 0xc7, 0xc2,
+	0x5b, 0xdb, 0x24, 0x9e, 0x41, 0xd0, 0xb3, 0x73, 0xd6, 0x67, 0xd4, 0xa7, 0x81, 0xee, 0xa0, 0x6b,
+	0xbe, 0xb1, 0x9e, 0xd5, 0x0d, 0x83, 0xf8, 0x21, 0x5f, 0x25, 0x7a, 0x8e, 0x01, 0x46, 0x84, 0x31,
+	0xca, 0xd0, 0x5f, 0x43, 0x35, 0x86, 0x29, 0xc9, 0x7f, 0x49, 0x0e, 0x45, 0x99, 0x60, 0x15, 0x26,
+	0x5d, 0x96, 0x44, 0xe0, 0x94, 0xcd, 0xb4, 0x0f, 0x1a, 0xeb, 0x0b, 0xf8, 0x1a, 0xc6, 0x65, 0xb3,
+	0xff, 0x94, 0x1c, 0x45, 0xbe, 0xe6, 0xac, 0x43, 0x3c, 0x48, 0x55, 0x96, 0xe6, 0x51, 0xdd, 0x3c,
+	0x24, 0x22, 0x48, 0x73, 0x76, 0x22, 0xd4, 0x3f, 0x24, 0x90, 0x8d, 0xbb, 0xa8, 0xe6, 0x6d, 0x9d,
+	0x92, 0x1d, 0x8b, 0x4b, 0x66, 0xec, 0x44, 0xe0, 0x15, 0x4c, 0xaa, 0x66, 0xff, 0x53, 0xf2, 0xa1,
+	0x16, 0xea, 0x33, 0xd6, 0x43, 0x5e, 0xe9, 0xad, 0x0d, 0xdb, 0x4a, 0x6f, 0xe8, 0x3b, 0x98, 0x3d,
+	0x3e, 0x24, 0x11, 0x51, 0x65, 0xf3, 0xe2, 0x5f, 0x99, 0x0f, 0xfb, 0x0e, 0x76, 0x6a, 0xc6, 0x2f,
+	0x41, 0xfe, 0x78, 0x88, 0xd2, 0xd5, 0x48, 0x3c, 0x2e, 0xd0, 0xb8, 0x41, 0xcd, 0x3a, 0x44, 0x29,
+	0x13, 0xbc, 0xfa, 0x15, 0xc8, 0x1c, 0xf1, 0x0f, 0xe3, 0x92, 0x20, 0xd0, 0x5f, 0x13, 0x74, 0xc1,
+	0x73, 0x10, 0xbe, 0x17, 0x21, 0x97, 0x78, 0xc8, 0x19, 0xd1, 0x4d, 0x34, 0x50, 0x7f, 0x95, 0x60,
+	0xb8, 0xcd, 0x62, 0xbc, 0x06, 0xe5, 0x90, 0x55, 0x75, 0x96, 0xa7, 0x6f, 0xa2, 0xea, 0xae, 0x1b,
+	0xf0, 0x9c, 0xc2, 0x18, 0xe4, 0xea, 0xd0, 0xa4, 0xdd, 0x7c, 0xe2, 0xcc, 0x77, 0x1a, 0xdd, 0x17,
+	0x4d, 0x5e, 0x8b, 0xd9, 0x64, 0xd6, 0xa1, 0xff, 0x3f, 0x9a, 0xfa, 0xbb, 0x04, 0xe3, 0x6d, 0x16,
+	0xeb, 0x1f, 0x3e, 0xe1, 0x25, 0x8c, 0xf6, 0x59, 0x6c, 0x9b, 0x9d, 0x99, 0x16, 0xfc, 0xa7, 0x8d,
+	0x2f, 0x61, 0x5c, 0xd5, 0x51, 0xdd, 0x54, 0xc2, 0xc6, 0x62, 0xb3, 0xd0, 0x5a, 0x09, 0x2d, 0x10,
+	0x2c, 0xeb, 0xaa, 0xfc, 0x5b, 0xdc, 0x65, 0xe9, 0xdd, 0x36, 0x8b, 0x85, 0x29, 0x99, 0xf5, 0x90,
+	0x0f, 0xf2, 0x90, 0x44, 0x55, 0x91, 0x8b, 0x9d, 0xce, 0x58, 0x87, 0xd4, 0x6f, 0x61, 0xdc, 0x6a,
+	0xf0, 0x3f, 0x40, 0x1b, 0x45, 0x62, 0xa2, 0x0b, 0x8e, 0xda, 0x07, 0x4f, 0x4c, 0x24, 0xf1, 0x97,
+	0xf0, 0x8e, 0x7a, 0x68, 0xc0, 0xf7, 0xea, 0xd0, 0x20, 0x44, 0xc3, 0xad, 0xfc, 0xe3, 0xa0, 0xdc,
+	0xef, 0xc7, 0x62, 0xd8, 0xaf, 0xff, 0x1e, 0x00, 0x84, 0xde, 0xaf, 0xfa, 0x6c, 0x05, 0x00, 0x00,
 }
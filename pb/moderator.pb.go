@@ -7,6 +7,7 @@ package pb
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -43,6 +44,7 @@ type Moderator struct {
 	Languages          []string       `protobuf:"bytes,3,rep,name=languages" json:"languages,omitempty"`
 	AcceptedCurrency   string         `protobuf:"bytes,4,opt,name=acceptedCurrency" json:"acceptedCurrency,omitempty"`
 	Fee                *Moderator_Fee `protobuf:"bytes,5,opt,name=fee" json:"fee,omitempty"`
+	AcceptedCurrencies []string       `protobuf:"bytes,6,rep,name=acceptedCurrencies" json:"acceptedCurrencies,omitempty"`
 }
 
 func (m *Moderator) Reset()                    { *m = Moderator{} }
@@ -85,6 +87,13 @@ func (m *Moderator) GetFee() *Moderator_Fee {
 	return nil
 }
 
+func (m *Moderator) GetAcceptedCurrencies() []string {
+	if m != nil {
+		return m.AcceptedCurrencies
+	}
+	return nil
+}
+
 type Moderator_Fee struct {
 	FixedFee   *Moderator_Price      `protobuf:"bytes,1,opt,name=fixedFee" json:"fixedFee,omitempty"`
 	Percentage float32               `protobuf:"fixed32,2,opt,name=percentage" json:"percentage,omitempty"`
@@ -181,43 +190,124 @@ func (m *DisputeUpdate) GetSerializedContract() []byte {
 	return nil
 }
 
+type DisputeEvidence struct {
+	OrderId     string                     `protobuf:"bytes,1,opt,name=orderId" json:"orderId,omitempty"`
+	SubmittedBy string                     `protobuf:"bytes,2,opt,name=submittedBy" json:"submittedBy,omitempty"`
+	Name        string                     `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	MediaType   string                     `protobuf:"bytes,4,opt,name=mediaType" json:"mediaType,omitempty"`
+	Size        uint64                     `protobuf:"varint,5,opt,name=size" json:"size,omitempty"`
+	Hash        string                     `protobuf:"bytes,6,opt,name=hash" json:"hash,omitempty"`
+	Address     string                     `protobuf:"bytes,7,opt,name=address" json:"address,omitempty"`
+	Timestamp   *google_protobuf.Timestamp `protobuf:"bytes,8,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *DisputeEvidence) Reset()                    { *m = DisputeEvidence{} }
+func (m *DisputeEvidence) String() string            { return proto.CompactTextString(m) }
+func (*DisputeEvidence) ProtoMessage()               {}
+func (*DisputeEvidence) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{2} }
+
+func (m *DisputeEvidence) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *DisputeEvidence) GetSubmittedBy() string {
+	if m != nil {
+		return m.SubmittedBy
+	}
+	return ""
+}
+
+func (m *DisputeEvidence) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DisputeEvidence) GetMediaType() string {
+	if m != nil {
+		return m.MediaType
+	}
+	return ""
+}
+
+func (m *DisputeEvidence) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *DisputeEvidence) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *DisputeEvidence) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *DisputeEvidence) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Moderator)(nil), "Moderator")
 	proto.RegisterType((*Moderator_Fee)(nil), "Moderator.Fee")
 	proto.RegisterType((*Moderator_Price)(nil), "Moderator.Price")
 	proto.RegisterType((*DisputeUpdate)(nil), "DisputeUpdate")
+	proto.RegisterType((*DisputeEvidence)(nil), "DisputeEvidence")
 	proto.RegisterEnum("Moderator_Fee_FeeType", Moderator_Fee_FeeType_name, Moderator_Fee_FeeType_value)
 }
 
 func init() { proto.RegisterFile("moderator.proto", fileDescriptor4) }
 
 var fileDescriptor4 = []byte{
-	// 427 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x92, 0xef, 0x6a, 0x13, 0x41,
-	0x14, 0xc5, 0xdd, 0x6c, 0xd2, 0xb8, 0x37, 0x6d, 0x1a, 0x2e, 0x58, 0xd6, 0x20, 0xb2, 0x04, 0xc1,
-	0x20, 0xb2, 0x48, 0x7c, 0x00, 0x89, 0xdb, 0x44, 0x0a, 0xfe, 0x09, 0x63, 0x0b, 0xe2, 0x97, 0x32,
-	0x9d, 0xb9, 0x09, 0x03, 0xcd, 0xcc, 0x30, 0x3b, 0x0b, 0xc6, 0x27, 0xf2, 0x49, 0x7c, 0x05, 0x5f,
-	0x47, 0x76, 0xba, 0x69, 0x13, 0x9a, 0x0f, 0xfb, 0x61, 0x7e, 0xe7, 0x70, 0xf7, 0x5e, 0xce, 0x81,
-	0xd3, 0xb5, 0x91, 0xe4, 0xb8, 0x37, 0x2e, 0xb7, 0xce, 0x78, 0x33, 0x3c, 0x15, 0x46, 0x7b, 0xc7,
-	0x85, 0x2f, 0xef, 0xc0, 0xe8, 0x5f, 0x0c, 0xc9, 0x97, 0xad, 0x09, 0x33, 0xe8, 0x49, 0x2a, 0x85,
-	0x53, 0xd6, 0x2b, 0xa3, 0xd3, 0x28, 0x8b, 0xc6, 0x09, 0xdb, 0x45, 0x98, 0x03, 0x7a, 0x72, 0xeb,
-	0x72, 0xaa, 0x65, 0x61, 0xb4, 0x54, 0x35, 0x2c, 0xd3, 0x56, 0x30, 0x1e, 0x50, 0xf0, 0x05, 0x24,
-	0xb7, 0x5c, 0xaf, 0x2a, 0xbe, 0xa2, 0x32, 0x8d, 0xb3, 0x78, 0x9c, 0xb0, 0x07, 0x80, 0x6f, 0x60,
-	0xc0, 0x85, 0x20, 0xeb, 0x49, 0x16, 0x95, 0x73, 0xa4, 0xc5, 0x26, 0x6d, 0x87, 0x59, 0x8f, 0x38,
-	0x66, 0x10, 0x2f, 0x89, 0xd2, 0x4e, 0x16, 0x8d, 0x7b, 0x93, 0x7e, 0x7e, 0xbf, 0x74, 0x3e, 0x27,
-	0x62, 0xb5, 0x34, 0xfc, 0x1b, 0x41, 0x3c, 0x27, 0xc2, 0xb7, 0xf0, 0x74, 0xa9, 0x7e, 0x91, 0x9c,
-	0x13, 0x85, 0x13, 0x7a, 0x93, 0xc1, 0x8e, 0x7d, 0xe1, 0x94, 0x20, 0x76, 0xef, 0xc0, 0x97, 0x00,
-	0x96, 0x9c, 0x20, 0xed, 0xf9, 0x8a, 0xc2, 0x25, 0x2d, 0xb6, 0x43, 0xf0, 0x1d, 0x74, 0x97, 0x44,
-	0x97, 0x1b, 0x4b, 0x69, 0x9c, 0x45, 0xe3, 0xfe, 0xe4, 0x6c, 0xff, 0xdf, 0xf5, 0x57, 0xab, 0x6c,
-	0x6b, 0x1b, 0x7d, 0x80, 0x6e, 0xc3, 0x30, 0x81, 0xce, 0xfc, 0xe2, 0xc7, 0xec, 0x7c, 0xf0, 0x04,
-	0xfb, 0x00, 0x8b, 0x19, 0x2b, 0x66, 0x5f, 0x2f, 0xa7, 0x9f, 0x66, 0x83, 0x08, 0x9f, 0xc3, 0xb3,
-	0x20, 0x5d, 0x2f, 0x3e, 0x5f, 0x7d, 0xbf, 0xde, 0x91, 0x5a, 0xc3, 0x02, 0x3a, 0x61, 0x4b, 0x1c,
-	0xc1, 0xb1, 0x68, 0xee, 0x2f, 0x8c, 0xa4, 0x26, 0x90, 0x3d, 0x86, 0x67, 0x70, 0xc4, 0xd7, 0xa6,
-	0xd2, 0x3e, 0xec, 0xde, 0x66, 0xcd, 0x6b, 0xf4, 0x27, 0x82, 0x93, 0x73, 0x55, 0xda, 0xca, 0xd3,
-	0x95, 0x95, 0xdc, 0x13, 0xa6, 0xd0, 0x35, 0x4e, 0x92, 0xbb, 0x90, 0xcd, 0xa0, 0xed, 0x13, 0x5f,
-	0xc1, 0x89, 0xe5, 0x1b, 0x53, 0xf9, 0xa9, 0x94, 0x8e, 0xca, 0x6d, 0xa0, 0xfb, 0x10, 0x5f, 0x43,
-	0x62, 0x2a, 0x6f, 0x8d, 0xd2, 0xfe, 0x2e, 0xcb, 0xde, 0x24, 0xc9, 0xbf, 0x35, 0x84, 0x3d, 0x68,
-	0x75, 0x49, 0x4a, 0x72, 0x8a, 0xdf, 0xaa, 0xdf, 0x54, 0x97, 0x21, 0x34, 0x2e, 0x04, 0x7b, 0xcc,
-	0x0e, 0x28, 0x1f, 0xdb, 0x3f, 0x5b, 0xf6, 0xe6, 0xe6, 0x28, 0x34, 0xf2, 0xfd, 0xff, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xb9, 0x9a, 0xb2, 0x62, 0xb5, 0x02, 0x00, 0x00,
+	// 558 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x7c, 0x53, 0x5d, 0x6f, 0xd3, 0x30,
+	0x14, 0x25, 0x6d, 0xd6, 0x2e, 0xb7, 0xfb, 0xa8, 0x2c, 0x31, 0x85, 0x0a, 0x41, 0x54, 0x21, 0x51,
+	0x21, 0x94, 0xa1, 0xf2, 0xc2, 0x1b, 0xda, 0xb2, 0x16, 0x4d, 0xe2, 0x63, 0x32, 0x9b, 0x84, 0x78,
+	0x99, 0xdc, 0xf8, 0xb6, 0xb3, 0xb4, 0xc4, 0x91, 0xed, 0x20, 0xba, 0x3f, 0xc0, 0x5f, 0xe1, 0x97,
+	0xf0, 0xa7, 0x78, 0x41, 0x71, 0x9c, 0xb5, 0x85, 0x8a, 0x37, 0xdf, 0x73, 0x4e, 0x6e, 0x8e, 0x7d,
+	0xcf, 0x85, 0xc3, 0x4c, 0x72, 0x54, 0xcc, 0x48, 0x15, 0x17, 0x4a, 0x1a, 0x39, 0x38, 0x4c, 0x65,
+	0x6e, 0x14, 0x4b, 0x8d, 0x76, 0xc0, 0xd3, 0x85, 0x94, 0x8b, 0x5b, 0x3c, 0xb6, 0xd5, 0xac, 0x9c,
+	0x1f, 0x1b, 0x91, 0xa1, 0x36, 0x2c, 0x2b, 0x6a, 0xc1, 0xf0, 0x87, 0x0f, 0xc1, 0x87, 0xa6, 0x0b,
+	0x89, 0xa0, 0xc7, 0x51, 0xa7, 0x4a, 0x14, 0x46, 0xc8, 0x3c, 0xf4, 0x22, 0x6f, 0x14, 0xd0, 0x75,
+	0x88, 0xc4, 0x40, 0x0c, 0xaa, 0x4c, 0x9f, 0xe4, 0x3c, 0x91, 0x39, 0x17, 0x15, 0xa8, 0xc3, 0x96,
+	0x15, 0x6e, 0x61, 0xc8, 0x63, 0x08, 0x6e, 0x59, 0xbe, 0x28, 0xd9, 0x02, 0x75, 0xd8, 0x8e, 0xda,
+	0xa3, 0x80, 0xae, 0x00, 0xf2, 0x02, 0xfa, 0x2c, 0x4d, 0xb1, 0x30, 0xc8, 0x93, 0x52, 0x29, 0xcc,
+	0xd3, 0x65, 0xe8, 0xdb, 0x5e, 0xff, 0xe0, 0x24, 0x82, 0xf6, 0x1c, 0x31, 0xdc, 0x89, 0xbc, 0x51,
+	0x6f, 0x7c, 0x10, 0xdf, 0x9b, 0x8e, 0xa7, 0x88, 0xb4, 0xa2, 0x2a, 0x6f, 0x7f, 0x7d, 0x25, 0x50,
+	0x87, 0x1d, 0xfb, 0xd3, 0x2d, 0xcc, 0xe0, 0x97, 0x07, 0xed, 0x29, 0x22, 0x79, 0x09, 0xbb, 0x73,
+	0xf1, 0x1d, 0xf9, 0x14, 0xd1, 0x5e, 0xb9, 0x37, 0xee, 0xaf, 0xb5, 0xbf, 0x50, 0x22, 0x45, 0x7a,
+	0xaf, 0x20, 0x4f, 0x00, 0x0a, 0x54, 0x29, 0xe6, 0x86, 0x2d, 0xd0, 0xde, 0xbc, 0x45, 0xd7, 0x10,
+	0xf2, 0x0a, 0xba, 0x73, 0xc4, 0xcb, 0x65, 0x81, 0x61, 0x3b, 0xf2, 0x46, 0x07, 0xe3, 0xa3, 0x4d,
+	0xaf, 0xf1, 0xb4, 0x66, 0x69, 0x23, 0x1b, 0xbe, 0x85, 0xae, 0xc3, 0x48, 0x00, 0x3b, 0xd3, 0xf3,
+	0x2f, 0x93, 0xb3, 0xfe, 0x03, 0x72, 0x00, 0x70, 0x31, 0xa1, 0xc9, 0xe4, 0xe3, 0xe5, 0xc9, 0xbb,
+	0x49, 0xdf, 0x23, 0x8f, 0xe0, 0xa1, 0xa5, 0xae, 0x2f, 0xde, 0x5f, 0x7d, 0xbe, 0x5e, 0xa3, 0x5a,
+	0x83, 0x04, 0x76, 0xac, 0x4b, 0x32, 0x84, 0xbd, 0xd4, 0xbd, 0x57, 0x22, 0x39, 0xba, 0x01, 0x6e,
+	0x60, 0xe4, 0x08, 0x3a, 0x2c, 0x93, 0x65, 0x6e, 0xac, 0x77, 0x9f, 0xba, 0x6a, 0xf8, 0xd3, 0x83,
+	0xfd, 0x33, 0xa1, 0x8b, 0xd2, 0xe0, 0x55, 0xc1, 0x99, 0x41, 0x12, 0x42, 0x57, 0x2a, 0x8e, 0xea,
+	0x9c, 0xbb, 0x46, 0x4d, 0x49, 0x9e, 0xc1, 0x7e, 0xc1, 0x96, 0xb2, 0x34, 0x27, 0x9c, 0x2b, 0xd4,
+	0x4d, 0x00, 0x36, 0x41, 0xf2, 0x1c, 0x02, 0x59, 0x9a, 0x42, 0x8a, 0xdc, 0xd4, 0xb3, 0xef, 0x8d,
+	0x83, 0xf8, 0x93, 0x43, 0xe8, 0x8a, 0xab, 0x06, 0xa7, 0x51, 0x09, 0x76, 0x2b, 0xee, 0x90, 0x27,
+	0x2e, 0xc2, 0x36, 0x08, 0x7b, 0x74, 0x0b, 0x33, 0xfc, 0xed, 0xc1, 0xa1, 0xb3, 0x3a, 0xf9, 0x26,
+	0x38, 0xe6, 0xe9, 0xff, 0xcc, 0x46, 0xd0, 0xd3, 0xe5, 0x2c, 0x13, 0xc6, 0x20, 0x3f, 0x5d, 0x3a,
+	0xab, 0xeb, 0x10, 0x21, 0xe0, 0xe7, 0x2c, 0xab, 0xe7, 0x15, 0x50, 0x7b, 0xae, 0x82, 0x9b, 0x21,
+	0x17, 0xcc, 0x0e, 0xb2, 0xce, 0xe4, 0x0a, 0xa8, 0xbe, 0xd0, 0xe2, 0xae, 0x4e, 0xa3, 0x4f, 0xed,
+	0xb9, 0xc2, 0x6e, 0x98, 0xbe, 0x09, 0x3b, 0x75, 0x97, 0xea, 0x5c, 0xb9, 0x62, 0xee, 0x89, 0xba,
+	0xb5, 0x2b, 0x57, 0x92, 0x37, 0x10, 0xdc, 0xef, 0x62, 0xb8, 0x6b, 0x53, 0x37, 0x88, 0xeb, 0x6d,
+	0x8d, 0x9b, 0x6d, 0x8d, 0x2f, 0x1b, 0x05, 0x5d, 0x89, 0x4f, 0xfd, 0xaf, 0xad, 0x62, 0x36, 0xeb,
+	0x58, 0xd1, 0xeb, 0x3f, 0x03, 0x00, 0x57, 0xc2, 0xa9, 0x04, 0x04, 0x04, 0x00, 0x00,
 }
@@ -40,6 +40,12 @@ const (
 	OrderState_CANCELED OrderState = 9
 	// Vendor declined to confirm the order (offline order only)
 	OrderState_REJECTED OrderState = 10
+	// Vendor claimed the escrowed funds after the buyer failed to complete the order
+	// before the escrow timeout
+	OrderState_PAYMENT_FINALIZED OrderState = 11
+	// Vendor refunded part of the order. The rest of the payment is final and for moderated
+	// orders was released to the vendor in the same transaction as the refund.
+	OrderState_PARTIALLY_REFUNDED OrderState = 12
 )
 
 var OrderState_name = map[int32]string{
@@ -54,19 +60,23 @@ var OrderState_name = map[int32]string{
 	8:  "REFUNDED",
 	9:  "CANCELED",
 	10: "REJECTED",
+	11: "PAYMENT_FINALIZED",
+	12: "PARTIALLY_REFUNDED",
 }
 var OrderState_value = map[string]int32{
-	"PENDING":   0,
-	"CONFIRMED": 1,
-	"FUNDED":    2,
-	"FULFILLED": 3,
-	"COMPLETE":  4,
-	"DISPUTED":  5,
-	"DECIDED":   6,
-	"RESOLVED":  7,
-	"REFUNDED":  8,
-	"CANCELED":  9,
-	"REJECTED":  10,
+	"PENDING":            0,
+	"CONFIRMED":          1,
+	"FUNDED":             2,
+	"FULFILLED":          3,
+	"COMPLETE":           4,
+	"DISPUTED":           5,
+	"DECIDED":            6,
+	"RESOLVED":           7,
+	"REFUNDED":           8,
+	"CANCELED":           9,
+	"REJECTED":           10,
+	"PAYMENT_FINALIZED":  11,
+	"PARTIALLY_REFUNDED": 12,
 }
 
 func (x OrderState) String() string {
@@ -81,16 +91,18 @@ func init() {
 func init() { proto.RegisterFile("orders.proto", fileDescriptor5) }
 
 var fileDescriptor5 = []byte{
-	// 175 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x2c, 0xce, 0x4b, 0x0e, 0x82, 0x30,
-	0x10, 0xc6, 0x71, 0x41, 0xe4, 0x31, 0x62, 0xd2, 0xf4, 0x18, 0x2e, 0xdc, 0x78, 0x02, 0xed, 0x4c,
-	0x4d, 0x4d, 0x69, 0x09, 0x0f, 0x17, 0xee, 0x24, 0xb2, 0x86, 0x20, 0x47, 0xf2, 0xa0, 0x66, 0xc0,
-	0xe5, 0xd7, 0x7f, 0xfa, 0xcb, 0x40, 0x3e, 0x4c, 0xef, 0x7e, 0xfa, 0x9c, 0xc6, 0x69, 0x98, 0x87,
-	0xe3, 0x37, 0x00, 0xf0, 0xfc, 0x50, 0xcf, 0xaf, 0xb9, 0x97, 0x7b, 0x48, 0x4a, 0x72, 0x68, 0xdc,
-	0x4d, 0x6c, 0xe4, 0x01, 0x32, 0xe5, 0x9d, 0x36, 0x55, 0x41, 0x28, 0x02, 0x09, 0x10, 0xeb, 0xd6,
-	0x21, 0xa1, 0x08, 0x39, 0xe9, 0xd6, 0x6a, 0x63, 0x2d, 0xa1, 0xd8, 0xca, 0x1c, 0x52, 0xe5, 0x8b,
-	0xd2, 0x52, 0x43, 0x22, 0xe2, 0x85, 0xa6, 0x2e, 0xdb, 0x86, 0x50, 0xec, 0x98, 0x44, 0x52, 0x86,
-	0xff, 0xc5, 0x9c, 0x2a, 0xaa, 0xbd, 0x7d, 0x10, 0x8a, 0x64, 0x5d, 0x7f, 0x33, 0x5d, 0x90, 0x8b,
-	0x53, 0xc4, 0x64, 0xb6, 0xb6, 0x3b, 0x29, 0x46, 0xe0, 0x1a, 0x3d, 0xc3, 0xb1, 0xeb, 0xe2, 0xe5,
-	0xe6, 0xf3, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x2b, 0xe9, 0xf9, 0x21, 0xc3, 0x00, 0x00, 0x00,
+	// 208 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x3c, 0xcf, 0xcf, 0x4a, 0xc3, 0x40,
+	0x10, 0xc7, 0x71, 0x5b, 0x6b, 0xda, 0x4e, 0x23, 0x8c, 0x0b, 0xfa, 0x10, 0x1e, 0xbc, 0xf8, 0x04,
+	0xeb, 0xce, 0xac, 0x8c, 0x4c, 0x36, 0x4b, 0xfe, 0x08, 0xed, 0xa5, 0x58, 0xec, 0x39, 0x25, 0xe6,
+	0x4d, 0x7d, 0x21, 0x99, 0x56, 0x7a, 0xfc, 0xed, 0x97, 0xfd, 0xc0, 0x40, 0x39, 0x8c, 0xdf, 0xc7,
+	0xf1, 0xe7, 0xe5, 0x34, 0x0e, 0xd3, 0xf0, 0xfc, 0x3b, 0x03, 0xa8, 0xed, 0xa1, 0x9d, 0xbe, 0xa6,
+	0xa3, 0xdb, 0xc0, 0x32, 0x73, 0x22, 0x49, 0xef, 0x78, 0xe3, 0xee, 0x61, 0x1d, 0xea, 0x14, 0xa5,
+	0xa9, 0x98, 0x70, 0xe6, 0x00, 0x8a, 0xd8, 0x27, 0x62, 0xc2, 0xb9, 0xa5, 0xd8, 0x6b, 0x14, 0x55,
+	0x26, 0xbc, 0x75, 0x25, 0xac, 0x42, 0x5d, 0x65, 0xe5, 0x8e, 0x71, 0x61, 0x8b, 0xa4, 0xcd, 0x7d,
+	0xc7, 0x84, 0x77, 0x46, 0x12, 0x07, 0xb1, 0x7f, 0x85, 0xa5, 0x86, 0xdb, 0x5a, 0x3f, 0x99, 0x70,
+	0x79, 0x59, 0xff, 0xe6, 0xea, 0x8c, 0xf8, 0x14, 0xd8, 0xc8, 0xf5, 0xa5, 0x7d, 0x70, 0x30, 0x04,
+	0xdc, 0x23, 0x3c, 0x64, 0xbf, 0xad, 0x38, 0x75, 0xfb, 0x28, 0xc9, 0xab, 0xec, 0x98, 0x70, 0xe3,
+	0x9e, 0xc0, 0x65, 0xdf, 0x74, 0xe2, 0x55, 0xb7, 0xfb, 0x2b, 0x55, 0xbe, 0x2d, 0x76, 0xf3, 0xd3,
+	0xe1, 0x50, 0x9c, 0x4f, 0x7c, 0xfd, 0x1b, 0x00, 0x96, 0xfc, 0x0c, 0xf0, 0xf2, 0x00, 0x00, 0x00,
 }
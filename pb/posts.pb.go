@@ -0,0 +1,187 @@
+// Code generated by protoc-gen-go.
+// source: posts.proto
+// DO NOT EDIT!
+
+package pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type Post_PostType int32
+
+const (
+	Post_ANNOUNCEMENT Post_PostType = 0
+	Post_RESTOCK      Post_PostType = 1
+	Post_NEW_LISTING  Post_PostType = 2
+)
+
+var Post_PostType_name = map[int32]string{
+	0: "ANNOUNCEMENT",
+	1: "RESTOCK",
+	2: "NEW_LISTING",
+}
+var Post_PostType_value = map[string]int32{
+	"ANNOUNCEMENT": 0,
+	"RESTOCK":      1,
+	"NEW_LISTING":  2,
+}
+
+func (x Post_PostType) String() string {
+	return proto.EnumName(Post_PostType_name, int32(x))
+}
+func (Post_PostType) EnumDescriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 0} }
+
+type Post struct {
+	Slug         string                     `protobuf:"bytes,1,opt,name=slug" json:"slug,omitempty"`
+	VendorID     *ID                        `protobuf:"bytes,2,opt,name=vendorID" json:"vendorID,omitempty"`
+	PostType     Post_PostType              `protobuf:"varint,3,opt,name=postType,enum=Post_PostType" json:"postType,omitempty"`
+	Title        string                     `protobuf:"bytes,4,opt,name=title" json:"title,omitempty"`
+	LongForm     string                     `protobuf:"bytes,5,opt,name=longForm" json:"longForm,omitempty"`
+	Images       []*Listing_Item_Image      `protobuf:"bytes,6,rep,name=images" json:"images,omitempty"`
+	Channels     []string                   `protobuf:"bytes,7,rep,name=channels" json:"channels,omitempty"`
+	ListingSlugs []string                   `protobuf:"bytes,8,rep,name=listingSlugs" json:"listingSlugs,omitempty"`
+	Timestamp    *google_protobuf.Timestamp `protobuf:"bytes,9,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *Post) Reset()                    { *m = Post{} }
+func (m *Post) String() string            { return proto.CompactTextString(m) }
+func (*Post) ProtoMessage()               {}
+func (*Post) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0} }
+
+func (m *Post) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+func (m *Post) GetVendorID() *ID {
+	if m != nil {
+		return m.VendorID
+	}
+	return nil
+}
+
+func (m *Post) GetPostType() Post_PostType {
+	if m != nil {
+		return m.PostType
+	}
+	return Post_ANNOUNCEMENT
+}
+
+func (m *Post) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Post) GetLongForm() string {
+	if m != nil {
+		return m.LongForm
+	}
+	return ""
+}
+
+func (m *Post) GetImages() []*Listing_Item_Image {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
+
+func (m *Post) GetChannels() []string {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *Post) GetListingSlugs() []string {
+	if m != nil {
+		return m.ListingSlugs
+	}
+	return nil
+}
+
+func (m *Post) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+type SignedPost struct {
+	Post      *Post  `protobuf:"bytes,1,opt,name=post" json:"post,omitempty"`
+	Hash      string `protobuf:"bytes,2,opt,name=hash" json:"hash,omitempty"`
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignedPost) Reset()                    { *m = SignedPost{} }
+func (m *SignedPost) String() string            { return proto.CompactTextString(m) }
+func (*SignedPost) ProtoMessage()               {}
+func (*SignedPost) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{1} }
+
+func (m *SignedPost) GetPost() *Post {
+	if m != nil {
+		return m.Post
+	}
+	return nil
+}
+
+func (m *SignedPost) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *SignedPost) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Post)(nil), "Post")
+	proto.RegisterType((*SignedPost)(nil), "SignedPost")
+	proto.RegisterEnum("Post_PostType", Post_PostType_name, Post_PostType_value)
+}
+
+func init() { proto.RegisterFile("posts.proto", fileDescriptor6) }
+
+var fileDescriptor6 = []byte{
+	// 377 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x54, 0x51, 0xcf, 0x6f, 0x94, 0x40,
+	0x14, 0x96, 0x85, 0xdd, 0xc2, 0x63, 0xd3, 0x92, 0xd1, 0xc3, 0xb8, 0x31, 0x29, 0xe1, 0x44, 0x34,
+	0x99, 0x26, 0x78, 0x31, 0xde, 0xb4, 0x45, 0x43, 0xac, 0xb4, 0x19, 0x30, 0x46, 0x2f, 0x86, 0xdd,
+	0x8e, 0xb3, 0x24, 0x30, 0x43, 0x98, 0xc1, 0xc4, 0xff, 0xdd, 0x83, 0x61, 0x58, 0x68, 0x7a, 0x9b,
+	0xef, 0x07, 0xef, 0xf1, 0xbe, 0x0f, 0xfc, 0x4e, 0x2a, 0xad, 0x48, 0xd7, 0x4b, 0x2d, 0x77, 0x17,
+	0x07, 0x29, 0x74, 0x5f, 0x1d, 0x16, 0xe2, 0x92, 0x4b, 0xc9, 0x1b, 0x76, 0x65, 0xd0, 0x7e, 0xf8,
+	0x7d, 0xa5, 0xeb, 0x96, 0x29, 0x5d, 0xb5, 0xdd, 0x64, 0x88, 0xfe, 0xad, 0xc0, 0xb9, 0x97, 0x4a,
+	0x23, 0x04, 0x8e, 0x6a, 0x06, 0x8e, 0xad, 0xd0, 0x8a, 0x3d, 0x6a, 0xde, 0xe8, 0x12, 0xdc, 0x3f,
+	0x4c, 0x3c, 0xc8, 0x3e, 0xbb, 0xc1, 0xab, 0xd0, 0x8a, 0xfd, 0xc4, 0x26, 0xd9, 0x0d, 0x5d, 0x48,
+	0xf4, 0x1a, 0xdc, 0x71, 0x7d, 0xf9, 0xb7, 0x63, 0xd8, 0x0e, 0xad, 0xf8, 0x3c, 0x39, 0x27, 0xe3,
+	0x34, 0x72, 0x7f, 0x62, 0xe9, 0xa2, 0xa3, 0x17, 0xb0, 0xd6, 0xb5, 0x6e, 0x18, 0x76, 0xcc, 0x86,
+	0x09, 0xa0, 0x1d, 0xb8, 0x8d, 0x14, 0xfc, 0x93, 0xec, 0x5b, 0xbc, 0x36, 0xc2, 0x82, 0xd1, 0x1b,
+	0xd8, 0xd4, 0x6d, 0xc5, 0x99, 0xc2, 0x9b, 0xd0, 0x8e, 0xfd, 0xe4, 0x39, 0xb9, 0xad, 0x95, 0xae,
+	0x05, 0x27, 0x99, 0x66, 0x2d, 0xc9, 0x46, 0x8d, 0x9e, 0x2c, 0xe3, 0xa0, 0xc3, 0xb1, 0x12, 0x82,
+	0x35, 0x0a, 0x9f, 0x85, 0xf6, 0x38, 0x68, 0xc6, 0x28, 0x82, 0x6d, 0x33, 0x7d, 0x59, 0x34, 0x03,
+	0x57, 0xd8, 0x35, 0xfa, 0x13, 0x0e, 0xbd, 0x03, 0x6f, 0xc9, 0x06, 0x7b, 0xe6, 0xd8, 0x1d, 0x99,
+	0xd2, 0x23, 0x73, 0x7a, 0xa4, 0x9c, 0x1d, 0xf4, 0xd1, 0x1c, 0xbd, 0x07, 0x77, 0x3e, 0x17, 0x05,
+	0xb0, 0xfd, 0x90, 0xe7, 0x77, 0xdf, 0xf2, 0xeb, 0xf4, 0x6b, 0x9a, 0x97, 0xc1, 0x33, 0xe4, 0xc3,
+	0x19, 0x4d, 0x8b, 0xf2, 0xee, 0xfa, 0x4b, 0x60, 0xa1, 0x0b, 0xf0, 0xf3, 0xf4, 0xfb, 0xaf, 0xdb,
+	0xac, 0x28, 0xb3, 0xfc, 0x73, 0xb0, 0x8a, 0x7e, 0x00, 0x14, 0x35, 0x17, 0xec, 0xc1, 0x74, 0xf0,
+	0x12, 0x9c, 0x31, 0x2e, 0xd3, 0x81, 0x9f, 0xac, 0x4d, 0x8a, 0xd4, 0xe9, 0x4e, 0xf5, 0x1c, 0x2b,
+	0x75, 0x34, 0x35, 0x78, 0xd4, 0xbc, 0xd1, 0x2b, 0xf0, 0x54, 0xcd, 0x45, 0xa5, 0x87, 0x7e, 0x8a,
+	0x7f, 0x4b, 0x1f, 0x89, 0x8f, 0xce, 0xcf, 0x55, 0xb7, 0xdf, 0x6f, 0xcc, 0xbf, 0xbf, 0xfd, 0x3f,
+	0x00, 0xbc, 0xf8, 0x00, 0x7b, 0x27, 0x02, 0x00, 0x00,
+}
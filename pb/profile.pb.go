@@ -37,7 +37,7 @@ type Profile struct {
 func (m *Profile) Reset()                    { *m = Profile{} }
 func (m *Profile) String() string            { return proto.CompactTextString(m) }
 func (*Profile) ProtoMessage()               {}
-func (*Profile) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0} }
+func (*Profile) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0} }
 
 func (m *Profile) GetPeerID() string {
 	if m != nil {
@@ -168,7 +168,7 @@ type Profile_Contact struct {
 func (m *Profile_Contact) Reset()                    { *m = Profile_Contact{} }
 func (m *Profile_Contact) String() string            { return proto.CompactTextString(m) }
 func (*Profile_Contact) ProtoMessage()               {}
-func (*Profile_Contact) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 0} }
+func (*Profile_Contact) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0, 0} }
 
 func (m *Profile_Contact) GetWebsite() string {
 	if m != nil {
@@ -207,7 +207,7 @@ type Profile_SocialAccount struct {
 func (m *Profile_SocialAccount) Reset()                    { *m = Profile_SocialAccount{} }
 func (m *Profile_SocialAccount) String() string            { return proto.CompactTextString(m) }
 func (*Profile_SocialAccount) ProtoMessage()               {}
-func (*Profile_SocialAccount) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 1} }
+func (*Profile_SocialAccount) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0, 1} }
 
 func (m *Profile_SocialAccount) GetType() string {
 	if m != nil {
@@ -241,7 +241,7 @@ type Profile_Image struct {
 func (m *Profile_Image) Reset()                    { *m = Profile_Image{} }
 func (m *Profile_Image) String() string            { return proto.CompactTextString(m) }
 func (*Profile_Image) ProtoMessage()               {}
-func (*Profile_Image) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 2} }
+func (*Profile_Image) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0, 2} }
 
 func (m *Profile_Image) GetTiny() string {
 	if m != nil {
@@ -289,7 +289,7 @@ type Profile_Colors struct {
 func (m *Profile_Colors) Reset()                    { *m = Profile_Colors{} }
 func (m *Profile_Colors) String() string            { return proto.CompactTextString(m) }
 func (*Profile_Colors) ProtoMessage()               {}
-func (*Profile_Colors) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 3} }
+func (*Profile_Colors) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0, 3} }
 
 func (m *Profile_Colors) GetPrimary() string {
 	if m != nil {
@@ -327,17 +327,18 @@ func (m *Profile_Colors) GetHighlightText() string {
 }
 
 type Profile_Stats struct {
-	FollowerCount  uint32  `protobuf:"varint,1,opt,name=followerCount" json:"followerCount,omitempty"`
-	FollowingCount uint32  `protobuf:"varint,2,opt,name=followingCount" json:"followingCount,omitempty"`
-	ListingCount   uint32  `protobuf:"varint,3,opt,name=listingCount" json:"listingCount,omitempty"`
-	RatingCount    uint32  `protobuf:"varint,4,opt,name=ratingCount" json:"ratingCount,omitempty"`
-	AverageRating  float32 `protobuf:"fixed32,5,opt,name=averageRating" json:"averageRating,omitempty"`
+	FollowerCount    uint32  `protobuf:"varint,1,opt,name=followerCount" json:"followerCount,omitempty"`
+	FollowingCount   uint32  `protobuf:"varint,2,opt,name=followingCount" json:"followingCount,omitempty"`
+	ListingCount     uint32  `protobuf:"varint,3,opt,name=listingCount" json:"listingCount,omitempty"`
+	RatingCount      uint32  `protobuf:"varint,4,opt,name=ratingCount" json:"ratingCount,omitempty"`
+	AverageRating    float32 `protobuf:"fixed32,5,opt,name=averageRating" json:"averageRating,omitempty"`
+	DisputesResolved uint32  `protobuf:"varint,6,opt,name=disputesResolved" json:"disputesResolved,omitempty"`
 }
 
 func (m *Profile_Stats) Reset()                    { *m = Profile_Stats{} }
 func (m *Profile_Stats) String() string            { return proto.CompactTextString(m) }
 func (*Profile_Stats) ProtoMessage()               {}
-func (*Profile_Stats) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 4} }
+func (*Profile_Stats) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{0, 4} }
 
 func (m *Profile_Stats) GetFollowerCount() uint32 {
 	if m != nil {
@@ -374,6 +375,13 @@ func (m *Profile_Stats) GetAverageRating() float32 {
 	return 0
 }
 
+func (m *Profile_Stats) GetDisputesResolved() uint32 {
+	if m != nil {
+		return m.DisputesResolved
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Profile)(nil), "Profile")
 	proto.RegisterType((*Profile_Contact)(nil), "Profile.Contact")
@@ -383,51 +391,52 @@ func init() {
 	proto.RegisterType((*Profile_Stats)(nil), "Profile.Stats")
 }
 
-func init() { proto.RegisterFile("profile.proto", fileDescriptor6) }
-
-var fileDescriptor6 = []byte{
-	// 675 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x93, 0xcf, 0x6e, 0xd4, 0x3a,
-	0x14, 0xc6, 0x35, 0xd3, 0xf9, 0xd3, 0x7a, 0x66, 0xda, 0x5e, 0xeb, 0xaa, 0xb2, 0xa2, 0x2b, 0xdd,
-	0x51, 0x55, 0xc1, 0x88, 0x45, 0x8a, 0x86, 0x3d, 0x12, 0xb4, 0x0b, 0xba, 0x28, 0xaa, 0xd2, 0xb2,
-	0x61, 0xe7, 0x24, 0x9e, 0xc4, 0xc2, 0xb1, 0x23, 0xdb, 0x69, 0x19, 0xf1, 0x08, 0xbc, 0x00, 0xaf,
-	0xc2, 0x92, 0x37, 0x43, 0x3e, 0x76, 0x32, 0x93, 0xc2, 0xce, 0xdf, 0xef, 0x7c, 0xc7, 0x39, 0x76,
-	0x3e, 0xa3, 0x45, 0xad, 0xd5, 0x86, 0x0b, 0x16, 0xd7, 0x5a, 0x59, 0x15, 0xfd, 0x5f, 0x28, 0x55,
-	0x08, 0x76, 0x09, 0x2a, 0x6d, 0x36, 0x97, 0x96, 0x57, 0xcc, 0x58, 0x5a, 0xd5, 0xc1, 0x70, 0x52,
-	0xa9, 0x9c, 0x69, 0x6a, 0x95, 0xf6, 0xe0, 0xfc, 0x27, 0x42, 0xd3, 0x3b, 0xbf, 0x07, 0x3e, 0x43,
-	0x93, 0x9a, 0x31, 0x7d, 0x73, 0x4d, 0x06, 0xcb, 0xc1, 0xea, 0x28, 0x09, 0xca, 0xf1, 0x92, 0xca,
-	0x5c, 0x30, 0x32, 0xf4, 0xdc, 0x2b, 0x8c, 0xd1, 0x48, 0xd2, 0x8a, 0x91, 0x03, 0xa0, 0xb0, 0xc6,
-	0x11, 0x3a, 0x14, 0x2a, 0xa3, 0x96, 0x2b, 0x49, 0x46, 0xc0, 0x3b, 0x8d, 0xff, 0x45, 0x63, 0x9a,
-	0xaa, 0xc6, 0x92, 0x31, 0x14, 0xbc, 0xc0, 0xaf, 0xd0, 0xa9, 0x29, 0x95, 0xb6, 0xd7, 0xcc, 0x64,
-	0x9a, 0xd7, 0xd0, 0x39, 0x01, 0xc3, 0x1f, 0x1c, 0xbe, 0x68, 0x36, 0x4f, 0x64, 0xba, 0x1c, 0xac,
-	0x0e, 0x13, 0x58, 0xbb, 0xe9, 0x1e, 0x99, 0xcc, 0x95, 0x26, 0x87, 0x40, 0x83, 0xc2, 0xff, 0xa1,
-	0xa3, 0xee, 0xb0, 0xe4, 0x08, 0x4a, 0x3b, 0x80, 0x5f, 0xa3, 0x45, 0x27, 0x6e, 0xe4, 0x46, 0x11,
-	0xb4, 0x1c, 0xac, 0x66, 0x6b, 0x14, 0xdf, 0xb6, 0x34, 0xe9, 0x1b, 0xf0, 0x1a, 0xcd, 0x32, 0x25,
-	0x2d, 0xcd, 0x2c, 0xf8, 0x67, 0xe0, 0x3f, 0x8d, 0xc3, 0xe5, 0xc5, 0x57, 0xbe, 0x96, 0xec, 0x9b,
-	0xf0, 0x4b, 0x34, 0xc9, 0x94, 0x50, 0xda, 0x90, 0x39, 0xd8, 0x4f, 0xf6, 0xec, 0x0e, 0x27, 0xa1,
-	0x8c, 0xd7, 0x68, 0x4e, 0x1f, 0xa9, 0xa5, 0xfa, 0x03, 0x35, 0x25, 0x33, 0x64, 0x01, 0xf6, 0xe3,
-	0xce, 0x7e, 0x53, 0xd1, 0x82, 0x25, 0x3d, 0x8f, 0xeb, 0x29, 0x19, 0xcd, 0x59, 0xdb, 0x73, 0xfc,
-	0xf7, 0x9e, 0x7d, 0x0f, 0xbe, 0x40, 0x63, 0x63, 0xa9, 0x35, 0xe4, 0xe4, 0x99, 0xf9, 0xde, 0xd1,
-	0xc4, 0x17, 0xf1, 0x05, 0x5a, 0xa4, 0xdc, 0x66, 0x8a, 0xcb, 0xbb, 0x26, 0xfd, 0xc2, 0xb6, 0xe4,
-	0x14, 0xfe, 0x47, 0x1f, 0xe2, 0xb7, 0x68, 0x2e, 0xa8, 0xb1, 0xb7, 0x2a, 0xe7, 0x1b, 0xce, 0x72,
-	0xf2, 0x0f, 0x6c, 0x19, 0xc5, 0x3e, 0x83, 0x71, 0x9b, 0xc1, 0xf8, 0xa1, 0xcd, 0x60, 0xd2, 0xf3,
-	0x47, 0xdf, 0x07, 0x68, 0x1a, 0x6e, 0x0d, 0x13, 0x34, 0x7d, 0x62, 0xa9, 0xe1, 0x96, 0x85, 0xec,
-	0xb5, 0xd2, 0x85, 0x86, 0x55, 0x94, 0x8b, 0x90, 0x3d, 0x2f, 0xf0, 0x12, 0xcd, 0xea, 0x52, 0x49,
-	0xf6, 0xb1, 0xa9, 0x52, 0xa6, 0x43, 0x02, 0xf7, 0x11, 0x8e, 0xd1, 0xc4, 0xa8, 0x8c, 0x53, 0x41,
-	0x46, 0xcb, 0x83, 0xd5, 0x6c, 0x7d, 0xb6, 0x3b, 0x2a, 0xe0, 0x77, 0x59, 0xa6, 0x1a, 0x69, 0x93,
-	0xe0, 0x8a, 0x3e, 0xa1, 0x45, 0xaf, 0xe0, 0xb2, 0x66, 0xb7, 0x75, 0x3b, 0x0f, 0xac, 0x5d, 0xba,
-	0x1b, 0xc3, 0x34, 0xa4, 0xde, 0xcf, 0xd3, 0x69, 0x37, 0x68, 0xad, 0x95, 0xda, 0x84, 0x61, 0xbc,
-	0x88, 0xbe, 0xa1, 0x31, 0xfc, 0x07, 0xd8, 0x8e, 0xcb, 0x6d, 0xb7, 0x1d, 0x97, 0x5b, 0xd7, 0x62,
-	0x2a, 0x2a, 0xba, 0xb3, 0x81, 0x70, 0x81, 0xae, 0x58, 0xce, 0x9b, 0x2a, 0xec, 0x14, 0x94, 0x73,
-	0x0b, 0xaa, 0x0b, 0x16, 0xde, 0x95, 0x17, 0x6e, 0x24, 0xa5, 0x79, 0xc1, 0x25, 0x15, 0xe1, 0x5d,
-	0x75, 0x3a, 0xfa, 0x31, 0x40, 0x13, 0x1f, 0x34, 0x77, 0xc1, 0xb5, 0xe6, 0x15, 0xd5, 0xed, 0x04,
-	0xad, 0x74, 0xef, 0xc4, 0xb0, 0x4c, 0xc9, 0xdc, 0xd5, 0xfc, 0x20, 0x3b, 0x00, 0x63, 0xb3, 0xaf,
-	0xb6, 0x7d, 0xe3, 0x6e, 0xed, 0x3a, 0x4a, 0x5e, 0x94, 0x82, 0x17, 0xa5, 0x0d, 0xc3, 0xec, 0x80,
-	0x0b, 0x4f, 0x27, 0x1e, 0x5c, 0xab, 0x9f, 0xaa, 0x0f, 0xa3, 0x5f, 0x03, 0x34, 0xbe, 0x6f, 0xc3,
-	0xb6, 0x51, 0x42, 0xa8, 0x27, 0xa6, 0xaf, 0xdc, 0xc5, 0xc3, 0x7c, 0x8b, 0xa4, 0x0f, 0xf1, 0x0b,
-	0x74, 0xec, 0x01, 0x97, 0x85, 0xb7, 0x0d, 0xc1, 0xf6, 0x8c, 0xe2, 0x73, 0x34, 0x17, 0xdc, 0xd8,
-	0xce, 0x75, 0x00, 0xae, 0x1e, 0x73, 0xe1, 0xd1, 0x74, 0x67, 0x19, 0x81, 0x65, 0x1f, 0xb9, 0x99,
-	0xe8, 0x23, 0xd3, 0xee, 0xfd, 0x00, 0x85, 0x33, 0x0c, 0x93, 0x3e, 0x7c, 0x3f, 0xfa, 0x3c, 0xac,
-	0xd3, 0x74, 0x02, 0x41, 0x7f, 0xf3, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x33, 0xea, 0x2f, 0x1b, 0x8b,
-	0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("profile.proto", fileDescriptor7) }
+
+var fileDescriptor7 = []byte{
+	// 692 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x94, 0x5f, 0x6f, 0xf3, 0x34,
+	0x14, 0xc6, 0xd5, 0xae, 0x7f, 0x36, 0xb7, 0xdd, 0x86, 0x85, 0x5e, 0x59, 0x11, 0x12, 0xd5, 0x34,
+	0x41, 0xc5, 0x45, 0x86, 0xca, 0x3d, 0x12, 0x6c, 0x17, 0xec, 0x62, 0x68, 0xca, 0xc6, 0x0d, 0x77,
+	0x4e, 0x72, 0x9a, 0x58, 0x38, 0x71, 0x64, 0x3b, 0x1d, 0x15, 0x1f, 0x81, 0x2f, 0xc0, 0x17, 0xe4,
+	0x92, 0xef, 0x80, 0x7c, 0xec, 0xa4, 0xcd, 0xf6, 0xde, 0xf9, 0xf9, 0x9d, 0xe7, 0xb8, 0xc7, 0xee,
+	0xe3, 0x90, 0x55, 0xa3, 0xd5, 0x4e, 0x48, 0x88, 0x1b, 0xad, 0xac, 0x8a, 0xbe, 0x2e, 0x94, 0x2a,
+	0x24, 0xdc, 0xa1, 0x4a, 0xdb, 0xdd, 0x9d, 0x15, 0x15, 0x18, 0xcb, 0xab, 0x26, 0x18, 0xae, 0x2a,
+	0x95, 0x83, 0xe6, 0x56, 0x69, 0x0f, 0x6e, 0xfe, 0x25, 0x64, 0xfe, 0xec, 0xf7, 0xa0, 0x9f, 0xc8,
+	0xac, 0x01, 0xd0, 0x8f, 0x0f, 0x6c, 0xb4, 0x1e, 0x6d, 0x2e, 0x92, 0xa0, 0x1c, 0x2f, 0x79, 0x9d,
+	0x4b, 0x60, 0x63, 0xcf, 0xbd, 0xa2, 0x94, 0x4c, 0x6a, 0x5e, 0x01, 0x3b, 0x43, 0x8a, 0x6b, 0x1a,
+	0x91, 0x73, 0xa9, 0x32, 0x6e, 0x85, 0xaa, 0xd9, 0x04, 0x79, 0xaf, 0xe9, 0x97, 0x64, 0xca, 0x53,
+	0xd5, 0x5a, 0x36, 0xc5, 0x82, 0x17, 0xf4, 0x3b, 0x72, 0x6d, 0x4a, 0xa5, 0xed, 0x03, 0x98, 0x4c,
+	0x8b, 0x06, 0x3b, 0x67, 0x68, 0xf8, 0xc0, 0xf1, 0x17, 0xcd, 0xee, 0x8d, 0xcd, 0xd7, 0xa3, 0xcd,
+	0x79, 0x82, 0x6b, 0x37, 0xdd, 0x1e, 0xea, 0x5c, 0x69, 0x76, 0x8e, 0x34, 0x28, 0xfa, 0x15, 0xb9,
+	0xe8, 0x0f, 0xcb, 0x2e, 0xb0, 0x74, 0x04, 0xf4, 0x7b, 0xb2, 0xea, 0xc5, 0x63, 0xbd, 0x53, 0x8c,
+	0xac, 0x47, 0x9b, 0xc5, 0x96, 0xc4, 0x4f, 0x1d, 0x4d, 0x86, 0x06, 0xba, 0x25, 0x8b, 0x4c, 0xd5,
+	0x96, 0x67, 0x16, 0xfd, 0x0b, 0xf4, 0x5f, 0xc7, 0xe1, 0xf2, 0xe2, 0x7b, 0x5f, 0x4b, 0x4e, 0x4d,
+	0xf4, 0x5b, 0x32, 0xcb, 0x94, 0x54, 0xda, 0xb0, 0x25, 0xda, 0xaf, 0x4e, 0xec, 0x0e, 0x27, 0xa1,
+	0x4c, 0xb7, 0x64, 0xc9, 0xf7, 0xdc, 0x72, 0xfd, 0x0b, 0x37, 0x25, 0x18, 0xb6, 0x42, 0xfb, 0x65,
+	0x6f, 0x7f, 0xac, 0x78, 0x01, 0xc9, 0xc0, 0xe3, 0x7a, 0x4a, 0xe0, 0x39, 0x74, 0x3d, 0x97, 0x9f,
+	0xef, 0x39, 0xf5, 0xd0, 0x5b, 0x32, 0x35, 0x96, 0x5b, 0xc3, 0xae, 0xde, 0x99, 0x5f, 0x1c, 0x4d,
+	0x7c, 0x91, 0xde, 0x92, 0x55, 0x2a, 0x6c, 0xa6, 0x44, 0xfd, 0xdc, 0xa6, 0x7f, 0xc0, 0x81, 0x5d,
+	0xe3, 0xff, 0x31, 0x84, 0xf4, 0x47, 0xb2, 0x94, 0xdc, 0xd8, 0x27, 0x95, 0x8b, 0x9d, 0x80, 0x9c,
+	0x7d, 0x81, 0x5b, 0x46, 0xb1, 0xcf, 0x60, 0xdc, 0x65, 0x30, 0x7e, 0xed, 0x32, 0x98, 0x0c, 0xfc,
+	0xd1, 0xdf, 0x23, 0x32, 0x0f, 0xb7, 0x46, 0x19, 0x99, 0xbf, 0x41, 0x6a, 0x84, 0x85, 0x90, 0xbd,
+	0x4e, 0xba, 0xd0, 0x40, 0xc5, 0x85, 0x0c, 0xd9, 0xf3, 0x82, 0xae, 0xc9, 0xa2, 0x29, 0x55, 0x0d,
+	0xbf, 0xb6, 0x55, 0x0a, 0x3a, 0x24, 0xf0, 0x14, 0xd1, 0x98, 0xcc, 0x8c, 0xca, 0x04, 0x97, 0x6c,
+	0xb2, 0x3e, 0xdb, 0x2c, 0xb6, 0x9f, 0x8e, 0x47, 0x45, 0xfc, 0x53, 0x96, 0xa9, 0xb6, 0xb6, 0x49,
+	0x70, 0x45, 0xbf, 0x91, 0xd5, 0xa0, 0xe0, 0xb2, 0x66, 0x0f, 0x4d, 0x37, 0x0f, 0xae, 0x5d, 0xba,
+	0x5b, 0x03, 0x1a, 0x53, 0xef, 0xe7, 0xe9, 0xb5, 0x1b, 0xb4, 0xd1, 0x4a, 0xed, 0xc2, 0x30, 0x5e,
+	0x44, 0x7f, 0x91, 0x29, 0xfe, 0x0f, 0xb8, 0x9d, 0xa8, 0x0f, 0xfd, 0x76, 0xa2, 0x3e, 0xb8, 0x16,
+	0x53, 0x71, 0xd9, 0x9f, 0x0d, 0x85, 0x0b, 0x74, 0x05, 0xb9, 0x68, 0xab, 0xb0, 0x53, 0x50, 0xce,
+	0x2d, 0xb9, 0x2e, 0x20, 0xbc, 0x2b, 0x2f, 0xdc, 0x48, 0x4a, 0x8b, 0x42, 0xd4, 0x5c, 0x86, 0x77,
+	0xd5, 0xeb, 0xe8, 0x9f, 0x11, 0x99, 0xf9, 0xa0, 0xb9, 0x0b, 0x6e, 0xb4, 0xa8, 0xb8, 0xee, 0x26,
+	0xe8, 0xa4, 0x7b, 0x27, 0x06, 0x32, 0x55, 0xe7, 0xae, 0xe6, 0x07, 0x39, 0x02, 0x1c, 0x1b, 0xfe,
+	0xb4, 0xdd, 0x1b, 0x77, 0x6b, 0xd7, 0x51, 0x8a, 0xa2, 0x94, 0xa2, 0x28, 0x6d, 0x18, 0xe6, 0x08,
+	0x5c, 0x78, 0x7a, 0xf1, 0xea, 0x5a, 0xfd, 0x54, 0x43, 0x18, 0xfd, 0x37, 0x22, 0xd3, 0x97, 0x2e,
+	0x6c, 0x3b, 0x25, 0xa5, 0x7a, 0x03, 0x7d, 0xef, 0x2e, 0x1e, 0xe7, 0x5b, 0x25, 0x43, 0x48, 0xbf,
+	0x21, 0x97, 0x1e, 0x88, 0xba, 0xf0, 0xb6, 0x31, 0xda, 0xde, 0x51, 0x7a, 0x43, 0x96, 0x52, 0x18,
+	0xdb, 0xbb, 0xce, 0xd0, 0x35, 0x60, 0x2e, 0x3c, 0x9a, 0x1f, 0x2d, 0x13, 0xb4, 0x9c, 0x22, 0x37,
+	0x13, 0xdf, 0x83, 0x76, 0xef, 0x07, 0x29, 0x9e, 0x61, 0x9c, 0x0c, 0xa1, 0xfb, 0x72, 0xe5, 0xc2,
+	0x34, 0xad, 0x05, 0x93, 0x80, 0x51, 0x72, 0x0f, 0x39, 0x7e, 0xb9, 0x56, 0xc9, 0x07, 0xfe, 0xf3,
+	0xe4, 0xf7, 0x71, 0x93, 0xa6, 0x33, 0x7c, 0x14, 0x3f, 0xfc, 0x3f, 0x00, 0xc5, 0xf0, 0x00, 0xbc,
+	0xb7, 0x05, 0x00, 0x00,
 }
@@ -0,0 +1,372 @@
+// Package backup writes and restores encrypted archives of a node's data directory.
+//
+// An archive is a tar stream encrypted with AES-256-CTR and authenticated with
+// HMAC-SHA256, both keyed from a password with PBKDF2. The first entry of the tar
+// stream is a manifest listing the SHA-256 of every file so a restore can check the
+// contents before anything is moved into the data directory.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Version of the archive layout. Bumped whenever an older node could misread a new archive.
+const FormatVersion = 1
+
+const (
+	manifestName  = "manifest.json"
+	saltLength    = 16
+	keyIterations = 100000
+)
+
+var magic = []byte("OBBACKUP")
+
+var ErrInvalidBackup = errors.New("Backup is corrupt or the password is incorrect")
+
+type Manifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	NodeVersion   string    `json:"nodeVersion"`
+	PeerID        string    `json:"peerID"`
+	Testnet       bool      `json:"testnet"`
+	DatabaseOnly  bool      `json:"databaseOnly"`
+	Created       time.Time `json:"created"`
+
+	// SHA-256 of each file keyed by its slash separated path in the data directory
+	Files map[string]string `json:"files"`
+}
+
+type Options struct {
+	RepoPath     string
+	Testnet      bool
+	NodeVersion  string
+	DatabaseOnly bool
+}
+
+// Return the path of the OpenBazaar database relative to the data directory
+func DatabasePath(testnet bool) string {
+	if testnet {
+		return "datastore/testnet.db"
+	}
+	return "datastore/mainnet.db"
+}
+
+// Return the files to back up relative to the data directory. Everything needed to run
+// the node is included: the config holding the identity key, the IPNS keystore, the
+// database and the root directory which is published on IPNS.
+func backupFiles(opts Options) ([]string, error) {
+	files := []string{DatabasePath(opts.Testnet)}
+	if opts.DatabaseOnly {
+		return files, nil
+	}
+	files = append(files, "config", "version")
+	for _, dir := range []string{"keystore", "root"} {
+		err := filepath.Walk(filepath.Join(opts.RepoPath, dir), func(p string, info os.FileInfo, err error) error {
+			if os.IsNotExist(err) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			rel, err := filepath.Rel(opts.RepoPath, p)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func hashFile(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func readPeerID(repoPath string) (string, error) {
+	f, err := os.Open(filepath.Join(repoPath, "config"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	var cfg struct {
+		Identity struct {
+			PeerID string
+		}
+	}
+	if err := json.NewDecoder(f).Decode(&cfg); err != nil {
+		return "", err
+	}
+	return cfg.Identity.PeerID, nil
+}
+
+// Derive the encryption and authentication keys from the password
+func deriveKeys(password string, salt []byte) ([]byte, []byte) {
+	key := pbkdf2.Key([]byte(password), salt, keyIterations, 64, sha256.New)
+	return key[:32], key[32:]
+}
+
+func header(salt, iv []byte) []byte {
+	h := append([]byte{}, magic...)
+	h = append(h, FormatVersion)
+	h = append(h, salt...)
+	return append(h, iv...)
+}
+
+// Create writes an encrypted backup of the data directory to w. The node must not be
+// running as the database is copied file by file.
+func Create(w io.Writer, opts Options, password string) (*Manifest, error) {
+	if password == "" {
+		return nil, errors.New("A backup password is required")
+	}
+	files, err := backupFiles(opts)
+	if err != nil {
+		return nil, err
+	}
+	peerID, err := readPeerID(opts.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		NodeVersion:   opts.NodeVersion,
+		PeerID:        peerID,
+		Testnet:       opts.Testnet,
+		DatabaseOnly:  opts.DatabaseOnly,
+		Created:       time.Now(),
+		Files:         make(map[string]string),
+	}
+	for _, f := range files {
+		h, err := hashFile(filepath.Join(opts.RepoPath, filepath.FromSlash(f)))
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files[f] = h
+	}
+	manifestBytes, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltLength)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	encKey, macKey := deriveKeys(password, salt)
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, macKey)
+	hdr := header(salt, iv)
+	if _, err := w.Write(hdr); err != nil {
+		return nil, err
+	}
+	mac.Write(hdr)
+
+	// Encrypt then MAC
+	stream := &cipher.StreamWriter{S: cipher.NewCTR(block, iv), W: io.MultiWriter(w, mac)}
+	tw := tar.NewWriter(stream)
+	if err := writeTarEntry(tw, manifestName, int64(len(manifestBytes)), bytes.NewReader(manifestBytes)); err != nil {
+		return nil, err
+	}
+	for _, name := range files {
+		if err := addFile(tw, opts.RepoPath, name); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(mac.Sum(nil)); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func addFile(tw *tar.Writer, repoPath, name string) error {
+	f, err := os.Open(filepath.Join(repoPath, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return writeTarEntry(tw, name, info.Size(), f)
+}
+
+func writeTarEntry(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := io.Copy(tw, r)
+	return err
+}
+
+// Extract verifies a backup and writes its files to dir, which must not already exist.
+// The archive is authenticated before it is decrypted and every file is checked against
+// the manifest. If anything fails dir is removed.
+func Extract(archivePath, password, dir string) (*Manifest, error) {
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s already exists", dir)
+	}
+	manifest, err := extract(archivePath, password, dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func extract(archivePath, password, dir string) (*Manifest, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	hdrLen := int64(len(magic) + 1 + saltLength + aes.BlockSize)
+	if info.Size() < hdrLen+sha256.Size {
+		return nil, ErrInvalidBackup
+	}
+	hdr := make([]byte, hdrLen)
+	if _, err := io.ReadFull(f, hdr); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(hdr[:len(magic)], magic) {
+		return nil, errors.New("Not an OpenBazaar backup")
+	}
+	if int(hdr[len(magic)]) > FormatVersion {
+		return nil, fmt.Errorf("Backup format version %d is newer than this version of openbazaar-go supports", hdr[len(magic)])
+	}
+	salt := hdr[len(magic)+1 : len(magic)+1+saltLength]
+	iv := hdr[len(magic)+1+saltLength:]
+	encKey, macKey := deriveKeys(password, salt)
+
+	// Authenticate the whole archive before decrypting any of it
+	ciphertextLen := info.Size() - hdrLen - sha256.Size
+	ciphertext := io.NewSectionReader(f, hdrLen, ciphertextLen)
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(hdr)
+	if _, err := io.Copy(mac, ciphertext); err != nil {
+		return nil, err
+	}
+	expected := make([]byte, sha256.Size)
+	if _, err := f.ReadAt(expected, hdrLen+ciphertextLen); err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return nil, ErrInvalidBackup
+	}
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := &cipher.StreamReader{S: cipher.NewCTR(block, iv), R: io.NewSectionReader(f, hdrLen, ciphertextLen)}
+	tr := tar.NewReader(plaintext)
+
+	th, err := tr.Next()
+	if err != nil || th.Name != manifestName {
+		return nil, ErrInvalidBackup
+	}
+	manifest := new(Manifest)
+	if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for {
+		th, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		expectedHash, ok := manifest.Files[th.Name]
+		if !ok || seen[th.Name] || !validName(th.Name) {
+			return nil, fmt.Errorf("Unexpected file %s in backup", th.Name)
+		}
+		seen[th.Name] = true
+		h, err := writeFile(filepath.Join(dir, filepath.FromSlash(th.Name)), tr)
+		if err != nil {
+			return nil, err
+		}
+		if h != expectedHash {
+			return nil, fmt.Errorf("%s does not match the backup manifest", th.Name)
+		}
+	}
+	for name := range manifest.Files {
+		if !seen[name] {
+			return nil, fmt.Errorf("%s is missing from the backup", name)
+		}
+	}
+	return manifest, nil
+}
+
+// Names must be relative paths which stay inside the data directory
+func validName(name string) bool {
+	return name != "" && !path.IsAbs(name) && path.Clean(name) == name && !strings.HasPrefix(name, "../") && name != ".."
+}
+
+func writeFile(p string, r io.Reader) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, h), r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,112 @@
+package backup
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestRepo(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"config":                   `{"Identity": {"PeerID": "QmTestPeer"}}`,
+		"version":                  "5",
+		"keystore/key":             "ipns key",
+		"datastore/mainnet.db":     "orders",
+		"root/listings/index.json": "[]",
+		"root/profile":             "{}",
+	}
+	for name, data := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(p), os.ModePerm)
+		if err := ioutil.WriteFile(p, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func writeBackup(t *testing.T, repoPath string, dbOnly bool) string {
+	var buf bytes.Buffer
+	_, err := Create(&buf, Options{RepoPath: repoPath, NodeVersion: "0.0.1", DatabaseOnly: dbOnly}, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := filepath.Join(repoPath, "..", filepath.Base(repoPath)+".obbak")
+	if err := ioutil.WriteFile(archive, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return archive
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	repoPath := newTestRepo(t)
+	defer os.RemoveAll(repoPath)
+	archive := writeBackup(t, repoPath, false)
+	defer os.Remove(archive)
+
+	out := repoPath + ".restored"
+	defer os.RemoveAll(out)
+	manifest, err := Extract(archive, "password", out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.PeerID != "QmTestPeer" || manifest.FormatVersion != FormatVersion || manifest.DatabaseOnly {
+		t.Error("Returned incorrect manifest")
+	}
+	for _, name := range []string{"config", "version", "keystore/key", "datastore/mainnet.db", "root/listings/index.json", "root/profile"} {
+		a, _ := ioutil.ReadFile(filepath.Join(repoPath, filepath.FromSlash(name)))
+		b, err := ioutil.ReadFile(filepath.Join(out, filepath.FromSlash(name)))
+		if err != nil || !bytes.Equal(a, b) {
+			t.Errorf("%s was not restored", name)
+		}
+	}
+}
+
+func TestBackupDatabaseOnly(t *testing.T) {
+	repoPath := newTestRepo(t)
+	defer os.RemoveAll(repoPath)
+	archive := writeBackup(t, repoPath, true)
+	defer os.Remove(archive)
+
+	out := repoPath + ".restored"
+	defer os.RemoveAll(out)
+	manifest, err := Extract(archive, "password", out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !manifest.DatabaseOnly || len(manifest.Files) != 1 {
+		t.Error("Database only backup contained other files")
+	}
+	if _, err := os.Stat(filepath.Join(out, "root")); !os.IsNotExist(err) {
+		t.Error("Database only backup restored the root directory")
+	}
+}
+
+func TestBackupVerification(t *testing.T) {
+	repoPath := newTestRepo(t)
+	defer os.RemoveAll(repoPath)
+	archive := writeBackup(t, repoPath, false)
+	defer os.Remove(archive)
+	out := repoPath + ".restored"
+	defer os.RemoveAll(out)
+
+	if _, err := Extract(archive, "wrong", out); err != ErrInvalidBackup {
+		t.Error("Extracted a backup with the wrong password")
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Error("Failed extraction left files behind")
+	}
+
+	b, _ := ioutil.ReadFile(archive)
+	b[len(b)/2] ^= 0xff
+	ioutil.WriteFile(archive, b, 0600)
+	if _, err := Extract(archive, "password", out); err != ErrInvalidBackup {
+		t.Error("Extracted a corrupted backup")
+	}
+}
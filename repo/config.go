@@ -2,10 +2,12 @@ package repo
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/ipfs/go-ipfs/repo"
 	"github.com/ipfs/go-ipfs/repo/config"
 	"io/ioutil"
 	"path"
+	"strings"
 )
 
 var DefaultBootstrapAddresses = []string{
@@ -28,6 +30,12 @@ type APIConfig struct {
 	SSLKey        string
 }
 
+// The command run by the command notifier
+type NotifierCommandConfig struct {
+	Command string
+	Args    []string
+}
+
 type TorConfig struct {
 	Password   string
 	TorControl string
@@ -46,6 +54,24 @@ type WalletConfig struct {
 	RPCPassword      string
 }
 
+type OrderTimeoutConfig struct {
+	// Hours an order may remain unfunded before it is canceled
+	UnfundedHours uint32
+
+	// Hours after which the vendor may claim the funds of a moderated order. This is set
+	// on new listings and committed to in the escrow script of orders placed against them.
+	EscrowTimeoutHours uint32
+
+	// Hours before each deadline to notify the user
+	NotifyBeforeHours uint32
+}
+
+var DefaultOrderTimeouts = OrderTimeoutConfig{
+	UnfundedHours:      48,
+	EscrowTimeoutHours: 1080,
+	NotifyBeforeHours:  24,
+}
+
 func GetAPIConfig(cfgPath string) (*APIConfig, error) {
 	file, err := ioutil.ReadFile(cfgPath)
 	if err != nil {
@@ -108,29 +134,86 @@ func GetWalletConfig(cfgPath string) (*WalletConfig, error) {
 	json.Unmarshal(file, &cfg)
 
 	wallet := cfg.(map[string]interface{})["Wallet"]
-	feeAPI := wallet.(map[string]interface{})["FeeAPI"].(string)
-	trustedPeer := wallet.(map[string]interface{})["TrustedPeer"].(string)
-	low := wallet.(map[string]interface{})["LowFeeDefault"].(float64)
-	medium := wallet.(map[string]interface{})["MediumFeeDefault"].(float64)
-	high := wallet.(map[string]interface{})["HighFeeDefault"].(float64)
-	maxFee := wallet.(map[string]interface{})["MaxFee"].(float64)
-	walletType := wallet.(map[string]interface{})["Type"].(string)
-	binary := wallet.(map[string]interface{})["Binary"].(string)
-	rpcUser := wallet.(map[string]interface{})["RPCUser"].(string)
-	rpcPassword := wallet.(map[string]interface{})["RPCPassword"].(string)
-	wCfg := &WalletConfig{
-		Type:             walletType,
-		Binary:           binary,
-		MaxFee:           int(maxFee),
-		FeeAPI:           feeAPI,
-		HighFeeDefault:   int(high),
-		MediumFeeDefault: int(medium),
-		LowFeeDefault:    int(low),
-		TrustedPeer:      trustedPeer,
-		RPCUser:          rpcUser,
-		RPCPassword:      rpcPassword,
+	return parseWalletConfig(wallet.(map[string]interface{})), nil
+}
+
+// Return the configs of any wallets run alongside the primary wallet keyed by currency code.
+// The Wallets section is optional and nodes without it only run the primary wallet.
+func GetAdditionalWalletConfigs(cfgPath string) (map[string]*WalletConfig, error) {
+	file, err := ioutil.ReadFile(cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	var cfg interface{}
+	json.Unmarshal(file, &cfg)
+
+	configs := make(map[string]*WalletConfig)
+	wallets, ok := cfg.(map[string]interface{})["Wallets"].(map[string]interface{})
+	if !ok {
+		return configs, nil
+	}
+	for code, w := range wallets {
+		wallet, ok := w.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Invalid wallet config for %s", code)
+		}
+		configs[strings.ToUpper(code)] = parseWalletConfig(wallet)
 	}
-	return wCfg, nil
+	return configs, nil
+}
+
+func parseWalletConfig(wallet map[string]interface{}) *WalletConfig {
+	str := func(key string) string {
+		v, _ := wallet[key].(string)
+		return v
+	}
+	num := func(key string) int {
+		v, _ := wallet[key].(float64)
+		return int(v)
+	}
+	return &WalletConfig{
+		Type:             str("Type"),
+		Binary:           str("Binary"),
+		MaxFee:           num("MaxFee"),
+		FeeAPI:           str("FeeAPI"),
+		HighFeeDefault:   num("HighFeeDefault"),
+		MediumFeeDefault: num("MediumFeeDefault"),
+		LowFeeDefault:    num("LowFeeDefault"),
+		TrustedPeer:      str("TrustedPeer"),
+		RPCUser:          str("RPCUser"),
+		RPCPassword:      str("RPCPassword"),
+	}
+}
+
+// Return the command notifier's command. It can only be set in the config file so that the
+// API can't be used to run programs on the host. Returns nil if no command is configured.
+func GetNotifierCommand(cfgPath string) (*NotifierCommandConfig, error) {
+	file, err := ioutil.ReadFile(cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	var cfg interface{}
+	json.Unmarshal(file, &cfg)
+
+	nc, ok := cfg.(map[string]interface{})["NotifierCommand"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	command, _ := nc["Command"].(string)
+	if command == "" {
+		return nil, nil
+	}
+	var args []string
+	if a, ok := nc["Args"].([]interface{}); ok {
+		for _, arg := range a {
+			s, ok := arg.(string)
+			if !ok {
+				return nil, fmt.Errorf("Invalid notifier command argument %v", arg)
+			}
+			args = append(args, s)
+		}
+	}
+	return &NotifierCommandConfig{Command: command, Args: args}, nil
 }
 
 func GetTorConfig(cfgPath string) (TorConfig, error) {
@@ -192,6 +275,32 @@ func GetResolverUrl(cfgPath string) (string, error) {
 	return r, nil
 }
 
+func GetOrderTimeoutConfig(cfgPath string) (OrderTimeoutConfig, error) {
+	file, err := ioutil.ReadFile(cfgPath)
+	if err != nil {
+		return OrderTimeoutConfig{}, err
+	}
+	var cfg interface{}
+	json.Unmarshal(file, &cfg)
+
+	// Older config files won't have this section
+	ot, ok := cfg.(map[string]interface{})["Order-timeouts"].(map[string]interface{})
+	if !ok {
+		return DefaultOrderTimeouts, nil
+	}
+	timeouts := DefaultOrderTimeouts
+	if v, ok := ot["UnfundedHours"].(float64); ok {
+		timeouts.UnfundedHours = uint32(v)
+	}
+	if v, ok := ot["EscrowTimeoutHours"].(float64); ok {
+		timeouts.EscrowTimeoutHours = uint32(v)
+	}
+	if v, ok := ot["NotifyBeforeHours"].(float64); ok {
+		timeouts.NotifyBeforeHours = uint32(v)
+	}
+	return timeouts, nil
+}
+
 func extendConfigFile(r repo.Repo, key string, value interface{}) error {
 	if err := r.SetConfigKey(key, value); err != nil {
 		return err
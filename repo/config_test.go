@@ -97,6 +97,49 @@ func TestGetWalletConfig(t *testing.T) {
 	}
 }
 
+func TestGetAdditionalWalletConfigs(t *testing.T) {
+	configs, err := GetAdditionalWalletConfigs(testConfigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 {
+		t.Fatalf("Expected 1 additional wallet, got %d", len(configs))
+	}
+	ltc, ok := configs["LTC"]
+	if !ok {
+		t.Fatal("Currency code was not normalized to upper case")
+	}
+	if ltc.Type != "litecoind" {
+		t.Error("Type does not equal expected value")
+	}
+	if ltc.MediumFeeDefault != 200 {
+		t.Error("Expected medium to be 200, got ", ltc.MediumFeeDefault)
+	}
+
+	_, err = GetAdditionalWalletConfigs(nonexistentTestConfigPath)
+	if err == nil {
+		t.Error("GetAdditionalWalletConfigs didn't throw an error")
+	}
+}
+
+func TestGetNotifierCommand(t *testing.T) {
+	nc, err := GetNotifierCommand(testConfigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nc == nil || nc.Command != "/usr/local/bin/process-order" {
+		t.Fatal("Command does not equal expected value")
+	}
+	if len(nc.Args) != 1 || nc.Args[0] != "--event-stdin" {
+		t.Error("Args do not equal expected value")
+	}
+
+	_, err = GetNotifierCommand(nonexistentTestConfigPath)
+	if err == nil {
+		t.Error("GetNotifierCommand didn't throw an error")
+	}
+}
+
 func TestGetDropboxApiToken(t *testing.T) {
 	dropboxApiToken, err := GetDropboxApiToken(testConfigPath)
 	if dropboxApiToken != "dropbox123" {
@@ -133,6 +176,21 @@ func TestGetResolverUrl(t *testing.T) {
 	}
 }
 
+func TestGetOrderTimeoutConfig(t *testing.T) {
+	timeouts, err := GetOrderTimeoutConfig(testConfigPath)
+	if err != nil {
+		t.Error("GetOrderTimeoutConfig threw an unexpected error")
+	}
+	if timeouts.UnfundedHours != 72 || timeouts.EscrowTimeoutHours != 720 || timeouts.NotifyBeforeHours != 12 {
+		t.Error("Order timeouts do not equal expected values")
+	}
+
+	_, err = GetOrderTimeoutConfig(nonexistentTestConfigPath)
+	if err == nil {
+		t.Error("GetOrderTimeoutConfig didn't throw an error")
+	}
+}
+
 func TestExtendConfigFile(t *testing.T) {
 	r, err := fsrepo.Open(testConfigFolder)
 	if err != nil {
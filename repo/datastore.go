@@ -27,6 +27,15 @@ type Datastore interface {
 	Coupons() Coupons
 	TxMetadata() TxMetadata
 	ModeratedStores() ModeratedStores
+	Bids() Bids
+	Pledges() Pledges
+	SearchIndex() SearchIndex
+	NotifierDeliveries() NotifierDeliveries
+	Cart() Cart
+	Ratings() Ratings
+	DigitalGoods() DigitalGoods
+	APITokens() APITokens
+	AuditLog() AuditLog
 	Close()
 }
 
@@ -165,6 +174,12 @@ type Purchases interface {
 
 	// Return the metadata for all purchases
 	GetAll(offsetId string, limit int) ([]Purchase, error)
+
+	// Return the purchases which have been in the given state since before the given time
+	GetStale(state pb.OrderState, before time.Time) ([]StaleOrder, error)
+
+	// Record that the user has been notified of the deadline for the order's current state
+	MarkDeadlineNotified(orderID string) error
 }
 
 type Sales interface {
@@ -188,6 +203,18 @@ type Sales interface {
 
 	// Return the metadata for all sales
 	GetAll(offsetId string, limit int) ([]Sale, error)
+
+	// Return the sales which have been in the given state since before the given time
+	GetStale(state pb.OrderState, before time.Time) ([]StaleOrder, error)
+
+	// Record that the user has been notified of the deadline for the order's current state
+	MarkDeadlineNotified(orderID string) error
+
+	// Append an action taken on a sale by one of the vendor's order rules
+	AddRuleDecision(orderID string, decision *pb.OrderRuleDecision) error
+
+	// Return the actions taken on a sale by order rules
+	GetRuleDecisions(orderID string) ([]*pb.OrderRuleDecision, error)
 }
 
 type Cases interface {
@@ -206,6 +233,9 @@ type Cases interface {
 	// Mark a case as closed in the database
 	MarkAsClosed(caseID string, resolution *pb.DisputeResolution) error
 
+	// Save a resolution proposed by another moderator of a panel
+	MarkAsDecided(caseID string, resolution *pb.DisputeResolution) error
+
 	// Delete a case
 	Delete(caseID string) error
 
@@ -217,6 +247,21 @@ type Cases interface {
 
 	// Return the metadata for all cases
 	GetAll(offsetId string, limit int) ([]Case, error)
+
+	// Return the number of cases which have been resolved
+	CountResolved() int
+
+	// Save evidence the buyer or vendor has submitted for a case
+	PutEvidence(caseID string, evidence *pb.DisputeEvidence) error
+
+	// Return the evidence submitted for a case, oldest first
+	GetEvidence(caseID string) ([]*pb.DisputeEvidence, error)
+
+	// Save the history of split proposals made while negotiating a case
+	PutProposals(caseID string, proposals []*pb.DisputeProposal) error
+
+	// Return the split proposals made while negotiating a case, oldest first
+	GetProposals(caseID string) ([]*pb.DisputeProposal, error)
 }
 
 type Chat interface {
@@ -260,6 +305,19 @@ type Notifications interface {
 	Delete(notifID int) error
 }
 
+type NotifierDeliveries interface {
+
+	// Record the outcome of delivering a notification to an external notifier
+	Put(delivery NotifierDelivery) error
+
+	/* Fetch delivery records, newest first.
+	   The offset and limit arguments can be used to for lazy loading. */
+	GetAll(offsetID int, limit int) ([]NotifierDelivery, error)
+
+	// Delete records older than the given time
+	Prune(before time.Time) error
+}
+
 type Coupons interface {
 
 	// Put a list of coupons to the db
@@ -270,6 +328,12 @@ type Coupons interface {
 
 	// Delete all coupons for a given slug
 	Delete(slug string) error
+
+	// Record a coupon being redeemed by a confirmed sale
+	PutRedemption(redemption CouponRedemption) error
+
+	// Return the redemptions of the coupon with the given hash on a listing
+	GetRedemptions(slug string, hash string) ([]CouponRedemption, error)
 }
 
 type TxMetadata interface {
@@ -298,3 +362,139 @@ type ModeratedStores interface {
 	// Delete a moderated store from the database
 	Delete(peerId string) error
 }
+
+type Bids interface {
+	// Put a new bid to the database
+	Put(bid Bid) error
+
+	// Update the status of a bid
+	UpdateStatus(bidID string, status pb.BidAck_Status) error
+
+	// Record the order placed for a winning bid
+	SetOrderId(bidID string, orderID string) error
+
+	// Return a bid given its ID
+	GetById(bidID string) (Bid, error)
+
+	// Return all bids on a vendor's listing ordered from highest to lowest
+	Get(vendorID string, slug string) ([]Bid, error)
+
+	// Delete all bids on a vendor's listing
+	DeleteAll(vendorID string, slug string) error
+}
+
+type Pledges interface {
+	// Put a new pledge to the database
+	Put(pledge Pledge) error
+
+	// Mark a pledge as settled once its funds have been released or refunded
+	MarkAsSettled(orderID string) error
+
+	// Return all pledges to a crowdfund listing
+	Get(slug string) ([]Pledge, error)
+
+	// Delete a pledge
+	Delete(orderID string) error
+}
+
+type SearchIndex interface {
+	// Add or replace a listing in the search index
+	Put(listing SearchListing) error
+
+	// Remove a single listing from the index
+	Delete(peerID string, slug string) error
+
+	// Remove all of a peer's listings and its root hash from the index
+	DeleteAll(peerID string) error
+
+	// Return all of a peer's indexed listings
+	Get(peerID string) ([]SearchListing, error)
+
+	// Return the root hash a peer's listings were last indexed at
+	GetRootHash(peerID string) (string, error)
+
+	// Record the root hash a peer's listings were indexed at
+	PutRootHash(peerID string, rootHash string) error
+
+	// Return the listings matching the query. An empty query matches all listings.
+	Query(query SearchQuery) ([]SearchListing, error)
+}
+
+type Cart interface {
+	// Add an item to the shopping cart
+	Put(item CartItem) error
+
+	// Return all items in the cart, oldest first
+	Get() ([]CartItem, error)
+
+	// Remove an item from the cart
+	Delete(itemID string) error
+
+	// Remove all items from the cart
+	DeleteAll() error
+
+	// Save or update a checkout
+	PutCheckout(checkout CartCheckout) error
+
+	// Return a checkout given its ID
+	GetCheckout(checkoutID string) (CartCheckout, error)
+
+	// Return all checkouts, newest first
+	GetCheckouts() ([]CartCheckout, error)
+}
+
+type Ratings interface {
+	// Cache a fetched rating along with the result of verifying it
+	Put(rating CachedRating) error
+
+	// Return a cached rating or rating response given its hash and kind
+	Get(hash, kind string) (CachedRating, error)
+}
+
+type DigitalGoods interface {
+	// Save or replace the file delivered to buyers of a digital good listing
+	Put(good DigitalGood) error
+
+	// Return the file delivered to buyers of the listing with the given slug
+	Get(slug string) (DigitalGood, error)
+
+	// Return whether orders for a listing are fulfilled automatically, without loading its file
+	GetAutoFulfill(slug string) (bool, error)
+
+	// Delete the file for a listing
+	Delete(slug string) error
+}
+
+type APITokens interface {
+	// Save a new API token
+	Put(token APIToken) error
+
+	// Return the token with the given hash of its secret
+	GetByHash(hash string) (APIToken, error)
+
+	// Return all tokens, including expired and revoked ones
+	GetAll() ([]APIToken, error)
+
+	// Revoke the token with the given name
+	Revoke(name string) error
+}
+
+type AuditLog interface {
+	/* Key the entries' hashes with a secret derived from the given one, normally the node's
+	   identity key. This must be set before the log is appended to or verified. */
+	SetKey(secret []byte)
+
+	/* Append an entry to the log, chaining its hash to the entry before it.
+	   The ID and hashes are set by the log. */
+	Append(entry AuditLogEntry) (AuditLogEntry, error)
+
+	// Return the entries matching the query, newest first
+	Get(query AuditLogQuery) ([]AuditLogEntry, error)
+
+	// Return the ID and hash of the last entry appended to the log
+	Head() (AuditLogHead, error)
+
+	/* Recompute the hash chain and check it ends at the stored head. Returns an error naming
+	   the first entry which doesn't match. */
+	Verify() error
+}
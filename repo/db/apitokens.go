@@ -0,0 +1,106 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+type APITokensDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (a *APITokensDB) Put(token repo.APIToken) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	scopes, err := json.Marshal(token.Scopes)
+	if err != nil {
+		return err
+	}
+	var expiry int
+	if !token.Expiry.IsZero() {
+		expiry = int(token.Expiry.Unix())
+	}
+	revoked := 0
+	if token.Revoked {
+		revoked = 1
+	}
+	_, err = a.db.Exec("insert into apitokens(name, hash, scopes, expiry, revoked, timestamp) values(?,?,?,?,?,?)",
+		token.Name,
+		token.Hash,
+		scopes,
+		expiry,
+		revoked,
+		int(token.Timestamp.Unix()),
+	)
+	return err
+}
+
+func (a *APITokensDB) GetByHash(hash string) (repo.APIToken, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	row := a.db.QueryRow("select name, hash, scopes, expiry, revoked, timestamp from apitokens where hash=?", hash)
+	return scanAPIToken(row)
+}
+
+func (a *APITokensDB) GetAll() ([]repo.APIToken, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	rows, err := a.db.Query("select name, hash, scopes, expiry, revoked, timestamp from apitokens order by timestamp")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.APIToken
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, token)
+	}
+	return ret, nil
+}
+
+func (a *APITokensDB) Revoke(name string) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	res, err := a.db.Exec("update apitokens set revoked=1 where name=?", name)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func scanAPIToken(row scanner) (repo.APIToken, error) {
+	var name, hash string
+	var scopes []byte
+	var expiry, revoked, timestamp int
+	if err := row.Scan(&name, &hash, &scopes, &expiry, &revoked, &timestamp); err != nil {
+		return repo.APIToken{}, err
+	}
+	token := repo.APIToken{
+		Name:      name,
+		Hash:      hash,
+		Revoked:   revoked == 1,
+		Timestamp: time.Unix(int64(timestamp), 0),
+	}
+	if expiry > 0 {
+		token.Expiry = time.Unix(int64(expiry), 0)
+	}
+	if err := json.Unmarshal(scopes, &token.Scopes); err != nil {
+		return repo.APIToken{}, err
+	}
+	return token, nil
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+var atdb APITokensDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	atdb = APITokensDB{
+		db: conn,
+	}
+}
+
+func TestAPITokensPutAndGet(t *testing.T) {
+	expiry := time.Now().Add(time.Hour)
+	err := atdb.Put(repo.APIToken{
+		Name:      "fulfillment",
+		Hash:      "abcd",
+		Scopes:    []string{"read", "orders"},
+		Expiry:    expiry,
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	token, err := atdb.GetByHash("abcd")
+	if err != nil {
+		t.Error(err)
+	}
+	if token.Name != "fulfillment" || len(token.Scopes) != 2 || token.Scopes[1] != "orders" || token.Expiry.Unix() != expiry.Unix() || token.Revoked {
+		t.Error("API token returned incorrect values")
+	}
+	if err := atdb.Put(repo.APIToken{Name: "fulfillment", Hash: "efgh", Timestamp: time.Now()}); err == nil {
+		t.Error("Allowed two tokens with the same name")
+	}
+	if _, err := atdb.GetByHash("efgh"); err == nil {
+		t.Error("Returned a token which doesn't exist")
+	}
+}
+
+func TestAPITokensRevoke(t *testing.T) {
+	atdb.Put(repo.APIToken{Name: "chatbot", Hash: "ijkl", Scopes: []string{"chat"}, Timestamp: time.Now()})
+	if err := atdb.Revoke("chatbot"); err != nil {
+		t.Error(err)
+	}
+	token, err := atdb.GetByHash("ijkl")
+	if err != nil {
+		t.Error(err)
+	}
+	if !token.Revoked || !token.Expiry.IsZero() {
+		t.Error("API token returned incorrect values")
+	}
+	if err := atdb.Revoke("nobody"); err != sql.ErrNoRows {
+		t.Error("Revoked a token which doesn't exist")
+	}
+	tokens, err := atdb.GetAll()
+	if err != nil {
+		t.Error(err)
+	}
+	found := false
+	for _, token := range tokens {
+		if token.Name == "chatbot" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Token missing from list of all tokens")
+	}
+}
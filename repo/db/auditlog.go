@@ -0,0 +1,219 @@
+package db
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+var ErrAuditLogKeyNotSet = errors.New("Audit log key has not been set")
+
+type AuditLogDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+	key  []byte
+}
+
+func (a *AuditLogDB) SetKey(secret []byte) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("OpenBazaar audit log"))
+	a.key = mac.Sum(nil)
+}
+
+// Each entry's hash is an HMAC covering its fields and the hash of the entry before it, so
+// altering or removing an entry breaks the chain from that point on, and the chain can't be
+// recomputed without the key. The last hash is also kept as the head so removing entries
+// from the end of the log is caught too.
+func hashAuditLogEntry(key []byte, entry repo.AuditLogEntry) string {
+	s := strings.Join([]string{
+		strconv.Itoa(entry.ID),
+		strconv.FormatInt(entry.Timestamp.Unix(), 10),
+		entry.Method,
+		entry.Path,
+		entry.Identity,
+		entry.RemoteAddr,
+		strconv.Itoa(entry.Status),
+		entry.PrevHash,
+	}, "\n")
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(s))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (a *AuditLogDB) Append(entry repo.AuditLogEntry) (repo.AuditLogEntry, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.key == nil {
+		return repo.AuditLogEntry{}, ErrAuditLogKeyNotSet
+	}
+	tx, err := a.db.Begin()
+	if err != nil {
+		return repo.AuditLogEntry{}, err
+	}
+	var lastID int
+	var prevHash string
+	err = tx.QueryRow("select id, hash from auditlog order by id desc limit 1").Scan(&lastID, &prevHash)
+	if err != nil && err != sql.ErrNoRows {
+		tx.Rollback()
+		return repo.AuditLogEntry{}, err
+	}
+	entry.ID = lastID + 1
+	entry.Timestamp = time.Unix(entry.Timestamp.Unix(), 0)
+	entry.PrevHash = prevHash
+	entry.Hash = hashAuditLogEntry(a.key, entry)
+	_, err = tx.Exec("insert into auditlog(id, timestamp, method, path, identity, remoteAddr, status, prevHash, hash) values(?,?,?,?,?,?,?,?,?)",
+		entry.ID,
+		int(entry.Timestamp.Unix()),
+		entry.Method,
+		entry.Path,
+		entry.Identity,
+		entry.RemoteAddr,
+		entry.Status,
+		entry.PrevHash,
+		entry.Hash,
+	)
+	if err != nil {
+		tx.Rollback()
+		return repo.AuditLogEntry{}, err
+	}
+	_, err = tx.Exec("insert or replace into auditloghead(id, entryID, hash) values(1,?,?)", entry.ID, entry.Hash)
+	if err != nil {
+		tx.Rollback()
+		return repo.AuditLogEntry{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return repo.AuditLogEntry{}, err
+	}
+	return entry, nil
+}
+
+func (a *AuditLogDB) Get(query repo.AuditLogQuery) ([]repo.AuditLogEntry, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	stm := "select id, timestamp, method, path, identity, remoteAddr, status, prevHash, hash from auditlog where 1=1"
+	var args []interface{}
+	if query.Method != "" {
+		stm += " and method=?"
+		args = append(args, strings.ToUpper(query.Method))
+	}
+	if query.Path != "" {
+		stm += " and substr(path, 1, ?)=?"
+		args = append(args, len(query.Path), query.Path)
+	}
+	if query.Identity != "" {
+		stm += " and identity=?"
+		args = append(args, query.Identity)
+	}
+	if !query.Since.IsZero() {
+		stm += " and timestamp>=?"
+		args = append(args, int(query.Since.Unix()))
+	}
+	if !query.Until.IsZero() {
+		stm += " and timestamp<=?"
+		args = append(args, int(query.Until.Unix()))
+	}
+	if query.OffsetId > 0 {
+		stm += " and id<?"
+		args = append(args, query.OffsetId)
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = -1
+	}
+	stm += " order by id desc limit ?"
+	args = append(args, limit)
+	rows, err := a.db.Query(stm, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.AuditLogEntry
+	for rows.Next() {
+		entry, err := scanAuditLogEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, entry)
+	}
+	return ret, nil
+}
+
+func (a *AuditLogDB) Head() (repo.AuditLogHead, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.head()
+}
+
+func (a *AuditLogDB) head() (repo.AuditLogHead, error) {
+	var head repo.AuditLogHead
+	err := a.db.QueryRow("select entryID, hash from auditloghead where id=1").Scan(&head.ID, &head.Hash)
+	if err != nil && err != sql.ErrNoRows {
+		return repo.AuditLogHead{}, err
+	}
+	return head, nil
+}
+
+func (a *AuditLogDB) Verify() error {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	if a.key == nil {
+		return ErrAuditLogKeyNotSet
+	}
+	head, err := a.head()
+	if err != nil {
+		return err
+	}
+	rows, err := a.db.Query("select id, timestamp, method, path, identity, remoteAddr, status, prevHash, hash from auditlog order by id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var prevHash string
+	expectedID := 1
+	for rows.Next() {
+		entry, err := scanAuditLogEntry(rows)
+		if err != nil {
+			return err
+		}
+		if entry.ID != expectedID {
+			return fmt.Errorf("Audit log entry %d is missing", expectedID)
+		}
+		if entry.PrevHash != prevHash || !hmac.Equal([]byte(entry.Hash), []byte(hashAuditLogEntry(a.key, entry))) {
+			return fmt.Errorf("Audit log entry %d has been altered", entry.ID)
+		}
+		prevHash = entry.Hash
+		expectedID++
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if expectedID-1 < head.ID {
+		return fmt.Errorf("Audit log entry %d is missing", expectedID)
+	}
+	if expectedID-1 != head.ID || prevHash != head.Hash {
+		return errors.New("Audit log head doesn't match its last entry")
+	}
+	return nil
+}
+
+func scanAuditLogEntry(row scanner) (repo.AuditLogEntry, error) {
+	var entry repo.AuditLogEntry
+	var timestamp int
+	err := row.Scan(&entry.ID, &timestamp, &entry.Method, &entry.Path, &entry.Identity, &entry.RemoteAddr, &entry.Status, &entry.PrevHash, &entry.Hash)
+	if err != nil {
+		return repo.AuditLogEntry{}, err
+	}
+	entry.Timestamp = time.Unix(int64(timestamp), 0)
+	return entry, nil
+}
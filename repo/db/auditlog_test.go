@@ -0,0 +1,126 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+func newAuditLogDB() *AuditLogDB {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	aldb := &AuditLogDB{
+		db: conn,
+	}
+	aldb.SetKey([]byte("identity key"))
+	return aldb
+}
+
+func TestAuditLogAppendAndGet(t *testing.T) {
+	aldb := newAuditLogDB()
+	first, err := aldb.Append(repo.AuditLogEntry{Timestamp: time.Now(), Method: "POST", Path: "/wallet/spend", Identity: "user:alice", RemoteAddr: "127.0.0.1", Status: 200})
+	if err != nil {
+		t.Error(err)
+	}
+	second, err := aldb.Append(repo.AuditLogEntry{Timestamp: time.Now(), Method: "PUT", Path: "/ob/settings", Identity: "token:scripts", RemoteAddr: "127.0.0.1", Status: 403})
+	if err != nil {
+		t.Error(err)
+	}
+	if first.ID != 1 || second.ID != 2 || first.PrevHash != "" || second.PrevHash != first.Hash {
+		t.Error("Audit log entries were not chained")
+	}
+	entries, err := aldb.Get(repo.AuditLogQuery{})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(entries) != 2 || entries[0].ID != 2 {
+		t.Error("Audit log returned incorrect entries")
+	}
+	entries, err = aldb.Get(repo.AuditLogQuery{Path: "/wallet"})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(entries) != 1 || entries[0].Path != "/wallet/spend" || entries[0].Identity != "user:alice" {
+		t.Error("Audit log returned incorrect entries for path filter")
+	}
+	entries, err = aldb.Get(repo.AuditLogQuery{Identity: "token:scripts", Method: "put"})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(entries) != 1 || entries[0].Status != 403 {
+		t.Error("Audit log returned incorrect entries for identity filter")
+	}
+	entries, err = aldb.Get(repo.AuditLogQuery{OffsetId: 2, Limit: 5})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(entries) != 1 || entries[0].ID != 1 {
+		t.Error("Audit log returned incorrect entries for offset")
+	}
+	head, err := aldb.Head()
+	if err != nil {
+		t.Error(err)
+	}
+	if head.ID != 2 || head.Hash != second.Hash {
+		t.Error("Audit log returned incorrect head")
+	}
+}
+
+func TestAuditLogKeyNotSet(t *testing.T) {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	aldb := AuditLogDB{db: conn}
+	if _, err := aldb.Append(repo.AuditLogEntry{Timestamp: time.Now(), Method: "POST"}); err != ErrAuditLogKeyNotSet {
+		t.Error("Audit log appended an entry without a key")
+	}
+	if err := aldb.Verify(); err != ErrAuditLogKeyNotSet {
+		t.Error("Audit log verified without a key")
+	}
+}
+
+func TestAuditLogVerify(t *testing.T) {
+	aldb := newAuditLogDB()
+	for i := 0; i < 3; i++ {
+		aldb.Append(repo.AuditLogEntry{Timestamp: time.Now(), Method: "POST", Path: "/ob/refund", Identity: "cookie", Status: 200})
+	}
+	if err := aldb.Verify(); err != nil {
+		t.Error(err)
+	}
+	if _, err := aldb.db.Exec("update auditlog set status=500 where id=2"); err == nil {
+		t.Error("Audit log allowed an entry to be changed")
+	}
+	if _, err := aldb.db.Exec("delete from auditlog where id=2"); err == nil {
+		t.Error("Audit log allowed an entry to be deleted")
+	}
+	aldb.db.Exec("drop trigger auditlog_no_update")
+	aldb.db.Exec("update auditlog set status=500 where id=2")
+	if err := aldb.Verify(); err == nil {
+		t.Error("Verify failed to detect an altered entry")
+	}
+}
+
+func TestAuditLogVerifyTailDeleted(t *testing.T) {
+	aldb := newAuditLogDB()
+	for i := 0; i < 3; i++ {
+		aldb.Append(repo.AuditLogEntry{Timestamp: time.Now(), Method: "POST", Path: "/ob/refund", Identity: "cookie", Status: 200})
+	}
+	aldb.db.Exec("drop trigger auditlog_no_delete")
+	aldb.db.Exec("delete from auditlog where id=3")
+	if err := aldb.Verify(); err == nil {
+		t.Error("Verify failed to detect a deleted last entry")
+	}
+}
+
+func TestAuditLogVerifyRecomputedChain(t *testing.T) {
+	aldb := newAuditLogDB()
+	for i := 0; i < 3; i++ {
+		aldb.Append(repo.AuditLogEntry{Timestamp: time.Now(), Method: "POST", Path: "/ob/refund", Identity: "cookie", Status: 200})
+	}
+	// A chain rebuilt without the node's key doesn't verify
+	aldb.SetKey([]byte("another key"))
+	if err := aldb.Verify(); err == nil {
+		t.Error("Verify accepted a chain hashed with a different key")
+	}
+}
@@ -0,0 +1,136 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+type BidsDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (b *BidsDB) Put(bid repo.Bid) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	tx, err := b.db.Begin()
+	if err != nil {
+		return err
+	}
+	stm := `insert or replace into bids(bidID, vendorID, slug, listingHash, peerID, amount, status, timestamp, purchaseData, orderID) values(?,?,?,?,?,?,?,?,?,?)`
+	stmt, err := tx.Prepare(stm)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		bid.BidId,
+		bid.VendorId,
+		bid.Slug,
+		bid.ListingHash,
+		bid.PeerId,
+		int(bid.Amount),
+		pb.BidAck_Status_value[bid.Status],
+		int(bid.Timestamp.Unix()),
+		bid.PurchaseData,
+		bid.OrderId,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (b *BidsDB) UpdateStatus(bidID string, status pb.BidAck_Status) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	_, err := b.db.Exec("update bids set status=? where bidID=?", int(status), bidID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (b *BidsDB) SetOrderId(bidID string, orderID string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	_, err := b.db.Exec("update bids set orderID=? where bidID=?", orderID, bidID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (b *BidsDB) GetById(bidID string) (repo.Bid, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	stmt, err := b.db.Prepare("select bidID, vendorID, slug, listingHash, peerID, amount, status, timestamp, purchaseData, orderID from bids where bidID=?")
+	if err != nil {
+		return repo.Bid{}, err
+	}
+	defer stmt.Close()
+	return scanBid(stmt.QueryRow(bidID))
+}
+
+func (b *BidsDB) Get(vendorID string, slug string) ([]repo.Bid, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	stm := "select bidID, vendorID, slug, listingHash, peerID, amount, status, timestamp, purchaseData, orderID from bids where vendorID=? and slug=? order by amount desc, timestamp asc;"
+	rows, err := b.db.Query(stm, vendorID, slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.Bid
+	for rows.Next() {
+		bid, err := scanBid(rows)
+		if err != nil {
+			return ret, err
+		}
+		ret = append(ret, bid)
+	}
+	return ret, nil
+}
+
+func (b *BidsDB) DeleteAll(vendorID string, slug string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	_, err := b.db.Exec("delete from bids where vendorID=? and slug=?", vendorID, slug)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBid(row scanner) (repo.Bid, error) {
+	var bidID, vendorID, slug, listingHash, peerID string
+	var amount, statusInt, timestamp int
+	var purchaseData []byte
+	var orderID sql.NullString
+	if err := row.Scan(&bidID, &vendorID, &slug, &listingHash, &peerID, &amount, &statusInt, &timestamp, &purchaseData, &orderID); err != nil {
+		return repo.Bid{}, err
+	}
+	return repo.Bid{
+		BidId:        bidID,
+		VendorId:     vendorID,
+		Slug:         slug,
+		ListingHash:  listingHash,
+		PeerId:       peerID,
+		Amount:       uint64(amount),
+		Status:       pb.BidAck_Status(statusInt).String(),
+		Timestamp:    time.Unix(int64(timestamp), 0),
+		PurchaseData: purchaseData,
+		OrderId:      orderID.String,
+	}, nil
+}
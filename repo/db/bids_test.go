@@ -0,0 +1,144 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/pb"
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+var bdb BidsDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	bdb = BidsDB{
+		db: conn,
+	}
+}
+
+func newTestBid(id string, slug string, amount uint64) repo.Bid {
+	return repo.Bid{
+		BidId:        id,
+		VendorId:     "QmVendor",
+		Slug:         slug,
+		ListingHash:  "QmListing",
+		PeerId:       "QmBuyer",
+		Amount:       amount,
+		Status:       pb.BidAck_ACCEPTED.String(),
+		Timestamp:    time.Now(),
+		PurchaseData: []byte("{}"),
+	}
+}
+
+func TestPutBid(t *testing.T) {
+	err := bdb.Put(newTestBid("bid1", "put-slug", 1000))
+	if err != nil {
+		t.Error(err)
+	}
+	stmt, err := bdb.db.Prepare("select slug, amount, status from bids where bidID=?")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer stmt.Close()
+	var slug string
+	var amount, status int
+	err = stmt.QueryRow("bid1").Scan(&slug, &amount, &status)
+	if err != nil {
+		t.Error(err)
+	}
+	if slug != "put-slug" || amount != 1000 || pb.BidAck_Status(status) != pb.BidAck_ACCEPTED {
+		t.Error("Bid returned incorrect values")
+	}
+}
+
+func TestGetBids(t *testing.T) {
+	bdb.Put(newTestBid("bid2", "get-slug", 1000))
+	bdb.Put(newTestBid("bid3", "get-slug", 3000))
+	bdb.Put(newTestBid("bid4", "get-slug", 2000))
+	bids, err := bdb.Get("QmVendor", "get-slug")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(bids) != 3 {
+		t.Error("Returned incorrect number of bids")
+		return
+	}
+	if bids[0].BidId != "bid3" || bids[1].BidId != "bid4" || bids[2].BidId != "bid2" {
+		t.Error("Bids not ordered by amount")
+	}
+	if string(bids[0].PurchaseData) != "{}" {
+		t.Error("Bid returned incorrect purchase data")
+	}
+}
+
+func TestGetBidById(t *testing.T) {
+	bdb.Put(newTestBid("bid5", "id-slug", 1000))
+	bid, err := bdb.GetById("bid5")
+	if err != nil {
+		t.Error(err)
+	}
+	if bid.Slug != "id-slug" || bid.Amount != 1000 || bid.PeerId != "QmBuyer" {
+		t.Error("Bid returned incorrect values")
+	}
+	_, err = bdb.GetById("nonexistent")
+	if err == nil {
+		t.Error("Returned bid that doesn't exist")
+	}
+}
+
+func TestUpdateBidStatus(t *testing.T) {
+	bdb.Put(newTestBid("bid6", "status-slug", 1000))
+	err := bdb.UpdateStatus("bid6", pb.BidAck_WON)
+	if err != nil {
+		t.Error(err)
+	}
+	bid, err := bdb.GetById("bid6")
+	if err != nil {
+		t.Error(err)
+	}
+	if bid.Status != pb.BidAck_WON.String() {
+		t.Error("Failed to update bid status")
+	}
+}
+
+func TestDeleteAllBids(t *testing.T) {
+	bdb.Put(newTestBid("bid7", "delete-slug", 1000))
+	bdb.Put(newTestBid("bid8", "delete-slug", 2000))
+	err := bdb.DeleteAll("QmVendor", "delete-slug")
+	if err != nil {
+		t.Error(err)
+	}
+	bids, err := bdb.Get("QmVendor", "delete-slug")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(bids) != 0 {
+		t.Error("Failed to delete bids")
+	}
+}
+
+func TestSetBidOrderId(t *testing.T) {
+	bdb.Put(newTestBid("bid9", "order-slug", 1000))
+	bid, err := bdb.GetById("bid9")
+	if err != nil {
+		t.Error(err)
+	}
+	if bid.OrderId != "" {
+		t.Error("New bid should not have an order")
+	}
+	err = bdb.SetOrderId("bid9", "QmOrder")
+	if err != nil {
+		t.Error(err)
+	}
+	bid, err = bdb.GetById("bid9")
+	if err != nil {
+		t.Error(err)
+	}
+	if bid.OrderId != "QmOrder" {
+		t.Error("Failed to record the order for the bid")
+	}
+}
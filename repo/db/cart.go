@@ -0,0 +1,140 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+type CartDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (c *CartDB) Put(item repo.CartItem) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("insert or replace into cart(itemID, vendorID, listingHash, item, timestamp) values(?,?,?,?,?)",
+		item.ItemId,
+		item.VendorId,
+		item.ListingHash,
+		[]byte(item.Item),
+		int(item.Timestamp.Unix()),
+	)
+	return err
+}
+
+func (c *CartDB) Get() ([]repo.CartItem, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	rows, err := c.db.Query("select itemID, vendorID, listingHash, item, timestamp from cart order by timestamp asc, rowid asc;")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.CartItem
+	for rows.Next() {
+		var itemID, vendorID, listingHash string
+		var item []byte
+		var timestamp int
+		if err := rows.Scan(&itemID, &vendorID, &listingHash, &item, &timestamp); err != nil {
+			return ret, err
+		}
+		ret = append(ret, repo.CartItem{
+			ItemId:      itemID,
+			VendorId:    vendorID,
+			ListingHash: listingHash,
+			Item:        json.RawMessage(item),
+			Timestamp:   time.Unix(int64(timestamp), 0),
+		})
+	}
+	return ret, nil
+}
+
+func (c *CartDB) Delete(itemID string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("delete from cart where itemID=?", itemID)
+	return err
+}
+
+func (c *CartDB) DeleteAll() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("delete from cart")
+	return err
+}
+
+func (c *CartDB) PutCheckout(checkout repo.CartCheckout) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	orderIDs, err := json.Marshal(checkout.OrderIds)
+	if err != nil {
+		return err
+	}
+	txids, err := json.Marshal(checkout.Txids)
+	if err != nil {
+		return err
+	}
+	_, err = c.db.Exec("insert or replace into cartcheckouts(checkoutID, orderIDs, txids, timestamp) values(?,?,?,?)",
+		checkout.CheckoutId,
+		orderIDs,
+		txids,
+		int(checkout.Timestamp.Unix()),
+	)
+	return err
+}
+
+func (c *CartDB) GetCheckout(checkoutID string) (repo.CartCheckout, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	var orderIDs, txids []byte
+	var timestamp int
+	err := c.db.QueryRow("select orderIDs, txids, timestamp from cartcheckouts where checkoutID=?", checkoutID).Scan(&orderIDs, &txids, &timestamp)
+	if err != nil {
+		return repo.CartCheckout{}, err
+	}
+	return unmarshalCheckout(checkoutID, orderIDs, txids, timestamp)
+}
+
+func (c *CartDB) GetCheckouts() ([]repo.CartCheckout, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	rows, err := c.db.Query("select checkoutID, orderIDs, txids, timestamp from cartcheckouts order by timestamp desc;")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.CartCheckout
+	for rows.Next() {
+		var checkoutID string
+		var orderIDs, txids []byte
+		var timestamp int
+		if err := rows.Scan(&checkoutID, &orderIDs, &txids, &timestamp); err != nil {
+			return ret, err
+		}
+		checkout, err := unmarshalCheckout(checkoutID, orderIDs, txids, timestamp)
+		if err != nil {
+			return ret, err
+		}
+		ret = append(ret, checkout)
+	}
+	return ret, nil
+}
+
+func unmarshalCheckout(checkoutID string, orderIDs, txids []byte, timestamp int) (repo.CartCheckout, error) {
+	checkout := repo.CartCheckout{
+		CheckoutId: checkoutID,
+		Timestamp:  time.Unix(int64(timestamp), 0),
+	}
+	if err := json.Unmarshal(orderIDs, &checkout.OrderIds); err != nil {
+		return checkout, err
+	}
+	if err := json.Unmarshal(txids, &checkout.Txids); err != nil {
+		return checkout, err
+	}
+	return checkout, nil
+}
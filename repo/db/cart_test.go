@@ -0,0 +1,94 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+var cartdb CartDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	cartdb = CartDB{
+		db: conn,
+	}
+}
+
+func TestCartPutAndGet(t *testing.T) {
+	cartdb.DeleteAll()
+	err := cartdb.Put(repo.CartItem{
+		ItemId:      "item1",
+		VendorId:    "QmVendor1",
+		ListingHash: "QmListing1",
+		Item:        json.RawMessage(`{"quantity":1}`),
+		Timestamp:   time.Now(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	cartdb.Put(repo.CartItem{ItemId: "item2", VendorId: "QmVendor2", ListingHash: "QmListing2", Item: json.RawMessage(`{}`), Timestamp: time.Now()})
+	items, err := cartdb.Get()
+	if err != nil {
+		t.Error(err)
+	}
+	if len(items) != 2 {
+		t.Fatal("Returned incorrect number of cart items")
+	}
+	if items[0].ItemId != "item1" || items[0].VendorId != "QmVendor1" || items[0].ListingHash != "QmListing1" || string(items[0].Item) != `{"quantity":1}` {
+		t.Error("Cart item returned incorrect values")
+	}
+}
+
+func TestCartDelete(t *testing.T) {
+	cartdb.DeleteAll()
+	cartdb.Put(repo.CartItem{ItemId: "item1", Item: json.RawMessage(`{}`), Timestamp: time.Now()})
+	cartdb.Put(repo.CartItem{ItemId: "item2", Item: json.RawMessage(`{}`), Timestamp: time.Now()})
+	if err := cartdb.Delete("item1"); err != nil {
+		t.Error(err)
+	}
+	items, _ := cartdb.Get()
+	if len(items) != 1 || items[0].ItemId != "item2" {
+		t.Error("Failed to delete cart item")
+	}
+	if err := cartdb.DeleteAll(); err != nil {
+		t.Error(err)
+	}
+	items, _ = cartdb.Get()
+	if len(items) != 0 {
+		t.Error("Failed to clear the cart")
+	}
+}
+
+func TestCartCheckouts(t *testing.T) {
+	err := cartdb.PutCheckout(repo.CartCheckout{
+		CheckoutId: "checkout1",
+		OrderIds:   []string{"order1", "order2"},
+		Timestamp:  time.Now().Add(-time.Hour),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	cartdb.PutCheckout(repo.CartCheckout{CheckoutId: "checkout2", OrderIds: []string{"order3"}, Txids: []string{"txid"}, Timestamp: time.Now()})
+	checkout, err := cartdb.GetCheckout("checkout1")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(checkout.OrderIds) != 2 || checkout.OrderIds[1] != "order2" || len(checkout.Txids) != 0 {
+		t.Error("Checkout returned incorrect values")
+	}
+	checkouts, err := cartdb.GetCheckouts()
+	if err != nil {
+		t.Error(err)
+	}
+	if len(checkouts) != 2 || checkouts[0].CheckoutId != "checkout2" || checkouts[0].Txids[0] != "txid" {
+		t.Error("Returned incorrect checkouts")
+	}
+	if _, err := cartdb.GetCheckout("missing"); err == nil {
+		t.Error("Expected an error for a missing checkout")
+	}
+}
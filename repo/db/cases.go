@@ -139,6 +139,14 @@ func (c *CasesDB) MarkAsRead(orderID string) error {
 }
 
 func (c *CasesDB) MarkAsClosed(caseID string, resolution *pb.DisputeResolution) error {
+	return c.updateResolution(caseID, resolution, pb.OrderState_RESOLVED)
+}
+
+func (c *CasesDB) MarkAsDecided(caseID string, resolution *pb.DisputeResolution) error {
+	return c.updateResolution(caseID, resolution, pb.OrderState_DECIDED)
+}
+
+func (c *CasesDB) updateResolution(caseID string, resolution *pb.DisputeResolution, state pb.OrderState) error {
 	m := jsonpb.Marshaler{
 		EnumsAsInts:  false,
 		EmitDefaults: true,
@@ -155,7 +163,7 @@ func (c *CasesDB) MarkAsClosed(caseID string, resolution *pb.DisputeResolution)
 	}
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	_, err = c.db.Exec("update cases set disputeResolution=?, state=? where caseID=?", rOut, int(pb.OrderState_RESOLVED), caseID)
+	_, err = c.db.Exec("update cases set disputeResolution=?, state=? where caseID=?", rOut, int(state), caseID)
 	if err != nil {
 		return err
 	}
@@ -169,6 +177,10 @@ func (c *CasesDB) Delete(orderID string) error {
 	if err != nil {
 		return err
 	}
+	_, err = c.db.Exec("delete from caseevidence where caseID=?", orderID)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -255,6 +267,15 @@ func (c *CasesDB) GetAll(offsetId string, limit int) ([]repo.Case, error) {
 	return ret, nil
 }
 
+func (c *CasesDB) CountResolved() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	row := c.db.QueryRow("select Count(*) from cases where state=?", int(pb.OrderState_RESOLVED))
+	var count int
+	row.Scan(&count)
+	return count
+}
+
 func (c *CasesDB) GetCaseMetadata(caseID string) (buyerContract, vendorContract *pb.RicardianContract, buyerValidationErrors, vendorValidationErrors []string, state pb.OrderState, read bool, timestamp time.Time, buyerOpened bool, claim string, resolution *pb.DisputeResolution, err error) {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
@@ -387,3 +408,77 @@ func (c *CasesDB) GetPayoutDetails(caseID string) (buyerContract, vendorContract
 	}
 	return brc, vrc, buyerAddr, vendorAddr, toPointer(buyerOutpointsOut), toPointer(vendorOutpointsOut), pb.OrderState(stateInt), nil
 }
+
+func (c *CasesDB) PutEvidence(caseID string, evidence *pb.DisputeEvidence) error {
+	m := jsonpb.Marshaler{
+		EnumsAsInts:  false,
+		EmitDefaults: true,
+		Indent:       "    ",
+		OrigName:     false,
+	}
+	out, err := m.MarshalToString(evidence)
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err = c.db.Exec("insert or replace into caseevidence(caseID, hash, submittedBy, evidence, timestamp) values(?,?,?,?,?)",
+		caseID,
+		evidence.Hash,
+		evidence.SubmittedBy,
+		out,
+		int(time.Now().Unix()),
+	)
+	return err
+}
+
+func (c *CasesDB) GetEvidence(caseID string) ([]*pb.DisputeEvidence, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	rows, err := c.db.Query("select evidence from caseevidence where caseID=? order by timestamp, rowid", caseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []*pb.DisputeEvidence
+	for rows.Next() {
+		var out string
+		if err := rows.Scan(&out); err != nil {
+			return nil, err
+		}
+		evidence := new(pb.DisputeEvidence)
+		if err := jsonpb.UnmarshalString(out, evidence); err != nil {
+			return nil, err
+		}
+		ret = append(ret, evidence)
+	}
+	return ret, nil
+}
+
+func (c *CasesDB) PutProposals(caseID string, proposals []*pb.DisputeProposal) error {
+	out, err := json.Marshal(proposals)
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err = c.db.Exec("update cases set proposals=? where caseID=?", out, caseID)
+	return err
+}
+
+func (c *CasesDB) GetProposals(caseID string) ([]*pb.DisputeProposal, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	var out []byte
+	err := c.db.QueryRow("select proposals from cases where caseID=?", caseID).Scan(&out)
+	if err != nil {
+		return nil, err
+	}
+	var proposals []*pb.DisputeProposal
+	if len(out) > 0 {
+		if err := json.Unmarshal(out, &proposals); err != nil {
+			return nil, err
+		}
+	}
+	return proposals, nil
+}
@@ -427,3 +427,115 @@ func TestCasesDB_GetAll(t *testing.T) {
 		t.Error("Returned incorrect number of cases")
 	}
 }
+
+func TestCasesDB_CountResolved(t *testing.T) {
+	err := casesdb.Put("caseID3", pb.OrderState_DISPUTED, true, "blah")
+	if err != nil {
+		t.Error(err)
+	}
+	count := casesdb.CountResolved()
+	err = casesdb.MarkAsClosed("caseID3", new(pb.DisputeResolution))
+	if err != nil {
+		t.Error(err)
+	}
+	if casesdb.CountResolved() != count+1 {
+		t.Error("Returned incorrect number of resolved cases")
+	}
+}
+
+func TestMarkAsDecided(t *testing.T) {
+	err := casesdb.Put("caseID4", pb.OrderState_DISPUTED, true, "blah")
+	if err != nil {
+		t.Error(err)
+	}
+	err = casesdb.UpdateBuyerInfo("caseID4", contract, []string{}, "addr1", buyerTestOutpoints)
+	if err != nil {
+		t.Error(err)
+	}
+	err = casesdb.UpdateVendorInfo("caseID4", contract, []string{}, "addr2", vendorTestOutpoints)
+	if err != nil {
+		t.Error(err)
+	}
+	d := new(pb.DisputeResolution)
+	d.Resolution = "Proposed"
+	err = casesdb.MarkAsDecided("caseID4", d)
+	if err != nil {
+		t.Error(err)
+	}
+	_, _, _, _, state, _, _, _, _, resolution, err := casesdb.GetCaseMetadata("caseID4")
+	if err != nil {
+		t.Error(err)
+	}
+	if state != pb.OrderState_DECIDED {
+		t.Error("Mark as decided failed to set state to decided")
+	}
+	if resolution.Resolution != d.Resolution {
+		t.Error("Failed to save correct dispute resolution")
+	}
+}
+
+func TestCasesDB_PutEvidence(t *testing.T) {
+	err := casesdb.Put("caseID5", pb.OrderState_DISPUTED, true, "blah")
+	if err != nil {
+		t.Error(err)
+	}
+	photo := &pb.DisputeEvidence{OrderId: "caseID5", SubmittedBy: "buyer", Name: "photo.jpg", MediaType: "image/jpeg", Size: 100, Hash: "aa"}
+	receipt := &pb.DisputeEvidence{OrderId: "caseID5", SubmittedBy: "vendor", Name: "receipt.pdf", MediaType: "application/pdf", Size: 200, Hash: "bb"}
+	if err := casesdb.PutEvidence("caseID5", photo); err != nil {
+		t.Error(err)
+	}
+	if err := casesdb.PutEvidence("caseID5", receipt); err != nil {
+		t.Error(err)
+	}
+	evidence, err := casesdb.GetEvidence("caseID5")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(evidence) != 2 {
+		t.Fatal("Returned incorrect number of evidence files")
+	}
+	if evidence[0].Name != "photo.jpg" || evidence[0].Hash != "aa" || evidence[1].SubmittedBy != "vendor" || evidence[1].Size != 200 {
+		t.Error("Returned incorrect evidence")
+	}
+	if err := casesdb.Delete("caseID5"); err != nil {
+		t.Error(err)
+	}
+	evidence, err = casesdb.GetEvidence("caseID5")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(evidence) != 0 {
+		t.Error("Failed to delete evidence along with the case")
+	}
+}
+
+func TestCasesDB_PutProposals(t *testing.T) {
+	err := casesdb.Put("caseID6", pb.OrderState_DISPUTED, true, "blah")
+	if err != nil {
+		t.Error(err)
+	}
+	proposals, err := casesdb.GetProposals("caseID6")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(proposals) != 0 {
+		t.Error("A new case should have no proposals")
+	}
+	err = casesdb.PutProposals("caseID6", []*pb.DisputeProposal{
+		{ProposalId: "p1", ProposedBy: "buyer", BuyerPercentage: 80, VendorPercentage: 20},
+		{ProposalId: "p2", ProposedBy: "vendor", BuyerPercentage: 50, VendorPercentage: 50, Counters: "p1", AcceptedBy: []string{"vendor"}},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	proposals, err = casesdb.GetProposals("caseID6")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(proposals) != 2 {
+		t.Fatal("Returned incorrect number of proposals")
+	}
+	if proposals[0].ProposalId != "p1" || proposals[0].BuyerPercentage != 80 || proposals[1].Counters != "p1" || proposals[1].AcceptedBy[0] != "vendor" {
+		t.Error("Returned incorrect proposals")
+	}
+}
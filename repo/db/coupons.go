@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"sync"
+	"time"
 
 	"github.com/OpenBazaar/openbazaar-go/repo"
 )
@@ -60,3 +61,42 @@ func (c *CouponDB) Delete(slug string) error {
 	}
 	return nil
 }
+
+func (c *CouponDB) PutRedemption(redemption repo.CouponRedemption) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("insert or replace into couponredemptions(slug, hash, orderID, buyerID, timestamp) values(?,?,?,?,?)",
+		redemption.Slug,
+		redemption.Hash,
+		redemption.OrderId,
+		redemption.BuyerId,
+		int(redemption.Timestamp.Unix()),
+	)
+	return err
+}
+
+func (c *CouponDB) GetRedemptions(slug string, hash string) ([]repo.CouponRedemption, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	rows, err := c.db.Query("select orderID, buyerID, timestamp from couponredemptions where slug=? and hash=? order by timestamp", slug, hash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.CouponRedemption
+	for rows.Next() {
+		var orderID, buyerID string
+		var timestamp int
+		if err := rows.Scan(&orderID, &buyerID, &timestamp); err != nil {
+			return ret, err
+		}
+		ret = append(ret, repo.CouponRedemption{
+			Slug:      slug,
+			Hash:      hash,
+			OrderId:   orderID,
+			BuyerId:   buyerID,
+			Timestamp: time.Unix(int64(timestamp), 0),
+		})
+	}
+	return ret, nil
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"github.com/OpenBazaar/openbazaar-go/repo"
 	"testing"
+	"time"
 )
 
 var coup CouponDB
@@ -96,3 +97,30 @@ func TestDeleteCoupons(t *testing.T) {
 		t.Error("Failed to delete coupons")
 	}
 }
+
+func TestCouponRedemptions(t *testing.T) {
+	redemptions := []repo.CouponRedemption{
+		{Slug: "slug", Hash: "hash1", OrderId: "order1", BuyerId: "buyer1", Timestamp: time.Now()},
+		{Slug: "slug", Hash: "hash1", OrderId: "order2", BuyerId: "buyer2", Timestamp: time.Now()},
+		{Slug: "other", Hash: "hash1", OrderId: "order3", BuyerId: "buyer1", Timestamp: time.Now()},
+	}
+	for _, r := range redemptions {
+		if err := coup.PutRedemption(r); err != nil {
+			t.Error(err)
+		}
+	}
+	// Recording a sale twice counts once
+	if err := coup.PutRedemption(redemptions[0]); err != nil {
+		t.Error(err)
+	}
+	ret, err := coup.GetRedemptions("slug", "hash1")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(ret) != 2 {
+		t.Fatal("Returned incorrect number of redemptions")
+	}
+	if ret[0].BuyerId != "buyer1" && ret[1].BuyerId != "buyer1" {
+		t.Error("Returned incorrect redemptions")
+	}
+}
@@ -3,7 +3,9 @@ package db
 import (
 	"database/sql"
 	"path"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/OpenBazaar/openbazaar-go/repo"
 	"github.com/OpenBazaar/spvwallet"
@@ -34,7 +36,17 @@ type SQLiteDatastore struct {
 	coupons         repo.Coupons
 	txMetadata      repo.TxMetadata
 	moderatedStores repo.ModeratedStores
+	bids            repo.Bids
+	pledges         repo.Pledges
+	searchIndex     repo.SearchIndex
+	deliveries      repo.NotifierDeliveries
+	cart            repo.Cart
+	ratings         repo.Ratings
+	digitalGoods    repo.DigitalGoods
+	apiTokens       repo.APITokens
+	auditLog        repo.AuditLog
 	db              *sql.DB
+	path            string
 	lock            sync.RWMutex
 }
 
@@ -136,7 +148,44 @@ func Create(repoPath, password string, testnet bool) (*SQLiteDatastore, error) {
 			db:   conn,
 			lock: l,
 		},
+		bids: &BidsDB{
+			db:   conn,
+			lock: l,
+		},
+		pledges: &PledgesDB{
+			db:   conn,
+			lock: l,
+		},
+		searchIndex: &SearchIndexDB{
+			db:   conn,
+			lock: l,
+		},
+		deliveries: &NotifierDeliveriesDB{
+			db:   conn,
+			lock: l,
+		},
+		cart: &CartDB{
+			db:   conn,
+			lock: l,
+		},
+		ratings: &RatingsDB{
+			db:   conn,
+			lock: l,
+		},
+		digitalGoods: &DigitalGoodsDB{
+			db:   conn,
+			lock: l,
+		},
+		apiTokens: &APITokensDB{
+			db:   conn,
+			lock: l,
+		},
+		auditLog: &AuditLogDB{
+			db:   conn,
+			lock: l,
+		},
 		db:   conn,
+		path: dbPath,
 		lock: l,
 	}
 
@@ -227,6 +276,42 @@ func (d *SQLiteDatastore) ModeratedStores() repo.ModeratedStores {
 	return d.moderatedStores
 }
 
+func (d *SQLiteDatastore) Bids() repo.Bids {
+	return d.bids
+}
+
+func (d *SQLiteDatastore) Pledges() repo.Pledges {
+	return d.pledges
+}
+
+func (d *SQLiteDatastore) SearchIndex() repo.SearchIndex {
+	return d.searchIndex
+}
+
+func (d *SQLiteDatastore) NotifierDeliveries() repo.NotifierDeliveries {
+	return d.deliveries
+}
+
+func (d *SQLiteDatastore) Cart() repo.Cart {
+	return d.cart
+}
+
+func (d *SQLiteDatastore) Ratings() repo.Ratings {
+	return d.ratings
+}
+
+func (d *SQLiteDatastore) DigitalGoods() repo.DigitalGoods {
+	return d.digitalGoods
+}
+
+func (d *SQLiteDatastore) APITokens() repo.APITokens {
+	return d.apiTokens
+}
+
+func (d *SQLiteDatastore) AuditLog() repo.AuditLog {
+	return d.auditLog
+}
+
 func (d *SQLiteDatastore) Copy(dbPath string, password string) error {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -243,6 +328,15 @@ func (d *SQLiteDatastore) Copy(dbPath string, password string) error {
 		if err := rows.Scan(&name); err != nil {
 			return err
 		}
+		// The search index is a cache rebuilt by the crawler. Its full-text tables can't be
+		// copied with a plain select so the whole index is left behind.
+		if strings.HasPrefix(name, "search") {
+			continue
+		}
+		// The new database records its own schema version when its tables are created
+		if name == "schema_version" {
+			continue
+		}
 		tables = append(tables, name)
 	}
 	if password == "" {
@@ -284,24 +378,46 @@ func initDatabaseTables(db *sql.DB, password string) error {
 	create table txmetadata (txid text primary key not null, address text, memo text, orderID text, thumbnail text, canBumpFee integer);
 	create table inventory (slug text, variantIndex integer, count integer);
 	create index index_inventory on inventory (slug);
-	create table purchases (orderID text primary key not null, contract blob, state integer, read integer, timestamp integer, total integer, thumbnail text, vendorID text, vendorBlockchainID text, title text, shippingName text, shippingAddress text, paymentAddr text, funded integer, transactions blob);
+	create table purchases (orderID text primary key not null, contract blob, state integer, read integer, timestamp integer, total integer, thumbnail text, vendorID text, vendorBlockchainID text, title text, shippingName text, shippingAddress text, paymentAddr text, funded integer, transactions blob, lastUpdated integer, deadlineNotified integer);
 	create index index_purchases on purchases (paymentAddr);
-	create table sales (orderID text primary key not null, contract blob, state integer, read integer, timestamp integer, total integer, thumbnail text, buyerID text, buyerBlockchainID text, title text, shippingName text, shippingAddress text, paymentAddr text, funded integer, transactions blob);
+	create table sales (orderID text primary key not null, contract blob, state integer, read integer, timestamp integer, total integer, thumbnail text, buyerID text, buyerBlockchainID text, title text, shippingName text, shippingAddress text, paymentAddr text, funded integer, transactions blob, lastUpdated integer, deadlineNotified integer, ruleDecisions blob);
 	create index index_sales on sales (paymentAddr);
 	create table watchedscripts (scriptPubKey text primary key not null);
-	create table cases (caseID text primary key not null, buyerContract blob, vendorContract blob, buyerValidationErrors blob, vendorValidationErrors blob, buyerPayoutAddress text, vendorPayoutAddress text, buyerOutpoints blob, vendorOutpoints blob, state integer, read integer, timestamp integer, buyerOpened integer, claim text, disputeResolution blob);
+	create table cases (caseID text primary key not null, buyerContract blob, vendorContract blob, buyerValidationErrors blob, vendorValidationErrors blob, buyerPayoutAddress text, vendorPayoutAddress text, buyerOutpoints blob, vendorOutpoints blob, state integer, read integer, timestamp integer, buyerOpened integer, claim text, disputeResolution blob, proposals blob);
 	create table chat (messageID text primary key not null, peerID text, subject text, message text, read integer, timestamp integer, outgoing integer);
 	create index index_chat on chat (peerID, subject, read, timestamp);
 	create table notifications (serializedNotification blob, timestamp integer, read integer);
 	create table coupons (slug text, code text, hash text);
+	create table couponredemptions (slug text not null, hash text not null, orderID text not null, buyerID text, timestamp integer, primary key (slug, hash, orderID));
 	create index index_coupons on coupons (slug);
 	create table moderatedstores (peerID text primary key not null);
+	create table bids (bidID text primary key not null, vendorID text, slug text, listingHash text, peerID text, amount integer, status integer, timestamp integer, purchaseData blob, orderID text);
+	create index index_bids on bids (vendorID, slug);
+	create table pledges (orderID text primary key not null, slug text, buyerID text, amount integer, timestamp integer, settled integer);
+	create index index_pledges on pledges (slug);
+	create table searchlistings (peerID text not null, slug text not null, hash text, title text, categories text, tags text, contractType text, description text, thumbnail text, currencyCode text, price integer, shipsTo text, freeShipping text, language text, averageRating real, ratingCount integer, primary key (peerID, slug));
+	create virtual table searchtext using fts4(title, description, categories, tags);
+	create table searchpeers (peerID text primary key not null, rootHash text, timestamp integer);
+	create table notifierdeliveries (notifier text, event text, attempts integer, delivered integer, error text, timestamp integer);
+	create table cart (itemID text primary key not null, vendorID text, listingHash text, item blob, timestamp integer);
+	create table cartcheckouts (checkoutID text primary key not null, orderIDs blob, txids blob, timestamp integer);
+	create table ratings (hash text not null, kind text not null, peerID text, slug text, valid integer, error text, rating blob, timestamp integer, primary key (hash, kind));
+	create table caseevidence (caseID text not null, hash text not null, submittedBy text not null, evidence blob, timestamp integer, primary key (caseID, hash, submittedBy));
+	create table digitalgoods (slug text primary key not null, filename text, mediaType text, hash text, size integer, autoFulfill integer, data blob, timestamp integer);
+	create table apitokens (name text primary key not null, hash text unique not null, scopes blob, expiry integer, revoked integer, timestamp integer);
+	create table auditlog (id integer primary key not null, timestamp integer, method text, path text, identity text, remoteAddr text, status integer, prevHash text, hash text);
+	create index index_auditlog on auditlog (timestamp);
+	create trigger auditlog_no_update before update on auditlog begin select raise(abort, 'audit log is append-only'); end;
+	create trigger auditlog_no_delete before delete on auditlog begin select raise(abort, 'audit log is append-only'); end;
+	create table auditloghead (id integer primary key not null check (id = 1), entryID integer, hash text);
+	create table schema_version (version integer primary key not null, description text, timestamp integer);
 	`
 	_, err := db.Exec(sqlStmt)
 	if err != nil {
 		return err
 	}
-	return nil
+	_, err = db.Exec("insert into schema_version(version, description, timestamp) values(?,?,?)", CurrentSchemaVersion(), "Initial schema", int(time.Now().Unix()))
+	return err
 }
 
 type ConfigDB struct {
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+type DigitalGoodsDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (d *DigitalGoodsDB) Put(good repo.DigitalGood) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	autoFulfill := 0
+	if good.AutoFulfill {
+		autoFulfill = 1
+	}
+	_, err := d.db.Exec("insert or replace into digitalgoods(slug, filename, mediaType, hash, size, autoFulfill, data, timestamp) values(?,?,?,?,?,?,?,?)",
+		good.Slug,
+		good.Filename,
+		good.MediaType,
+		good.Hash,
+		good.Size,
+		autoFulfill,
+		good.Data,
+		int(good.Timestamp.Unix()),
+	)
+	return err
+}
+
+func (d *DigitalGoodsDB) Get(slug string) (repo.DigitalGood, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	var filename, mediaType, hash string
+	var size, autoFulfill, timestamp int
+	var data []byte
+	err := d.db.QueryRow("select filename, mediaType, hash, size, autoFulfill, data, timestamp from digitalgoods where slug=?", slug).Scan(&filename, &mediaType, &hash, &size, &autoFulfill, &data, &timestamp)
+	if err != nil {
+		return repo.DigitalGood{}, err
+	}
+	return repo.DigitalGood{
+		Slug:        slug,
+		Filename:    filename,
+		MediaType:   mediaType,
+		Hash:        hash,
+		Size:        size,
+		AutoFulfill: autoFulfill == 1,
+		Data:        data,
+		Timestamp:   time.Unix(int64(timestamp), 0),
+	}, nil
+}
+
+func (d *DigitalGoodsDB) GetAutoFulfill(slug string) (bool, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	var autoFulfill int
+	err := d.db.QueryRow("select autoFulfill from digitalgoods where slug=?", slug).Scan(&autoFulfill)
+	if err != nil {
+		return false, err
+	}
+	return autoFulfill == 1, nil
+}
+
+func (d *DigitalGoodsDB) Delete(slug string) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	_, err := d.db.Exec("delete from digitalgoods where slug=?", slug)
+	return err
+}
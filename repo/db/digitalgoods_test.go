@@ -0,0 +1,66 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+var dgdb DigitalGoodsDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	dgdb = DigitalGoodsDB{
+		db: conn,
+	}
+}
+
+func TestDigitalGoodsPutAndGet(t *testing.T) {
+	err := dgdb.Put(repo.DigitalGood{
+		Slug:        "ebook",
+		Filename:    "book.epub",
+		MediaType:   "application/epub+zip",
+		Hash:        "abcd",
+		Size:        4,
+		AutoFulfill: true,
+		Data:        []byte("book"),
+		Timestamp:   time.Now(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	good, err := dgdb.Get("ebook")
+	if err != nil {
+		t.Error(err)
+	}
+	if good.Filename != "book.epub" || good.MediaType != "application/epub+zip" || good.Hash != "abcd" || good.Size != 4 || !good.AutoFulfill || string(good.Data) != "book" {
+		t.Error("Digital good returned incorrect values")
+	}
+}
+
+func TestDigitalGoodsGetAutoFulfill(t *testing.T) {
+	dgdb.Put(repo.DigitalGood{Slug: "album", Filename: "album.zip", AutoFulfill: true, Data: []byte("album"), Timestamp: time.Now()})
+	dgdb.Put(repo.DigitalGood{Slug: "video", Filename: "video.mp4", Data: []byte("video"), Timestamp: time.Now()})
+	if autoFulfill, err := dgdb.GetAutoFulfill("album"); err != nil || !autoFulfill {
+		t.Error("Digital good should be auto-fulfilled")
+	}
+	if autoFulfill, err := dgdb.GetAutoFulfill("video"); err != nil || autoFulfill {
+		t.Error("Digital good should not be auto-fulfilled")
+	}
+	if _, err := dgdb.GetAutoFulfill("missing"); err == nil {
+		t.Error("Expected an error for a missing digital good")
+	}
+}
+
+func TestDigitalGoodsDelete(t *testing.T) {
+	dgdb.Put(repo.DigitalGood{Slug: "song", Filename: "song.mp3", Data: []byte("song"), Timestamp: time.Now()})
+	if err := dgdb.Delete("song"); err != nil {
+		t.Error(err)
+	}
+	if _, err := dgdb.Get("song"); err == nil {
+		t.Error("Digital good was not deleted")
+	}
+}
@@ -107,6 +107,11 @@ func Encrypt() error {
 		fmt.Println("The database is alredy encrypted")
 		return nil
 	}
+	// The copy is made into a database with the current schema
+	if _, _, err := sqlliteDB.Migrate(); err != nil {
+		fmt.Println(err)
+		return err
+	}
 	if err := os.MkdirAll(path.Join(repoPath, "tmp", "datastore"), os.ModePerm); err != nil {
 		return err
 	}
@@ -194,6 +199,11 @@ func Decrypt() error {
 		fmt.Println("Invalid password")
 		return err
 	}
+	// The copy is made into a database with the current schema
+	if _, _, err := sqlliteDB.Migrate(); err != nil {
+		fmt.Println(err)
+		return err
+	}
 	if err := os.MkdirAll(path.Join(repoPath, "tmp", "datastore"), os.ModePerm); err != nil {
 		return err
 	}
@@ -0,0 +1,261 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// A Migration upgrades the database schema by one version. Migrations run in order
+// inside a single transaction so a failure leaves the database untouched.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(tx *sql.Tx) error
+}
+
+// Migrations must be appended in version order and never edited once released.
+// New tables and columns should also be added to initDatabaseTables so new
+// databases start at the current version.
+var migrations = []Migration{
+	{1, "Add auction bids", func(tx *sql.Tx) error {
+		return execAll(tx,
+			"create table if not exists bids (bidID text primary key not null, vendorID text, slug text, listingHash text, peerID text, amount integer, status integer, timestamp integer, purchaseData blob);",
+			"create index if not exists index_bids on bids (vendorID, slug);",
+		)
+	}},
+	{2, "Add crowdfund pledges", func(tx *sql.Tx) error {
+		return execAll(tx,
+			"create table if not exists pledges (orderID text primary key not null, slug text, buyerID text, amount integer, timestamp integer, settled integer);",
+			"create index if not exists index_pledges on pledges (slug);",
+		)
+	}},
+	{3, "Add order timeout tracking", func(tx *sql.Tx) error {
+		for _, table := range []string{"purchases", "sales"} {
+			if err := addColumn(tx, table, "lastUpdated", "integer"); err != nil {
+				return err
+			}
+			if err := addColumn(tx, table, "deadlineNotified", "integer"); err != nil {
+				return err
+			}
+			// Existing orders get a full timeout period from the upgrade rather than
+			// expiring as soon as the node starts
+			if _, err := tx.Exec("update "+table+" set lastUpdated=?, deadlineNotified=0 where lastUpdated is null", int(time.Now().Unix())); err != nil {
+				return err
+			}
+		}
+		return nil
+	}},
+	{4, "Add search index", func(tx *sql.Tx) error {
+		return execAll(tx,
+			"create table if not exists searchlistings (peerID text not null, slug text not null, hash text, title text, categories text, tags text, contractType text, description text, thumbnail text, currencyCode text, price integer, shipsTo text, freeShipping text, language text, averageRating real, ratingCount integer, primary key (peerID, slug));",
+			"create virtual table if not exists searchtext using fts4(title, description, categories, tags);",
+			"create table if not exists searchpeers (peerID text primary key not null, rootHash text, timestamp integer);",
+		)
+	}},
+	{5, "Add notifier delivery log", func(tx *sql.Tx) error {
+		return execAll(tx,
+			"create table if not exists notifierdeliveries (notifier text, event text, attempts integer, delivered integer, error text, timestamp integer);",
+		)
+	}},
+	{6, "Add shopping cart", func(tx *sql.Tx) error {
+		return execAll(tx,
+			"create table if not exists cart (itemID text primary key not null, vendorID text, listingHash text, item blob, timestamp integer);",
+			"create table if not exists cartcheckouts (checkoutID text primary key not null, orderIDs blob, txids blob, timestamp integer);",
+		)
+	}},
+	{7, "Add ratings cache", func(tx *sql.Tx) error {
+		return execAll(tx,
+			"create table if not exists ratings (hash text primary key not null, peerID text, slug text, valid integer, error text, rating blob, timestamp integer);",
+		)
+	}},
+	{8, "Add dispute evidence", func(tx *sql.Tx) error {
+		return execAll(tx,
+			"create table if not exists caseevidence (caseID text not null, hash text not null, submittedBy text not null, evidence blob, timestamp integer, primary key (caseID, hash, submittedBy));",
+		)
+	}},
+	{9, "Add dispute negotiation", func(tx *sql.Tx) error {
+		return addColumn(tx, "cases", "proposals", "blob")
+	}},
+	{10, "Add digital goods", func(tx *sql.Tx) error {
+		return execAll(tx,
+			"create table if not exists digitalgoods (slug text primary key not null, filename text, mediaType text, hash text, size integer, autoFulfill integer, data blob, timestamp integer);",
+		)
+	}},
+	{11, "Add order rule decisions", func(tx *sql.Tx) error {
+		return addColumn(tx, "sales", "ruleDecisions", "blob")
+	}},
+	{12, "Add coupon redemptions", func(tx *sql.Tx) error {
+		return execAll(tx,
+			"create table if not exists couponredemptions (slug text not null, hash text not null, orderID text not null, buyerID text, timestamp integer, primary key (slug, hash, orderID));",
+		)
+	}},
+	{13, "Add API tokens", func(tx *sql.Tx) error {
+		return execAll(tx,
+			"create table if not exists apitokens (name text primary key not null, hash text unique not null, scopes blob, expiry integer, revoked integer, timestamp integer);",
+		)
+	}},
+	{14, "Add API audit log", func(tx *sql.Tx) error {
+		return execAll(tx,
+			"create table if not exists auditlog (id integer primary key not null, timestamp integer, method text, path text, identity text, remoteAddr text, status integer, prevHash text, hash text);",
+			"create index if not exists index_auditlog on auditlog (timestamp);",
+			"create trigger if not exists auditlog_no_update before update on auditlog begin select raise(abort, 'audit log is append-only'); end;",
+			"create trigger if not exists auditlog_no_delete before delete on auditlog begin select raise(abort, 'audit log is append-only'); end;",
+			"create table if not exists auditloghead (id integer primary key not null check (id = 1), entryID integer, hash text);",
+		)
+	}},
+	{15, "Separate cached ratings and rating responses", func(tx *sql.Tx) error {
+		// The cache didn't record which kind of object each hash was, so it's rebuilt
+		return execAll(tx,
+			"drop table if exists ratings;",
+			"create table ratings (hash text not null, kind text not null, peerID text, slug text, valid integer, error text, rating blob, timestamp integer, primary key (hash, kind));",
+		)
+	}},
+}
+
+// Return the schema version created by initDatabaseTables
+func CurrentSchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+func execAll(tx *sql.Tx, stmts ...string) error {
+	for _, stm := range stmts {
+		if _, err := tx.Exec(stm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add a column unless it already exists. SQLite has no 'add column if not exists'.
+func addColumn(tx *sql.Tx, table, column, columnType string) error {
+	rows, err := tx.Query("pragma table_info(" + table + ")")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, ctype string
+		var dflt interface{}
+		if err := rows.Scan(&cid, &name, &ctype, &notNull, &dflt, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	rows.Close()
+	_, err = tx.Exec("alter table " + table + " add column " + column + " " + columnType + ";")
+	return err
+}
+
+func schemaVersion(db *sql.DB) (int, error) {
+	var name string
+	err := db.QueryRow("select name from sqlite_master where type='table' and name='schema_version'").Scan(&name)
+	if err == sql.ErrNoRows {
+		// Databases created before migrations were introduced
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	var version sql.NullInt64
+	if err := db.QueryRow("select max(version) from schema_version").Scan(&version); err != nil {
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
+
+// Return the schema version of the database
+func (d *SQLiteDatastore) SchemaVersion() (int, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return schemaVersion(d.db)
+}
+
+// Return the migrations which have not been run against the database
+func (d *SQLiteDatastore) PendingMigrations() ([]Migration, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return pendingMigrations(d.db)
+}
+
+func pendingMigrations(db *sql.DB) ([]Migration, error) {
+	version, err := schemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	if version > CurrentSchemaVersion() {
+		return nil, fmt.Errorf("Database schema version %d is newer than this version of openbazaar-go supports (%d)", version, CurrentSchemaVersion())
+	}
+	var pending []Migration
+	for _, m := range migrations {
+		if m.Version > version {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate runs any pending migrations. The database file is copied before anything is
+// changed and the path of the copy is returned so it can be restored if something
+// goes wrong. Nothing is copied if the database is already up to date.
+func (d *SQLiteDatastore) Migrate() ([]Migration, string, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	pending, err := pendingMigrations(d.db)
+	if err != nil || len(pending) == 0 {
+		return nil, "", err
+	}
+	var backupPath string
+	if d.path != "" {
+		backupPath = fmt.Sprintf("%s.v%d.bak", d.path, pending[0].Version-1)
+		if err := copyFile(d.path, backupPath); err != nil {
+			return nil, "", err
+		}
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, backupPath, err
+	}
+	if _, err := tx.Exec("create table if not exists schema_version (version integer primary key not null, description text, timestamp integer);"); err != nil {
+		tx.Rollback()
+		return nil, backupPath, err
+	}
+	for _, m := range pending {
+		log.Noticef("Migrating database to schema version %d: %s", m.Version, m.Description)
+		if err := m.Up(tx); err != nil {
+			tx.Rollback()
+			return nil, backupPath, fmt.Errorf("Migration %d failed: %s", m.Version, err)
+		}
+		if _, err := tx.Exec("insert into schema_version(version, description, timestamp) values(?,?,?)", m.Version, m.Description, int(time.Now().Unix())); err != nil {
+			tx.Rollback()
+			return nil, backupPath, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, backupPath, err
+	}
+	return pending, backupPath, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
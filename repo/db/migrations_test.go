@@ -0,0 +1,87 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestInitialSchemaVersion(t *testing.T) {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	d := &SQLiteDatastore{db: conn}
+	version, err := d.SchemaVersion()
+	if err != nil {
+		t.Error(err)
+	}
+	if version != CurrentSchemaVersion() {
+		t.Error("New database was not created at the current schema version")
+	}
+	pending, err := d.PendingMigrations()
+	if err != nil {
+		t.Error(err)
+	}
+	if len(pending) != 0 {
+		t.Error("New database has pending migrations")
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	// The purchases, sales and cases tables as created before migrations were introduced
+	_, err := conn.Exec("create table purchases (orderID text primary key not null, contract blob, state integer, read integer, timestamp integer, total integer, thumbnail text, vendorID text, vendorBlockchainID text, title text, shippingName text, shippingAddress text, paymentAddr text, funded integer, transactions blob);" +
+		"create table sales (orderID text primary key not null, contract blob, state integer, read integer, timestamp integer, total integer, thumbnail text, buyerID text, buyerBlockchainID text, title text, shippingName text, shippingAddress text, paymentAddr text, funded integer, transactions blob);" +
+		"create table cases (caseID text primary key not null, buyerContract blob, vendorContract blob, buyerValidationErrors blob, vendorValidationErrors blob, buyerPayoutAddress text, vendorPayoutAddress text, buyerOutpoints blob, vendorOutpoints blob, state integer, read integer, timestamp integer, buyerOpened integer, claim text, disputeResolution blob);" +
+		"insert into purchases(orderID, state, timestamp) values('QmOrder', 1, 1000);")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &SQLiteDatastore{db: conn}
+	pending, err := d.PendingMigrations()
+	if err != nil {
+		t.Error(err)
+	}
+	if len(pending) != CurrentSchemaVersion() {
+		t.Error("Returned incorrect pending migrations")
+	}
+	applied, _, err := d.Migrate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != len(pending) {
+		t.Error("Not all migrations were applied")
+	}
+	version, _ := d.SchemaVersion()
+	if version != CurrentSchemaVersion() {
+		t.Error("Schema version was not updated")
+	}
+	var lastUpdated, notified int
+	err = conn.QueryRow("select lastUpdated, deadlineNotified from purchases where orderID='QmOrder'").Scan(&lastUpdated, &notified)
+	if err != nil {
+		t.Error(err)
+	}
+	if lastUpdated == 0 {
+		t.Error("Existing orders were not given a last updated time")
+	}
+	for _, table := range []string{"bids", "pledges", "searchlistings", "notifierdeliveries"} {
+		if _, err := conn.Exec("select * from " + table); err != nil {
+			t.Errorf("Table %s was not created", table)
+		}
+	}
+	if _, err := conn.Exec("select kind from ratings"); err != nil {
+		t.Error("Ratings cache was not given a kind column")
+	}
+	applied, _, err = d.Migrate()
+	if err != nil || len(applied) != 0 {
+		t.Error("Migrating an up to date database should do nothing")
+	}
+}
+
+func TestMigrateNewerSchema(t *testing.T) {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	conn.Exec("insert into schema_version(version, description, timestamp) values(?,?,?)", CurrentSchemaVersion()+1, "From the future", 0)
+	d := &SQLiteDatastore{db: conn}
+	if _, _, err := d.Migrate(); err == nil {
+		t.Error("Migrated a database with a newer schema")
+	}
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+type NotifierDeliveriesDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (n *NotifierDeliveriesDB) Put(delivery repo.NotifierDelivery) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	delivered := 0
+	if delivery.Delivered {
+		delivered = 1
+	}
+	_, err := n.db.Exec("insert into notifierdeliveries(notifier, event, attempts, delivered, error, timestamp) values(?,?,?,?,?,?)",
+		delivery.Notifier,
+		delivery.Event,
+		delivery.Attempts,
+		delivered,
+		delivery.Error,
+		int(delivery.Timestamp.Unix()),
+	)
+	return err
+}
+
+func (n *NotifierDeliveriesDB) GetAll(offsetID int, limit int) ([]repo.NotifierDelivery, error) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	var rows *sql.Rows
+	var err error
+	if offsetID > 0 {
+		rows, err = n.db.Query("select rowid, notifier, event, attempts, delivered, error, timestamp from notifierdeliveries where rowid<? order by rowid desc limit ?", offsetID, limit)
+	} else {
+		rows, err = n.db.Query("select rowid, notifier, event, attempts, delivered, error, timestamp from notifierdeliveries order by rowid desc limit ?", limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.NotifierDelivery
+	for rows.Next() {
+		var id, attempts, delivered, timestamp int
+		var notifier, event, errStr string
+		if err := rows.Scan(&id, &notifier, &event, &attempts, &delivered, &errStr, &timestamp); err != nil {
+			return ret, err
+		}
+		ret = append(ret, repo.NotifierDelivery{
+			ID:        id,
+			Notifier:  notifier,
+			Event:     event,
+			Attempts:  attempts,
+			Delivered: delivered == 1,
+			Error:     errStr,
+			Timestamp: time.Unix(int64(timestamp), 0),
+		})
+	}
+	return ret, nil
+}
+
+func (n *NotifierDeliveriesDB) Prune(before time.Time) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	_, err := n.db.Exec("delete from notifierdeliveries where timestamp<?", int(before.Unix()))
+	return err
+}
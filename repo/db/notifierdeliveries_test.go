@@ -0,0 +1,79 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+var nddb NotifierDeliveriesDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	nddb = NotifierDeliveriesDB{
+		db: conn,
+	}
+}
+
+func TestNotifierDeliveriesDB_Put(t *testing.T) {
+	err := nddb.Put(repo.NotifierDelivery{
+		Notifier:  "webhook",
+		Event:     "order",
+		Attempts:  3,
+		Delivered: false,
+		Error:     "connection refused",
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	deliveries, err := nddb.GetAll(0, -1)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(deliveries) == 0 {
+		t.Fatal("Failed to return delivery")
+	}
+	d := deliveries[0]
+	if d.Notifier != "webhook" || d.Event != "order" || d.Attempts != 3 || d.Delivered || d.Error != "connection refused" {
+		t.Error("Returned incorrect delivery")
+	}
+}
+
+func TestNotifierDeliveriesDB_GetAll(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		nddb.Put(repo.NotifierDelivery{Notifier: "command", Event: "payment", Attempts: 1, Delivered: true, Timestamp: time.Now()})
+	}
+	deliveries, err := nddb.GetAll(0, 2)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatal("Returned incorrect number of deliveries")
+	}
+	older, err := nddb.GetAll(deliveries[1].ID, -1)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, d := range older {
+		if d.ID >= deliveries[1].ID {
+			t.Error("Offset returned newer deliveries")
+		}
+	}
+}
+
+func TestNotifierDeliveriesDB_Prune(t *testing.T) {
+	nddb.Put(repo.NotifierDelivery{Notifier: "matrix", Event: "refund", Timestamp: time.Now().Add(-time.Hour * 24 * 60)})
+	if err := nddb.Prune(time.Now().Add(-time.Hour * 24 * 30)); err != nil {
+		t.Error(err)
+	}
+	deliveries, _ := nddb.GetAll(0, -1)
+	for _, d := range deliveries {
+		if d.Notifier == "matrix" {
+			t.Error("Failed to prune old delivery")
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+type PledgesDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (p *PledgesDB) Put(pledge repo.Pledge) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare("insert or replace into pledges(orderID, slug, buyerID, amount, timestamp, settled) values(?,?,?,?,?,?)")
+	if err != nil {
+		return err
+	}
+	settled := 0
+	if pledge.Settled {
+		settled = 1
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(pledge.OrderId, pledge.Slug, pledge.BuyerId, int(pledge.Amount), int(pledge.Timestamp.Unix()), settled)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (p *PledgesDB) MarkAsSettled(orderID string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, err := p.db.Exec("update pledges set settled=1 where orderID=?", orderID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *PledgesDB) Get(slug string) ([]repo.Pledge, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	rows, err := p.db.Query("select orderID, slug, buyerID, amount, timestamp, settled from pledges where slug=? order by timestamp asc;", slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.Pledge
+	for rows.Next() {
+		var orderID, slug, buyerID string
+		var amount, timestamp, settledInt int
+		if err := rows.Scan(&orderID, &slug, &buyerID, &amount, &timestamp, &settledInt); err != nil {
+			return ret, err
+		}
+		settled := false
+		if settledInt > 0 {
+			settled = true
+		}
+		ret = append(ret, repo.Pledge{
+			OrderId:   orderID,
+			Slug:      slug,
+			BuyerId:   buyerID,
+			Amount:    uint64(amount),
+			Timestamp: time.Unix(int64(timestamp), 0),
+			Settled:   settled,
+		})
+	}
+	return ret, nil
+}
+
+func (p *PledgesDB) Delete(orderID string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, err := p.db.Exec("delete from pledges where orderID=?", orderID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
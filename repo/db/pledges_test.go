@@ -0,0 +1,93 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+var pldb PledgesDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	pldb = PledgesDB{
+		db: conn,
+	}
+}
+
+func newTestPledge(orderID, slug, buyerID string, amount uint64) repo.Pledge {
+	return repo.Pledge{
+		OrderId:   orderID,
+		Slug:      slug,
+		BuyerId:   buyerID,
+		Amount:    amount,
+		Timestamp: time.Now(),
+	}
+}
+
+func TestPutPledge(t *testing.T) {
+	err := pldb.Put(newTestPledge("order1", "put-slug", "QmBuyer", 5000))
+	if err != nil {
+		t.Error(err)
+	}
+	stmt, err := pldb.db.Prepare("select slug, buyerID, amount, settled from pledges where orderID=?")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer stmt.Close()
+	var slug, buyerID string
+	var amount, settled int
+	err = stmt.QueryRow("order1").Scan(&slug, &buyerID, &amount, &settled)
+	if err != nil {
+		t.Error(err)
+	}
+	if slug != "put-slug" || buyerID != "QmBuyer" || amount != 5000 || settled != 0 {
+		t.Error("Pledge returned incorrect values")
+	}
+}
+
+func TestGetPledges(t *testing.T) {
+	pldb.Put(newTestPledge("order2", "get-slug", "QmBuyer1", 1000))
+	pldb.Put(newTestPledge("order3", "get-slug", "QmBuyer2", 2000))
+	pledges, err := pldb.Get("get-slug")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(pledges) != 2 {
+		t.Error("Returned incorrect number of pledges")
+	}
+}
+
+func TestMarkPledgeAsSettled(t *testing.T) {
+	pldb.Put(newTestPledge("order4", "settle-slug", "QmBuyer", 1000))
+	err := pldb.MarkAsSettled("order4")
+	if err != nil {
+		t.Error(err)
+	}
+	pledges, err := pldb.Get("settle-slug")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(pledges) != 1 || !pledges[0].Settled {
+		t.Error("Failed to mark pledge as settled")
+	}
+}
+
+func TestDeletePledge(t *testing.T) {
+	pldb.Put(newTestPledge("order5", "delete-slug", "QmBuyer", 1000))
+	err := pldb.Delete("order5")
+	if err != nil {
+		t.Error(err)
+	}
+	pledges, err := pldb.Get("delete-slug")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(pledges) != 0 {
+		t.Error("Failed to delete pledge")
+	}
+}
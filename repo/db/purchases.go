@@ -38,7 +38,7 @@ func (p *PurchasesDB) Put(orderID string, contract pb.RicardianContract, state p
 	if err != nil {
 		return err
 	}
-	stm := `insert or replace into purchases(orderID, contract, state, read, timestamp, total, thumbnail, vendorID, vendorBlockchainID, title, shippingName, shippingAddress, paymentAddr, funded, transactions) values(?,?,?,?,?,?,?,?,?,?,?,?,?,(select funded from purchases where orderID="` + orderID + `"),(select transactions from purchases where orderID="` + orderID + `"))`
+	stm := `insert or replace into purchases(orderID, contract, state, read, timestamp, total, thumbnail, vendorID, vendorBlockchainID, title, shippingName, shippingAddress, paymentAddr, funded, transactions, lastUpdated, deadlineNotified) values(?,?,?,?,?,?,?,?,?,?,?,?,?,(select funded from purchases where orderID="` + orderID + `"),(select transactions from purchases where orderID="` + orderID + `"),coalesce((select lastUpdated from purchases where orderID=? and state=?),?),coalesce((select deadlineNotified from purchases where orderID=? and state=?),0))`
 	stmt, err := tx.Prepare(stm)
 	if err != nil {
 		return err
@@ -71,6 +71,11 @@ func (p *PurchasesDB) Put(orderID string, contract pb.RicardianContract, state p
 		shippingName,
 		shippingAddress,
 		paymentAddr,
+		orderID,
+		int(state),
+		int(time.Now().Unix()),
+		orderID,
+		int(state),
 	)
 	if err != nil {
 		tx.Rollback()
@@ -221,3 +226,40 @@ func (p *PurchasesDB) GetByOrderId(orderId string) (*pb.RicardianContract, pb.Or
 	json.Unmarshal(serializedTransactions, &records)
 	return rc, pb.OrderState(stateInt), funded, records, read, nil
 }
+
+func (p *PurchasesDB) GetStale(state pb.OrderState, before time.Time) ([]repo.StaleOrder, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	rows, err := p.db.Query("select orderID, funded, lastUpdated, deadlineNotified from purchases where state=? and lastUpdated<?", int(state), int(before.Unix()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.StaleOrder
+	for rows.Next() {
+		var orderID string
+		var fundedInt, notifiedInt *int
+		var lastUpdated int
+		if err := rows.Scan(&orderID, &fundedInt, &lastUpdated, &notifiedInt); err != nil {
+			return ret, err
+		}
+		ret = append(ret, repo.StaleOrder{
+			OrderId:          orderID,
+			State:            state.String(),
+			Funded:           fundedInt != nil && *fundedInt == 1,
+			LastUpdated:      time.Unix(int64(lastUpdated), 0),
+			DeadlineNotified: notifiedInt != nil && *notifiedInt == 1,
+		})
+	}
+	return ret, nil
+}
+
+func (p *PurchasesDB) MarkDeadlineNotified(orderID string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, err := p.db.Exec("update purchases set deadlineNotified=1 where orderID=?", orderID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
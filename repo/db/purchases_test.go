@@ -287,3 +287,47 @@ func TestPurchasesDB_GetAll(t *testing.T) {
 		t.Error("Returned incorrect number of purchases")
 	}
 }
+
+func TestPurchasesGetStale(t *testing.T) {
+	purdb.Put("staleOrderID", *contract, pb.OrderState_FULFILLED, false)
+	stale, err := purdb.GetStale(pb.OrderState_FULFILLED, time.Now().Add(time.Second))
+	if err != nil {
+		t.Error(err)
+	}
+	if len(stale) != 1 || stale[0].OrderId != "staleOrderID" || stale[0].DeadlineNotified {
+		t.Error("Returned incorrect stale orders")
+	}
+	stale, err = purdb.GetStale(pb.OrderState_FULFILLED, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Error(err)
+	}
+	if len(stale) != 0 {
+		t.Error("Returned order updated after the given time")
+	}
+}
+
+func TestPurchasesMarkDeadlineNotified(t *testing.T) {
+	purdb.Put("notifiedOrderID", *contract, pb.OrderState_FULFILLED, false)
+	err := purdb.MarkDeadlineNotified("notifiedOrderID")
+	if err != nil {
+		t.Error(err)
+	}
+	purdb.Put("notifiedOrderID", *contract, pb.OrderState_FULFILLED, false)
+	stale, err := purdb.GetStale(pb.OrderState_FULFILLED, time.Now().Add(time.Second))
+	if err != nil {
+		t.Error(err)
+	}
+	for _, s := range stale {
+		if s.OrderId == "notifiedOrderID" && !s.DeadlineNotified {
+			t.Error("Deadline notification was reset without a state change")
+		}
+	}
+	purdb.Put("notifiedOrderID", *contract, pb.OrderState_COMPLETE, false)
+	stale, err = purdb.GetStale(pb.OrderState_COMPLETE, time.Now().Add(time.Second))
+	if err != nil {
+		t.Error(err)
+	}
+	if len(stale) != 1 || stale[0].DeadlineNotified {
+		t.Error("Deadline notification was not reset after a state change")
+	}
+}
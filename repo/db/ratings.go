@@ -0,0 +1,56 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+type RatingsDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+func (r *RatingsDB) Put(rating repo.CachedRating) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	valid := 0
+	if rating.Valid {
+		valid = 1
+	}
+	_, err := r.db.Exec("insert or replace into ratings(hash, kind, peerID, slug, valid, error, rating, timestamp) values(?,?,?,?,?,?,?,?)",
+		rating.Hash,
+		rating.Kind,
+		rating.PeerId,
+		rating.Slug,
+		valid,
+		rating.Error,
+		rating.Rating,
+		int(rating.Timestamp.Unix()),
+	)
+	return err
+}
+
+func (r *RatingsDB) Get(hash, kind string) (repo.CachedRating, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var peerID, slug, errStr string
+	var valid, timestamp int
+	var rating []byte
+	err := r.db.QueryRow("select peerID, slug, valid, error, rating, timestamp from ratings where hash=? and kind=?", hash, kind).Scan(&peerID, &slug, &valid, &errStr, &rating, &timestamp)
+	if err != nil {
+		return repo.CachedRating{}, err
+	}
+	return repo.CachedRating{
+		Hash:      hash,
+		Kind:      kind,
+		PeerId:    peerID,
+		Slug:      slug,
+		Valid:     valid == 1,
+		Error:     errStr,
+		Rating:    rating,
+		Timestamp: time.Unix(int64(timestamp), 0),
+	}, nil
+}
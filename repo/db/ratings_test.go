@@ -0,0 +1,77 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+var ratdb RatingsDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	ratdb = RatingsDB{
+		db: conn,
+	}
+}
+
+func TestRatingsPutAndGet(t *testing.T) {
+	err := ratdb.Put(repo.CachedRating{
+		Hash:      "QmRating1",
+		Kind:      repo.CachedRatingKindRating,
+		PeerId:    "QmVendor",
+		Slug:      "slug",
+		Valid:     true,
+		Rating:    []byte(`{"ratingData":{}}`),
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	rating, err := ratdb.Get("QmRating1", repo.CachedRatingKindRating)
+	if err != nil {
+		t.Error(err)
+	}
+	if rating.PeerId != "QmVendor" || rating.Slug != "slug" || !rating.Valid || string(rating.Rating) != `{"ratingData":{}}` {
+		t.Error("Cached rating returned incorrect values")
+	}
+}
+
+func TestRatingsPutInvalid(t *testing.T) {
+	ratdb.Put(repo.CachedRating{Hash: "QmRating2", Kind: repo.CachedRatingKindRating, PeerId: "QmVendor", Error: "Invalid vendor signature on rating", Timestamp: time.Now()})
+	rating, err := ratdb.Get("QmRating2", repo.CachedRatingKindRating)
+	if err != nil {
+		t.Error(err)
+	}
+	if rating.Valid || rating.Error != "Invalid vendor signature on rating" {
+		t.Error("Cached rating returned incorrect values")
+	}
+	if _, err := ratdb.Get("QmMissing", repo.CachedRatingKindRating); err == nil {
+		t.Error("Expected an error for a rating which isn't cached")
+	}
+}
+
+func TestRatingsKindsAreSeparate(t *testing.T) {
+	ratdb.Put(repo.CachedRating{Hash: "QmRating3", Kind: repo.CachedRatingKindRating, PeerId: "QmVendor", Valid: true, Rating: []byte("rating"), Timestamp: time.Now()})
+	if _, err := ratdb.Get("QmRating3", repo.CachedRatingKindResponse); err == nil {
+		t.Error("A cached rating was returned as a rating response")
+	}
+	ratdb.Put(repo.CachedRating{Hash: "QmRating3", Kind: repo.CachedRatingKindResponse, PeerId: "QmVendor", Error: "Rating response is for a different rating", Timestamp: time.Now()})
+	rating, err := ratdb.Get("QmRating3", repo.CachedRatingKindRating)
+	if err != nil {
+		t.Error(err)
+	}
+	if !rating.Valid || string(rating.Rating) != "rating" {
+		t.Error("Caching a response replaced the rating with the same hash")
+	}
+	response, err := ratdb.Get("QmRating3", repo.CachedRatingKindResponse)
+	if err != nil {
+		t.Error(err)
+	}
+	if response.Valid || response.Kind != repo.CachedRatingKindResponse {
+		t.Error("Cached rating response returned incorrect values")
+	}
+}
@@ -38,7 +38,7 @@ func (s *SalesDB) Put(orderID string, contract pb.RicardianContract, state pb.Or
 	if err != nil {
 		return err
 	}
-	stm := `insert or replace into sales(orderID, contract, state, read, timestamp, total, thumbnail, buyerID, buyerBlockchainID, title, shippingName, shippingAddress, paymentAddr, funded, transactions) values(?,?,?,?,?,?,?,?,?,?,?,?,?,(select funded from sales where orderID="` + orderID + `"),(select transactions from sales where orderID="` + orderID + `"))`
+	stm := `insert or replace into sales(orderID, contract, state, read, timestamp, total, thumbnail, buyerID, buyerBlockchainID, title, shippingName, shippingAddress, paymentAddr, funded, transactions, lastUpdated, deadlineNotified, ruleDecisions) values(?,?,?,?,?,?,?,?,?,?,?,?,?,(select funded from sales where orderID="` + orderID + `"),(select transactions from sales where orderID="` + orderID + `"),coalesce((select lastUpdated from sales where orderID=? and state=?),?),coalesce((select deadlineNotified from sales where orderID=? and state=?),0),(select ruleDecisions from sales where orderID=?))`
 	stmt, err := tx.Prepare(stm)
 	if err != nil {
 		return err
@@ -72,6 +72,12 @@ func (s *SalesDB) Put(orderID string, contract pb.RicardianContract, state pb.Or
 		shippingName,
 		shippingAddress,
 		address,
+		orderID,
+		int(state),
+		int(time.Now().Unix()),
+		orderID,
+		int(state),
+		orderID,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -222,3 +228,79 @@ func (s *SalesDB) GetByOrderId(orderId string) (*pb.RicardianContract, pb.OrderS
 	json.Unmarshal(serializedTransactions, &records)
 	return rc, pb.OrderState(stateInt), funded, records, read, nil
 }
+
+func (s *SalesDB) GetStale(state pb.OrderState, before time.Time) ([]repo.StaleOrder, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	rows, err := s.db.Query("select orderID, funded, lastUpdated, deadlineNotified from sales where state=? and lastUpdated<?", int(state), int(before.Unix()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []repo.StaleOrder
+	for rows.Next() {
+		var orderID string
+		var fundedInt, notifiedInt *int
+		var lastUpdated int
+		if err := rows.Scan(&orderID, &fundedInt, &lastUpdated, &notifiedInt); err != nil {
+			return ret, err
+		}
+		ret = append(ret, repo.StaleOrder{
+			OrderId:          orderID,
+			State:            state.String(),
+			Funded:           fundedInt != nil && *fundedInt == 1,
+			LastUpdated:      time.Unix(int64(lastUpdated), 0),
+			DeadlineNotified: notifiedInt != nil && *notifiedInt == 1,
+		})
+	}
+	return ret, nil
+}
+
+func (s *SalesDB) MarkDeadlineNotified(orderID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, err := s.db.Exec("update sales set deadlineNotified=1 where orderID=?", orderID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *SalesDB) AddRuleDecision(orderID string, decision *pb.OrderRuleDecision) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var out []byte
+	err := s.db.QueryRow("select ruleDecisions from sales where orderID=?", orderID).Scan(&out)
+	if err != nil {
+		return err
+	}
+	var decisions []*pb.OrderRuleDecision
+	if len(out) > 0 {
+		if err := json.Unmarshal(out, &decisions); err != nil {
+			return err
+		}
+	}
+	out, err = json.Marshal(append(decisions, decision))
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec("update sales set ruleDecisions=? where orderID=?", out, orderID)
+	return err
+}
+
+func (s *SalesDB) GetRuleDecisions(orderID string) ([]*pb.OrderRuleDecision, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var out []byte
+	err := s.db.QueryRow("select ruleDecisions from sales where orderID=?", orderID).Scan(&out)
+	if err != nil {
+		return nil, err
+	}
+	var decisions []*pb.OrderRuleDecision
+	if len(out) > 0 {
+		if err := json.Unmarshal(out, &decisions); err != nil {
+			return nil, err
+		}
+	}
+	return decisions, nil
+}
@@ -285,3 +285,73 @@ func TestSalesDB_GetAll(t *testing.T) {
 		t.Error("Returned incorrect number of sales")
 	}
 }
+
+func TestSalesGetStale(t *testing.T) {
+	saldb.Put("staleOrderID", *contract, pb.OrderState_FULFILLED, false)
+	stale, err := saldb.GetStale(pb.OrderState_FULFILLED, time.Now().Add(time.Second))
+	if err != nil {
+		t.Error(err)
+	}
+	if len(stale) != 1 || stale[0].OrderId != "staleOrderID" || stale[0].DeadlineNotified {
+		t.Error("Returned incorrect stale orders")
+	}
+	stale, err = saldb.GetStale(pb.OrderState_FULFILLED, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Error(err)
+	}
+	if len(stale) != 0 {
+		t.Error("Returned order updated after the given time")
+	}
+}
+
+func TestSalesMarkDeadlineNotified(t *testing.T) {
+	saldb.Put("notifiedOrderID", *contract, pb.OrderState_FULFILLED, false)
+	err := saldb.MarkDeadlineNotified("notifiedOrderID")
+	if err != nil {
+		t.Error(err)
+	}
+	saldb.Put("notifiedOrderID", *contract, pb.OrderState_FULFILLED, false)
+	stale, err := saldb.GetStale(pb.OrderState_FULFILLED, time.Now().Add(time.Second))
+	if err != nil {
+		t.Error(err)
+	}
+	for _, s := range stale {
+		if s.OrderId == "notifiedOrderID" && !s.DeadlineNotified {
+			t.Error("Deadline notification was reset without a state change")
+		}
+	}
+	saldb.Put("notifiedOrderID", *contract, pb.OrderState_COMPLETE, false)
+	stale, err = saldb.GetStale(pb.OrderState_COMPLETE, time.Now().Add(time.Second))
+	if err != nil {
+		t.Error(err)
+	}
+	if len(stale) != 1 || stale[0].DeadlineNotified {
+		t.Error("Deadline notification was not reset after a state change")
+	}
+}
+
+func TestSalesRuleDecisions(t *testing.T) {
+	if err := saldb.Put("ruleOrder", *contract, pb.OrderState_PENDING, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := saldb.AddRuleDecision("ruleOrder", &pb.OrderRuleDecision{Rule: "small orders", Action: "ACCEPT"}); err != nil {
+		t.Error(err)
+	}
+	if err := saldb.AddRuleDecision("ruleOrder", &pb.OrderRuleDecision{Rule: "services", Action: "FULFILL"}); err != nil {
+		t.Error(err)
+	}
+	// Updating the sale must keep its decisions
+	if err := saldb.Put("ruleOrder", *contract, pb.OrderState_FULFILLED, false); err != nil {
+		t.Error(err)
+	}
+	decisions, err := saldb.GetRuleDecisions("ruleOrder")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(decisions) != 2 || decisions[0].Action != "ACCEPT" || decisions[1].Rule != "services" {
+		t.Error("Returned incorrect rule decisions")
+	}
+	if err := saldb.AddRuleDecision("missing", &pb.OrderRuleDecision{}); err == nil {
+		t.Error("Expected an error for an unknown sale")
+	}
+}
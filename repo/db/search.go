@@ -0,0 +1,292 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+type SearchIndexDB struct {
+	db   *sql.DB
+	lock sync.RWMutex
+}
+
+const searchColumns = "peerID, slug, hash, title, categories, tags, contractType, description, thumbnail, currencyCode, price, shipsTo, freeShipping, language, averageRating, ratingCount"
+
+func (s *SearchIndexDB) Put(listing repo.SearchListing) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	categories, err := json.Marshal(listing.Categories)
+	if err != nil {
+		return err
+	}
+	tags, err := json.Marshal(listing.Tags)
+	if err != nil {
+		return err
+	}
+	thumbnail, err := json.Marshal(listing.Thumbnail)
+	if err != nil {
+		return err
+	}
+	shipsTo, err := json.Marshal(listing.ShipsTo)
+	if err != nil {
+		return err
+	}
+	freeShipping, err := json.Marshal(listing.FreeShipping)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := deleteSearchListing(tx, listing.PeerId, listing.Slug); err != nil {
+		tx.Rollback()
+		return err
+	}
+	res, err := tx.Exec("insert into searchlistings("+searchColumns+") values(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)",
+		listing.PeerId,
+		listing.Slug,
+		listing.Hash,
+		listing.Title,
+		string(categories),
+		string(tags),
+		listing.ContractType,
+		listing.Description,
+		string(thumbnail),
+		listing.Price.CurrencyCode,
+		int64(listing.Price.Amount),
+		string(shipsTo),
+		string(freeShipping),
+		listing.Language,
+		float64(listing.AverageRating),
+		int(listing.RatingCount),
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	rowid, err := res.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Exec("insert into searchtext(docid, title, description, categories, tags) values(?,?,?,?,?)",
+		rowid,
+		listing.Title,
+		listing.Description,
+		strings.Join(listing.Categories, " "),
+		strings.Join(listing.Tags, " "),
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func deleteSearchListing(tx *sql.Tx, peerID, slug string) error {
+	_, err := tx.Exec("delete from searchtext where docid in (select rowid from searchlistings where peerID=? and slug=?)", peerID, slug)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("delete from searchlistings where peerID=? and slug=?", peerID, slug)
+	return err
+}
+
+func (s *SearchIndexDB) Delete(peerID string, slug string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := deleteSearchListing(tx, peerID, slug); err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (s *SearchIndexDB) DeleteAll(peerID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmts := []string{
+		"delete from searchtext where docid in (select rowid from searchlistings where peerID=?)",
+		"delete from searchlistings where peerID=?",
+		"delete from searchpeers where peerID=?",
+	}
+	for _, stm := range stmts {
+		if _, err := tx.Exec(stm, peerID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
+func (s *SearchIndexDB) Get(peerID string) ([]repo.SearchListing, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	rows, err := s.db.Query("select "+searchColumns+" from searchlistings where peerID=?", peerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanSearchListings(rows)
+}
+
+func (s *SearchIndexDB) GetRootHash(peerID string) (string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var rootHash string
+	err := s.db.QueryRow("select rootHash from searchpeers where peerID=?", peerID).Scan(&rootHash)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return rootHash, err
+}
+
+func (s *SearchIndexDB) PutRootHash(peerID string, rootHash string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, err := s.db.Exec("insert or replace into searchpeers(peerID, rootHash, timestamp) values(?,?,?)", peerID, rootHash, int(time.Now().Unix()))
+	return err
+}
+
+func (s *SearchIndexDB) Query(query repo.SearchQuery) ([]repo.SearchListing, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var where []string
+	var args []interface{}
+	var orderArgs []interface{}
+	match := ftsMatchExpression(query.Query)
+	if match != "" {
+		where = append(where, "rowid in (select docid from searchtext where searchtext match ?)")
+		args = append(args, match)
+	}
+	if query.Category != "" {
+		where = append(where, "instr(categories, ?) > 0")
+		args = append(args, jsonString(query.Category))
+	}
+	if query.ShipsTo != "" {
+		// Listings which aren't physical goods don't ship anywhere so they always match
+		where = append(where, "(contractType!='PHYSICAL_GOOD' or instr(shipsTo, ?) > 0 or instr(shipsTo, '\"ALL\"') > 0)")
+		args = append(args, jsonString(strings.ToUpper(query.ShipsTo)))
+	}
+	if query.ComparesPrices() && query.PriceCurrency == "" {
+		return nil, errors.New("A currency code is required to filter or sort by price")
+	}
+	if query.PriceCurrency != "" {
+		where = append(where, "upper(currencyCode)=?")
+		args = append(args, strings.ToUpper(query.PriceCurrency))
+	}
+	if query.MinPrice > 0 {
+		where = append(where, "price >= ?")
+		args = append(args, int64(query.MinPrice))
+	}
+	if query.MaxPrice > 0 {
+		where = append(where, "price <= ?")
+		args = append(args, int64(query.MaxPrice))
+	}
+
+	var order string
+	switch query.Sort {
+	case "", "relevance":
+		// FTS4 has no ranking function so listings matching on their title come first
+		if match != "" {
+			order = "rowid in (select docid from searchtext where title match ?) desc, averageRating desc, ratingCount desc"
+			orderArgs = append(orderArgs, match)
+		} else {
+			order = "averageRating desc, ratingCount desc"
+		}
+	case "price-asc":
+		order = "price asc"
+	case "price-desc":
+		order = "price desc"
+	case "rating":
+		order = "averageRating desc, ratingCount desc"
+	default:
+		return nil, errors.New("Unknown sort order")
+	}
+
+	stm := "select " + searchColumns + " from searchlistings"
+	if len(where) > 0 {
+		stm += " where " + strings.Join(where, " and ")
+	}
+	stm += " order by " + order + ";"
+	rows, err := s.db.Query(stm, append(args, orderArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanSearchListings(rows)
+}
+
+func scanSearchListings(rows *sql.Rows) ([]repo.SearchListing, error) {
+	var ret []repo.SearchListing
+	for rows.Next() {
+		var peerID, slug, hash, title, categories, tags, contractType, description, thumbnail, currencyCode, shipsTo, freeShipping, language string
+		var price int64
+		var averageRating float64
+		var ratingCount int
+		if err := rows.Scan(&peerID, &slug, &hash, &title, &categories, &tags, &contractType, &description, &thumbnail, &currencyCode, &price, &shipsTo, &freeShipping, &language, &averageRating, &ratingCount); err != nil {
+			return ret, err
+		}
+		listing := repo.SearchListing{
+			PeerId:        peerID,
+			Hash:          hash,
+			Slug:          slug,
+			Title:         title,
+			ContractType:  contractType,
+			Description:   description,
+			Price:         repo.SearchPrice{CurrencyCode: currencyCode, Amount: uint64(price)},
+			Language:      language,
+			AverageRating: float32(averageRating),
+			RatingCount:   uint32(ratingCount),
+		}
+		json.Unmarshal([]byte(categories), &listing.Categories)
+		json.Unmarshal([]byte(tags), &listing.Tags)
+		json.Unmarshal([]byte(thumbnail), &listing.Thumbnail)
+		json.Unmarshal([]byte(shipsTo), &listing.ShipsTo)
+		json.Unmarshal([]byte(freeShipping), &listing.FreeShipping)
+		ret = append(ret, listing)
+	}
+	return ret, nil
+}
+
+// Build an FTS match expression from free text. Each word is matched as a prefix and all
+// words must match. Anything other than letters and digits is dropped so user input can't
+// inject FTS query syntax.
+func ftsMatchExpression(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for i, word := range words {
+		words[i] = strings.ToLower(word) + "*"
+	}
+	return strings.Join(words, " ")
+}
+
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/OpenBazaar/openbazaar-go/repo"
+)
+
+var sidb SearchIndexDB
+
+func init() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	sidb = SearchIndexDB{
+		db: conn,
+	}
+}
+
+func newTestSearchListing(peerID, slug, title string, price uint64, rating float32) repo.SearchListing {
+	return repo.SearchListing{
+		PeerId:        peerID,
+		Hash:          "Qm" + slug,
+		Slug:          slug,
+		Title:         title,
+		Categories:    []string{"Electronics"},
+		Tags:          []string{"gadget"},
+		ContractType:  "PHYSICAL_GOOD",
+		Description:   "A description of " + title,
+		Price:         repo.SearchPrice{CurrencyCode: "USD", Amount: price},
+		ShipsTo:       []string{"UNITED_STATES"},
+		AverageRating: rating,
+	}
+}
+
+func TestSearchIndexPut(t *testing.T) {
+	err := sidb.Put(newTestSearchListing("QmPutPeer", "put-slug", "Blue Widget", 1000, 4))
+	if err != nil {
+		t.Error(err)
+	}
+	err = sidb.Put(newTestSearchListing("QmPutPeer", "put-slug", "Red Widget", 1000, 4))
+	if err != nil {
+		t.Error(err)
+	}
+	listings, err := sidb.Query(repo.SearchQuery{Query: "widget"})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(listings) != 1 || listings[0].Title != "Red Widget" || listings[0].Price.Amount != 1000 {
+		t.Error("Put did not replace the existing listing")
+	}
+	if len(listings) == 1 && (len(listings[0].Categories) != 1 || listings[0].Categories[0] != "Electronics") {
+		t.Error("Query returned incorrect categories")
+	}
+}
+
+func TestSearchIndexQuery(t *testing.T) {
+	sidb.Put(newTestSearchListing("QmQueryPeer", "lamp", "Brass Lamp", 3000, 3))
+	sidb.Put(newTestSearchListing("QmQueryPeer", "shade", "Lamp Shade", 500, 5))
+	cheap := newTestSearchListing("QmQueryPeer", "bulb", "Light Bulb", 100, 4)
+	cheap.Categories = []string{"Lighting"}
+	cheap.ShipsTo = []string{"GERMANY"}
+	cheap.Description = "Fits any lamp"
+	sidb.Put(cheap)
+
+	listings, err := sidb.Query(repo.SearchQuery{Query: "lam"})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(listings) != 3 || listings[2].Slug != "bulb" {
+		t.Error("Title matches were not ranked first")
+	}
+	listings, _ = sidb.Query(repo.SearchQuery{Query: "lamp", PriceCurrency: "usd", Sort: "price-asc"})
+	if len(listings) != 3 || listings[0].Slug != "bulb" || listings[2].Slug != "lamp" {
+		t.Error("Listings were not sorted by price")
+	}
+	listings, _ = sidb.Query(repo.SearchQuery{Query: "lamp", Category: "Lighting"})
+	if len(listings) != 1 || listings[0].Slug != "bulb" {
+		t.Error("Category filter returned incorrect listings")
+	}
+	listings, _ = sidb.Query(repo.SearchQuery{Query: "lamp", ShipsTo: "germany"})
+	if len(listings) != 1 || listings[0].Slug != "bulb" {
+		t.Error("ShipsTo filter returned incorrect listings")
+	}
+	listings, _ = sidb.Query(repo.SearchQuery{Query: "lamp", PriceCurrency: "USD", MinPrice: 200, MaxPrice: 1000})
+	if len(listings) != 1 || listings[0].Slug != "shade" {
+		t.Error("Price filter returned incorrect listings")
+	}
+	listings, _ = sidb.Query(repo.SearchQuery{Query: "lamp OR \"", Sort: "rating"})
+	if len(listings) != 0 {
+		t.Error("Query syntax was not escaped")
+	}
+	_, err = sidb.Query(repo.SearchQuery{Query: "lamp", MinPrice: 200})
+	if err == nil {
+		t.Error("Query compared prices without a currency")
+	}
+	_, err = sidb.Query(repo.SearchQuery{Sort: "nonsense"})
+	if err == nil {
+		t.Error("Query accepted an unknown sort order")
+	}
+}
+
+func TestSearchIndexGet(t *testing.T) {
+	sidb.Put(newTestSearchListing("QmGetPeer", "one", "One", 100, 0))
+	sidb.Put(newTestSearchListing("QmGetPeer", "two", "Two", 100, 0))
+	if err := sidb.Delete("QmGetPeer", "two"); err != nil {
+		t.Error(err)
+	}
+	listings, err := sidb.Get("QmGetPeer")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(listings) != 1 || listings[0].Slug != "one" || listings[0].Hash != "Qmone" {
+		t.Error("Returned incorrect listings")
+	}
+	listings, _ = sidb.Query(repo.SearchQuery{Query: "two"})
+	if len(listings) != 0 {
+		t.Error("Deleted listing is still searchable")
+	}
+}
+
+func TestSearchIndexDeleteAll(t *testing.T) {
+	sidb.Put(newTestSearchListing("QmDeletePeer", "gone", "Vanishing Cabinet", 100, 0))
+	if err := sidb.PutRootHash("QmDeletePeer", "QmRoot"); err != nil {
+		t.Error(err)
+	}
+	rootHash, err := sidb.GetRootHash("QmDeletePeer")
+	if err != nil || rootHash != "QmRoot" {
+		t.Error("Returned incorrect root hash")
+	}
+	if err := sidb.DeleteAll("QmDeletePeer"); err != nil {
+		t.Error(err)
+	}
+	rootHash, err = sidb.GetRootHash("QmDeletePeer")
+	if err != nil || rootHash != "" {
+		t.Error("Failed to delete root hash")
+	}
+	listings, _ := sidb.Query(repo.SearchQuery{Query: "cabinet"})
+	if len(listings) != 0 {
+		t.Error("Failed to delete listings")
+	}
+}
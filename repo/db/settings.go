@@ -107,6 +107,12 @@ func (s *SettingsDB) Update(settings repo.SettingsData) error {
 	if settings.SMTPSettings == nil {
 		settings.SMTPSettings = current.SMTPSettings
 	}
+	if settings.Notifiers == nil {
+		settings.Notifiers = current.Notifiers
+	}
+	if settings.OrderRules == nil {
+		settings.OrderRules = current.OrderRules
+	}
 	err = s.Put(settings)
 	if err != nil {
 		return err
@@ -42,9 +42,8 @@ func DoInit(repoRoot string, nBitsForKeypair int, testnet bool, password string,
 			return err
 		}
 	}
-	seed := bip39.NewSeed(mnemonic, "Secret Passphrase")
 	fmt.Printf("Generating Ed25519 keypair...")
-	identityKey, err := ipfs.IdentityKeyFromSeed(seed, nBitsForKeypair)
+	identityKey, err := IdentityKeyFromMnemonic(mnemonic, nBitsForKeypair)
 	if err != nil {
 		return err
 	}
@@ -72,6 +71,12 @@ func DoInit(repoRoot string, nBitsForKeypair int, testnet bool, password string,
 	return initializeIpnsKeyspace(repoRoot, identityKey)
 }
 
+// Derive the node's identity key from its mnemonic seed
+func IdentityKeyFromMnemonic(mnemonic string, nBitsForKeypair int) ([]byte, error) {
+	seed := bip39.NewSeed(mnemonic, "Secret Passphrase")
+	return ipfs.IdentityKeyFromSeed(seed, nBitsForKeypair)
+}
+
 func maybeCreateOBDirectories(repoRoot string) error {
 	if err := os.MkdirAll(path.Join(repoRoot, "root"), os.ModePerm); err != nil {
 		return err
@@ -216,6 +221,9 @@ func addConfigExtensions(repoRoot string, testnet bool) error {
 	if err := extendConfigFile(r, "Tor-config", t); err != nil {
 		return err
 	}
+	if err := extendConfigFile(r, "Order-timeouts", DefaultOrderTimeouts); err != nil {
+		return err
+	}
 	if err := r.Close(); err != nil {
 		return err
 	}
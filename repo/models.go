@@ -1,24 +1,27 @@
 package repo
 
 import (
+	"encoding/json"
 	"time"
 )
 
 type SettingsData struct {
-	PaymentDataInQR    *bool              `json:"paymentDataInQR"`
-	ShowNotifications  *bool              `json:"showNotifications"`
-	ShowNsfw           *bool              `json:"showNsfw"`
-	ShippingAddresses  *[]ShippingAddress `json:"shippingAddresses"`
-	LocalCurrency      *string            `json:"localCurrency"`
-	Country            *string            `json:"country"`
-	Language           *string            `json:"language"`
-	TermsAndConditions *string            `json:"termsAndConditions"`
-	RefundPolicy       *string            `json:"refundPolicy"`
-	BlockedNodes       *[]string          `json:"blockedNodes"`
-	StoreModerators    *[]string          `json:"storeModerators"`
-	MisPaymentBuffer   *float32           `json:"mispaymentBuffer"`
-	SMTPSettings       *SMTPSettings      `json:"smtpSettings"`
-	Version            *string            `json:"version"`
+	PaymentDataInQR    *bool               `json:"paymentDataInQR"`
+	ShowNotifications  *bool               `json:"showNotifications"`
+	ShowNsfw           *bool               `json:"showNsfw"`
+	ShippingAddresses  *[]ShippingAddress  `json:"shippingAddresses"`
+	LocalCurrency      *string             `json:"localCurrency"`
+	Country            *string             `json:"country"`
+	Language           *string             `json:"language"`
+	TermsAndConditions *string             `json:"termsAndConditions"`
+	RefundPolicy       *string             `json:"refundPolicy"`
+	BlockedNodes       *[]string           `json:"blockedNodes"`
+	StoreModerators    *[]string           `json:"storeModerators"`
+	MisPaymentBuffer   *float32            `json:"mispaymentBuffer"`
+	SMTPSettings       *SMTPSettings       `json:"smtpSettings"`
+	Notifiers          *[]NotifierSettings `json:"notifiers"`
+	OrderRules         *[]OrderRule        `json:"orderRules"`
+	Version            *string             `json:"version"`
 }
 
 type ShippingAddress struct {
@@ -33,6 +36,34 @@ type ShippingAddress struct {
 	AddressNotes   string `json:"addressNotes"`
 }
 
+// A rule which automatically accepts, rejects or fulfills sales. Rules are tried in order
+// and a rule applies to a sale when all of its conditions match.
+type OrderRule struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+
+	// ACCEPT, REJECT or FULFILL
+	Action string `json:"action"`
+
+	// Every item in the order is one of these contract types
+	ContractTypes []string `json:"contractTypes"`
+
+	// The order total, in the smallest unit of the node's primary coin, is at most this.
+	// Zero matches any total.
+	MaxTotal uint64 `json:"maxTotal"`
+
+	// Limits on the total of orders paid with other coins, keyed by currency code and in
+	// the smallest unit of that coin. When the rule has any limit, orders paid with a coin
+	// it has no limit for don't match.
+	MaxTotals map[string]uint64 `json:"maxTotals,omitempty"`
+
+	// The order ships to one of these countries
+	ShipsTo []string `json:"shipsTo"`
+
+	// The order ships to a country other than these
+	NotShipsTo []string `json:"notShipsTo"`
+}
+
 type SMTPSettings struct {
 	Notifications  bool   `json:"notifications"`
 	ServerAddress  string `json:"serverAddress"`
@@ -40,6 +71,38 @@ type SMTPSettings struct {
 	Password       string `json:"password"`
 	SenderEmail    string `json:"senderEmail"`
 	RecipientEmail string `json:"recipientEmail"`
+
+	// Notification types to email. All are sent if empty.
+	Events []string `json:"events"`
+}
+
+// Settings for an external notifier. Type selects the registered notifier
+// and only the fields used by that type need to be set.
+type NotifierSettings struct {
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+
+	// Notification types to deliver. All are delivered if empty.
+	Events []string `json:"events"`
+
+	// Webhook
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+
+	// Matrix
+	HomeServer  string `json:"homeServer"`
+	RoomID      string `json:"roomId"`
+	AccessToken string `json:"accessToken"`
+}
+
+type NotifierDelivery struct {
+	ID        int       `json:"id"`
+	Notifier  string    `json:"notifier"`
+	Event     string    `json:"event"`
+	Attempts  int       `json:"attempts"`
+	Delivered bool      `json:"delivered"`
+	Error     string    `json:"error"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
 type Coupon struct {
@@ -48,6 +111,14 @@ type Coupon struct {
 	Hash string
 }
 
+type CouponRedemption struct {
+	Slug      string    `json:"slug"`
+	Hash      string    `json:"hash"`
+	OrderId   string    `json:"orderId"`
+	BuyerId   string    `json:"buyerId"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 type ChatMessage struct {
 	MessageId string    `json:"messageId"`
 	PeerId    string    `json:"peerId"`
@@ -120,3 +191,160 @@ type Case struct {
 	Read               bool      `json:"read"`
 	UnreadChatMessages int       `json:"unreadChatMessages"`
 }
+
+type Bid struct {
+	BidId        string    `json:"bidId"`
+	VendorId     string    `json:"vendorId"`
+	Slug         string    `json:"slug"`
+	ListingHash  string    `json:"listingHash"`
+	PeerId       string    `json:"peerId"`
+	Amount       uint64    `json:"amount"`
+	Status       string    `json:"status"`
+	Timestamp    time.Time `json:"timestamp"`
+	PurchaseData []byte    `json:"-"`
+	OrderId      string    `json:"orderId,omitempty"`
+}
+
+type StaleOrder struct {
+	OrderId          string    `json:"orderId"`
+	State            string    `json:"state"`
+	Funded           bool      `json:"funded"`
+	LastUpdated      time.Time `json:"lastUpdated"`
+	DeadlineNotified bool      `json:"deadlineNotified"`
+}
+
+type Pledge struct {
+	OrderId   string    `json:"orderId"`
+	Slug      string    `json:"slug"`
+	BuyerId   string    `json:"buyerId"`
+	Amount    uint64    `json:"amount"`
+	Timestamp time.Time `json:"timestamp"`
+	Settled   bool      `json:"settled"`
+}
+
+type CartItem struct {
+	ItemId      string          `json:"itemId"`
+	VendorId    string          `json:"vendorId"`
+	ListingHash string          `json:"listingHash"`
+	Item        json.RawMessage `json:"item"`
+	Timestamp   time.Time       `json:"timestamp"`
+}
+
+type CartCheckout struct {
+	CheckoutId string    `json:"checkoutId"`
+	OrderIds   []string  `json:"orderIds"`
+	Txids      []string  `json:"txids"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// The kinds of objects kept in the ratings cache
+const (
+	CachedRatingKindRating   = "rating"
+	CachedRatingKindResponse = "response"
+)
+
+type CachedRating struct {
+	Hash      string    `json:"hash"`
+	Kind      string    `json:"kind"`
+	PeerId    string    `json:"peerId"`
+	Slug      string    `json:"slug"`
+	Valid     bool      `json:"valid"`
+	Error     string    `json:"error"`
+	Rating    []byte    `json:"-"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type DigitalGood struct {
+	Slug        string    `json:"slug"`
+	Filename    string    `json:"filename"`
+	MediaType   string    `json:"mediaType"`
+	Hash        string    `json:"hash"`
+	Size        int       `json:"size"`
+	AutoFulfill bool      `json:"autoFulfill"`
+	Data        []byte    `json:"-"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// APIToken grants access to a subset of the JSON API. Only the hash of its secret is stored.
+type APIToken struct {
+	Name      string    `json:"name"`
+	Hash      string    `json:"-"`
+	Scopes    []string  `json:"scopes"`
+	Expiry    time.Time `json:"expiry"` // Zero if the token doesn't expire
+	Revoked   bool      `json:"revoked"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type AuditLogEntry struct {
+	ID         int       `json:"id"`
+	Timestamp  time.Time `json:"timestamp"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Identity   string    `json:"identity"`
+	RemoteAddr string    `json:"remoteAddr"`
+	Status     int       `json:"status"`
+	PrevHash   string    `json:"prevHash"`
+	Hash       string    `json:"hash"`
+}
+
+type AuditLogHead struct {
+	ID   int    `json:"id"`
+	Hash string `json:"hash"`
+}
+
+// Empty fields match every entry. Path matches entries whose path starts with it.
+type AuditLogQuery struct {
+	Method   string
+	Path     string
+	Identity string
+	Since    time.Time
+	Until    time.Time
+	OffsetId int
+	Limit    int
+}
+
+type SearchListing struct {
+	PeerId        string          `json:"peerId"`
+	Hash          string          `json:"hash"`
+	Slug          string          `json:"slug"`
+	Title         string          `json:"title"`
+	Categories    []string        `json:"categories"`
+	Tags          []string        `json:"tags"`
+	ContractType  string          `json:"contractType"`
+	Description   string          `json:"description"`
+	Thumbnail     SearchThumbnail `json:"thumbnail"`
+	Price         SearchPrice     `json:"price"`
+	ShipsTo       []string        `json:"shipsTo"`
+	FreeShipping  []string        `json:"freeShipping"`
+	Language      string          `json:"language"`
+	AverageRating float32         `json:"averageRating"`
+	RatingCount   uint32          `json:"ratingCount"`
+}
+
+type SearchThumbnail struct {
+	Tiny   string `json:"tiny"`
+	Small  string `json:"small"`
+	Medium string `json:"medium"`
+}
+
+type SearchPrice struct {
+	CurrencyCode string `json:"currencyCode"`
+	Amount       uint64 `json:"amount"`
+}
+
+// Prices are only comparable within one currency so price filters and sorts only return
+// listings priced in PriceCurrency. MinPrice and MaxPrice are in its smallest unit.
+type SearchQuery struct {
+	Query         string
+	Category      string
+	ShipsTo       string
+	PriceCurrency string
+	MinPrice      uint64
+	MaxPrice      uint64
+	Sort          string
+}
+
+// Returns true if the query filters or sorts listings by price
+func (q SearchQuery) ComparesPrices() bool {
+	return q.MinPrice > 0 || q.MaxPrice > 0 || q.Sort == "price-asc" || q.Sort == "price-desc"
+}
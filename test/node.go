@@ -2,6 +2,7 @@ package test
 
 import (
 	// "github.com/ipfs/go-ipfs/thirdparty/testutil"
+	"github.com/OpenBazaar/openbazaar-go/bitcoin"
 	"github.com/OpenBazaar/openbazaar-go/core"
 	"github.com/OpenBazaar/openbazaar-go/ipfs"
 	"github.com/OpenBazaar/openbazaar-go/net"
@@ -24,6 +25,12 @@ func NewNode() (*core.OpenBazaarNode, error) {
 		return nil, err
 	}
 
+	identityKey, err := repository.DB.Config().GetIdentityKey()
+	if err != nil {
+		return nil, err
+	}
+	repository.DB.AuditLog().SetKey(identityKey)
+
 	// Create test ipfs node
 	ipfsNode, err := ipfs.NewMockNode()
 	if err != nil {
@@ -63,12 +70,13 @@ func NewNode() (*core.OpenBazaarNode, error) {
 
 	// Put it all together in an OpenBazaarNode
 	node := &core.OpenBazaarNode{
-		Context:    ctx,
-		RepoPath:   GetRepoPath(),
-		IpfsNode:   ipfsNode,
-		Datastore:  repository.DB,
-		Wallet:     wallet,
-		BanManager: net.NewBanManager([]peer.ID{}),
+		Context:     ctx,
+		RepoPath:    GetRepoPath(),
+		IpfsNode:    ipfsNode,
+		Datastore:   repository.DB,
+		Wallet:      wallet,
+		Multiwallet: bitcoin.NewMultiWallet(wallet),
+		BanManager:  net.NewBanManager([]peer.ID{}),
 	}
 
 	node.Service = service.New(node, ctx, repository.DB)